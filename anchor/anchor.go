@@ -0,0 +1,263 @@
+// Package anchor assigns stable identifiers to operations and schemas of OpenAPI documents,
+// so that rendered docs, changelogs and lint findings can deep-link consistently across versions.
+package anchor
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Extension is a name of vendor extension with anchor identifier.
+const Extension = "x-anchor"
+
+// Kind is a kind of anchored entity.
+type Kind string
+
+// Kind values.
+const (
+	Operation = Kind("operation")
+	Schema    = Kind("schema")
+)
+
+// Anchor identifies an operation or a component schema.
+type Anchor struct {
+	ID   string
+	Kind Kind
+
+	// Method and Path are set for operations, method is in lower case.
+	Method string
+	Path   string
+
+	// Name is a name of component schema.
+	Name string
+
+	// Pointer is a JSON Pointer to the entity, e.g. "/paths/~1things/get".
+	Pointer string
+}
+
+// Index keeps anchors of a document.
+type Index struct {
+	anchors   []Anchor
+	byPointer map[string]Anchor
+	byID      map[string]Anchor
+}
+
+// Build collects anchors of a document.
+//
+// Identifiers are taken from `x-anchor` extensions when available, otherwise they are derived
+// from method and path of operation (e.g. "get-things-id") or from name of schema (e.g. "schema-thing").
+// Operation IDs are not used, as they are often regenerated between versions.
+// Conflicting derived identifiers are suffixed with a number in order of pointers.
+func Build(s *openapi3.Spec) *Index {
+	idx := &Index{
+		byPointer: map[string]Anchor{},
+		byID:      map[string]Anchor{},
+	}
+
+	var derived []Anchor
+
+	add := func(a Anchor, ext map[string]interface{}) {
+		if id, ok := ext[Extension].(string); ok && id != "" && idx.byID[id].ID == "" {
+			a.ID = id
+			idx.add(a)
+
+			return
+		}
+
+		derived = append(derived, a)
+	}
+
+	eachOperation(s, func(path, method string, op openapi3.Operation) {
+		add(Anchor{
+			ID:      slug(method + " " + path),
+			Kind:    Operation,
+			Method:  method,
+			Path:    path,
+			Pointer: lint.Pointer("paths", path, method),
+		}, op.MapOfAnything)
+	})
+
+	eachSchema(s, func(name string, sch *openapi3.Schema) {
+		a := Anchor{
+			ID:      "schema-" + slug(name),
+			Kind:    Schema,
+			Name:    name,
+			Pointer: lint.Pointer("components", "schemas", name),
+		}
+
+		if sch != nil {
+			add(a, sch.MapOfAnything)
+		} else {
+			add(a, nil)
+		}
+	})
+
+	for _, a := range derived {
+		id := a.ID
+
+		for i := 2; idx.byID[id].ID != ""; i++ {
+			id = a.ID + "-" + strconv.Itoa(i)
+		}
+
+		a.ID = id
+		idx.add(a)
+	}
+
+	sort.Slice(idx.anchors, func(i, j int) bool {
+		return idx.anchors[i].Pointer < idx.anchors[j].Pointer
+	})
+
+	return idx
+}
+
+// Annotate sets `x-anchor` extensions of operations and component schemas and returns index.
+//
+// Existing extensions are kept, so anchors can be pinned to survive renames of paths or schemas.
+func Annotate(s *openapi3.Spec) *Index {
+	idx := Build(s)
+
+	for _, a := range idx.anchors {
+		switch a.Kind {
+		case Operation:
+			pi := s.Paths.MapOfPathItemValues[a.Path]
+			op := pi.MapOfOperationValues[a.Method]
+			op.WithMapOfAnythingItem(Extension, a.ID)
+			pi.MapOfOperationValues[a.Method] = op
+		case Schema:
+			if sch := s.Components.Schemas.MapOfSchemaOrRefValues[a.Name].Schema; sch != nil {
+				sch.WithMapOfAnythingItem(Extension, a.ID)
+			}
+		}
+	}
+
+	return idx
+}
+
+func (idx *Index) add(a Anchor) {
+	idx.anchors = append(idx.anchors, a)
+	idx.byPointer[a.Pointer] = a
+	idx.byID[a.ID] = a
+}
+
+// Anchors returns all anchors ordered by pointer.
+func (idx *Index) Anchors() []Anchor {
+	return append([]Anchor(nil), idx.anchors...)
+}
+
+// ID returns anchor by identifier.
+func (idx *Index) ID(id string) (Anchor, bool) {
+	a, ok := idx.byID[id]
+
+	return a, ok
+}
+
+// Operation returns anchor of an operation.
+func (idx *Index) Operation(method, path string) (Anchor, bool) {
+	a, ok := idx.byPointer[lint.Pointer("paths", path, strings.ToLower(method))]
+
+	return a, ok
+}
+
+// Schema returns anchor of a component schema.
+func (idx *Index) Schema(name string) (Anchor, bool) {
+	a, ok := idx.byPointer[lint.Pointer("components", "schemas", name)]
+
+	return a, ok
+}
+
+// Find returns anchor of an entity that contains JSON Pointer, e.g. lint.Finding.Pointer.
+func (idx *Index) Find(pointer string) (Anchor, bool) {
+	for pointer != "" {
+		if a, ok := idx.byPointer[pointer]; ok {
+			return a, true
+		}
+
+		pos := strings.LastIndex(pointer, "/")
+		if pos < 0 {
+			break
+		}
+
+		pointer = pointer[:pos]
+	}
+
+	return Anchor{}, false
+}
+
+func eachOperation(s *openapi3.Spec, fn func(path, method string, op openapi3.Operation)) {
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			fn(path, method, pi.MapOfOperationValues[method])
+		}
+	}
+}
+
+func eachSchema(s *openapi3.Spec, fn func(name string, sch *openapi3.Schema)) {
+	if s.Components == nil || s.Components.Schemas == nil {
+		return
+	}
+
+	names := make([]string, 0, len(s.Components.Schemas.MapOfSchemaOrRefValues))
+	for name := range s.Components.Schemas.MapOfSchemaOrRefValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		fn(name, s.Components.Schemas.MapOfSchemaOrRefValues[name].Schema)
+	}
+}
+
+// slug converts text to lower case words joined with `-`, e.g. "OrderItem" to "order-item".
+func slug(s string) string {
+	var sb strings.Builder
+
+	dash := false
+	prev := ' '
+
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			dash = sb.Len() > 0
+			prev = r
+
+			continue
+		}
+
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			dash = true
+		}
+
+		prev = r
+
+		if dash {
+			sb.WriteByte('-')
+
+			dash = false
+		}
+
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package anchor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/anchor"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestAnnotate(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+paths:
+  /orders/{id}:
+    get:
+      operationId: getOrder
+      responses: {200: {description: OK}}
+    delete:
+      x-anchor: cancel-order
+      responses: {204: {description: No Content}}
+  /orders/id:
+    get:
+      responses: {200: {description: OK}}
+components:
+  schemas:
+    OrderItem: {type: object}
+    Order:
+      type: object
+      x-anchor: order
+`)))
+
+	idx := anchor.Annotate(&s)
+
+	ids := map[string]string{}
+	for _, a := range idx.Anchors() {
+		ids[a.Pointer] = a.ID
+	}
+
+	assert.Equal(t, map[string]string{
+		"/paths/~1orders~1id/get":       "get-orders-id",
+		"/paths/~1orders~1{id}/delete":  "cancel-order",
+		"/paths/~1orders~1{id}/get":     "get-orders-id-2",
+		"/components/schemas/Order":     "order",
+		"/components/schemas/OrderItem": "schema-order-item",
+	}, ids)
+
+	a, ok := idx.Operation("GET", "/orders/{id}")
+	require.True(t, ok)
+	assert.Equal(t, anchor.Anchor{
+		ID:      "get-orders-id-2",
+		Kind:    anchor.Operation,
+		Method:  "get",
+		Path:    "/orders/{id}",
+		Pointer: "/paths/~1orders~1{id}/get",
+	}, a)
+
+	a, ok = idx.Find("/components/schemas/OrderItem/properties/sku")
+	require.True(t, ok)
+	assert.Equal(t, "schema-order-item", a.ID)
+
+	a, ok = idx.ID("cancel-order")
+	require.True(t, ok)
+	assert.Equal(t, "/orders/{id}", a.Path)
+
+	_, ok = idx.Find("/info")
+	assert.False(t, ok)
+
+	assertjson.EqMarshal(t, `{
+	  "responses":{"200":{"description":"OK"}},"operationId":"getOrder",
+	  "x-anchor":"get-orders-id-2"
+	}`, s.Paths.MapOfPathItemValues["/orders/{id}"].MapOfOperationValues["get"])
+
+	assertjson.EqMarshal(t, `{"type":"object","x-anchor":"schema-order-item"}`,
+		s.Components.Schemas.MapOfSchemaOrRefValues["OrderItem"])
+
+	// Anchors are kept on rebuild.
+	assert.Equal(t, idx.Anchors(), anchor.Build(&s).Anchors())
+}
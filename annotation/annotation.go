@@ -0,0 +1,336 @@
+// Package annotation registers operations declared with magic comments above handler functions.
+//
+// Comments are read with static analysis of Go sources, e.g.:
+//
+//	// GetThing finds a thing by ID.
+//	//
+//	// Things are cached for a minute.
+//	//
+//	// openapi:operation GET /things/{id} getThing
+//	// openapi:tags things
+//	// openapi:security bearer read:things
+//	func GetThing(w http.ResponseWriter, r *http.Request) {}
+//
+// Supported lines are `openapi:operation METHOD PATH [ID]`, `openapi:tags TAG...`,
+// `openapi:security SCHEME [SCOPE...]` and `openapi:deprecated`, directive form `//openapi:...` is also accepted.
+// Other lines of a comment make summary (first paragraph) and description.
+package annotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+const prefix = "openapi:"
+
+// Security is a security requirement of an operation.
+type Security struct {
+	Name   string
+	Scopes []string
+}
+
+// Operation is declared with comments above a function.
+type Operation struct {
+	Method string
+	Path   string
+	ID     string
+
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+	Security    []Security
+
+	// Func is a name of annotated function, methods are prefixed with receiver type, e.g. `Handler.GetThing`.
+	Func string
+
+	// Package is a name of package of annotated function.
+	Package string
+
+	// Position is a location of `openapi:operation` comment.
+	Position token.Position
+}
+
+// ScanDir reads operations from Go files of a directory and its subdirectories.
+//
+// Test files, `testdata`, `vendor` and hidden directories are skipped.
+// Operations are ordered by file and line.
+func ScanDir(dir string) ([]Operation, error) {
+	var ops []Operation
+
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := d.Name()
+
+		if d.IsDir() {
+			if path != dir && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") ||
+				strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path) //nolint:gosec // Path is walked from trusted directory.
+		if err != nil {
+			return err
+		}
+
+		fileOps, err := ScanFile(fset, path, src)
+		if err != nil {
+			return err
+		}
+
+		ops = append(ops, fileOps...)
+
+		return nil
+	})
+
+	return ops, err
+}
+
+// ScanFile reads operations from Go source.
+func ScanFile(fset *token.FileSet, filename string, src []byte) ([]Operation, error) {
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []Operation
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc == nil {
+			continue
+		}
+
+		op, found, err := parseDoc(fset, fn.Doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		op.Func = funcName(fn)
+		op.Package = f.Name.Name
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+func parseDoc(fset *token.FileSet, doc *ast.CommentGroup) (Operation, bool, error) {
+	var (
+		op    Operation
+		found bool
+		text  []string
+	)
+
+	for _, c := range doc.List {
+		line := strings.TrimPrefix(c.Text, "//")
+		if strings.HasPrefix(line, "/*") {
+			continue
+		}
+
+		line = strings.TrimSpace(line)
+
+		if !strings.HasPrefix(line, prefix) {
+			text = append(text, line)
+
+			continue
+		}
+
+		pos := fset.Position(c.Slash)
+		fields := strings.Fields(strings.TrimPrefix(line, prefix))
+		if len(fields) == 0 {
+			return op, false, fmt.Errorf("%s: empty annotation", pos)
+		}
+
+		args := fields[1:]
+
+		switch fields[0] {
+		case "operation":
+			if found {
+				return op, false, fmt.Errorf("%s: duplicate openapi:operation", pos)
+			}
+
+			if len(args) < 2 || len(args) > 3 {
+				return op, false, fmt.Errorf("%s: openapi:operation expects METHOD PATH [ID]", pos)
+			}
+
+			found = true
+			op.Method = strings.ToUpper(args[0])
+			op.Path = args[1]
+			op.Position = pos
+
+			if len(args) == 3 {
+				op.ID = args[2]
+			}
+		case "tags":
+			op.Tags = append(op.Tags, args...)
+		case "security":
+			if len(args) == 0 {
+				return op, false, fmt.Errorf("%s: openapi:security expects SCHEME [SCOPE...]", pos)
+			}
+
+			op.Security = append(op.Security, Security{Name: args[0], Scopes: args[1:]})
+		case "deprecated":
+			op.Deprecated = true
+		default:
+			return op, false, fmt.Errorf("%s: unknown annotation openapi:%s", pos, fields[0])
+		}
+	}
+
+	op.Summary, op.Description = splitText(text)
+
+	return op, found, nil
+}
+
+// splitText returns first paragraph as summary and the rest as description.
+func splitText(lines []string) (summary, description string) {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, l := range lines {
+		if l == "" {
+			return strings.Join(lines[:i], " "), strings.TrimSpace(strings.Join(lines[i+1:], "\n"))
+		}
+	}
+
+	return strings.Join(lines, " "), ""
+}
+
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	t := fn.Recv.List[0].Type
+
+	for {
+		switch tt := t.(type) {
+		case *ast.StarExpr:
+			t = tt.X
+		case *ast.IndexExpr:
+			t = tt.X
+		case *ast.IndexListExpr:
+			t = tt.X
+		case *ast.Ident:
+			return tt.Name + "." + fn.Name.Name
+		default:
+			return fn.Name.Name
+		}
+	}
+}
+
+// Register adds operations to reflector.
+//
+// Setup is called for every operation before it is added to reflector, it can add request
+// and response structures, e.g. by operation ID, nil setup adds operations without structures.
+// Errors of all operations are returned together, prefixed with annotation positions.
+func Register(r openapi.Reflector, ops []Operation, setup func(op Operation, oc openapi.OperationContext) error) error {
+	return RegisterContext(context.Background(), r, ops, setup)
+}
+
+// RegisterContext adds operations to reflector until context is done.
+//
+// Context error is returned without errors of previous operations, remaining operations are not added.
+// Reflection of an operation is also aborted if reflector implements openapi.ContextReflector.
+func RegisterContext(
+	ctx context.Context,
+	r openapi.Reflector,
+	ops []Operation,
+	setup func(op Operation, oc openapi.OperationContext) error,
+) error {
+	var errs []string
+
+	for _, op := range ops {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := register(ctx, r, op, setup); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+
+			errs = append(errs, fmt.Sprintf("%s: %s", op.Position, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+func register(
+	ctx context.Context,
+	r openapi.Reflector,
+	op Operation,
+	setup func(op Operation, oc openapi.OperationContext) error,
+) error {
+	oc, err := r.NewOperationContext(op.Method, op.Path)
+	if err != nil {
+		return err
+	}
+
+	if op.ID != "" {
+		oc.SetID(op.ID)
+	}
+
+	if op.Summary != "" {
+		oc.SetSummary(op.Summary)
+	}
+
+	if op.Description != "" {
+		oc.SetDescription(op.Description)
+	}
+
+	if len(op.Tags) > 0 {
+		oc.SetTags(op.Tags...)
+	}
+
+	if op.Deprecated {
+		oc.SetIsDeprecated(true)
+	}
+
+	for _, s := range op.Security {
+		oc.AddSecurity(s.Name, s.Scopes...)
+	}
+
+	if setup != nil {
+		if err := setup(op, oc); err != nil {
+			return err
+		}
+	}
+
+	if cr, ok := r.(openapi.ContextReflector); ok {
+		return cr.AddOperationContext(ctx, oc)
+	}
+
+	return r.AddOperation(oc)
+}
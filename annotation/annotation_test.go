@@ -0,0 +1,133 @@
+package annotation_test
+
+import (
+	"context"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/annotation"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+const handlers = `package api
+
+import "net/http"
+
+// GetThing finds a thing
+// by ID.
+//
+// Things are cached for a minute.
+//
+// openapi:operation get /things/{id} getThing
+// openapi:tags things
+// openapi:security bearer read:things
+func GetThing(w http.ResponseWriter, r *http.Request) {}
+
+// ListThings lists things.
+//
+//openapi:operation GET /things
+//openapi:deprecated
+func (h *Handler[T]) ListThings(w http.ResponseWriter, r *http.Request) {}
+
+// helper is not an operation.
+func helper() {}
+`
+
+func TestScanFile(t *testing.T) {
+	ops, err := annotation.ScanFile(token.NewFileSet(), "api.go", []byte(handlers))
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+
+	assert.Equal(t, annotation.Operation{
+		Method:      http.MethodGet,
+		Path:        "/things/{id}",
+		ID:          "getThing",
+		Summary:     "GetThing finds a thing by ID.",
+		Description: "Things are cached for a minute.",
+		Tags:        []string{"things"},
+		Security:    []annotation.Security{{Name: "bearer", Scopes: []string{"read:things"}}},
+		Func:        "GetThing",
+		Package:     "api",
+		Position:    token.Position{Filename: "api.go", Offset: 109, Line: 10, Column: 1},
+	}, ops[0])
+
+	assert.Equal(t, "Handler.ListThings", ops[1].Func)
+	assert.Equal(t, "ListThings lists things.", ops[1].Summary)
+	assert.True(t, ops[1].Deprecated)
+
+	_, err = annotation.ScanFile(token.NewFileSet(), "bad.go", []byte("package api\n\n// openapi:operation GET\nfunc Bad() {}\n"))
+	assert.EqualError(t, err, "bad.go:3:1: openapi:operation expects METHOD PATH [ID]")
+}
+
+func TestRegister(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api.go"), []byte(handlers), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "testdata"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "testdata", "skipped.go"), []byte("package broken {"), 0o600))
+
+	ops, err := annotation.ScanDir(dir)
+	require.NoError(t, err)
+
+	type thing struct {
+		ID   int    `path:"id" json:"-"`
+		Name string `json:"name"`
+	}
+
+	r := openapi3.NewReflector()
+	require.NoError(t, annotation.Register(r, ops, func(op annotation.Operation, oc openapi.OperationContext) error {
+		if op.ID == "getThing" {
+			oc.AddReqStructure(thing{})
+			oc.AddRespStructure(thing{})
+		}
+
+		return nil
+	}))
+
+	assertjson.EqMarshal(t, `{
+	  "/things":{"get":{"summary":"ListThings lists things.","responses":{"204":{"description":"No Content"}},"deprecated":true}},
+	  "/things/{id}":{
+		"get":{
+		  "tags":["things"],"summary":"GetThing finds a thing by ID.",
+		  "description":"Things are cached for a minute.","operationId":"getThing",
+		  "parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+		  "responses":{
+			"200":{
+			  "description":"OK",
+			  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/AnnotationTestThing"}}}
+			}
+		  },
+		  "security":[{"bearer":["read:things"]}]
+		}
+	  }
+	}`, r.Spec.Paths)
+
+	err = annotation.Register(openapi3.NewReflector(), []annotation.Operation{
+		{Method: "FETCH", Path: "/things", Position: token.Position{Filename: "api.go", Line: 3, Column: 1}},
+	}, nil)
+	assert.EqualError(t, err, "api.go:3:1: unexpected http method: fetch")
+}
+
+func TestRegisterContext(t *testing.T) {
+	ops := []annotation.Operation{
+		{Method: http.MethodGet, Path: "/things"},
+		{Method: http.MethodGet, Path: "/things/{id}"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := openapi3.NewReflector()
+
+	err := annotation.RegisterContext(ctx, r, ops, func(op annotation.Operation, _ openapi.OperationContext) error {
+		cancel()
+
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, r.Spec.Paths.MapOfPathItemValues)
+}
@@ -0,0 +1,34 @@
+package openapi
+
+// Binary returns a ContentUnitPreparer of binary body with a media type, e.g. "application/pdf",
+// body is documented as `type: string, format: binary`.
+func Binary(contentType string) ContentUnitPreparer {
+	return binaryContent{contentType: contentType}
+}
+
+// Download returns a ContentUnitPreparer of file download response with a media type, e.g. "application/pdf",
+// binary body is documented with `Content-Disposition` header, e.g. `attachment; filename="report.pdf"`.
+func Download(contentType string) ContentUnitPreparer {
+	return binaryContent{contentType: contentType, download: true}
+}
+
+type binaryContent struct {
+	contentType string
+	download    bool
+}
+
+type downloadHeaders struct {
+	ContentDisposition string `header:"Content-Disposition" required:"true" example:"attachment; filename=\"file\"" description:"Presentation and file name of downloaded content."`
+}
+
+// SetupContentUnit implements ContentUnitPreparer.
+func (b binaryContent) SetupContentUnit(cu *ContentUnit) {
+	cu.ContentType = b.contentType
+	cu.Format = "binary"
+
+	if b.download {
+		cu.Structure = downloadHeaders{}
+	} else {
+		cu.Structure = []byte(nil)
+	}
+}
@@ -0,0 +1,208 @@
+// Package chirouter documents routes of existing github.com/go-chi/chi routers.
+//
+// Collector.Walk matches chi.WalkFunc, so route tree is collected with
+//
+//	c := chirouter.NewCollector(reflector)
+//	err := chi.Walk(router, c.Walk)
+//
+// without this package depending on chi.
+package chirouter
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// Collector pre-populates operation contexts of walked chi routes.
+//
+// Operations are described with Operation before Add, routes that are not described yet
+// are added with string path parameters, so that services can be documented incrementally.
+type Collector struct {
+	reflector openapi.Reflector
+	tags      map[uintptr][]string
+	ops       []openapi.OperationContext
+	index     map[string]int
+}
+
+// NewCollector creates collector of operations for reflector.
+func NewCollector(r openapi.Reflector) *Collector {
+	return &Collector{
+		reflector: r,
+		tags:      map[uintptr][]string{},
+		index:     map[string]int{},
+	}
+}
+
+// TagMiddleware sets tags to operations of routes using middleware.
+//
+// Middlewares are matched by function, so closures made by the same constructor
+// (e.g. middleware.BasicAuth) share tags.
+func (c *Collector) TagMiddleware(mw func(http.Handler) http.Handler, tags ...string) {
+	p := reflect.ValueOf(mw).Pointer()
+	c.tags[p] = append(c.tags[p], tags...)
+}
+
+// Walk collects operation of route, it is compatible with chi.WalkFunc.
+//
+// CONNECT routes are skipped as they can not be documented.
+func (c *Collector) Walk(method, route string, _ http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+	if strings.EqualFold(method, http.MethodConnect) {
+		return nil
+	}
+
+	path := Path(route)
+	key := strings.ToUpper(method) + " " + path
+
+	if _, ok := c.index[key]; ok {
+		return nil
+	}
+
+	oc, err := c.reflector.NewOperationContext(method, path)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, route, err)
+	}
+
+	var tags []string
+
+	seen := map[string]bool{}
+
+	for _, mw := range middlewares {
+		for _, t := range c.tags[reflect.ValueOf(mw).Pointer()] {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+
+	if len(tags) > 0 {
+		oc.SetTags(tags...)
+	}
+
+	c.index[key] = len(c.ops)
+	c.ops = append(c.ops, oc)
+
+	return nil
+}
+
+// Operation returns collected operation context of method and path template, or nil if route was not walked.
+//
+// Path is normalized as in Path, so chi route can be used as well.
+func (c *Collector) Operation(method, path string) openapi.OperationContext {
+	i, ok := c.index[strings.ToUpper(method)+" "+Path(path)]
+	if !ok {
+		return nil
+	}
+
+	return c.ops[i]
+}
+
+// Operations returns collected operation contexts in order of walking.
+func (c *Collector) Operations() []openapi.OperationContext {
+	return c.ops
+}
+
+// Add adds collected operations to reflector.
+//
+// Operations without request structures get path parameters of string type.
+func (c *Collector) Add() error {
+	for _, oc := range c.ops {
+		if len(oc.Request()) == 0 {
+			if params := pathParams(oc.PathPattern()); params != nil {
+				oc.AddReqStructure(params)
+			}
+		}
+
+		if err := c.reflector.AddOperation(oc); err != nil {
+			return fmt.Errorf("%s %s: %w", oc.Method(), oc.PathPattern(), err)
+		}
+	}
+
+	return nil
+}
+
+// Path returns OpenAPI path template of chi route.
+//
+// Regular expressions of parameters are removed (`{id:[0-9]+}` becomes `{id}`) and
+// trailing `*` wildcard is documented as `{path}` parameter.
+func Path(route string) string {
+	route = strings.ReplaceAll(route, "/*/", "/")
+
+	var sb strings.Builder
+
+	for i := 0; i < len(route); i++ {
+		if route[i] != '{' {
+			sb.WriteByte(route[i])
+
+			continue
+		}
+
+		// Find closing brace, regular expression may have braces too.
+		end, depth := i, 0
+
+		for ; end < len(route); end++ {
+			if route[end] == '{' {
+				depth++
+			} else if route[end] == '}' {
+				depth--
+			}
+
+			if depth == 0 {
+				break
+			}
+		}
+
+		name := route[i+1 : end]
+		if pos := strings.Index(name, ":"); pos >= 0 {
+			name = name[:pos]
+		}
+
+		sb.WriteString("{" + name + "}")
+
+		i = end
+	}
+
+	path := sb.String()
+
+	if strings.HasSuffix(path, "*") {
+		path = strings.TrimSuffix(path, "*") + "{path}"
+	}
+
+	return path
+}
+
+// pathParams returns a value of struct with string fields of path parameters.
+func pathParams(path string) interface{} {
+	var fields []reflect.StructField
+
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			break
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: "P" + strconv.Itoa(len(fields)),
+			Type: reflect.TypeOf(""),
+			Tag:  reflect.StructTag(`path:"` + path[start+1:start+end] + `"`),
+		})
+
+		path = path[start+end+1:]
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return reflect.New(reflect.StructOf(fields)).Elem().Interface()
+}
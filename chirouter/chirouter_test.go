@@ -0,0 +1,84 @@
+package chirouter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/chirouter"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestPath(t *testing.T) {
+	for route, expected := range map[string]string{
+		"/things/{id}":               "/things/{id}",
+		"/things/{id:[0-9]+}/parts":  "/things/{id}/parts",
+		"/codes/{code:[a-z]{3}}":     "/codes/{code}",
+		"/files/*":                   "/files/{path}",
+		"/api/*/things/{id:^\\d+$}/": "/api/things/{id}/",
+		"/static/{version}/assets/*": "/static/{version}/assets/{path}",
+		"/":                          "/",
+	} {
+		assert.Equal(t, expected, chirouter.Path(route), route)
+	}
+}
+
+func auth(next http.Handler) http.Handler { return next }
+
+func logger(next http.Handler) http.Handler { return next }
+
+func TestCollector(t *testing.T) {
+	r := openapi3.NewReflector()
+	c := chirouter.NewCollector(r)
+
+	c.TagMiddleware(auth, "Private")
+
+	h := http.NotFoundHandler()
+
+	// Same calls as chi.Walk(router, c.Walk) would make.
+	require.NoError(t, c.Walk(http.MethodGet, "/things/{id:[0-9]+}", h, logger, auth))
+	require.NoError(t, c.Walk(http.MethodPost, "/things/", h, logger))
+	require.NoError(t, c.Walk(http.MethodConnect, "/things/", h))
+
+	assert.Len(t, c.Operations(), 2)
+	assert.Nil(t, c.Operation(http.MethodDelete, "/things/{id}"))
+
+	oc := c.Operation(http.MethodPost, "/things/")
+	require.NotNil(t, oc)
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	oc.SetSummary("Create thing.")
+	oc.AddReqStructure(thing{})
+
+	require.NoError(t, c.Add())
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/things/{id}":{
+		  "get":{
+			"tags":["Private"],
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"string"}}],
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		},
+		"/things/":{
+		  "post":{
+			"summary":"Create thing.",
+			"requestBody":{
+			  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/ChirouterTestThing"}}}
+			},
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{"ChirouterTestThing":{"type":"object","properties":{"name":{"type":"string"}}}}
+	  }
+	}`, r.SpecEns())
+}
@@ -0,0 +1,307 @@
+// Package client generates Go code for API clients from OpenAPI documents.
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal/codegen"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Config controls code generation.
+type Config struct {
+	// Package is a name of Go package of generated file, required.
+	Package string
+}
+
+// GenerateErrors renders Go file with typed errors of documented non-2xx responses.
+//
+// For every operation with such responses it declares an error type per status,
+// e.g. `GetThingNotFoundError` with decoded `Body`, and `DecodeGetThingError(resp *http.Response) error`
+// that a client calls on unsuccessful response, so that callers can use errors.As with a particular status.
+// Undocumented statuses are returned as *UnexpectedStatusError.
+//
+// Types of response schemas are generated from referenced components and inline schemas,
+// non-JSON bodies are kept as []byte.
+func GenerateErrors(s *openapi3.Spec, cfg Config) ([]byte, error) {
+	if cfg.Package == "" {
+		return nil, errors.New("client: package is required")
+	}
+
+	g := generator{spec: s, names: map[string]int{}, types: codegen.NewTypes(s)}
+
+	ops := bytes.NewBuffer(nil)
+
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			if err := g.operation(ops, strings.ToUpper(method), path, pi.MapOfOperationValues[method]); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString("// Code generated by openapi-go/client, DO NOT EDIT.\n\n")
+	buf.WriteString("package " + cfg.Package + "\n\n")
+	buf.WriteString("import (\n\"encoding/json\"\n\"fmt\"\n\"io\"\n\"net/http\"\n)\n\n")
+	buf.WriteString(`// UnexpectedStatusError is returned for response status that is not documented.
+type UnexpectedStatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements error.
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status %d: %s", e.StatusCode, e.Body)
+}
+
+// decodeErrorBody reads response body and decodes JSON into v if it is not nil.
+func decodeErrorBody(resp *http.Response, v interface{}) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || v == nil {
+		return body, err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return body, fmt.Errorf("decode %d response: %w", resp.StatusCode, err)
+	}
+
+	return body, nil
+}
+`)
+
+	if err := g.types.Render(buf); err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+
+	buf.Write(ops.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("client: format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+type generator struct {
+	spec  *openapi3.Spec
+	names map[string]int
+	types *codegen.Types
+}
+
+type errorResponse struct {
+	status   string
+	typeName string
+	bodyType string
+	isJSON   bool
+	desc     string
+}
+
+func (g *generator) operation(buf *bytes.Buffer, method, path string, op openapi3.Operation) error {
+	codes := make([]string, 0, len(op.Responses.MapOfResponseOrRefValues))
+	for code := range op.Responses.MapOfResponseOrRefValues {
+		if !strings.HasPrefix(code, "2") && !strings.HasPrefix(code, "1") && !strings.HasPrefix(code, "3") {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Strings(codes)
+
+	if len(codes) == 0 && op.Responses.Default == nil {
+		return nil
+	}
+
+	name := g.opName(method, path, op.ID)
+
+	var responses []errorResponse
+
+	for _, code := range codes {
+		er, err := g.errorResponse(name, code, op.Responses.MapOfResponseOrRefValues[code])
+		if err != nil {
+			return err
+		}
+
+		responses = append(responses, er)
+	}
+
+	if op.Responses.Default != nil {
+		er, err := g.errorResponse(name, "default", *op.Responses.Default)
+		if err != nil {
+			return err
+		}
+
+		responses = append(responses, er)
+	}
+
+	for _, er := range responses {
+		fmt.Fprintf(buf, "\n// %s is a %s response of %s %s.\n", er.typeName, er.status, method, path)
+
+		if er.desc != "" {
+			fmt.Fprintf(buf, "//\n// %s\n", strings.ReplaceAll(er.desc, "\n", "\n// "))
+		}
+
+		fmt.Fprintf(buf, "type %s struct {\nStatusCode int\nBody %s\n}\n\n", er.typeName, er.bodyType)
+		fmt.Fprintf(buf, "// Error implements error.\nfunc (e *%s) Error() string {\n", er.typeName)
+		fmt.Fprintf(buf, "return fmt.Sprintf(%q, e.StatusCode)\n}\n", method+" "+path+": response status %d")
+	}
+
+	fmt.Fprintf(buf, "\n// Decode%sError decodes unsuccessful response of %s %s into a typed error.\n", name, method, path)
+	fmt.Fprintf(buf, "func Decode%sError(resp *http.Response) error {\n", name)
+
+	var def *errorResponse
+
+	if len(codes) > 0 {
+		buf.WriteString("switch {\n")
+
+		for _, er := range responses[:len(codes)] {
+			fmt.Fprintf(buf, "case %s:\n", statusCondition(er.status))
+			writeDecode(buf, er)
+		}
+
+		buf.WriteString("}\n\n")
+	}
+
+	if op.Responses.Default != nil {
+		def = &responses[len(codes)]
+	}
+
+	if def != nil {
+		writeDecode(buf, *def)
+	} else {
+		buf.WriteString("body, err := decodeErrorBody(resp, nil)\nif err != nil {\nreturn err\n}\n\n")
+		buf.WriteString("return &UnexpectedStatusError{StatusCode: resp.StatusCode, Body: body}\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return nil
+}
+
+func writeDecode(buf *bytes.Buffer, er errorResponse) {
+	fmt.Fprintf(buf, "e := &%s{StatusCode: resp.StatusCode}\n", er.typeName)
+
+	if er.isJSON {
+		buf.WriteString("if _, err := decodeErrorBody(resp, &e.Body); err != nil {\nreturn err\n}\n\nreturn e\n")
+
+		return
+	}
+
+	if er.bodyType == "[]byte" {
+		buf.WriteString("body, err := decodeErrorBody(resp, nil)\nif err != nil {\nreturn err\n}\n\ne.Body = body\n\nreturn e\n")
+
+		return
+	}
+
+	buf.WriteString("if _, err := decodeErrorBody(resp, nil); err != nil {\nreturn err\n}\n\nreturn e\n")
+}
+
+func statusCondition(status string) string {
+	if strings.HasSuffix(status, "XX") {
+		d := status[:1]
+
+		return "resp.StatusCode >= " + d + "00 && resp.StatusCode <= " + d + "99"
+	}
+
+	return "resp.StatusCode == " + status
+}
+
+func (g *generator) errorResponse(opName, status string, rr openapi3.ResponseOrRef) (errorResponse, error) {
+	er := errorResponse{status: status, typeName: opName + statusName(status) + "Error"}
+
+	resp := rr.Response
+
+	if rr.ResponseReference != nil {
+		name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+		if c := g.spec.Components; c != nil && c.Responses != nil {
+			resp = c.Responses.MapOfResponseOrRefValues[name].Response
+		}
+
+		if resp == nil {
+			return er, fmt.Errorf("unresolved response reference %s", rr.ResponseReference.Ref)
+		}
+	}
+
+	er.desc = resp.Description
+	er.bodyType = "struct{}"
+
+	cts := make([]string, 0, len(resp.Content))
+	for ct := range resp.Content {
+		cts = append(cts, ct)
+	}
+
+	sort.Strings(cts)
+
+	for _, ct := range cts {
+		if ct == "application/json" || strings.HasSuffix(ct, "+json") {
+			er.isJSON = true
+			er.bodyType = g.types.GoType(resp.Content[ct].Schema)
+
+			return er, nil
+		}
+	}
+
+	if len(cts) > 0 {
+		er.bodyType = "[]byte"
+	}
+
+	return er, nil
+}
+
+func statusName(status string) string {
+	switch {
+	case status == "default":
+		return "Default"
+	case strings.HasSuffix(status, "XX"):
+		return "Status" + status
+	}
+
+	code, err := strconv.Atoi(status)
+	if err != nil || http.StatusText(code) == "" {
+		return "Status" + status
+	}
+
+	return codegen.GoName(http.StatusText(code))
+}
+
+func (g *generator) opName(method, path string, id *string) string {
+	var name string
+
+	if id != nil && *id != "" {
+		name = codegen.GoName(*id)
+	} else {
+		name = codegen.GoName(strings.ToLower(method) + " " + path)
+	}
+
+	g.names[name]++
+	if n := g.names[name]; n > 1 {
+		name += strconv.Itoa(n)
+	}
+
+	return name
+}
@@ -0,0 +1,139 @@
+package client_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/client"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestGenerateErrors(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things/{id}:
+    get:
+      operationId: getThing
+      responses:
+        "200": {description: OK}
+        "404":
+          $ref: '#/components/responses/NotFound'
+        "429":
+          description: Too many requests.
+          content:
+            text/plain: {schema: {type: string}}
+        5XX:
+          description: Server failure.
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [retryAfter]
+                properties:
+                  retryAfter: {type: integer}
+  /health:
+    get:
+      responses:
+        "200": {description: OK}
+components:
+  responses:
+    NotFound:
+      description: Resource not found.
+      content:
+        application/problem+json:
+          schema: {$ref: '#/components/schemas/Problem'}
+  schemas:
+    Problem:
+      type: object
+      description: Problem details.
+      properties:
+        title: {type: string}
+        cause: {$ref: '#/components/schemas/Problem'}
+        errors:
+          type: array
+          items: {$ref: '#/components/schemas/FieldError'}
+    FieldError:
+      type: object
+      properties:
+        field: {type: string}
+`)))
+
+	src, err := client.GenerateErrors(&s, client.Config{Package: "api"})
+	require.NoError(t, err)
+
+	typeCheck(t, "errors.go", src)
+
+	code := string(src)
+
+	assert.NotContains(t, code, "Health")
+	assert.Contains(t, code, `// FieldError is a schema of FieldError component.
+type FieldError struct {
+	Field string `+"`"+`json:"field,omitempty"`+"`"+`
+}`)
+	assert.Contains(t, code, `// Problem is a schema of Problem component.
+//
+// Problem details.
+type Problem struct {
+	Title  string       `+"`"+`json:"title,omitempty"`+"`"+`
+	Cause  *Problem     `+"`"+`json:"cause,omitempty"`+"`"+`
+	Errors []FieldError `+"`"+`json:"errors,omitempty"`+"`"+`
+}`)
+	assert.Contains(t, code, `// GetThingNotFoundError is a 404 response of GET /things/{id}.
+//
+// Resource not found.
+type GetThingNotFoundError struct {
+	StatusCode int
+	Body       Problem
+}`)
+	assert.Contains(t, code, `type GetThingTooManyRequestsError struct {
+	StatusCode int
+	Body       []byte
+}`)
+	assert.Contains(t, code, `type GetThingStatus5XXError struct {
+	StatusCode int
+	Body       struct {
+		RetryAfter int64 `+"`"+`json:"retryAfter"`+"`"+`
+	}
+}`)
+	assert.Contains(t, code, `func DecodeGetThingError(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == 404:
+		e := &GetThingNotFoundError{StatusCode: resp.StatusCode}
+		if _, err := decodeErrorBody(resp, &e.Body); err != nil {
+			return err
+		}
+
+		return e
+	case resp.StatusCode == 429:`)
+	assert.Contains(t, code, `func (e *GetThingNotFoundError) Error() string {
+	return fmt.Sprintf("GET /things/{id}: response status %d", e.StatusCode)
+}`)
+	assert.Contains(t, code, `	case resp.StatusCode >= 500 && resp.StatusCode <= 599:`)
+	assert.Contains(t, code, `	return &UnexpectedStatusError{StatusCode: resp.StatusCode, Body: body}
+}`)
+
+	_, err = client.GenerateErrors(&s, client.Config{})
+	assert.EqualError(t, err, "client: package is required")
+}
+
+// typeCheck fails if generated code is not a valid Go package, e.g. has recursive types.
+func typeCheck(t *testing.T, name string, src []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, name, src, 0)
+	require.NoError(t, err)
+
+	_, err = (&types.Config{Importer: importer.Default()}).Check("api", fset, []*ast.File{f}, nil)
+	require.NoError(t, err)
+}
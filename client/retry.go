@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Retry defaults for values that are not set in `x-retryable` extension.
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 100 * time.Millisecond
+	DefaultMultiplier     = 2.0
+)
+
+// DefaultRetryStatuses are retried if extension does not list statuses.
+var DefaultRetryStatuses = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryTransport retries requests of operations that have `x-retryable` extension.
+//
+// Requests are matched to operations by method and path template, server base path is ignored.
+// Only idempotent operations are retried, except 429 Too Many Requests and 503 Service Unavailable
+// responses with Retry-After header, as server did not process such requests. Requests with body
+// are only retried if http.Request.GetBody is available.
+type RetryTransport struct {
+	// Base performs requests, http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Policy can override retry semantics of an operation, e.g. to limit attempts, ok is false for
+	// operations without extension. Retries are disabled if Policy returns false or MaxAttempts below 2.
+	Policy func(method, pathPattern string, r openapi.Retry, ok bool) (openapi.Retry, bool)
+
+	// Sleep waits between attempts, it is time.Sleep with cancellation if nil.
+	Sleep func(ctx context.Context, d time.Duration) error
+
+	routes routes
+}
+
+// NewRetryTransport creates a transport with retry semantics of spec operations.
+func NewRetryTransport(s *openapi3.Spec, base http.RoundTripper) *RetryTransport {
+	return &RetryTransport{Base: base, routes: newRoutes(s)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	r, ok := t.policy(req)
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return base.RoundTrip(req)
+	}
+
+	attempts := r.MaxAttempts
+	if attempts == 0 {
+		attempts = DefaultMaxAttempts
+	}
+
+	statuses := r.Statuses
+	if len(statuses) == 0 {
+		statuses = DefaultRetryStatuses
+	}
+
+	backoff := time.Duration(r.InitialBackoffMs) * time.Millisecond
+	if backoff == 0 {
+		backoff = DefaultInitialBackoff
+	}
+
+	multiplier := r.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultMultiplier
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+
+		retry := attempt < attempts
+		if err != nil {
+			retry = retry && r.Idempotent
+		} else {
+			retry = retry && hasStatus(statuses, resp.StatusCode) && (r.Idempotent || notProcessed(resp))
+		}
+
+		if !retry {
+			return resp, err
+		}
+
+		delay := backoff
+		if resp != nil {
+			if ra := retryAfter(resp); ra > 0 {
+				delay = ra
+			}
+
+			resp.Body.Close() //nolint:errcheck // Response is discarded.
+		}
+
+		if r.MaxBackoffMs > 0 && delay > time.Duration(r.MaxBackoffMs)*time.Millisecond {
+			delay = time.Duration(r.MaxBackoffMs) * time.Millisecond
+		}
+
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		backoff = time.Duration(float64(backoff) * multiplier)
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *RetryTransport) policy(req *http.Request) (openapi.Retry, bool) {
+	rt, found := t.routes.match(req)
+	if !found {
+		return openapi.Retry{}, false
+	}
+
+	if t.Policy != nil {
+		r, ok := t.Policy(rt.method, rt.pattern, rt.retry, rt.retryOK)
+
+		return r, ok && r.MaxAttempts > 1
+	}
+
+	return rt.retry, rt.retryOK
+}
+
+func (t *RetryTransport) sleep(ctx context.Context, d time.Duration) error {
+	if t.Sleep != nil {
+		return t.Sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func hasStatus(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// notProcessed checks if response tells that request was rejected before processing and can be repeated
+// regardless of idempotency.
+func notProcessed(resp *http.Response) bool {
+	return (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) &&
+		resp.Header.Get("Retry-After") != ""
+}
+
+// retryAfter returns delay of Retry-After header in seconds, zero if absent.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if sec, err := strconv.Atoi(v); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+
+	return 0
+}
@@ -0,0 +1,174 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/client"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestRetryTransport(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/things/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(struct {
+		ID   string `path:"id"`
+		Name string `json:"name"`
+	}{})
+	require.NoError(t, openapi3.SetRetry(oc, openapi.Retry{
+		Idempotent:       true,
+		MaxAttempts:      3,
+		InitialBackoffMs: 10,
+		MaxBackoffMs:     15,
+		Statuses:         []int{http.StatusServiceUnavailable},
+	}))
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	require.NoError(t, r.AddOperation(oc))
+
+	// Extension survives serialization.
+	j, err := r.Spec.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"x-retryable":{"idempotent":true,"maxAttempts":3,"initialBackoffMs":10,`+
+		`"maxBackoffMs":15,"statuses":[503]}`)
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalJSON(j))
+
+	var bodies []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b := make([]byte, 64)
+		n, _ := req.Body.Read(b)
+		bodies = append(bodies, req.Method+" "+req.URL.Path+" "+string(b[:n]))
+
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+
+	tr := client.NewRetryTransport(&s, nil)
+	tr.Sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+
+		return nil
+	}
+
+	c := http.Client{Transport: tr}
+
+	resp, err := c.Do(newRequest(t, http.MethodPut, srv.URL+"/v1/things/123", `{"name":"a"}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, []string{
+		`PUT /v1/things/123 {"name":"a"}`,
+		`PUT /v1/things/123 {"name":"a"}`,
+		`PUT /v1/things/123 {"name":"a"}`,
+	}, bodies)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 15 * time.Millisecond}, delays)
+
+	// Operation without extension is not retried.
+	bodies = nil
+
+	resp, err = c.Do(newRequest(t, http.MethodPost, srv.URL+"/things", ""))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Len(t, bodies, 1)
+
+	// Policy overrides extension.
+	bodies = nil
+	tr.Policy = func(method, pathPattern string, r openapi.Retry, ok bool) (openapi.Retry, bool) {
+		assert.Equal(t, "/things/{id}", pathPattern)
+
+		r.MaxAttempts = 2
+
+		return r, ok
+	}
+
+	resp, err = c.Do(newRequest(t, http.MethodPut, srv.URL+"/things/123", `{}`))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Len(t, bodies, 2)
+}
+
+func newRequest(t *testing.T, method, url, body string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), method, url, strings.NewReader(body))
+	require.NoError(t, err)
+
+	return req
+}
+
+func TestRetryTransport_notIdempotent(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	require.NoError(t, openapi3.SetRetry(oc, openapi.Retry{Idempotent: false}))
+	require.NoError(t, r.AddOperation(oc))
+
+	calls := 0
+	status := http.StatusGatewayTimeout
+	retryAfter := ""
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		if retryAfter != "" {
+			rw.Header().Set("Retry-After", retryAfter)
+		}
+
+		rw.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	tr := client.NewRetryTransport(r.Spec, nil)
+	tr.Sleep = func(context.Context, time.Duration) error { return nil }
+
+	c := http.Client{Transport: tr}
+
+	do := func() {
+		t.Helper()
+
+		resp, err := c.Do(newRequest(t, http.MethodPost, srv.URL+"/things", `{}`))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	// Gateway timeout may happen after request was processed.
+	do()
+	assert.Equal(t, 1, calls)
+
+	// Service unavailable without Retry-After is not known to be unprocessed.
+	calls, status = 0, http.StatusServiceUnavailable
+	do()
+	assert.Equal(t, 1, calls)
+
+	calls, retryAfter = 0, "1"
+	do()
+	assert.Equal(t, client.DefaultMaxAttempts, calls)
+
+	// Policy disables retries with single attempt.
+	calls = 0
+	tr.Policy = func(_, _ string, r openapi.Retry, ok bool) (openapi.Retry, bool) {
+		r.MaxAttempts = 0
+
+		return r, ok
+	}
+
+	do()
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// route keeps client semantics of an operation from spec extensions.
+type route struct {
+	method   string
+	pattern  string
+	segments []string
+
+	retry   openapi.Retry
+	retryOK bool
+
+	timeout   time.Duration
+	timeoutOK bool
+}
+
+// routes are sorted so that templates with more literal segments take precedence.
+type routes []route
+
+func newRoutes(s *openapi3.Spec) routes {
+	var rs routes
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			rt := route{
+				method:   strings.ToUpper(method),
+				pattern:  path,
+				segments: strings.Split(strings.Trim(path, "/"), "/"),
+			}
+
+			rt.retry, rt.retryOK = openapi.RetryOf(op.MapOfAnything[openapi.XRetryable])
+			rt.timeout, rt.timeoutOK = openapi.TimeoutOf(op.MapOfAnything[openapi.XTimeoutMs])
+			rs = append(rs, rt)
+		}
+	}
+
+	sort.Slice(rs, func(i, j int) bool {
+		li, lj := literalSegments(rs[i].segments), literalSegments(rs[j].segments)
+		if li != lj {
+			return li > lj
+		}
+
+		return rs[i].pattern < rs[j].pattern
+	})
+
+	return rs
+}
+
+// match finds operation of request by method and path, server base path is ignored.
+func (rs routes) match(req *http.Request) (route, bool) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	for _, rt := range rs {
+		if rt.method == req.Method && matchSegments(rt.segments, segments) {
+			return rt, true
+		}
+	}
+
+	return route{}, false
+}
+
+func literalSegments(segments []string) int {
+	n := 0
+
+	for _, s := range segments {
+		if !strings.HasPrefix(s, "{") {
+			n++
+		}
+	}
+
+	return n
+}
+
+// matchSegments checks trailing path segments against template, leading segments belong to base path.
+func matchSegments(template, segments []string) bool {
+	if len(segments) < len(template) {
+		return false
+	}
+
+	segments = segments[len(segments)-len(template):]
+
+	for i, s := range template {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if segments[i] == "" {
+				return false
+			}
+
+			continue
+		}
+
+		if s != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// TimeoutTransport applies `x-timeout-ms` extension of operations as request context deadline.
+//
+// Earlier deadline of request context is kept.
+type TimeoutTransport struct {
+	// Base performs requests, http.DefaultTransport is used if nil.
+	Base http.RoundTripper
+
+	// Policy can override timeout of an operation, ok is false for operations without extension.
+	Policy func(method, pathPattern string, d time.Duration, ok bool) (time.Duration, bool)
+
+	routes routes
+}
+
+// NewTimeoutTransport creates a transport with timeouts of spec operations.
+func NewTimeoutTransport(s *openapi3.Spec, base http.RoundTripper) *TimeoutTransport {
+	return &TimeoutTransport{Base: base, routes: newRoutes(s)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TimeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	var (
+		d  time.Duration
+		ok bool
+	)
+
+	if rt, found := t.routes.match(req); found {
+		d, ok = rt.timeout, rt.timeoutOK
+
+		if t.Policy != nil {
+			d, ok = t.Policy(rt.method, rt.pattern, d, ok)
+		}
+	}
+
+	if !ok || d <= 0 {
+		return base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), d)
+
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	// Deadline also covers reading of response body.
+	resp.Body = cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	defer b.cancel()
+
+	return b.ReadCloser.Close()
+}
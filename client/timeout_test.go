@@ -0,0 +1,69 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/client"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/slow")
+	require.NoError(t, err)
+	require.NoError(t, openapi3.SetTimeout(oc, 20*time.Millisecond))
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/fast")
+	require.NoError(t, err)
+	require.NoError(t, r.AddOperation(oc))
+
+	j, err := r.Spec.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"x-timeout-ms":20`)
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalJSON(j))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, hasDeadline := req.Context().Deadline()
+		assert.False(t, hasDeadline)
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-req.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	var deadlines []bool
+
+	c := http.Client{Transport: client.NewTimeoutTransport(&s, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		_, hasDeadline := req.Context().Deadline()
+		deadlines = append(deadlines, hasDeadline)
+
+		return http.DefaultTransport.RoundTrip(req)
+	}))}
+
+	_, err = c.Do(newRequest(t, http.MethodGet, srv.URL+"/api/slow", "")) //nolint:bodyclose // Request fails.
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	resp, err := c.Do(newRequest(t, http.MethodGet, srv.URL+"/fast", ""))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, []bool{true, false}, deadlines)
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,112 @@
+// Command openapi-go provides development tools for OpenAPI documents.
+//
+// Usage:
+//
+//	openapi-go watch [-dir ./api] [-interval 500ms] [-o openapi.yaml] ./cmd/spec [args...]
+//
+// The watch subcommand runs Go package that prints the spec to standard output whenever
+// Go sources change and reports changed lines.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/swaggest/openapi-go/watch"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: openapi-go watch [flags] <package> [args...]")
+	}
+
+	switch args[0] {
+	case "watch":
+		return watchCmd(args[1:], stdout, stderr)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+type dirs []string
+
+func (d *dirs) String() string {
+	return strings.Join(*d, ",")
+}
+
+func (d *dirs) Set(v string) error {
+	*d = append(*d, v)
+
+	return nil
+}
+
+func watchCmd(args []string, stdout, stderr io.Writer) error {
+	var cfg watch.Config
+
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Var((*dirs)(&cfg.Dirs), "dir", "directory with Go sources to watch, can be repeated (default current directory)")
+	fs.DurationVar(&cfg.Interval, "interval", 500*time.Millisecond, "polling interval")
+	out := fs.String("o", "", "file to write regenerated spec to")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("watch: missing package that prints spec")
+	}
+
+	initial := true
+
+	cfg.Generate = watch.GoRun(fs.Arg(0), fs.Args()[1:]...)
+	cfg.Report = func(r watch.Result) {
+		now := time.Now().Format("15:04:05")
+
+		if r.Err != nil {
+			_, _ = fmt.Fprintln(stderr, r.Err)
+
+			return
+		}
+
+		if *out != "" {
+			if err := os.WriteFile(*out, r.Spec, 0o600); err != nil {
+				_, _ = fmt.Fprintln(stderr, err)
+			}
+		}
+
+		switch {
+		case initial:
+			initial = false
+
+			_, _ = fmt.Fprintf(stdout, "%s generated spec, %d bytes\n", now, len(r.Spec))
+		case len(r.Diff) == 0:
+			_, _ = fmt.Fprintf(stdout, "%s spec unchanged\n", now)
+		default:
+			_, _ = fmt.Fprintf(stdout, "%s spec changed\n%s\n", now, strings.Join(r.Diff, "\n"))
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := watch.Run(ctx, cfg); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,378 @@
+// Package contract checks that tests of a handler exercise operations of OpenAPI 3.0 document
+// and that requests and responses conform to it.
+//
+// Harness is used as a middleware of tested handler, e.g.:
+//
+//	func TestAPI(t *testing.T) {
+//		h := contract.New(spec)
+//		srv := httptest.NewServer(h.Middleware(handler))
+//		// ... run tests against srv ...
+//		h.Check(t)
+//	}
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/validator"
+)
+
+// Coverage is a number of calls of a documented response.
+type Coverage struct {
+	// Method is an upper-case HTTP method and Path is a path template of operation.
+	Method string
+	Path   string
+
+	// Status is a key of documented response, e.g. "200", "4XX" or "default".
+	Status string
+
+	Calls int
+}
+
+// Violation describes a request or response that does not conform to document.
+type Violation struct {
+	// Method and Path identify operation, Path is a request path for undocumented operations.
+	Method string
+	Path   string
+
+	// Status is a status code of response.
+	Status int
+
+	Message string
+}
+
+// String returns violation as a single line.
+func (v Violation) String() string {
+	return v.Method + " " + v.Path + " " + strconv.Itoa(v.Status) + ": " + v.Message
+}
+
+// Report summarizes exercised operations and violations.
+type Report struct {
+	// Coverage lists documented responses ordered by path, method and status.
+	Coverage []Coverage
+
+	// Violations are unique violations ordered by path, method, status and message.
+	Violations []Violation
+}
+
+// Uncovered returns documented responses that were never exercised.
+func (r Report) Uncovered() []Coverage {
+	var res []Coverage
+
+	for _, c := range r.Coverage {
+		if c.Calls == 0 {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// String returns coverage summary, one line per documented response.
+func (r Report) String() string {
+	var (
+		b       strings.Builder
+		covered int
+	)
+
+	for _, c := range r.Coverage {
+		if c.Calls > 0 {
+			covered++
+		}
+
+		fmt.Fprintf(&b, "%s %s %s: %d\n", c.Method, c.Path, c.Status, c.Calls)
+	}
+
+	fmt.Fprintf(&b, "%d of %d responses covered, %d violations\n", covered, len(r.Coverage), len(r.Violations))
+
+	return b.String()
+}
+
+// Harness records requests and responses of document operations, it is safe for concurrent use.
+type Harness struct {
+	spec      *openapi3.Spec
+	table     openapi3.TelemetryTable
+	validator *validator.Validator
+
+	mu         sync.Mutex
+	calls      map[string]int
+	violations map[Violation]bool
+}
+
+// New creates a contract testing harness of document.
+//
+// Document should not be changed while harness is in use.
+func New(s *openapi3.Spec) *Harness {
+	return &Harness{
+		spec:       s,
+		table:      s.TelemetryTable(),
+		validator:  validator.New(s),
+		calls:      map[string]int{},
+		violations: map[Violation]bool{},
+	}
+}
+
+// Middleware checks requests and responses of handler.
+//
+// Invalid requests are still passed to handler, as tests may exercise error handling.
+func (h *Harness) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		reqErr := h.validator.Validate(req)
+		cw := &captureWriter{ResponseWriter: rw}
+
+		next.ServeHTTP(cw, req)
+
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+
+		h.record(req, reqErr, cw.status, cw.Header(), cw.body.Bytes())
+	})
+}
+
+func (h *Harness) record(req *http.Request, reqErr error, status int, header http.Header, body []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rt, ok := h.table.Match(req.Method, req.URL.Path)
+	if !ok {
+		h.violations[Violation{Method: req.Method, Path: req.URL.Path, Status: status, Message: "operation is not documented"}] = true
+
+		return
+	}
+
+	fail := func(msg string) {
+		h.violations[Violation{Method: rt.Method, Path: rt.Route, Status: status, Message: msg}] = true
+	}
+
+	var re *validator.RequestError
+
+	if errors.As(reqErr, &re) {
+		for _, fe := range re.Errors {
+			fail("invalid request: " + fe.String())
+		}
+	} else if reqErr != nil {
+		fail("invalid request: " + reqErr.Error())
+	}
+
+	op := h.spec.Paths.MapOfPathItemValues[rt.Route].MapOfOperationValues[strings.ToLower(rt.Method)]
+
+	key, resp := h.response(op.Responses, status)
+	if resp == nil {
+		fail("response is not documented")
+
+		return
+	}
+
+	h.calls[rt.Method+" "+rt.Route+" "+key]++
+
+	for _, name := range sortedKeys(resp.Headers) {
+		hdr := h.header(resp.Headers[name])
+		if hdr != nil && hdr.Required != nil && *hdr.Required && header.Get(name) == "" {
+			fail("required header " + name + " is missing")
+		}
+	}
+
+	if len(body) == 0 {
+		return
+	}
+
+	contentType := header.Get("Content-Type")
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = contentType
+	}
+
+	media, ok := resp.Content[mt]
+	if !ok {
+		fail("content type " + strconv.Quote(contentType) + " is not documented")
+
+		return
+	}
+
+	if media.Schema == nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+		return
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(body, &value); err != nil {
+		fail("invalid JSON body: " + err.Error())
+
+		return
+	}
+
+	var errs openapi3.ValidationErrors
+
+	if err := h.spec.ValidateValue(*media.Schema, value); errors.As(err, &errs) {
+		for _, e := range errs {
+			fail("invalid response: body" + e.Pointer + ": " + e.Message)
+		}
+	}
+}
+
+// response finds documented response by status, status family (e.g. `4XX`) and default.
+func (h *Harness) response(responses openapi3.Responses, status int) (string, *openapi3.Response) {
+	code := strconv.Itoa(status)
+
+	for _, key := range []string{code, code[:1] + "XX"} {
+		if rr, ok := responses.MapOfResponseOrRefValues[key]; ok {
+			return key, h.resolveResponse(rr)
+		}
+	}
+
+	if responses.Default != nil {
+		return "default", h.resolveResponse(*responses.Default)
+	}
+
+	return "", nil
+}
+
+func (h *Harness) resolveResponse(rr openapi3.ResponseOrRef) *openapi3.Response {
+	if rr.ResponseReference != nil {
+		if c := h.spec.Components; c != nil && c.Responses != nil {
+			name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+			return c.Responses.MapOfResponseOrRefValues[name].Response
+		}
+
+		return nil
+	}
+
+	return rr.Response
+}
+
+func (h *Harness) header(hr openapi3.HeaderOrRef) *openapi3.Header {
+	if hr.HeaderReference != nil {
+		if c := h.spec.Components; c != nil && c.Headers != nil {
+			name := strings.TrimPrefix(hr.HeaderReference.Ref, "#/components/headers/")
+
+			return c.Headers.MapOfHeaderOrRefValues[name].Header
+		}
+
+		return nil
+	}
+
+	return hr.Header
+}
+
+// Report returns coverage of documented responses and violations recorded so far.
+func (h *Harness) Report() Report {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var r Report
+
+	for _, path := range sortedKeys(h.spec.Paths.MapOfPathItemValues) {
+		pi := h.spec.Paths.MapOfPathItemValues[path]
+
+		for _, method := range sortedKeys(pi.MapOfOperationValues) {
+			responses := pi.MapOfOperationValues[method].Responses
+			method = strings.ToUpper(method)
+
+			statuses := sortedKeys(responses.MapOfResponseOrRefValues)
+			if responses.Default != nil {
+				statuses = append(statuses, "default")
+			}
+
+			for _, status := range statuses {
+				r.Coverage = append(r.Coverage, Coverage{
+					Method: method,
+					Path:   path,
+					Status: status,
+					Calls:  h.calls[method+" "+path+" "+status],
+				})
+			}
+		}
+	}
+
+	for v := range h.violations {
+		r.Violations = append(r.Violations, v)
+	}
+
+	sort.Slice(r.Violations, func(i, j int) bool {
+		a, b := r.Violations[i], r.Violations[j]
+
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+
+		return a.Message < b.Message
+	})
+
+	return r
+}
+
+// Check logs coverage summary and fails test for every violation and every response that was never exercised.
+func (h *Harness) Check(t testing.TB) {
+	t.Helper()
+
+	r := h.Report()
+
+	t.Log("contract coverage:\n" + r.String())
+
+	for _, v := range r.Violations {
+		t.Error("contract violation: " + v.String())
+	}
+
+	for _, c := range r.Uncovered() {
+		t.Errorf("response is not exercised: %s %s %s", c.Method, c.Path, c.Status)
+	}
+}
+
+// captureWriter keeps status and body of response.
+type captureWriter struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,120 @@
+package contract_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/contract"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type recordingTB struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recordingTB) Helper()                   {}
+func (r *recordingTB) Log(_ ...interface{})      {}
+func (r *recordingTB) Error(args ...interface{}) { r.errors = append(r.errors, fmt.Sprint(args...)) }
+func (r *recordingTB) Errorf(f string, a ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(f, a...))
+}
+
+func TestHarness(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Request-Id: {required: true, schema: {type: string}}
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, name]
+                properties:
+                  id: {type: integer}
+                  name: {type: string}
+        4XX:
+          description: Client error
+  /users:
+    post:
+      responses:
+        "201": {description: Created}
+`)))
+
+	h := contract.New(&s)
+
+	srv := httptest.NewServer(h.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1":
+			rw.Header().Set("X-Request-Id", "abc")
+			rw.Header().Set("Content-Type", "application/json")
+			_, _ = rw.Write([]byte(`{"id":1,"name":"John"}`))
+		case "/users/2":
+			rw.Header().Set("Content-Type", "application/json")
+			_, _ = rw.Write([]byte(`{"id":"2"}`))
+		case "/users/abc":
+			rw.WriteHeader(http.StatusBadRequest)
+		default:
+			http.NotFound(rw, r)
+		}
+	})))
+	defer srv.Close()
+
+	for _, path := range []string{"/users/1", "/users/1", "/users/2", "/users/abc", "/orders"} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	r := h.Report()
+
+	assert.Equal(t, []contract.Coverage{
+		{Method: "POST", Path: "/users", Status: "201", Calls: 0},
+		{Method: "GET", Path: "/users/{id}", Status: "200", Calls: 3},
+		{Method: "GET", Path: "/users/{id}", Status: "4XX", Calls: 1},
+	}, r.Coverage)
+
+	assert.Equal(t, []contract.Coverage{{Method: "POST", Path: "/users", Status: "201"}}, r.Uncovered())
+
+	var violations []string
+	for _, v := range r.Violations {
+		violations = append(violations, v.String())
+	}
+
+	assert.Equal(t, []string{
+		"GET /orders 404: operation is not documented",
+		"GET /users/{id} 200: invalid response: body/id: string value for integer",
+		"GET /users/{id} 200: invalid response: body/name: required property is missing",
+		"GET /users/{id} 200: required header X-Request-Id is missing",
+		"GET /users/{id} 400: invalid request: path id: string value for integer",
+	}, violations)
+
+	assert.Equal(t, strings.Join([]string{
+		"POST /users 201: 0",
+		"GET /users/{id} 200: 3",
+		"GET /users/{id} 4XX: 1",
+		"2 of 3 responses covered, 5 violations",
+		"",
+	}, "\n"), r.String())
+
+	tb := &recordingTB{TB: t}
+	h.Check(tb)
+	assert.Len(t, tb.errors, 6)
+	assert.Equal(t, "response is not exercised: POST /users 201", tb.errors[5])
+}
@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DocComments maps Go types and fields to their doc comments, keys are
+// `<package path>.<Type>` and `<package path>.<Type>.<Field>`.
+//
+// It can be filled by parsing sources with AddPackage, or loaded from a metadata file
+// (e.g. JSON generated with `go generate`) when sources are not available at runtime.
+type DocComments map[string]string
+
+// AddPackage reads doc comments of struct types and their fields from Go files in dir,
+// pkgPath is an import path of the package.
+//
+// Field doc comments take precedence over line comments.
+// External test package (with `_test` suffix of pkgPath) is read from its own files.
+func (dc DocComments) AddPackage(pkgPath, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	testPkg := strings.HasSuffix(pkgPath, "_test")
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(f.Name.Name, "_test") != testPkg {
+			continue
+		}
+
+		dc.addFile(pkgPath, f)
+	}
+
+	return nil
+}
+
+// Type returns doc comment of a type by package path and name.
+func (dc DocComments) Type(pkgPath, typeName string) string {
+	return dc[pkgPath+"."+typeName]
+}
+
+// Field returns doc comment of a struct field by package path, type name and field name.
+func (dc DocComments) Field(pkgPath, typeName, fieldName string) string {
+	return dc[pkgPath+"."+typeName+"."+fieldName]
+}
+
+func (dc DocComments) addFile(pkgPath string, f *ast.File) {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+
+			if text := strings.TrimSpace(doc.Text()); text != "" {
+				dc[pkgPath+"."+ts.Name.Name] = text
+			}
+
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			for _, field := range st.Fields.List {
+				doc := field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+
+				text := strings.TrimSpace(doc.Text())
+				if text == "" {
+					continue
+				}
+
+				for _, name := range field.Names {
+					dc[pkgPath+"."+ts.Name.Name+"."+name.Name] = text
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package docs serves OpenAPI documents over HTTP.
+package docs
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Handler serves multiple named documents.
+//
+// Routes are relative to handler mount point, use http.StripPrefix to mount it under a path:
+//   - `/` is an index page with a list of available documents,
+//   - `/{name}/openapi.json` is a document in JSON,
+//   - `/{name}/openapi.yaml` is a document in YAML,
+//   - `/{name}/openapi` is a document in format and version negotiated with Accept header,
+//     e.g. `application/yaml` or `application/vnd.oai.openapi+json;version=3.1`,
+//   - `/{name}/versions` lists available versions and formats of a document in JSON.
+//
+// Documents are converted between JSON and YAML on the fly, `version` query parameter
+// selects OpenAPI version of a document, see VersionedSpecProvider.
+type Handler struct {
+	// Provider is a source of documents.
+	Provider SpecProvider
+
+	// Title is a heading of an index page, default "API Documentation".
+	Title string
+
+	// ErrorLog receives errors of provider and conversion, default is a standard logger of log package.
+	// Clients get a generic message with status 500, so that internal details are not exposed.
+	ErrorLog *log.Logger
+}
+
+var _ http.Handler = Handler{}
+
+// ServeHTTP serves index page and documents.
+func (h Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/")
+	if p == "" {
+		h.serveIndex(rw, r)
+
+		return
+	}
+
+	pos := strings.LastIndex(p, "/")
+	if pos <= 0 {
+		http.NotFound(rw, r)
+
+		return
+	}
+
+	name, file := p[:pos], p[pos+1:]
+
+	switch file {
+	case "openapi.json":
+		h.serveSpec(rw, r, name, r.URL.Query().Get("version"), false)
+	case "openapi.yaml", "openapi.yml":
+		h.serveSpec(rw, r, name, r.URL.Query().Get("version"), true)
+	case "openapi":
+		h.serveNegotiated(rw, r, name)
+	case "versions":
+		h.serveDiscovery(rw, r, name)
+	default:
+		http.NotFound(rw, r)
+	}
+}
+
+// ServeSpec writes a named document of provider in JSON or YAML, document is converted if it has another format.
+//
+// Unknown document is reported with status 404, `version` query parameter selects OpenAPI version of a document.
+func ServeSpec(rw http.ResponseWriter, r *http.Request, p SpecProvider, name string, toYAML bool) {
+	Handler{Provider: p}.serveSpec(rw, r, name, r.URL.Query().Get("version"), toYAML)
+}
+
+func (h Handler) serveSpec(rw http.ResponseWriter, r *http.Request, name, version string, toYAML bool) {
+	s, err := h.spec(r.Context(), name, version)
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	isJSON := len(bytes.TrimSpace(s)) > 0 && bytes.TrimSpace(s)[0] == '{'
+
+	switch {
+	case toYAML && isJSON:
+		s, err = yaml.JSONToYAML(s)
+	case !toYAML && !isJSON:
+		s, err = yaml.YAMLToJSON(s)
+	}
+
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	if toYAML {
+		rw.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+	} else {
+		rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	_, _ = rw.Write(s)
+}
+
+var indexTmpl = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+{{- range .Docs}}
+<li>{{.Name}}: <a href="{{.Path}}/openapi.json">JSON</a>, <a href="{{.Path}}/openapi.yaml">YAML</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+type indexDoc struct {
+	Name string
+	Path string
+}
+
+func (h Handler) serveIndex(rw http.ResponseWriter, r *http.Request) {
+	names, err := h.Provider.Names(r.Context())
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	data := struct {
+		Title string
+		Docs  []indexDoc
+	}{
+		Title: h.Title,
+	}
+
+	if data.Title == "" {
+		data.Title = "API Documentation"
+	}
+
+	base := "./"
+	if r.URL.Path == "" {
+		// Handler is mounted without trailing slash, relative links need the last path segment.
+		base = "./" + lastSegment(r.RequestURI) + "/"
+	}
+
+	for _, name := range names {
+		segments := strings.Split(name, "/")
+		for i, s := range segments {
+			segments[i] = url.PathEscape(s)
+		}
+
+		data.Docs = append(data.Docs, indexDoc{Name: name, Path: base + strings.Join(segments, "/")})
+	}
+
+	var buf bytes.Buffer
+
+	if err := indexTmpl.Execute(&buf, data); err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = rw.Write(buf.Bytes())
+}
+
+func lastSegment(requestURI string) string {
+	if pos := strings.IndexAny(requestURI, "?#"); pos >= 0 {
+		requestURI = requestURI[:pos]
+	}
+
+	return requestURI[strings.LastIndex(requestURI, "/")+1:]
+}
+
+func (h Handler) writeError(rw http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(rw, r)
+
+		return
+	}
+
+	logf := log.Printf
+	if h.ErrorLog != nil {
+		logf = h.ErrorLog.Printf
+	}
+
+	logf("docs: %s %s: %v", r.Method, r.URL.Path, err)
+
+	http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,186 @@
+package docs_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/docs"
+)
+
+func serve(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return rw
+}
+
+func TestHandler(t *testing.T) {
+	h := http.StripPrefix("/docs", docs.Handler{
+		Provider: docs.StaticProvider{
+			"users":  []byte(`{"openapi":"3.0.3","info":{"title":"Users","version":"1"}}`),
+			"orders": []byte("openapi: 3.0.3\ninfo:\n  title: Orders\n  version: \"1\"\n"),
+		},
+	})
+
+	rw := serve(t, h, "/docs/")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `<li>orders: <a href="./orders/openapi.json">JSON</a>, <a href="./orders/openapi.yaml">YAML</a></li>
+<li>users: <a href="./users/openapi.json">JSON</a>`)
+
+	rw = serve(t, h, "/docs")
+	assert.Contains(t, rw.Body.String(), `<a href="./docs/orders/openapi.json">`)
+
+	rw = serve(t, h, "/docs/orders/openapi.json")
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"Orders","version":"1"}}`, rw.Body.String())
+
+	rw = serve(t, h, "/docs/users/openapi.yaml")
+	assert.Equal(t, "application/yaml; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "title: Users")
+
+	assert.Equal(t, http.StatusNotFound, serve(t, h, "/docs/unknown/openapi.json").Code)
+	assert.Equal(t, http.StatusNotFound, serve(t, h, "/docs/users/index.html").Code)
+}
+
+func serveAccept(t *testing.T, h http.Handler, target, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Accept", accept)
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+
+	return rw
+}
+
+func TestHandler_versions(t *testing.T) {
+	h := docs.Handler{
+		Provider: docs.VersionedProvider{
+			"3.0": docs.StaticProvider{
+				"users": []byte(`{"openapi":"3.0.3","info":{"title":"Users","version":"1"}}`),
+			},
+			"3.1": docs.StaticProvider{
+				"users":  []byte(`{"openapi":"3.1.0","info":{"title":"Users","version":"1"}}`),
+				"orders": []byte(`{"openapi":"3.1.0","info":{"title":"Orders","version":"1"}}`),
+			},
+		},
+	}
+
+	rw := serve(t, h, "/users/versions")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+	  "name":"users",
+	  "versions":[
+		{"openapi":"3.0","json":"openapi.json?version=3.0","yaml":"openapi.yaml?version=3.0"},
+		{"openapi":"3.1","json":"openapi.json?version=3.1","yaml":"openapi.yaml?version=3.1"}
+	  ]
+	}`, rw.Body.String())
+
+	assert.Contains(t, serve(t, h, "/users/openapi.json").Body.String(), `"openapi":"3.1.0"`)
+	assert.Contains(t, serve(t, h, "/users/openapi.json?version=3.0").Body.String(), `"openapi":"3.0.3"`)
+	assert.Contains(t, serve(t, h, "/users/openapi.yaml?version=3.0.3").Body.String(), "openapi: 3.0.3")
+	assert.Equal(t, http.StatusNotFound, serve(t, h, "/orders/openapi.json?version=3.0").Code)
+	assert.Equal(t, http.StatusNotFound, serve(t, h, "/unknown/versions").Code)
+
+	rw = serveAccept(t, h, "/users/openapi", "")
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", rw.Header().Get("Vary"))
+	assert.Contains(t, rw.Body.String(), `"openapi":"3.1.0"`)
+
+	rw = serveAccept(t, h, "/users/openapi", "application/vnd.oai.openapi;version=3.0")
+	assert.Equal(t, "application/yaml; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "openapi: 3.0.3")
+
+	rw = serveAccept(t, h, "/users/openapi",
+		"application/vnd.oai.openapi+json;version=3.2, application/yaml;q=0.5, application/json;q=0.9;version=3.0")
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), `"openapi":"3.0.3"`)
+
+	rw = serveAccept(t, h, "/orders/openapi", "application/json;version=3.0, text/html")
+	assert.Equal(t, http.StatusNotAcceptable, rw.Code)
+}
+
+func TestHandler_versions_plainProvider(t *testing.T) {
+	h := docs.Handler{
+		Provider: docs.StaticProvider{
+			"orders": []byte("openapi: 3.0.3\ninfo:\n  title: Orders\n  version: \"1\"\n"),
+		},
+	}
+
+	assert.JSONEq(t, `{
+	  "name":"orders",
+	  "versions":[{"openapi":"3.0","json":"openapi.json?version=3.0","yaml":"openapi.yaml?version=3.0"}]
+	}`, serve(t, h, "/orders/versions").Body.String())
+
+	rw := serveAccept(t, h, "/orders/openapi", "text/yaml, */*;q=0.1")
+	assert.Equal(t, "application/yaml; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "title: Orders")
+
+	assert.Equal(t, http.StatusNotAcceptable, serveAccept(t, h, "/orders/openapi", "application/json;version=3.1").Code)
+	assert.Equal(t, http.StatusNotFound, serve(t, h, "/orders/openapi.json?version=3.1").Code)
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Names(_ context.Context) ([]string, error) {
+	return nil, errors.New("registry at 10.0.0.1 is unavailable")
+}
+
+func (p failingProvider) Spec(ctx context.Context, _ string) ([]byte, error) {
+	_, err := p.Names(ctx)
+
+	return nil, err
+}
+
+func TestHandler_error(t *testing.T) {
+	var logged bytes.Buffer
+
+	h := docs.Handler{Provider: failingProvider{}, ErrorLog: log.New(&logged, "", 0)}
+
+	rw := serve(t, h, "/users/openapi.json")
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Equal(t, "Internal Server Error\n", rw.Body.String())
+	assert.Equal(t, "docs: GET /users/openapi.json: registry at 10.0.0.1 is unavailable\n", logged.String())
+}
+
+func TestDirProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "billing.yaml"), []byte("openapi: 3.1.0\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "auth.json"), []byte(`{"openapi":"3.1.0"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Partners.YML"), []byte("openapi: 3.0.3\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Specs"), 0o600))
+
+	p := docs.DirProvider{Dir: dir}
+
+	names, err := p.Names(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Partners", "auth", "billing"}, names)
+
+	s, err := p.Spec(context.Background(), "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\n", string(s))
+
+	s, err = p.Spec(context.Background(), "Partners")
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.0.3\n", string(s))
+
+	_, err = p.Spec(context.Background(), "../billing")
+	assert.ErrorIs(t, err, docs.ErrNotFound)
+
+	_, err = p.Spec(context.Background(), "README")
+	assert.ErrorIs(t, err, docs.ErrNotFound)
+}
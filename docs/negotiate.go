@@ -0,0 +1,241 @@
+package docs
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// Media types of documents, OpenAPI media types may have `version` parameter, e.g. `application/vnd.oai.openapi+json;version=3.1`.
+var (
+	jsonMediaTypes = []string{"application/json", "application/vnd.oai.openapi+json"}
+	yamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml", "application/vnd.oai.openapi"}
+)
+
+type discoveryVersion struct {
+	OpenAPI string `json:"openapi"`
+	JSON    string `json:"json"`
+	YAML    string `json:"yaml"`
+}
+
+type discovery struct {
+	Name     string             `json:"name"`
+	Versions []discoveryVersion `json:"versions"`
+}
+
+// serveDiscovery lists available versions and formats of a document.
+func (h Handler) serveDiscovery(rw http.ResponseWriter, r *http.Request, name string) {
+	versions, err := h.versions(r.Context(), name)
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	d := discovery{Name: name, Versions: make([]discoveryVersion, 0, len(versions))}
+
+	for _, v := range versions {
+		q := "?version=" + url.QueryEscape(v)
+
+		d.Versions = append(d.Versions, discoveryVersion{
+			OpenAPI: v,
+			JSON:    "openapi.json" + q,
+			YAML:    "openapi.yaml" + q,
+		})
+	}
+
+	j, err := json.Marshal(d)
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = rw.Write(j)
+}
+
+// serveNegotiated serves a document in format and version of Accept header, JSON of default version if it is empty.
+func (h Handler) serveNegotiated(rw http.ResponseWriter, r *http.Request, name string) {
+	rw.Header().Add("Vary", "Accept")
+
+	versions, err := h.versions(r.Context(), name)
+	if err != nil {
+		h.writeError(rw, r, err)
+
+		return
+	}
+
+	toYAML, version, ok := negotiate(r.Header.Get("Accept"), versions)
+	if !ok {
+		http.Error(rw, http.StatusText(http.StatusNotAcceptable), http.StatusNotAcceptable)
+
+		return
+	}
+
+	h.serveSpec(rw, r, name, version, toYAML)
+}
+
+type acceptRange struct {
+	mediaType string
+	version   string
+	q         float64
+}
+
+// negotiate picks format and version of a document by Accept header.
+func negotiate(accept string, versions []string) (toYAML bool, version string, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return false, "", true
+	}
+
+	var ranges []acceptRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		ar := acceptRange{mediaType: mt, version: params["version"], q: 1}
+
+		if q, ok := params["q"]; ok {
+			if ar.q, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		if ar.q > 0 {
+			ranges = append(ranges, ar)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	for _, ar := range ranges {
+		if ar.version != "" && !hasVersion(versions, ar.version) {
+			continue
+		}
+
+		switch {
+		case ar.mediaType == "*/*" || ar.mediaType == "application/*" || contains(jsonMediaTypes, ar.mediaType):
+			return false, ar.version, true
+		case ar.mediaType == "text/*" || contains(yamlMediaTypes, ar.mediaType):
+			return true, ar.version, true
+		}
+	}
+
+	return false, "", false
+}
+
+// versions returns available OpenAPI versions of a document.
+func (h Handler) versions(ctx context.Context, name string) ([]string, error) {
+	if vp, ok := h.Provider.(VersionedSpecProvider); ok {
+		versions, err := vp.Versions(ctx, name)
+		if err == nil && len(versions) == 0 {
+			err = ErrNotFound
+		}
+
+		return versions, err
+	}
+
+	s, err := h.Provider.Spec(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{specVersion(s)}, nil
+}
+
+// spec returns a document of requested OpenAPI version, default version if it is empty.
+func (h Handler) spec(ctx context.Context, name, version string) ([]byte, error) {
+	if version == "" {
+		return h.Provider.Spec(ctx, name)
+	}
+
+	if vp, ok := h.Provider.(VersionedSpecProvider); ok {
+		versions, err := vp.Versions(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range versions {
+			if sameVersion(v, version) {
+				return vp.SpecVersion(ctx, name, v)
+			}
+		}
+
+		return nil, ErrNotFound
+	}
+
+	s, err := h.Provider.Spec(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameVersion(specVersion(s), version) {
+		return nil, ErrNotFound
+	}
+
+	return s, nil
+}
+
+// specVersion returns major and minor version of `openapi` (or `swagger`) field of a JSON or YAML document.
+func specVersion(s []byte) string {
+	var d struct {
+		OpenAPI string `yaml:"openapi"`
+		Swagger string `yaml:"swagger"`
+	}
+
+	if err := yaml.Unmarshal(s, &d); err != nil {
+		return ""
+	}
+
+	v := d.OpenAPI
+	if v == "" {
+		v = d.Swagger
+	}
+
+	return majorMinor(v)
+}
+
+// sameVersion compares major and minor parts of versions, e.g. "3.1" and "3.1.0" are same.
+func sameVersion(a, b string) bool {
+	return majorMinor(a) == majorMinor(b)
+}
+
+func majorMinor(v string) string {
+	if parts := strings.SplitN(v, ".", 3); len(parts) > 2 {
+		return parts[0] + "." + parts[1]
+	}
+
+	return v
+}
+
+func hasVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if sameVersion(v, version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
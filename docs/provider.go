@@ -0,0 +1,236 @@
+package docs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by SpecProvider for unknown documents.
+var ErrNotFound = errors.New("spec not found")
+
+// SpecProvider is a storage of named OpenAPI documents.
+//
+// Implementations can serve static documents, files or fetch them from a schema registry.
+type SpecProvider interface {
+	// Names lists available documents.
+	Names(ctx context.Context) ([]string, error)
+
+	// Spec returns JSON or YAML document by name, ErrNotFound for unknown name.
+	Spec(ctx context.Context, name string) ([]byte, error)
+}
+
+// StaticProvider serves documents from memory.
+type StaticProvider map[string][]byte
+
+var _ SpecProvider = StaticProvider{}
+
+// Names lists available documents.
+func (p StaticProvider) Names(_ context.Context) ([]string, error) {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Spec returns document by name.
+func (p StaticProvider) Spec(_ context.Context, name string) ([]byte, error) {
+	if s, ok := p[name]; ok {
+		return s, nil
+	}
+
+	return nil, ErrNotFound
+}
+
+// DirProvider serves *.json, *.yaml and *.yml files from a directory, file name without extension is a document name,
+// extensions are matched case-insensitively.
+//
+// Directory is read on every request, so that documents can be updated without restart.
+type DirProvider struct {
+	Dir string
+}
+
+var _ SpecProvider = DirProvider{}
+
+// Names lists available documents.
+func (p DirProvider) Names(_ context.Context) ([]string, error) {
+	files, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Spec returns document by name.
+func (p DirProvider) Spec(_ context.Context, name string) ([]byte, error) {
+	files, err := p.files()
+	if err != nil {
+		return nil, err
+	}
+
+	fileName, ok := files[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	s, err := os.ReadFile(filepath.Join(p.Dir, fileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return s, err
+}
+
+// files maps document names to file names, first file in lexical order wins if a name has several files.
+func (p DirProvider) files() (map[string]string, error) {
+	entries, err := os.ReadDir(p.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(entries))
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if name, ok := specName(e.Name()); ok {
+			if _, found := files[name]; !found {
+				files[name] = e.Name()
+			}
+		}
+	}
+
+	return files, nil
+}
+
+func specName(fileName string) (string, bool) {
+	ext := filepath.Ext(fileName)
+
+	switch strings.ToLower(ext) {
+	case ".json", ".yaml", ".yml":
+		return strings.TrimSuffix(fileName, ext), true
+	}
+
+	return "", false
+}
+
+// VersionedSpecProvider is a storage of documents available in multiple OpenAPI versions.
+//
+// Handler uses it to serve a document in a version requested by a client,
+// documents of plain SpecProvider are served in their own version only.
+type VersionedSpecProvider interface {
+	SpecProvider
+
+	// Versions lists available OpenAPI versions of a document, e.g. "3.0" and "3.1".
+	Versions(ctx context.Context, name string) ([]string, error)
+
+	// SpecVersion returns JSON or YAML document by name and version, ErrNotFound for unknown name or version.
+	SpecVersion(ctx context.Context, name, version string) ([]byte, error)
+}
+
+// VersionedProvider serves documents of multiple OpenAPI versions, map key is a version, e.g. "3.0" or "3.1".
+//
+// Latest available version of a document is served if version is not requested.
+type VersionedProvider map[string]SpecProvider
+
+var _ VersionedSpecProvider = VersionedProvider{}
+
+// Names lists documents available in any version.
+func (p VersionedProvider) Names(ctx context.Context) ([]string, error) {
+	unique := map[string]bool{}
+
+	var names []string
+
+	for _, version := range p.sortedVersions() {
+		vn, err := p[version].Names(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range vn {
+			if !unique[name] {
+				unique[name] = true
+
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Spec returns latest available version of a document.
+func (p VersionedProvider) Spec(ctx context.Context, name string) ([]byte, error) {
+	versions, err := p.Versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return p.SpecVersion(ctx, name, versions[len(versions)-1])
+}
+
+// Versions lists versions of a document in ascending order.
+func (p VersionedProvider) Versions(ctx context.Context, name string) ([]string, error) {
+	var versions []string
+
+	for _, version := range p.sortedVersions() {
+		vn, err := p[version].Names(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range vn {
+			if n == name {
+				versions = append(versions, version)
+
+				break
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// SpecVersion returns document by name and version.
+func (p VersionedProvider) SpecVersion(ctx context.Context, name, version string) ([]byte, error) {
+	vp, ok := p[version]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return vp.Spec(ctx, name)
+}
+
+func (p VersionedProvider) sortedVersions() []string {
+	versions := make([]string, 0, len(p))
+	for version := range p {
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+
+	return versions
+}
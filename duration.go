@@ -0,0 +1,18 @@
+package openapi
+
+// DurationPolicy defines how reflected time.Duration values are described.
+type DurationPolicy int
+
+// DurationPolicy values enumeration.
+const (
+	// DurationNanoseconds describes durations as integer nanoseconds, as encoding/json marshals them,
+	// this is the default.
+	DurationNanoseconds = DurationPolicy(iota)
+
+	// DurationString describes durations as strings with `format: duration` (ISO 8601, e.g. `PT1M30S`),
+	// integer `example`, `default`, `const` and `enum` values are converted from nanoseconds.
+	//
+	// This policy fits APIs that encode durations with a custom marshaler,
+	// `format` field tag overrides format, e.g. `format:"go-duration"`.
+	DurationString
+)
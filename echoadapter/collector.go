@@ -0,0 +1,114 @@
+package echoadapter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// Collector pre-populates operation contexts of routes of existing echo applications.
+//
+//	c := echoadapter.NewCollector(reflector)
+//	for _, r := range e.Routes() {
+//		if err := c.Route(r.Method, r.Path); err != nil { ... }
+//	}
+//
+// Operations are described with Operation before Add, routes that are not described yet
+// are added with string path parameters, so that applications can be documented incrementally.
+type Collector struct {
+	reflector openapi.Reflector
+	ops       []openapi.OperationContext
+	index     map[string]int
+}
+
+// NewCollector creates collector of operations for reflector.
+func NewCollector(r openapi.Reflector) *Collector {
+	return &Collector{
+		reflector: r,
+		index:     map[string]int{},
+	}
+}
+
+// Route collects operation of echo route.
+//
+// Routes with methods that can not be documented (e.g. CONNECT, PROPFIND or not found handlers) are skipped.
+func (c *Collector) Route(method, path string) error {
+	if _, _, _, err := openapi.SanitizeMethodPath(method, "/"); err != nil {
+		return nil //nolint:nilerr // Undocumented method is skipped.
+	}
+
+	path = Path(path)
+	key := strings.ToUpper(method) + " " + path
+
+	if _, ok := c.index[key]; ok {
+		return nil
+	}
+
+	oc, err := c.reflector.NewOperationContext(method, path)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	c.index[key] = len(c.ops)
+	c.ops = append(c.ops, oc)
+
+	return nil
+}
+
+// Operation returns collected operation context of method and echo path, or nil if route was not collected.
+func (c *Collector) Operation(method, path string) openapi.OperationContext {
+	i, ok := c.index[strings.ToUpper(method)+" "+Path(path)]
+	if !ok {
+		return nil
+	}
+
+	return c.ops[i]
+}
+
+// Operations returns collected operation contexts in order of collecting.
+func (c *Collector) Operations() []openapi.OperationContext {
+	return c.ops
+}
+
+// Add adds collected operations to reflector.
+//
+// Operations without request structures get path parameters of string type.
+func (c *Collector) Add() error {
+	for _, oc := range c.ops {
+		if len(oc.Request()) == 0 {
+			if params := pathParams(oc.PathPattern()); params != nil {
+				oc.AddReqStructure(params)
+			}
+		}
+
+		if err := c.reflector.AddOperation(oc); err != nil {
+			return fmt.Errorf("%s %s: %w", oc.Method(), oc.PathPattern(), err)
+		}
+	}
+
+	return nil
+}
+
+// pathParams returns a value of struct with string fields of path parameters.
+func pathParams(path string) interface{} {
+	var fields []reflect.StructField
+
+	for _, s := range strings.Split(path, "/") {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			fields = append(fields, reflect.StructField{
+				Name: "P" + strconv.Itoa(len(fields)),
+				Type: reflect.TypeOf(""),
+				Tag:  reflect.StructTag(`path:"` + s[1:len(s)-1] + `"`),
+			})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return reflect.New(reflect.StructOf(fields)).Elem().Interface()
+}
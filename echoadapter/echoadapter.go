@@ -0,0 +1,156 @@
+// Package echoadapter documents routes of github.com/labstack/echo applications.
+//
+// Routes are documented while they are registered with Wrap, or collected from existing applications
+// with Collector. Echo and groups are wrapped by method set, so this package does not depend on echo:
+//
+//	api := echoadapter.Wrap[echo.HandlerFunc, echo.MiddlewareFunc, *echo.Route](e.Group("/api"), "/api", reflector)
+//	err := api.GET("/things/:id", getThingReq{}, thing{}, getThing)
+package echoadapter
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+// Router is implemented by *echo.Echo and *echo.Group with H of echo.HandlerFunc, M of echo.MiddlewareFunc
+// and R of *echo.Route.
+type Router[H any, M any, R any] interface {
+	Add(method, path string, handler H, middleware ...M) R
+}
+
+// Group registers handlers on echo router and adds their operations to reflector.
+type Group[H any, M any, R any] struct {
+	router    Router[H, M, R]
+	prefix    string
+	reflector openapi.Reflector
+}
+
+// Wrap creates an adapter of echo router and reflector, prefix is a path prefix of echo group
+// (empty for *echo.Echo).
+func Wrap[H any, M any, R any](r Router[H, M, R], prefix string, reflector openapi.Reflector) *Group[H, M, R] {
+	return &Group[H, M, R]{router: r, prefix: prefix, reflector: reflector}
+}
+
+// Router returns underlying echo router.
+func (g *Group[H, M, R]) Router() Router[H, M, R] {
+	return g.router
+}
+
+// Add documents operation with request and response samples and registers handler on echo router.
+//
+// Request sample is reflected with echo binding tags, see BindingTags, response sample declares
+// body of successful response, nil samples are not documented. Setup functions describe operation further.
+//
+// Handler is not registered if operation can not be added to reflector.
+func (g *Group[H, M, R]) Add(
+	method, path string, req, resp interface{}, h H, middleware []M, setup ...func(oc openapi.OperationContext),
+) error {
+	oc, err := g.reflector.NewOperationContext(method, Path(g.prefix+path))
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	if req != nil {
+		oc.AddReqStructure(req, BindingTags)
+	}
+
+	if resp != nil {
+		oc.AddRespStructure(resp)
+	}
+
+	for _, s := range setup {
+		s(oc)
+	}
+
+	if err := g.reflector.AddOperation(oc); err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	g.router.Add(method, path, h, middleware...)
+
+	return nil
+}
+
+// GET documents and registers GET operation, see Add.
+func (g *Group[H, M, R]) GET(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodGet, path, req, resp, h, m)
+}
+
+// POST documents and registers POST operation, see Add.
+func (g *Group[H, M, R]) POST(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodPost, path, req, resp, h, m)
+}
+
+// PUT documents and registers PUT operation, see Add.
+func (g *Group[H, M, R]) PUT(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodPut, path, req, resp, h, m)
+}
+
+// PATCH documents and registers PATCH operation, see Add.
+func (g *Group[H, M, R]) PATCH(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodPatch, path, req, resp, h, m)
+}
+
+// DELETE documents and registers DELETE operation, see Add.
+func (g *Group[H, M, R]) DELETE(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodDelete, path, req, resp, h, m)
+}
+
+// HEAD documents and registers HEAD operation, see Add.
+func (g *Group[H, M, R]) HEAD(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodHead, path, req, resp, h, m)
+}
+
+// OPTIONS documents and registers OPTIONS operation, see Add.
+func (g *Group[H, M, R]) OPTIONS(path string, req, resp interface{}, h H, m ...M) error {
+	return g.Add(http.MethodOptions, path, req, resp, h, m)
+}
+
+// BindingTags maps fields of request structure with echo `param` tags to path parameters and
+// keeps fields with `form` tags out of query parameters, as echo binds them from request body only.
+//
+// Other echo binding tags (`query`, `header` and `json`) are reflected as is.
+func BindingTags(cu *openapi.ContentUnit) {
+	path := map[string]string{}
+	query := map[string]string{}
+
+	refl.WalkTaggedFields(reflect.ValueOf(cu.Structure), func(_ reflect.Value, sf reflect.StructField, tag string) {
+		path[sf.Name] = tag
+	}, "param")
+
+	refl.WalkTaggedFields(reflect.ValueOf(cu.Structure), func(_ reflect.Value, sf reflect.StructField, _ string) {
+		if _, ok := sf.Tag.Lookup("query"); !ok {
+			query[sf.Name] = "-"
+		}
+	}, "form")
+
+	if len(path) > 0 {
+		cu.SetFieldMapping(openapi.InPath, path)
+	}
+
+	if len(query) > 0 {
+		cu.SetFieldMapping(openapi.InQuery, query)
+	}
+}
+
+// Path returns OpenAPI path template of echo path, `:name` segments become `{name}` and
+// trailing `*` wildcard becomes `{path}`.
+func Path(echoPath string) string {
+	segments := strings.Split(echoPath, "/")
+
+	for i, s := range segments {
+		switch {
+		case len(s) > 1 && s[0] == ':':
+			segments[i] = "{" + s[1:] + "}"
+		case s == "*" && i == len(segments)-1:
+			segments[i] = "{path}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
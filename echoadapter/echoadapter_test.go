@@ -0,0 +1,164 @@
+package echoadapter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/echoadapter"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type (
+	handlerFunc    func() error
+	middlewareFunc func(handlerFunc) handlerFunc
+)
+
+type route struct {
+	Method, Path string
+}
+
+// group mimics *echo.Group.
+type group struct {
+	prefix string
+	routes []route
+}
+
+func (g *group) Add(method, path string, _ handlerFunc, _ ...middlewareFunc) *route {
+	g.routes = append(g.routes, route{Method: method, Path: g.prefix + path})
+
+	return &g.routes[len(g.routes)-1]
+}
+
+func TestPath(t *testing.T) {
+	for echoPath, expected := range map[string]string{
+		"/things/:id":               "/things/{id}",
+		"/things/:id/parts/:partID": "/things/{id}/parts/{partID}",
+		"/files/*":                  "/files/{path}",
+		"/things/":                  "/things/",
+	} {
+		assert.Equal(t, expected, echoadapter.Path(echoPath), echoPath)
+	}
+}
+
+func TestGroup_GET(t *testing.T) {
+	r := openapi3.NewReflector()
+	g := &group{prefix: "/api"}
+	api := echoadapter.Wrap[handlerFunc, middlewareFunc, *route](g, g.prefix, r)
+
+	type getThingReq struct {
+		ID      int    `param:"id"`
+		Fields  string `query:"fields"`
+		TraceID string `header:"X-Trace-Id"`
+	}
+
+	type thing struct {
+		ID   int    `json:"id"`
+		Name string `json:"name" form:"name"`
+	}
+
+	h := func() error { return nil }
+
+	require.NoError(t, api.GET("/things/:id", getThingReq{}, thing{}, h))
+	require.NoError(t, api.Add(http.MethodPut, "/things/:id", struct {
+		getThingReq
+		Name string `form:"name"`
+	}{}, nil, h, nil, func(oc openapi.OperationContext) {
+		oc.SetSummary("Update thing.")
+	}))
+
+	// Undocumented path parameter fails before handler is registered.
+	assert.Error(t, api.DELETE("/things/:id", nil, nil, h))
+
+	assert.Equal(t, []route{{"GET", "/api/things/:id"}, {"PUT", "/api/things/:id"}}, g.routes)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/api/things/{id}":{
+		  "get":{
+			"parameters":[
+			  {"name":"fields","in":"query","schema":{"type":"string"}},
+			  {"name":"id","in":"path","required":true,"schema":{"type":"integer"}},
+			  {"name":"X-Trace-Id","in":"header","schema":{"type":"string"}}
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/EchoadapterTestThing"}}}
+			  }
+			}
+		  },
+		  "put":{
+			"summary":"Update thing.",
+			"parameters":[
+			  {"name":"fields","in":"query","schema":{"type":"string"}},
+			  {"name":"id","in":"path","required":true,"schema":{"type":"integer"}},
+			  {"name":"X-Trace-Id","in":"header","schema":{"type":"string"}}
+			],
+			"requestBody":{
+			  "content":{
+				"application/x-www-form-urlencoded":{
+				  "schema":{"type":"object","properties":{"name":{"type":"string"}}}
+				}
+			  }
+			},
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "EchoadapterTestThing":{
+			"type":"object","properties":{"id":{"type":"integer"},"name":{"type":"string"}}
+		  }
+		}
+	  }
+	}`, r.SpecEns())
+}
+
+func TestCollector(t *testing.T) {
+	r := openapi3.NewReflector()
+	c := echoadapter.NewCollector(r)
+
+	// Same routes as echo.Echo.Routes would return.
+	for _, rt := range []route{
+		{"GET", "/things/:id"},
+		{"GET", "/files/*"},
+		{"PROPFIND", "/files/*"},
+		{"echo_route_not_found", "/*"},
+	} {
+		require.NoError(t, c.Route(rt.Method, rt.Path))
+	}
+
+	assert.Len(t, c.Operations(), 2)
+
+	oc := c.Operation(http.MethodGet, "/things/:id")
+	require.NotNil(t, oc)
+	oc.AddReqStructure(struct {
+		ID int `param:"id"`
+	}{}, echoadapter.BindingTags)
+
+	require.NoError(t, c.Add())
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/things/{id}":{
+		  "get":{
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		},
+		"/files/{path}":{
+		  "get":{
+			"parameters":[{"name":"path","in":"path","required":true,"schema":{"type":"string"}}],
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		}
+	  }
+	}`, r.SpecEns())
+}
@@ -0,0 +1,29 @@
+package openapi
+
+import (
+	"reflect"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// Enumer exposes allowed values of a type, e.g. of string or int constants declared with iota.
+//
+// It is the same interface as jsonschema.Enum, types implementing it get `enum` in reflected schemas.
+type Enumer interface {
+	Enum() []interface{}
+}
+
+var _ jsonschema.Enum = Enumer(nil)
+
+// EnumRegistry keeps allowed values of types that can not implement Enumer, e.g. types of other packages.
+type EnumRegistry map[reflect.Type][]interface{}
+
+// Add registers values for the type of sample.
+func (r EnumRegistry) Add(sample interface{}, values ...interface{}) {
+	t := reflect.TypeOf(sample)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	r[t] = values
+}
@@ -0,0 +1,21 @@
+package openapi
+
+// ExampleProvider is implemented by request and response structures that provide named examples,
+// examples are added to `examples` of media type of body.
+type ExampleProvider interface {
+	Examples() map[string]interface{}
+}
+
+// WithExamples is a ContentUnit option to add named examples of media type of body,
+// they take precedence over examples of ExampleProvider with the same names.
+func WithExamples(examples map[string]interface{}) ContentOption {
+	return func(cu *ContentUnit) {
+		if cu.Examples == nil {
+			cu.Examples = make(map[string]interface{}, len(examples))
+		}
+
+		for name, value := range examples {
+			cu.Examples[name] = value
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GetExtension returns value of vendor extension (e.g. `x-logo`) of a document entity,
+// such as *openapi3.Info or *openapi31.Operation.
+//
+// Values of loaded documents are generic JSON, they are converted to T with JSON,
+// false is returned if extension is missing or can not be converted, or node is not a pointer to entity
+// with extensions.
+func GetExtension[T any](node interface{}, key string) (T, bool) {
+	var res T
+
+	m, err := extensions(node)
+	if err != nil || m.IsNil() {
+		return res, false
+	}
+
+	v := m.MapIndex(reflect.ValueOf(key))
+	if !v.IsValid() {
+		return res, false
+	}
+
+	if t, ok := v.Interface().(T); ok {
+		return t, true
+	}
+
+	j, err := JSON.Marshal(v.Interface())
+	if err != nil {
+		return res, false
+	}
+
+	if err := JSON.Unmarshal(j, &res); err != nil {
+		return res, false
+	}
+
+	return res, true
+}
+
+// SetExtension sets value of vendor extension of a document entity, such as *openapi3.Info.
+//
+// It fails if key has no `x-` prefix or node is not a pointer to entity with extensions.
+func SetExtension(node interface{}, key string, value interface{}) error {
+	if !strings.HasPrefix(key, "x-") {
+		return fmt.Errorf("extension key must start with x-: %s", key)
+	}
+
+	m, err := extensions(node)
+	if err != nil {
+		return err
+	}
+
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+
+	m.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(&value).Elem())
+
+	return nil
+}
+
+// extensions returns `MapOfAnything` field of entity.
+func extensions(node interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(node)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return v, fmt.Errorf("pointer to entity expected, %T received", node)
+	}
+
+	m := v.Elem().FieldByName("MapOfAnything")
+	if !m.IsValid() || m.Type() != reflect.TypeOf(map[string]interface{}{}) {
+		return m, fmt.Errorf("%T has no extensions", node)
+	}
+
+	return m, nil
+}
+
+// Extension is a key of vendor extension with a typed value.
+type Extension[T any] string
+
+// Get returns value of extension of a document entity, see GetExtension.
+func (e Extension[T]) Get(node interface{}) (T, bool) {
+	return GetExtension[T](node, string(e))
+}
+
+// Set sets value of extension of a document entity, see SetExtension.
+func (e Extension[T]) Set(node interface{}, value T) error {
+	return SetExtension(node, string(e), value)
+}
+
+// Common vendor extensions.
+const (
+	// XLogo is a logo of API in Info, supported by ReDoc.
+	XLogo = Extension[Logo]("x-logo")
+
+	// XCodeSamples are examples of client code in Operation, supported by ReDoc.
+	XCodeSamples = Extension[[]CodeSample]("x-codeSamples")
+
+	// XInternal marks an entity (e.g. Operation or Schema) as internal, such entities can be dropped from public documents.
+	XInternal = Extension[bool]("x-internal")
+)
+
+// Logo is a value of `x-logo` extension.
+type Logo struct {
+	URL             string `json:"url"`
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	AltText         string `json:"altText,omitempty"`
+	Href            string `json:"href,omitempty"`
+}
+
+// CodeSample is an item of `x-codeSamples` extension.
+type CodeSample struct {
+	Lang   string `json:"lang"`
+	Label  string `json:"label,omitempty"`
+	Source string `json:"source"`
+}
@@ -0,0 +1,86 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+func TestGetExtension(t *testing.T) {
+	var s openapi3.Spec
+
+	require.NoError(t, s.UnmarshalYAML([]byte(`openapi: 3.0.3
+info:
+  title: API
+  version: 1.0.0
+  x-logo: {url: https://example.com/logo.png, altText: Logo}
+  x-rank: 3
+paths:
+  /users:
+    get:
+      x-internal: true
+      x-codeSamples:
+        - {lang: Go, source: client.ListUsers(ctx)}
+      responses:
+        "200": {description: OK}
+`)))
+
+	logo, ok := openapi.XLogo.Get(&s.Info)
+	assert.True(t, ok)
+	assert.Equal(t, openapi.Logo{URL: "https://example.com/logo.png", AltText: "Logo"}, logo)
+
+	rank, ok := openapi.GetExtension[int](&s.Info, "x-rank")
+	assert.True(t, ok)
+	assert.Equal(t, 3, rank)
+
+	_, ok = openapi.GetExtension[string](&s.Info, "x-rank")
+	assert.False(t, ok)
+
+	_, ok = openapi.GetExtension[string](&s.Info, "x-missing")
+	assert.False(t, ok)
+
+	op := s.Paths.MapOfPathItemValues["/users"].MapOfOperationValues["get"]
+
+	internal, ok := openapi.XInternal.Get(&op)
+	assert.True(t, ok)
+	assert.True(t, internal)
+
+	samples, ok := openapi.XCodeSamples.Get(&op)
+	assert.True(t, ok)
+	assert.Equal(t, []openapi.CodeSample{{Lang: "Go", Source: "client.ListUsers(ctx)"}}, samples)
+}
+
+func TestSetExtension(t *testing.T) {
+	var s openapi31.Spec
+
+	s.Info.WithTitle("API").WithVersion("1.0.0")
+	require.NoError(t, openapi.XLogo.Set(&s.Info, openapi.Logo{URL: "https://example.com/logo.png"}))
+	require.NoError(t, openapi.SetExtension(&s.Info, "x-rank", 3))
+
+	logo, ok := openapi.XLogo.Get(&s.Info)
+	assert.True(t, ok)
+	assert.Equal(t, "https://example.com/logo.png", logo.URL)
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "openapi":"","info":{"title":"API","version":"1.0.0","x-logo":{"url":"https://example.com/logo.png"},"x-rank":3}
+	}`), s)
+
+	assert.EqualError(t, openapi.SetExtension(&s.Info, "rank", 3), "extension key must start with x-: rank")
+	assert.EqualError(t, openapi.SetExtension(s.Info, "x-rank", 3),
+		"pointer to entity expected, openapi31.Info received")
+	assert.EqualError(t, openapi.SetExtension(&logo, "x-rank", 3), "*openapi.Logo has no extensions")
+
+	_, ok = openapi.GetExtension[int](s.Info, "x-rank")
+	assert.False(t, ok)
+
+	_, ok = openapi.GetExtension[int](&logo, "x-rank")
+	assert.False(t, ok)
+
+	_, ok = openapi.GetExtension[int](nil, "x-rank")
+	assert.False(t, ok)
+}
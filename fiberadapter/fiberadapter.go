@@ -0,0 +1,239 @@
+// Package fiberadapter documents routes of github.com/gofiber/fiber (v2) applications while they are registered.
+//
+// Routers are wrapped by method set, so this package does not depend on fiber:
+//
+//	app := fiberadapter.Wrap[fiber.Handler, fiber.Router](fiber.New(), reflector)
+//	v1 := app.Group("/v1").Server("https://api.example.com").Tags("V1")
+//	err := v1.Get("/things/:id<int>", getThingReq{}, thing{}, getThing)
+package fiberadapter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+// Router is implemented by fiber.Router with H of fiber.Handler and R of fiber.Router.
+type Router[H any, R any] interface {
+	Add(method, path string, handlers ...H) R
+	Group(prefix string, handlers ...H) R
+}
+
+// Group registers handlers on fiber router and adds their operations to reflector.
+type Group[H any, R Router[H, R]] struct {
+	router    R
+	reflector openapi.Reflector
+	prefix    string
+	server    *string
+	tags      []string
+}
+
+// Wrap creates an adapter of fiber router and reflector.
+func Wrap[H any, R Router[H, R]](r R, reflector openapi.Reflector) *Group[H, R] {
+	return &Group[H, R]{router: r, reflector: reflector}
+}
+
+// Router returns underlying fiber router.
+func (g *Group[H, R]) Router() R {
+	return g.router
+}
+
+// Group creates fiber group with prefix and handlers, prefix is added to paths of operations of the group.
+func (g *Group[H, R]) Group(prefix string, handlers ...H) *Group[H, R] {
+	c := *g
+	c.router = g.router.Group(prefix, handlers...)
+	c.prefix = strings.TrimSuffix(g.prefix, "/") + prefix
+
+	return &c
+}
+
+// Tags returns a copy of group that adds tags to its operations.
+func (g *Group[H, R]) Tags(tags ...string) *Group[H, R] {
+	c := *g
+	c.tags = append(append([]string{}, g.tags...), tags...)
+
+	return &c
+}
+
+// Server returns a copy of group that documents accumulated path prefix as server of its operations,
+// e.g. group "/v1" with base URL "https://api.example.com" has operations with "https://api.example.com/v1"
+// server and paths without "/v1", empty base URL makes server URL relative.
+//
+// Base URL is ignored if group already has server, prefixes of nested groups are appended to it.
+func (g *Group[H, R]) Server(baseURL string) *Group[H, R] {
+	if g.server != nil {
+		baseURL = *g.server
+	}
+
+	c := *g
+	server := strings.TrimSuffix(baseURL, "/") + Path(g.prefix)
+	c.server = &server
+	c.prefix = ""
+
+	return &c
+}
+
+// Add documents operation with request and response samples and registers handlers on fiber router.
+//
+// Request sample declares parameters (e.g. `path:"id"` for `:id`) and body, response sample declares
+// body of successful response, nil samples are not documented. Setup functions describe operation further.
+//
+// Handlers are not registered if operation can not be added to reflector.
+func (g *Group[H, R]) Add(
+	method, path string, req, resp interface{}, handlers []H, setup ...func(oc openapi.OperationContext),
+) error {
+	oc, err := g.reflector.NewOperationContext(method, Path(strings.TrimSuffix(g.prefix, "/")+path))
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	if len(g.tags) > 0 {
+		oc.SetTags(g.tags...)
+	}
+
+	if g.server != nil {
+		if err := setServer(oc, *g.server); err != nil {
+			return fmt.Errorf("%s %s: %w", method, path, err)
+		}
+	}
+
+	if req != nil {
+		oc.AddReqStructure(req)
+	}
+
+	if resp != nil {
+		oc.AddRespStructure(resp)
+	}
+
+	for _, s := range setup {
+		s(oc)
+	}
+
+	if err := g.reflector.AddOperation(oc); err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+
+	g.router.Add(method, path, handlers...)
+
+	return nil
+}
+
+// Get documents and registers GET operation, see Add.
+func (g *Group[H, R]) Get(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodGet, path, req, resp, handlers)
+}
+
+// Post documents and registers POST operation, see Add.
+func (g *Group[H, R]) Post(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodPost, path, req, resp, handlers)
+}
+
+// Put documents and registers PUT operation, see Add.
+func (g *Group[H, R]) Put(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodPut, path, req, resp, handlers)
+}
+
+// Patch documents and registers PATCH operation, see Add.
+func (g *Group[H, R]) Patch(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodPatch, path, req, resp, handlers)
+}
+
+// Delete documents and registers DELETE operation, see Add.
+func (g *Group[H, R]) Delete(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodDelete, path, req, resp, handlers)
+}
+
+// Head documents and registers HEAD operation, see Add.
+func (g *Group[H, R]) Head(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodHead, path, req, resp, handlers)
+}
+
+// Options documents and registers OPTIONS operation, see Add.
+func (g *Group[H, R]) Options(path string, req, resp interface{}, handlers ...H) error {
+	return g.Add(http.MethodOptions, path, req, resp, handlers)
+}
+
+func setServer(oc openapi.OperationContext, url string) error {
+	switch e := oc.(type) {
+	case openapi3.OperationExposer:
+		e.Operation().Servers = []openapi3.Server{{URL: url}}
+	case openapi31.OperationExposer:
+		e.Operation().Servers = []openapi31.Server{{URL: url}}
+	default:
+		return fmt.Errorf("operation context does not expose operation to set server %s", url)
+	}
+
+	return nil
+}
+
+// Path returns OpenAPI path template of fiber path.
+//
+// Parameters (`:name`, optional `:name?`, constrained `:name<int>`) become `{name}`,
+// wildcards `*` and `+` become `{path}` (`*2` becomes `{path2}`), escaped `\:` becomes `:`.
+func Path(fiberPath string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(fiberPath); i++ {
+		ch := fiberPath[i]
+
+		switch ch {
+		case '\\':
+			if i+1 < len(fiberPath) {
+				i++
+				sb.WriteByte(fiberPath[i])
+			}
+		case ':':
+			start := i + 1
+			for i+1 < len(fiberPath) && !strings.ContainsRune("/-.<?", rune(fiberPath[i+1])) {
+				i++
+			}
+
+			sb.WriteString("{" + fiberPath[start:i+1] + "}")
+
+			i = skipConstraint(fiberPath, i)
+		case '*', '+':
+			start := i + 1
+			for i+1 < len(fiberPath) && fiberPath[i+1] >= '0' && fiberPath[i+1] <= '9' {
+				i++
+			}
+
+			sb.WriteString("{path" + fiberPath[start:i+1] + "}")
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+
+	return sb.String()
+}
+
+// skipConstraint returns position of last byte of parameter constraint and optional mark after i.
+func skipConstraint(fiberPath string, i int) int {
+	if i+1 < len(fiberPath) && fiberPath[i+1] == '<' {
+		depth := 0
+
+		for i++; i < len(fiberPath); i++ {
+			switch fiberPath[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case '>':
+				if depth == 0 {
+					return skipConstraint(fiberPath, i)
+				}
+			}
+		}
+
+		return len(fiberPath) - 1
+	}
+
+	if i+1 < len(fiberPath) && fiberPath[i+1] == '?' {
+		i++
+	}
+
+	return i
+}
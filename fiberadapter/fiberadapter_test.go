@@ -0,0 +1,113 @@
+package fiberadapter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/fiberadapter"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type handler func() error
+
+// router mimics fiber.Router.
+type router interface {
+	Add(method, path string, handlers ...handler) router
+	Group(prefix string, handlers ...handler) router
+}
+
+type app struct {
+	prefix string
+	routes *[]string
+}
+
+func (a app) Add(method, path string, _ ...handler) router {
+	*a.routes = append(*a.routes, method+" "+a.prefix+path)
+
+	return a
+}
+
+func (a app) Group(prefix string, _ ...handler) router {
+	return app{prefix: a.prefix + prefix, routes: a.routes}
+}
+
+func TestPath(t *testing.T) {
+	for fiberPath, expected := range map[string]string{
+		"/things/:id":                   "/things/{id}",
+		"/things/:id<int;min(1)>/a":     "/things/{id}/a",
+		"/things/:id?":                  "/things/{id}",
+		"/flights/:from-:to":            "/flights/{from}-{to}",
+		"/files/:name.:ext":             "/files/{name}.{ext}",
+		"/files/*":                      "/files/{path}",
+		"/files/+/*2":                   "/files/{path}/{path2}",
+		"/time/:hh<regex(\\d{2})>\\:00": "/time/{hh}:00",
+	} {
+		assert.Equal(t, expected, fiberadapter.Path(fiberPath), fiberPath)
+	}
+}
+
+func TestGroup_Get(t *testing.T) {
+	r := openapi3.NewReflector()
+	routes := []string{}
+	a := fiberadapter.Wrap[handler, router](app{routes: &routes}, r)
+
+	type getThingReq struct {
+		ID int `path:"id"`
+	}
+
+	type thing struct {
+		ID int `json:"id"`
+	}
+
+	h := func() error { return nil }
+
+	require.NoError(t, a.Get("/health", nil, nil, h))
+
+	v1 := a.Group("/api").Group("/v1").Server("https://api.example.com").Tags("V1")
+	require.NoError(t, v1.Get("/things/:id<int>", getThingReq{}, thing{}, h))
+
+	admin := v1.Group("/admin").Tags("Admin")
+	require.NoError(t, admin.Delete("/things/:id", getThingReq{}, nil, h))
+
+	// Undocumented path parameter fails before handler is registered.
+	assert.Error(t, v1.Put("/things/:id", nil, nil, h))
+
+	assert.Equal(t, []string{
+		"GET /health",
+		"GET /api/v1/things/:id<int>",
+		"DELETE /api/v1/admin/things/:id",
+	}, routes)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/health":{"get":{"responses":{"204":{"description":"No Content"}}}},
+		"/things/{id}":{
+		  "get":{
+			"tags":["V1"],
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/FiberadapterTestThing"}}}
+			  }
+			},
+			"servers":[{"url":"https://api.example.com/api/v1"}]
+		  }
+		},
+		"/admin/things/{id}":{
+		  "delete":{
+			"tags":["V1","Admin"],
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+			"responses":{"204":{"description":"No Content"}},
+			"servers":[{"url":"https://api.example.com/api/v1"}]
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{"FiberadapterTestThing":{"type":"object","properties":{"id":{"type":"integer"}}}}
+	  }
+	}`, r.SpecEns())
+}
@@ -0,0 +1,114 @@
+// Package fuzz generates Go fuzz targets from OpenAPI operations.
+//
+// Generated targets seed the fuzzing corpus with valid example requests and with values
+// just beyond schema constraints, then assert that handler neither panics nor responds with 5xx status.
+package fuzz
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// Param is a request parameter value.
+type Param struct {
+	In    string
+	Name  string
+	Value string
+}
+
+// Request describes a fuzzed request.
+type Request struct {
+	Method string
+
+	// Path is a path template with {name} placeholders.
+	Path string
+
+	Params      []Param
+	ContentType string
+	Body        []byte
+}
+
+// HTTP builds HTTP request.
+func (r Request) HTTP() *http.Request {
+	path := r.Path
+	query := url.Values{}
+
+	for _, p := range r.Params {
+		switch p.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(p.Value))
+		case "query":
+			query.Add(p.Name, p.Value)
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req := httptest.NewRequest(r.Method, path, bytes.NewReader(r.Body))
+
+	if r.ContentType != "" && r.Body != nil {
+		req.Header.Set("Content-Type", r.ContentType)
+	}
+
+	for _, p := range r.Params {
+		switch p.In {
+		case "header":
+			req.Header.Add(p.Name, headerValue(p.Value))
+		case "cookie":
+			req.AddCookie(&http.Cookie{Name: p.Name, Value: url.QueryEscape(p.Value)})
+		}
+	}
+
+	return req
+}
+
+// headerValue removes characters that can not be transmitted in a header.
+func headerValue(v string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+
+		return r
+	}, v)
+}
+
+// Check serves request with handler and fails the test on panic or server error.
+func Check(t testing.TB, h http.Handler, r Request) {
+	t.Helper()
+
+	if err := Serve(h, r); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Serve serves request with handler and returns an error on panic or server error.
+func Serve(h http.Handler, r Request) (err error) {
+	req := r.HTTP()
+	rw := httptest.NewRecorder()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			if rec == http.ErrAbortHandler { //nolint:errorlint,goerr113 // Sentinel panic value.
+				return
+			}
+
+			err = fmt.Errorf("%s %s: panic: %v", req.Method, req.RequestURI, rec)
+		}
+	}()
+
+	h.ServeHTTP(rw, req)
+
+	if rw.Code >= http.StatusInternalServerError {
+		return fmt.Errorf("%s %s: unexpected status %d: %s", req.Method, req.RequestURI, rw.Code, rw.Body.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package fuzz_test
+
+import (
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/fuzz"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestServe(t *testing.T) {
+	h := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		items := make([]int, 10)
+		_ = items[limit] // Panics on out of range.
+	})
+
+	req := fuzz.Request{Method: http.MethodGet, Path: "/things/{id}"}
+
+	assert.NoError(t, fuzz.Serve(h, req))
+
+	req.Params = []fuzz.Param{{In: "path", Name: "id", Value: "a/b"}, {In: "query", Name: "limit", Value: "3"}}
+	assert.Equal(t, "/things/a%2Fb?limit=3", req.HTTP().RequestURI)
+	assert.NoError(t, fuzz.Serve(h, req))
+
+	req.Params[1].Value = "11"
+	assert.EqualError(t, fuzz.Serve(h, req),
+		"GET /things/a%2Fb?limit=11: panic: runtime error: index out of range [11] with length 10")
+
+	fail := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	})
+	assert.EqualError(t, fuzz.Serve(fail, req), "GET /things/a%2Fb?limit=11: unexpected status 502: ")
+}
+
+func TestGenerate(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type thingReq struct {
+		ID    int    `path:"id" minimum:"1"`
+		Limit int    `query:"limit" minimum:"1" maximum:"100"`
+		Name  string `json:"name" required:"true" maxLength:"8"`
+		Kind  string `json:"kind" enum:"a,b"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/things/{id}")
+	require.NoError(t, err)
+	oc.SetID("updateThing")
+	oc.AddReqStructure(thingReq{})
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/health")
+	require.NoError(t, err)
+	require.NoError(t, r.AddOperation(oc))
+
+	src, err := fuzz.Generate(r.SpecEns(), fuzz.Config{Package: "api_test", Handler: "newHandler()"})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "fuzz_test.go", src, 0)
+	require.NoError(t, err)
+
+	s := string(src)
+
+	assert.NotContains(t, s, "/health")
+	assert.Contains(t, s, "// FuzzUpdateThing fuzzes PUT /things/{id}.\nfunc FuzzUpdateThing(f *testing.F) {\n\th := newHandler()\n")
+	assert.Contains(t, s, `f.Add("1", "1", []byte("{\"kind\":\"a\",\"name\":\"a\"}"))`)
+	assert.Contains(t, s, `f.Add("0", "1", []byte("{\"kind\":\"a\",\"name\":\"a\"}"))`)
+	assert.Contains(t, s, `f.Add("101", "1", []byte("{\"kind\":\"a\",\"name\":\"a\"}"))`)
+	assert.Contains(t, s, `f.Add("1", "1", []byte("{\"kind\":\"a\"}"))`)
+	assert.Contains(t, s, `f.Add("1", "1", []byte("{\"kind\":\"a\",\"name\":\"aaaaaaaaa\"}"))`)
+	assert.Contains(t, s, `f.Add("1", "1", []byte("{\"kind\":\"not-in-enum\",\"name\":\"a\"}"))`)
+	assert.Contains(t, s, `	f.Fuzz(func(t *testing.T, p0 string, p1 string, body []byte) {
+		fuzz.Check(t, h, fuzz.Request{
+			Method: "PUT",
+			Path:   "/things/{id}",
+			Params: []fuzz.Param{
+				{In: "query", Name: "limit", Value: p0},
+				{In: "path", Name: "id", Value: p1},
+			},
+			ContentType: "application/json",
+			Body:        body,
+		})
+	})`)
+}
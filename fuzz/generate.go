@@ -0,0 +1,570 @@
+package fuzz
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Config controls fuzz targets generation.
+type Config struct {
+	// Package is a name of Go package of generated file, required.
+	Package string
+
+	// Handler is a Go expression of http.Handler type, for example "newHandler()", required.
+	Handler string
+
+	// Imports are additional import paths needed by Handler expression.
+	Imports []string
+}
+
+const maxDepth = 5
+
+// Generate renders Go test file with a fuzz target for every operation that has parameters or request body.
+func Generate(s *openapi3.Spec, cfg Config) ([]byte, error) {
+	if cfg.Package == "" || cfg.Handler == "" {
+		return nil, errors.New("fuzz: package and handler are required")
+	}
+
+	g := generator{spec: s, names: map[string]int{}}
+
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString("// Code generated by openapi-go/fuzz, DO NOT EDIT.\n\n")
+	buf.WriteString("package " + cfg.Package + "\n\n")
+	buf.WriteString("import (\n\t\"testing\"\n\n\t\"github.com/swaggest/openapi-go/fuzz\"\n")
+
+	for _, imp := range cfg.Imports {
+		buf.WriteString("\t" + strconv.Quote(imp) + "\n")
+	}
+
+	buf.WriteString(")\n")
+
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			op := pi.MapOfOperationValues[method]
+
+			if err := g.operation(buf, cfg, strings.ToUpper(method), path, pi.Parameters, op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+		}
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("fuzz: format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+type generator struct {
+	spec  *openapi3.Spec
+	names map[string]int
+}
+
+type fuzzParam struct {
+	param  openapi3.Parameter
+	valid  string
+	beyond []string
+}
+
+func (g *generator) operation(buf *bytes.Buffer, cfg Config, method, path string, common []openapi3.ParameterOrRef, op openapi3.Operation) error {
+	var params []fuzzParam
+
+	seen := map[string]int{}
+
+	for _, pr := range append(append([]openapi3.ParameterOrRef{}, common...), op.Parameters...) {
+		p, err := g.parameter(pr)
+		if err != nil {
+			return err
+		}
+
+		schema := g.schema(p.Schema, 0)
+		fp := fuzzParam{param: p, valid: paramValue(g.valid(schema, 0))}
+
+		if p.Example != nil {
+			fp.valid = paramValue(*p.Example)
+		}
+
+		for _, v := range g.beyond(schema) {
+			fp.beyond = append(fp.beyond, paramValue(v))
+		}
+
+		key := string(p.In) + "." + p.Name
+		if i, ok := seen[key]; ok {
+			params[i] = fp // Operation parameter overrides path item parameter.
+		} else {
+			seen[key] = len(params)
+			params = append(params, fp)
+		}
+	}
+
+	contentType, body, err := g.requestBody(op.RequestBody)
+	if err != nil {
+		return err
+	}
+
+	if len(params) == 0 && contentType == "" {
+		return nil
+	}
+
+	valid := make([]string, 0, len(params)+1)
+	for _, p := range params {
+		valid = append(valid, strconv.Quote(p.valid))
+	}
+
+	var bodySeeds []interface{}
+
+	if contentType != "" {
+		v := g.valid(body, 0)
+		valid = append(valid, bytesLiteral(v))
+		bodySeeds = g.bodyBeyond(body, v)
+	}
+
+	name := g.funcName(method, path, op.ID)
+
+	fmt.Fprintf(buf, "\n// %s fuzzes %s %s.\nfunc %s(f *testing.F) {\n", name, method, path, name)
+	fmt.Fprintf(buf, "h := %s\n\n", cfg.Handler)
+	fmt.Fprintf(buf, "f.Add(%s)\n", strings.Join(valid, ", "))
+
+	for i, p := range params {
+		for _, b := range p.beyond {
+			seed := append([]string{}, valid...)
+			seed[i] = strconv.Quote(b)
+			fmt.Fprintf(buf, "f.Add(%s)\n", strings.Join(seed, ", "))
+		}
+	}
+
+	for _, b := range bodySeeds {
+		seed := append([]string{}, valid...)
+		seed[len(seed)-1] = bytesLiteral(b)
+		fmt.Fprintf(buf, "f.Add(%s)\n", strings.Join(seed, ", "))
+	}
+
+	args := make([]string, 0, len(params)+1)
+	for i := range params {
+		args = append(args, "p"+strconv.Itoa(i)+" string")
+	}
+
+	if contentType != "" {
+		args = append(args, "body []byte")
+	}
+
+	fmt.Fprintf(buf, "\nf.Fuzz(func(t *testing.T, %s) {\n", strings.Join(args, ", "))
+	fmt.Fprintf(buf, "fuzz.Check(t, h, fuzz.Request{\nMethod: %q,\nPath: %q,\n", method, path)
+
+	if len(params) > 0 {
+		buf.WriteString("Params: []fuzz.Param{\n")
+
+		for i, p := range params {
+			fmt.Fprintf(buf, "{In: %q, Name: %q, Value: p%d},\n", p.param.In, p.param.Name, i)
+		}
+
+		buf.WriteString("},\n")
+	}
+
+	if contentType != "" {
+		fmt.Fprintf(buf, "ContentType: %q,\nBody: body,\n", contentType)
+	}
+
+	buf.WriteString("})\n})\n}\n")
+
+	return nil
+}
+
+func (g *generator) funcName(method, path string, id *string) string {
+	var words []string
+
+	if id != nil && *id != "" {
+		words = splitWords(*id)
+	} else {
+		words = append([]string{strings.ToLower(method)}, splitWords(path)...)
+	}
+
+	name := "Fuzz"
+
+	for _, w := range words {
+		name += strings.ToUpper(w[:1]) + w[1:]
+	}
+
+	g.names[name]++
+	if n := g.names[name]; n > 1 {
+		name += strconv.Itoa(n)
+	}
+
+	return name
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r))
+	})
+}
+
+func (g *generator) parameter(pr openapi3.ParameterOrRef) (openapi3.Parameter, error) {
+	if pr.Parameter != nil {
+		return *pr.Parameter, nil
+	}
+
+	if pr.ParameterReference != nil {
+		name := strings.TrimPrefix(pr.ParameterReference.Ref, "#/components/parameters/")
+
+		if c := g.spec.Components; c != nil && c.Parameters != nil {
+			if p, ok := c.Parameters.MapOfParameterOrRefValues[name]; ok && p.Parameter != nil {
+				return *p.Parameter, nil
+			}
+		}
+
+		return openapi3.Parameter{}, fmt.Errorf("unresolved parameter reference %s", pr.ParameterReference.Ref)
+	}
+
+	return openapi3.Parameter{}, errors.New("empty parameter")
+}
+
+func (g *generator) requestBody(rb *openapi3.RequestBodyOrRef) (string, *openapi3.Schema, error) {
+	if rb == nil {
+		return "", nil, nil
+	}
+
+	body := rb.RequestBody
+
+	if rb.RequestBodyReference != nil {
+		name := strings.TrimPrefix(rb.RequestBodyReference.Ref, "#/components/requestBodies/")
+
+		if c := g.spec.Components; c != nil && c.RequestBodies != nil {
+			body = c.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody
+		}
+
+		if body == nil {
+			return "", nil, fmt.Errorf("unresolved request body reference %s", rb.RequestBodyReference.Ref)
+		}
+	}
+
+	if body == nil || len(body.Content) == 0 {
+		return "", nil, nil
+	}
+
+	if mt, ok := body.Content["application/json"]; ok {
+		return "application/json", g.schema(mt.Schema, 0), nil
+	}
+
+	contentTypes := make([]string, 0, len(body.Content))
+	for ct := range body.Content {
+		contentTypes = append(contentTypes, ct)
+	}
+
+	sort.Strings(contentTypes)
+
+	return contentTypes[0], g.schema(body.Content[contentTypes[0]].Schema, 0), nil
+}
+
+func (g *generator) schema(sr *openapi3.SchemaOrRef, depth int) *openapi3.Schema {
+	if sr == nil || depth > maxDepth {
+		return nil
+	}
+
+	if sr.SchemaReference != nil {
+		name := strings.TrimPrefix(sr.SchemaReference.Ref, "#/components/schemas/")
+
+		if c := g.spec.Components; c != nil && c.Schemas != nil {
+			if s, ok := c.Schemas.MapOfSchemaOrRefValues[name]; ok {
+				return g.schema(&s, depth+1)
+			}
+		}
+
+		return nil
+	}
+
+	return sr.Schema
+}
+
+func schemaType(s *openapi3.Schema) openapi3.SchemaType {
+	if s.Type != nil {
+		return *s.Type
+	}
+
+	if s.Properties != nil {
+		return openapi3.SchemaTypeObject
+	}
+
+	if s.Items != nil {
+		return openapi3.SchemaTypeArray
+	}
+
+	return ""
+}
+
+// valid returns a value that satisfies schema constraints.
+func (g *generator) valid(s *openapi3.Schema, depth int) interface{} {
+	if s == nil || depth > maxDepth {
+		return "a"
+	}
+
+	switch {
+	case s.Example != nil:
+		return *s.Example
+	case s.Default != nil:
+		return *s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	}
+
+	for _, sr := range s.AllOf {
+		if sub := g.schema(&sr, depth+1); sub != nil && schemaType(sub) != "" {
+			return g.valid(sub, depth+1)
+		}
+	}
+
+	for _, variants := range [][]openapi3.SchemaOrRef{s.OneOf, s.AnyOf} {
+		if len(variants) > 0 {
+			return g.valid(g.schema(&variants[0], depth+1), depth+1)
+		}
+	}
+
+	switch schemaType(s) {
+	case openapi3.SchemaTypeString:
+		return validString(s)
+	case openapi3.SchemaTypeInteger, openapi3.SchemaTypeNumber:
+		v := 1.0
+
+		if s.Minimum != nil {
+			v = math.Ceil(*s.Minimum)
+			if s.ExclusiveMinimum != nil && *s.ExclusiveMinimum {
+				v++
+			}
+		} else if s.Maximum != nil && *s.Maximum < v {
+			v = math.Floor(*s.Maximum)
+		}
+
+		return v
+	case openapi3.SchemaTypeBoolean:
+		return true
+	case openapi3.SchemaTypeArray:
+		n := int64(1)
+		if s.MinItems != nil && *s.MinItems > n {
+			n = *s.MinItems
+		}
+
+		var items *openapi3.Schema
+		if s.Items != nil {
+			items = g.schema(s.Items, depth+1)
+		}
+
+		res := make([]interface{}, 0, n)
+		for i := int64(0); i < n; i++ {
+			res = append(res, g.valid(items, depth+1))
+		}
+
+		return res
+	case openapi3.SchemaTypeObject:
+		res := map[string]interface{}{}
+
+		if s.Properties != nil {
+			for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+				prop := g.schema(&pair.Value, depth+1)
+				if prop != nil && prop.ReadOnly != nil && *prop.ReadOnly {
+					continue
+				}
+
+				res[pair.Key] = g.valid(prop, depth+1)
+			}
+		}
+
+		return res
+	}
+
+	return "a"
+}
+
+func validString(s *openapi3.Schema) string {
+	if s.Format != nil {
+		switch *s.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		case "date":
+			return "2006-01-02"
+		case "uuid":
+			return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		case "email":
+			return "user@example.com"
+		case "uri", "url":
+			return "https://example.com/"
+		}
+	}
+
+	n := int64(1)
+	if s.MinLength != nil && *s.MinLength > n {
+		n = *s.MinLength
+	}
+
+	if s.MaxLength != nil && *s.MaxLength < n {
+		n = *s.MaxLength
+	}
+
+	return strings.Repeat("a", int(n))
+}
+
+// beyond returns values that violate schema constraints.
+func (g *generator) beyond(s *openapi3.Schema) []interface{} {
+	if s == nil {
+		return []interface{}{""}
+	}
+
+	var res []interface{}
+
+	if len(s.Enum) > 0 {
+		res = append(res, "not-in-enum")
+	}
+
+	switch schemaType(s) {
+	case openapi3.SchemaTypeString:
+		res = append(res, "")
+
+		if s.MaxLength != nil {
+			res = append(res, strings.Repeat("a", int(*s.MaxLength)+1))
+		}
+
+		if s.Format != nil || s.Pattern != nil {
+			res = append(res, "%invalid%")
+		}
+	case openapi3.SchemaTypeInteger, openapi3.SchemaTypeNumber:
+		if s.Minimum != nil {
+			res = append(res, math.Floor(*s.Minimum)-1)
+		}
+
+		if s.Maximum != nil {
+			res = append(res, math.Ceil(*s.Maximum)+1)
+		}
+
+		if schemaType(s) == openapi3.SchemaTypeInteger {
+			res = append(res, 1.5, float64(math.MaxInt64))
+		}
+
+		res = append(res, "NaN")
+	case openapi3.SchemaTypeBoolean:
+		res = append(res, "maybe")
+	case openapi3.SchemaTypeArray:
+		if s.MinItems != nil && *s.MinItems > 0 {
+			res = append(res, []interface{}{})
+		}
+
+		if s.MaxItems != nil {
+			items := make([]interface{}, 0, *s.MaxItems+1)
+			for i := int64(0); i <= *s.MaxItems; i++ {
+				items = append(items, g.valid(g.schema(s.Items, 1), 1))
+			}
+
+			res = append(res, items)
+		}
+	}
+
+	return res
+}
+
+// bodyBeyond returns request bodies that break schema.
+func (g *generator) bodyBeyond(s *openapi3.Schema, valid interface{}) []interface{} {
+	res := []interface{}{nil, []interface{}{}}
+
+	if s == nil {
+		return res
+	}
+
+	if schemaType(s) != openapi3.SchemaTypeObject {
+		return append(res, map[string]interface{}{})
+	}
+
+	res = append(res, "")
+
+	obj, ok := valid.(map[string]interface{})
+	if !ok || s.Properties == nil {
+		return res
+	}
+
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+
+		seed := cloneObject(obj)
+		delete(seed, name)
+		res = append(res, seed)
+	}
+
+	for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		if _, ok := obj[pair.Key]; !ok {
+			continue
+		}
+
+		for _, b := range g.beyond(g.schema(&pair.Value, 1)) {
+			seed := cloneObject(obj)
+			seed[pair.Key] = b
+			res = append(res, seed)
+		}
+	}
+
+	return res
+}
+
+func cloneObject(obj map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		res[k] = v
+	}
+
+	return res
+}
+
+func paramValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, i := range v {
+			items = append(items, paramValue(i))
+		}
+
+		return strings.Join(items, ",")
+	case nil:
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
+
+func bytesLiteral(v interface{}) string {
+	j, err := json.Marshal(v)
+	if err != nil {
+		j = []byte("null")
+	}
+
+	return "[]byte(" + strconv.Quote(string(j)) + ")"
+}
@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"strconv"
+
+	"github.com/goccy/go-yaml"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type envoyRoutes struct {
+	Routes []envoyRoute `yaml:"routes"`
+}
+
+type envoyRoute struct {
+	Name                 string                    `yaml:"name"`
+	Match                envoyMatch                `yaml:"match"`
+	Route                envoyAction               `yaml:"route"`
+	TypedPerFilterConfig map[string]envoyRateLimit `yaml:"typed_per_filter_config"`
+}
+
+type envoyMatch struct {
+	SafeRegex envoyRegex    `yaml:"safe_regex"`
+	Headers   []envoyHeader `yaml:"headers"`
+}
+
+type envoyRegex struct {
+	Regex string `yaml:"regex"`
+}
+
+type envoyHeader struct {
+	Name        string            `yaml:"name"`
+	StringMatch map[string]string `yaml:"string_match"`
+}
+
+type envoyAction struct {
+	Cluster string `yaml:"cluster"`
+}
+
+type envoyRateLimit struct {
+	Type           string           `yaml:"@type"`
+	StatPrefix     string           `yaml:"stat_prefix"`
+	TokenBucket    envoyTokenBucket `yaml:"token_bucket"`
+	FilterEnabled  envoyFraction    `yaml:"filter_enabled"`
+	FilterEnforced envoyFraction    `yaml:"filter_enforced"`
+}
+
+type envoyTokenBucket struct {
+	MaxTokens     int    `yaml:"max_tokens"`
+	TokensPerFill int    `yaml:"tokens_per_fill"`
+	FillInterval  string `yaml:"fill_interval"`
+}
+
+type envoyFraction struct {
+	DefaultValue envoyPercent `yaml:"default_value"`
+}
+
+type envoyPercent struct {
+	Numerator   int    `yaml:"numerator"`
+	Denominator string `yaml:"denominator"`
+}
+
+const envoyLocalRateLimit = "envoy.filters.http.local_ratelimit"
+
+// Envoy returns routes of virtual host configuration with per-route `local_ratelimit` filter config.
+//
+// Token bucket is filled every minute, or every day if quota only limits requests per day,
+// burst is added to bucket size. Routes forward to cluster. HTTP connection manager must have
+// envoy.filters.http.local_ratelimit filter enabled.
+func Envoy(s *openapi3.Spec, cluster string) ([]byte, error) {
+	cfg := envoyRoutes{Routes: []envoyRoute{}}
+	enabled := envoyFraction{DefaultValue: envoyPercent{Numerator: 100, Denominator: "HUNDRED"}}
+
+	for _, oq := range Quotas(s) {
+		tokens, interval := oq.Quota.RequestsPerMinute, 60
+		if tokens == 0 {
+			tokens, interval = oq.Quota.RequestsPerDay, 24*60*60
+		}
+
+		cfg.Routes = append(cfg.Routes, envoyRoute{
+			Name: oq.Name,
+			Match: envoyMatch{
+				SafeRegex: envoyRegex{Regex: oq.Regex},
+				Headers:   []envoyHeader{{Name: ":method", StringMatch: map[string]string{"exact": oq.Method}}},
+			},
+			Route: envoyAction{Cluster: cluster},
+			TypedPerFilterConfig: map[string]envoyRateLimit{
+				envoyLocalRateLimit: {
+					Type:       "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit",
+					StatPrefix: oq.Name,
+					TokenBucket: envoyTokenBucket{
+						MaxTokens:     tokens + oq.Quota.Burst,
+						TokensPerFill: tokens,
+						FillInterval:  strconv.Itoa(interval) + "s",
+					},
+					FilterEnabled:  enabled,
+					FilterEnforced: enabled,
+				},
+			},
+		})
+	}
+
+	return yaml.Marshal(cfg)
+}
@@ -0,0 +1,84 @@
+// Package gateway exports operation rate limits of OpenAPI documents to API gateway configuration.
+package gateway
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// OperationQuota is a rate limit of an operation from `x-quota` extension.
+type OperationQuota struct {
+	// Name is an operation ID or a name derived from method and path.
+	Name string
+
+	// Method is an upper-case HTTP method.
+	Method string
+
+	// Path is a path template.
+	Path string
+
+	// Regex matches actual URL paths of operation.
+	Regex string
+
+	Quota openapi.Quota
+}
+
+var nameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// Quotas returns operations with `x-quota` extension ordered by path and method.
+func Quotas(s *openapi3.Spec) []OperationQuota {
+	var res []OperationQuota
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			q, ok := openapi.QuotaOf(op.MapOfAnything[openapi.XQuota])
+			if !ok || q.Validate() != nil {
+				continue
+			}
+
+			oq := OperationQuota{
+				Method: strings.ToUpper(method),
+				Path:   path,
+				Regex:  pathRegex(path),
+				Quota:  q,
+			}
+
+			if op.ID != nil && *op.ID != "" {
+				oq.Name = nameSanitizer.ReplaceAllString(*op.ID, "_")
+			} else {
+				oq.Name = strings.ToLower(method) + "_" + strings.Trim(nameSanitizer.ReplaceAllString(path, "_"), "_")
+			}
+
+			res = append(res, oq)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Path != res[j].Path {
+			return res[i].Path < res[j].Path
+		}
+
+		return res[i].Method < res[j].Method
+	})
+
+	return res
+}
+
+// pathRegex converts path template to regular expression, path parameters match a single segment.
+func pathRegex(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = "[^/]+"
+		} else {
+			segments[i] = regexp.QuoteMeta(s)
+		}
+	}
+
+	return "^" + strings.Join(segments, "/") + "$"
+}
@@ -0,0 +1,172 @@
+package gateway_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/gateway"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func quotaSpec(t *testing.T) *openapi3.Spec {
+	t.Helper()
+
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id}")
+	require.NoError(t, err)
+	oc.SetID("getThing")
+	oc.AddReqStructure(struct {
+		ID string `path:"id"`
+	}{})
+	require.NoError(t, openapi3.SetQuota(oc, openapi.Quota{RequestsPerMinute: 60, RequestsPerDay: 1000, Burst: 10}))
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	require.NoError(t, openapi3.SetQuota(oc, openapi.Quota{RequestsPerDay: 100}))
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	require.NoError(t, r.AddOperation(oc))
+
+	require.EqualError(t, openapi3.SetQuota(oc, openapi.Quota{Burst: 5}),
+		"quota must limit requests per minute or per day")
+
+	// Quotas survive serialization.
+	j, err := r.Spec.MarshalJSON()
+	require.NoError(t, err)
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalJSON(j))
+
+	return &s
+}
+
+func TestQuotas(t *testing.T) {
+	assert.Equal(t, []gateway.OperationQuota{
+		{
+			Name: "post_things", Method: http.MethodPost, Path: "/things", Regex: "^/things$",
+			Quota: openapi.Quota{RequestsPerDay: 100},
+		},
+		{
+			Name: "getThing", Method: http.MethodGet, Path: "/things/{id}", Regex: "^/things/[^/]+$",
+			Quota: openapi.Quota{RequestsPerMinute: 60, RequestsPerDay: 1000, Burst: 10},
+		},
+	}, gateway.Quotas(quotaSpec(t)))
+}
+
+func TestKong(t *testing.T) {
+	y, err := gateway.Kong(quotaSpec(t), "api")
+	require.NoError(t, err)
+
+	assert.Equal(t, `_format_version: "3.0"
+routes:
+- name: post_things
+  service: api
+  methods:
+  - POST
+  paths:
+  - ~^/things$
+  strip_path: false
+  plugins:
+  - name: rate-limiting
+    config:
+      day: 100
+      policy: local
+- name: getThing
+  service: api
+  methods:
+  - GET
+  paths:
+  - ~^/things/[^/]+$
+  strip_path: false
+  plugins:
+  - name: rate-limiting
+    config:
+      minute: 60
+      day: 1000
+      policy: local
+`, string(y))
+}
+
+func TestEnvoy(t *testing.T) {
+	y, err := gateway.Envoy(quotaSpec(t), "api")
+	require.NoError(t, err)
+
+	assert.Equal(t, `routes:
+- name: post_things
+  match:
+    safe_regex:
+      regex: ^/things$
+    headers:
+    - name: :method
+      string_match:
+        exact: POST
+  route:
+    cluster: api
+  typed_per_filter_config:
+    envoy.filters.http.local_ratelimit:
+      "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+      stat_prefix: post_things
+      token_bucket:
+        max_tokens: 100
+        tokens_per_fill: 100
+        fill_interval: 86400s
+      filter_enabled:
+        default_value:
+          numerator: 100
+          denominator: HUNDRED
+      filter_enforced:
+        default_value:
+          numerator: 100
+          denominator: HUNDRED
+- name: getThing
+  match:
+    safe_regex:
+      regex: ^/things/[^/]+$
+    headers:
+    - name: :method
+      string_match:
+        exact: GET
+  route:
+    cluster: api
+  typed_per_filter_config:
+    envoy.filters.http.local_ratelimit:
+      "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+      stat_prefix: getThing
+      token_bucket:
+        max_tokens: 70
+        tokens_per_fill: 60
+        fill_interval: 60s
+      filter_enabled:
+        default_value:
+          numerator: 100
+          denominator: HUNDRED
+      filter_enforced:
+        default_value:
+          numerator: 100
+          denominator: HUNDRED
+`, string(y))
+}
+
+func TestNGINX(t *testing.T) {
+	httpConf, serverConf := gateway.NGINX(quotaSpec(t))
+
+	assert.Equal(t, `# GET /things/{id}
+map $request_method $quota_getThing {
+    GET $binary_remote_addr;
+    default "";
+}
+limit_req_zone $quota_getThing zone=getThing:10m rate=60r/m;
+`, string(httpConf))
+
+	assert.Equal(t, `location ~ ^/things/[^/]+$ {
+    limit_req zone=getThing burst=10 nodelay;
+}
+`, string(serverConf))
+}
@@ -0,0 +1,57 @@
+package gateway
+
+import (
+	"github.com/goccy/go-yaml"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type kongConfig struct {
+	FormatVersion string      `yaml:"_format_version"`
+	Routes        []kongRoute `yaml:"routes"`
+}
+
+type kongRoute struct {
+	Name      string       `yaml:"name"`
+	Service   string       `yaml:"service,omitempty"`
+	Methods   []string     `yaml:"methods"`
+	Paths     []string     `yaml:"paths"`
+	StripPath bool         `yaml:"strip_path"`
+	Plugins   []kongPlugin `yaml:"plugins"`
+}
+
+type kongPlugin struct {
+	Name   string           `yaml:"name"`
+	Config kongRateLimiting `yaml:"config"`
+}
+
+type kongRateLimiting struct {
+	Minute int    `yaml:"minute,omitempty"`
+	Day    int    `yaml:"day,omitempty"`
+	Policy string `yaml:"policy"`
+}
+
+// Kong returns declarative configuration of Kong routes with `rate-limiting` plugin.
+//
+// Routes refer to service by name if it is not empty. Burst is not supported by the plugin and is ignored.
+func Kong(s *openapi3.Spec, service string) ([]byte, error) {
+	cfg := kongConfig{FormatVersion: "3.0", Routes: []kongRoute{}}
+
+	for _, oq := range Quotas(s) {
+		cfg.Routes = append(cfg.Routes, kongRoute{
+			Name:    oq.Name,
+			Service: service,
+			Methods: []string{oq.Method},
+			Paths:   []string{"~" + oq.Regex},
+			Plugins: []kongPlugin{{
+				Name: "rate-limiting",
+				Config: kongRateLimiting{
+					Minute: oq.Quota.RequestsPerMinute,
+					Day:    oq.Quota.RequestsPerDay,
+					Policy: "local",
+				},
+			}},
+		})
+	}
+
+	return yaml.Marshal(cfg)
+}
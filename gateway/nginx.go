@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// NGINX returns `limit_req` configuration with zones for http context and locations for server context.
+//
+// Every operation gets a zone keyed by client address, limited to its HTTP method with a map,
+// and location blocks apply zones to paths. Requests per day are not supported by `limit_req`
+// and are ignored, operations with only daily quota are skipped.
+func NGINX(s *openapi3.Spec) (httpConf, serverConf []byte) {
+	var (
+		zones     bytes.Buffer
+		locations bytes.Buffer
+		paths     []string
+	)
+
+	limits := map[string][]string{}
+
+	for _, oq := range Quotas(s) {
+		if oq.Quota.RequestsPerMinute == 0 {
+			continue
+		}
+
+		key := "$quota_" + oq.Name
+
+		fmt.Fprintf(&zones, "# %s %s\n", oq.Method, oq.Path)
+		fmt.Fprintf(&zones, "map $request_method %s {\n    %s $binary_remote_addr;\n    default \"\";\n}\n", key, oq.Method)
+		fmt.Fprintf(&zones, "limit_req_zone %s zone=%s:10m rate=%dr/m;\n\n", key, oq.Name, oq.Quota.RequestsPerMinute)
+
+		limit := "limit_req zone=" + oq.Name
+		if oq.Quota.Burst > 0 {
+			limit += " burst=" + strconv.Itoa(oq.Quota.Burst) + " nodelay"
+		}
+
+		if _, ok := limits[oq.Regex]; !ok {
+			paths = append(paths, oq.Regex)
+		}
+
+		limits[oq.Regex] = append(limits[oq.Regex], limit)
+	}
+
+	for _, p := range paths {
+		fmt.Fprintf(&locations, "location ~ %s {\n", p)
+
+		for _, l := range limits[p] {
+			fmt.Fprintf(&locations, "    %s;\n", l)
+		}
+
+		locations.WriteString("}\n\n")
+	}
+
+	return bytes.TrimSuffix(zones.Bytes(), []byte("\n")), bytes.TrimSuffix(locations.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,12 @@
+package openapi
+
+import "strings"
+
+// TypeArgsMangler builds a definition name of an instantiated generic type, e.g. `Page[User]`,
+// from a name of generic type and names of type arguments.
+type TypeArgsMangler func(base string, args []string) string
+
+// MangleOf names instantiated generic types like `PageOfUser` or `PairOfStringAndUser`.
+func MangleOf(base string, args []string) string {
+	return base + "Of" + strings.Join(args, "And")
+}
@@ -0,0 +1,131 @@
+// Package ginadapter documents routes of github.com/gin-gonic/gin router groups while they are registered.
+//
+// Router groups are wrapped by method set, so this package does not depend on gin:
+//
+//	api := ginadapter.Wrap[gin.HandlerFunc, gin.IRoutes](engine.Group("/api"), reflector)
+//	err := api.GET("/things/:id", getThingReq{}, thing{}, getThing)
+package ginadapter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// Group is implemented by *gin.RouterGroup with H of gin.HandlerFunc and R of gin.IRoutes.
+type Group[H any, R any] interface {
+	Handle(httpMethod, relativePath string, handlers ...H) R
+	BasePath() string
+}
+
+// RouterGroup registers handlers on router group and adds their operations to reflector.
+type RouterGroup[H any, R any] struct {
+	group     Group[H, R]
+	reflector openapi.Reflector
+}
+
+// Wrap creates an adapter of router group and reflector.
+func Wrap[H any, R any](g Group[H, R], r openapi.Reflector) *RouterGroup[H, R] {
+	return &RouterGroup[H, R]{group: g, reflector: r}
+}
+
+// Group returns underlying router group.
+func (g *RouterGroup[H, R]) Group() Group[H, R] {
+	return g.group
+}
+
+// Handle documents operation with request and response samples and registers handlers on router group.
+//
+// Request sample declares parameters (e.g. `path:"id"` for `:id`) and body, response sample declares
+// body of successful response, nil samples are not documented. Setup functions describe operation further.
+//
+// Handlers are not registered if operation can not be added to reflector.
+func (g *RouterGroup[H, R]) Handle(
+	method, relativePath string, req, resp interface{}, handlers []H, setup ...func(oc openapi.OperationContext),
+) error {
+	path := Path(joinPaths(g.group.BasePath(), relativePath))
+
+	oc, err := g.reflector.NewOperationContext(method, path)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, relativePath, err)
+	}
+
+	if req != nil {
+		oc.AddReqStructure(req)
+	}
+
+	if resp != nil {
+		oc.AddRespStructure(resp)
+	}
+
+	for _, s := range setup {
+		s(oc)
+	}
+
+	if err := g.reflector.AddOperation(oc); err != nil {
+		return fmt.Errorf("%s %s: %w", method, relativePath, err)
+	}
+
+	g.group.Handle(method, relativePath, handlers...)
+
+	return nil
+}
+
+// GET documents and registers GET operation, see Handle.
+func (g *RouterGroup[H, R]) GET(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodGet, relativePath, req, resp, handlers)
+}
+
+// POST documents and registers POST operation, see Handle.
+func (g *RouterGroup[H, R]) POST(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodPost, relativePath, req, resp, handlers)
+}
+
+// PUT documents and registers PUT operation, see Handle.
+func (g *RouterGroup[H, R]) PUT(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodPut, relativePath, req, resp, handlers)
+}
+
+// PATCH documents and registers PATCH operation, see Handle.
+func (g *RouterGroup[H, R]) PATCH(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodPatch, relativePath, req, resp, handlers)
+}
+
+// DELETE documents and registers DELETE operation, see Handle.
+func (g *RouterGroup[H, R]) DELETE(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodDelete, relativePath, req, resp, handlers)
+}
+
+// HEAD documents and registers HEAD operation, see Handle.
+func (g *RouterGroup[H, R]) HEAD(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodHead, relativePath, req, resp, handlers)
+}
+
+// OPTIONS documents and registers OPTIONS operation, see Handle.
+func (g *RouterGroup[H, R]) OPTIONS(relativePath string, req, resp interface{}, handlers ...H) error {
+	return g.Handle(http.MethodOptions, relativePath, req, resp, handlers)
+}
+
+// Path returns OpenAPI path template of gin path, `:name` and `*name` segments become `{name}`.
+func Path(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+
+	for i, s := range segments {
+		if len(s) > 1 && (s[0] == ':' || s[0] == '*') {
+			segments[i] = "{" + s[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// joinPaths concatenates base and relative paths keeping trailing slash of relative path as gin does.
+func joinPaths(base, relative string) string {
+	if relative == "" {
+		return base
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(relative, "/")
+}
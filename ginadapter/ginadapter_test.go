@@ -0,0 +1,102 @@
+package ginadapter_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/ginadapter"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// group mimics *gin.RouterGroup.
+type group struct {
+	base   string
+	routes []string
+}
+
+type handlerFunc func()
+
+func (g *group) Handle(method, relativePath string, handlers ...handlerFunc) *group {
+	for range handlers {
+		g.routes = append(g.routes, method+" "+g.base+relativePath)
+	}
+
+	return g
+}
+
+func (g *group) BasePath() string {
+	return g.base
+}
+
+func TestPath(t *testing.T) {
+	for ginPath, expected := range map[string]string{
+		"/things/:id":               "/things/{id}",
+		"/things/:id/parts/:partID": "/things/{id}/parts/{partID}",
+		"/files/*filepath":          "/files/{filepath}",
+		"/things/":                  "/things/",
+	} {
+		assert.Equal(t, expected, ginadapter.Path(ginPath), ginPath)
+	}
+}
+
+func TestRouterGroup_GET(t *testing.T) {
+	r := openapi3.NewReflector()
+	g := &group{base: "/api"}
+	api := ginadapter.Wrap[handlerFunc, *group](g, r)
+
+	type getThingReq struct {
+		ID     int    `path:"id"`
+		Fields string `query:"fields"`
+	}
+
+	type thing struct {
+		ID int `json:"id"`
+	}
+
+	require.NoError(t, api.GET("/things/:id", getThingReq{}, thing{}, func() {}))
+	require.NoError(t, api.Handle(http.MethodPost, "/things", thing{}, nil, []handlerFunc{func() {}},
+		func(oc openapi.OperationContext) {
+			oc.SetSummary("Create thing.")
+		}))
+
+	// Undocumented path parameter fails before handler is registered.
+	assert.Error(t, api.DELETE("/things/:id", nil, nil, func() {}))
+
+	assert.Equal(t, []string{"GET /api/things/:id", "POST /api/things"}, g.routes)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/api/things/{id}":{
+		  "get":{
+			"parameters":[
+			  {"name":"fields","in":"query","schema":{"type":"string"}},
+			  {"name":"id","in":"path","required":true,"schema":{"type":"integer"}}
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/GinadapterTestThing"}}}
+			  }
+			}
+		  }
+		},
+		"/api/things":{
+		  "post":{
+			"summary":"Create thing.",
+			"requestBody":{
+			  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/GinadapterTestThing"}}}
+			},
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{"GinadapterTestThing":{"type":"object","properties":{"id":{"type":"integer"}}}}
+	  }
+	}`, r.SpecEns())
+}
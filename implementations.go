@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Implementations declares concrete types of an interface.
+type Implementations struct {
+	// Samples are values of concrete types, e.g. Circle{} and Square{}.
+	Samples []interface{}
+
+	// AnyOf emits `anyOf` instead of `oneOf`, for implementations that may match same values.
+	AnyOf bool
+
+	// Discriminator is an optional name of property that identifies implementation in a payload.
+	Discriminator string
+
+	// Mapping maps discriminator values to samples of implementations, it is documented as discriminator mapping
+	// of references, samples that are missing in Samples are added to variants.
+	Mapping map[string]interface{}
+}
+
+// Discriminated declares implementations identified by values of discriminator property,
+// e.g. Discriminated("kind", map[string]interface{}{"circle": Circle{}, "square": Square{}}).
+//
+// Implementations are ordered by discriminator values.
+func Discriminated(property string, mapping map[string]interface{}) Implementations {
+	values := make([]string, 0, len(mapping))
+	for value := range mapping {
+		values = append(values, value)
+	}
+
+	sort.Strings(values)
+
+	impl := Implementations{Discriminator: property, Mapping: mapping}
+	seen := map[reflect.Type]bool{}
+
+	for _, value := range values {
+		sample := mapping[value]
+		if t := reflect.TypeOf(sample); !seen[t] {
+			seen[t] = true
+
+			impl.Samples = append(impl.Samples, sample)
+		}
+	}
+
+	return impl
+}
+
+// ImplementationRegistry keeps concrete types of interfaces, fields of registered interface types
+// are reflected as `oneOf` of references to implementations instead of an empty schema.
+type ImplementationRegistry map[reflect.Type]Implementations
+
+// Add registers implementations of interface, iface is a nil pointer to interface, e.g. (*Shape)(nil).
+func (r ImplementationRegistry) Add(iface interface{}, samples ...interface{}) {
+	r.AddImplementations(iface, Implementations{Samples: samples})
+}
+
+// AddImplementations registers implementations of interface, iface is a nil pointer to interface, e.g. (*Shape)(nil).
+func (r ImplementationRegistry) AddImplementations(iface interface{}, impl Implementations) {
+	t := reflect.TypeOf(iface)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	r[t] = impl
+}
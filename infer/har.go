@@ -0,0 +1,101 @@
+package infer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// HTTP Archive (HAR) 1.2 structures, only fields used for inference are declared.
+type (
+	harFile struct {
+		Log struct {
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+
+	harEntry struct {
+		Request struct {
+			Method   string      `json:"method"`
+			URL      string      `json:"url"`
+			Headers  []harHeader `json:"headers"`
+			PostData *struct {
+				MimeType string `json:"mimeType"`
+				Text     string `json:"text"`
+			} `json:"postData"`
+		} `json:"request"`
+		Response struct {
+			Status  int         `json:"status"`
+			Headers []harHeader `json:"headers"`
+			Content struct {
+				MimeType string `json:"mimeType"`
+				Text     string `json:"text"`
+				Encoding string `json:"encoding"`
+			} `json:"content"`
+		} `json:"response"`
+	}
+
+	harHeader struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+)
+
+func harHeaders(headers []harHeader) http.Header {
+	h := make(http.Header, len(headers))
+	for _, hh := range headers {
+		h.Add(hh.Name, hh.Value)
+	}
+
+	return h
+}
+
+// AddHAR records exchanges of HTTP Archive, e.g. exported from browser developer tools or a proxy.
+func (b *Builder) AddHAR(r io.Reader) error {
+	var har harFile
+
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return fmt.Errorf("decoding HAR: %w", err)
+	}
+
+	for i, e := range har.Log.Entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		ex := Exchange{
+			Method:         e.Request.Method,
+			URL:            u,
+			RequestHeader:  harHeaders(e.Request.Headers),
+			Status:         e.Response.Status,
+			ResponseHeader: harHeaders(e.Response.Headers),
+			ResponseBody:   []byte(e.Response.Content.Text),
+		}
+
+		if pd := e.Request.PostData; pd != nil {
+			ex.RequestBody = []byte(pd.Text)
+
+			if ex.RequestHeader.Get("Content-Type") == "" {
+				ex.RequestHeader.Set("Content-Type", pd.MimeType)
+			}
+		}
+
+		if ex.ResponseHeader.Get("Content-Type") == "" {
+			ex.ResponseHeader.Set("Content-Type", e.Response.Content.MimeType)
+		}
+
+		if e.Response.Content.Encoding == "base64" {
+			if ex.ResponseBody, err = base64.StdEncoding.DecodeString(e.Response.Content.Text); err != nil {
+				return fmt.Errorf("entry %d: decoding response: %w", i, err)
+			}
+		}
+
+		b.Add(ex)
+	}
+
+	return nil
+}
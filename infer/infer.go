@@ -0,0 +1,340 @@
+// Package infer builds an initial OpenAPI 3.0 document from recorded HTTP traffic,
+// e.g. to bootstrap documentation of a legacy service.
+//
+// Exchanges are added from HAR files, from a middleware of handler in tests or one by one:
+//
+//	b := infer.NewBuilder()
+//	b.AddPathTemplate("/users/{name}")
+//	_ = b.AddHAR(harFile)
+//	spec, err := b.Spec()
+//
+// Numeric and UUID path segments are replaced with parameters, query parameters and
+// JSON bodies of requests and responses are merged across samples of an operation:
+// properties present in every sample are required, conflicting types widen schema.
+package infer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/param"
+)
+
+// Exchange is a recorded request and response.
+type Exchange struct {
+	Method string
+	URL    *url.URL
+
+	RequestHeader http.Header
+	RequestBody   []byte
+
+	Status         int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+}
+
+// Builder accumulates exchanges and infers a document from them, it is safe for concurrent use.
+type Builder struct {
+	mu         sync.Mutex
+	templates  []string
+	operations map[string]*operation
+}
+
+type operation struct {
+	method string
+	path   string
+	calls  int
+
+	pathParams  map[string]*shape
+	queryParams map[string]*shape
+	queryCalls  map[string]int
+
+	requestType string
+	request     *shape
+	responses   map[int]map[string]*shape
+}
+
+// NewBuilder creates a builder of document.
+func NewBuilder() *Builder {
+	return &Builder{operations: map[string]*operation{}}
+}
+
+// AddPathTemplate registers path template (e.g. `/users/{name}`) for paths that can not be recognized
+// automatically, matching paths are recorded with this template.
+func (b *Builder) AddPathTemplate(template string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.templates = append(b.templates, template)
+}
+
+var (
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	numericSegment = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// template returns path template of URL path and values of its parameters.
+func (b *Builder) template(urlPath string) (string, map[string]string) {
+	for _, t := range b.templates {
+		if params, ok := param.MatchPath(t, urlPath); ok {
+			return t, params
+		}
+	}
+
+	segments := strings.Split(urlPath, "/")
+	params := map[string]string{}
+	prev := ""
+
+	for i, s := range segments {
+		if !numericSegment.MatchString(s) && !uuidSegment.MatchString(s) {
+			prev = s
+
+			continue
+		}
+
+		name := "id"
+		if prev != "" {
+			name = strings.TrimSuffix(prev, "s") + "Id"
+		}
+
+		for n := 2; params[name] != ""; n++ {
+			name = strings.TrimRight(name, "0123456789") + strconv.Itoa(n)
+		}
+
+		params[name] = s
+		segments[i] = "{" + name + "}"
+		prev = ""
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+// Add records an exchange.
+func (b *Builder) Add(ex Exchange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	path, pathParams := b.template(ex.URL.Path)
+	method := strings.ToUpper(ex.Method)
+	key := method + " " + path
+
+	op, ok := b.operations[key]
+	if !ok {
+		op = &operation{
+			method:      method,
+			path:        path,
+			pathParams:  map[string]*shape{},
+			queryParams: map[string]*shape{},
+			queryCalls:  map[string]int{},
+			responses:   map[int]map[string]*shape{},
+		}
+		b.operations[key] = op
+	}
+
+	op.calls++
+
+	for name, value := range pathParams {
+		op.pathParams[name] = op.pathParams[name].merge(scalarShape(value))
+	}
+
+	for name, values := range ex.URL.Query() {
+		op.queryCalls[name]++
+
+		for _, value := range values {
+			op.queryParams[name] = op.queryParams[name].merge(scalarShape(value))
+		}
+	}
+
+	if mt, s := bodyShape(ex.RequestHeader.Get("Content-Type"), ex.RequestBody); mt != "" {
+		op.requestType = mt
+		op.request = op.request.merge(s)
+	}
+
+	if ex.Status == 0 {
+		ex.Status = http.StatusOK
+	}
+
+	content, ok := op.responses[ex.Status]
+	if !ok {
+		content = map[string]*shape{}
+		op.responses[ex.Status] = content
+	}
+
+	if mt, s := bodyShape(ex.ResponseHeader.Get("Content-Type"), ex.ResponseBody); mt != "" {
+		content[mt] = content[mt].merge(s)
+	}
+}
+
+// Middleware records requests and responses of handler, e.g. of httptest server.
+func (b *Builder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var reqBody []byte
+
+		if req.Body != nil {
+			if body, err := io.ReadAll(req.Body); err == nil {
+				reqBody = body
+			}
+
+			req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+
+		cw := &captureWriter{ResponseWriter: rw}
+
+		next.ServeHTTP(cw, req)
+
+		b.Add(Exchange{
+			Method:         req.Method,
+			URL:            req.URL,
+			RequestHeader:  req.Header,
+			RequestBody:    reqBody,
+			Status:         cw.status,
+			ResponseHeader: cw.Header(),
+			ResponseBody:   cw.body.Bytes(),
+		})
+	})
+}
+
+// Spec returns a document inferred from recorded exchanges.
+func (b *Builder) Spec() (*openapi3.Spec, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := &openapi3.Spec{Openapi: "3.0.3"}
+	s.Info.WithTitle("Inferred API").WithVersion("0.0.0")
+
+	keys := make([]string, 0, len(b.operations))
+	for k := range b.operations {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		op := b.operations[k]
+		o := openapi3.Operation{}
+
+		for _, name := range sortedKeys(op.pathParams) {
+			o.Parameters = append(o.Parameters, parameter(openapi3.ParameterInPath, name, true, op.pathParams[name]))
+		}
+
+		for _, name := range sortedKeys(op.queryParams) {
+			o.Parameters = append(o.Parameters,
+				parameter(openapi3.ParameterInQuery, name, op.queryCalls[name] == op.calls, op.queryParams[name]))
+		}
+
+		if op.request != nil {
+			o.RequestBody = &openapi3.RequestBodyOrRef{RequestBody: &openapi3.RequestBody{
+				Content: map[string]openapi3.MediaType{op.requestType: {Schema: op.request.schemaOrRef()}},
+			}}
+		}
+
+		for status, content := range op.responses {
+			resp := openapi3.Response{Description: http.StatusText(status)}
+
+			for mt, sh := range content {
+				if resp.Content == nil {
+					resp.Content = map[string]openapi3.MediaType{}
+				}
+
+				resp.Content[mt] = openapi3.MediaType{Schema: sh.schemaOrRef()}
+			}
+
+			o.Responses.WithMapOfResponseOrRefValuesItem(strconv.Itoa(status), openapi3.ResponseOrRef{Response: &resp})
+		}
+
+		if err := s.AddOperation(op.method, op.path, o); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func parameter(in openapi3.ParameterIn, name string, required bool, sh *shape) openapi3.ParameterOrRef {
+	p := openapi3.Parameter{In: in, Name: name, Schema: sh.schemaOrRef()}
+	if required {
+		p.WithRequired(true)
+	}
+
+	return openapi3.ParameterOrRef{Parameter: &p}
+}
+
+// bodyShape returns media type without parameters and shape of body, JSON bodies are inferred in detail.
+func bodyShape(contentType string, body []byte) (string, *shape) {
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = contentType
+	}
+
+	if mt == "" {
+		mt = "application/octet-stream"
+	}
+
+	if mt == "application/json" || strings.HasSuffix(mt, "+json") {
+		var v interface{}
+
+		if err := json.Unmarshal(body, &v); err == nil {
+			return mt, valueShape(v)
+		}
+	}
+
+	sh := typeShape(openapi3.SchemaTypeString)
+
+	if !strings.HasPrefix(mt, "text/") {
+		f := "binary"
+		sh.format = &f
+	}
+
+	return mt, sh
+}
+
+// captureWriter keeps status and body of response.
+type captureWriter struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
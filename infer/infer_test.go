@@ -0,0 +1,167 @@
+package infer_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/infer"
+)
+
+func TestBuilder_Middleware(t *testing.T) {
+	b := infer.NewBuilder()
+
+	h := b.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPost {
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"id":3}`))
+
+			return
+		}
+
+		switch r.URL.Path {
+		case "/users/1":
+			_, _ = rw.Write([]byte(`{"id":1,"name":"John","email":"john@example.com","tags":["a"],"score":1}`))
+		case "/users/2":
+			_, _ = rw.Write([]byte(`{"id":2,"name":"Jane","manager":null,"tags":[],"score":2.5}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/users/1?expand=true&limit=10", nil),
+		httptest.NewRequest(http.MethodGet, "/users/2?limit=20", nil),
+		httptest.NewRequest(http.MethodGet, "/users/3", nil),
+		httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"Bob","birthday":"2000-01-02"}`)),
+	} {
+		req.Header.Set("Content-Type", "application/json")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	s, err := b.Spec()
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"Inferred API","version":"0.0.0"},
+	  "paths":{
+		"/users":{
+		  "post":{
+			"requestBody":{
+			  "content":{
+				"application/json":{
+				  "schema":{
+					"required":["birthday","name"],"type":"object",
+					"properties":{"birthday":{"type":"string","format":"date"},"name":{"type":"string"}}
+				  }
+				}
+			  }
+			},
+			"responses":{
+			  "201":{
+				"description":"Created",
+				"content":{
+				  "application/json":{
+					"schema":{"required":["id"],"type":"object","properties":{"id":{"type":"integer"}}}
+				  }
+				}
+			  }
+			}
+		  }
+		},
+		"/users/{userId}":{
+		  "get":{
+			"parameters":[
+			  {"name":"userId","in":"path","required":true,"schema":{"type":"integer"}},
+			  {"name":"expand","in":"query","schema":{"type":"boolean"}},
+			  {"name":"limit","in":"query","schema":{"type":"integer"}}
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{
+				  "application/json":{
+					"schema":{
+					  "required":["id","name","score","tags"],"type":"object",
+					  "properties":{
+						"email":{"type":"string","format":"email"},"id":{"type":"integer"},
+						"manager":{},"name":{"type":"string"},
+						"score":{"type":"number"},"tags":{"type":"array","items":{"type":"string"}}
+					  }
+					}
+				  }
+				}
+			  },
+			  "404":{
+				"description":"Not Found",
+				"content":{
+				  "application/json":{
+					"schema":{"required":["error"],"type":"object","properties":{"error":{"type":"string"}}}
+				  }
+				}
+			  }
+			}
+		  }
+		}
+	  }
+	}`, s)
+}
+
+func TestBuilder_AddHAR(t *testing.T) {
+	b := infer.NewBuilder()
+	b.AddPathTemplate("/files/{name}")
+
+	require.NoError(t, b.AddHAR(strings.NewReader(`{"log":{"entries":[
+	  {
+		"request":{"method":"GET","url":"https://example.com/files/report.pdf","headers":[]},
+		"response":{"status":200,"headers":[],"content":{"mimeType":"application/pdf","text":"JVBERg==","encoding":"base64"}}
+	  },
+	  {
+		"request":{
+		  "method":"PUT","url":"https://example.com/orgs/3fa85f64-5717-4562-b3fc-2c963f66afa6/users/7",
+		  "headers":[{"name":"Content-Type","value":"application/json"}],
+		  "postData":{"mimeType":"application/json","text":"{\"role\":\"admin\"}"}
+		},
+		"response":{"status":204,"headers":[],"content":{"mimeType":"","text":""}}
+	  }
+	]}}`)))
+
+	s, err := b.Spec()
+	require.NoError(t, err)
+
+	assert.Len(t, s.Paths.MapOfPathItemValues, 2)
+
+	assertjson.EqMarshal(t, `{
+	  "parameters":[
+		{"name":"orgId","in":"path","required":true,"schema":{"type":"string","format":"uuid"}},
+		{"name":"userId","in":"path","required":true,"schema":{"type":"integer"}}
+	  ],
+	  "requestBody":{
+		"content":{
+		  "application/json":{
+			"schema":{"required":["role"],"type":"object","properties":{"role":{"type":"string"}}}
+		  }
+		}
+	  },
+	  "responses":{"204":{"description":"No Content"}}
+	}`, s.Paths.MapOfPathItemValues["/orgs/{orgId}/users/{userId}"].MapOfOperationValues["put"])
+
+	assertjson.EqMarshal(t, `{
+	  "parameters":[{"name":"name","in":"path","required":true,"schema":{"type":"string"}}],
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{"application/pdf":{"schema":{"type":"string","format":"binary"}}}
+		}
+	  }
+	}`, s.Paths.MapOfPathItemValues["/files/{name}"].MapOfOperationValues["get"])
+
+	assert.Error(t, b.AddHAR(strings.NewReader(`{`)))
+}
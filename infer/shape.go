@@ -0,0 +1,217 @@
+package infer
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// shape is an inferred structure of values merged across samples.
+type shape struct {
+	types    map[openapi3.SchemaType]bool
+	nullable bool
+
+	// format is common format of string samples, empty if samples disagree.
+	format *string
+
+	// properties of object samples, counts are numbers of samples that have property.
+	objects    int
+	properties map[string]*shape
+	counts     map[string]int
+
+	items *shape
+}
+
+var emailValue = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func stringFormat(s string) string {
+	switch {
+	case uuidSegment.MatchString(s):
+		return "uuid"
+	case emailValue.MatchString(s):
+		return "email"
+	}
+
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "date-time"
+	}
+
+	if _, err := time.Parse("2006-01-02", s); err == nil {
+		return "date"
+	}
+
+	return ""
+}
+
+func typeShape(t openapi3.SchemaType) *shape {
+	return &shape{types: map[openapi3.SchemaType]bool{t: true}}
+}
+
+// scalarShape infers shape of a parameter value.
+func scalarShape(v string) *shape {
+	if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return typeShape(openapi3.SchemaTypeInteger)
+	}
+
+	if _, err := strconv.ParseFloat(v, 64); err == nil {
+		return typeShape(openapi3.SchemaTypeNumber)
+	}
+
+	if v == "true" || v == "false" {
+		return typeShape(openapi3.SchemaTypeBoolean)
+	}
+
+	return valueShape(v)
+}
+
+// valueShape infers shape of a value decoded from JSON.
+func valueShape(v interface{}) *shape {
+	switch v := v.(type) {
+	case nil:
+		return &shape{types: map[openapi3.SchemaType]bool{}, nullable: true}
+	case bool:
+		return typeShape(openapi3.SchemaTypeBoolean)
+	case float64:
+		if v == math.Trunc(v) {
+			return typeShape(openapi3.SchemaTypeInteger)
+		}
+
+		return typeShape(openapi3.SchemaTypeNumber)
+	case string:
+		sh := typeShape(openapi3.SchemaTypeString)
+		f := stringFormat(v)
+		sh.format = &f
+
+		return sh
+	case []interface{}:
+		sh := typeShape(openapi3.SchemaTypeArray)
+
+		for _, item := range v {
+			sh.items = sh.items.merge(valueShape(item))
+		}
+
+		return sh
+	case map[string]interface{}:
+		sh := typeShape(openapi3.SchemaTypeObject)
+		sh.objects = 1
+		sh.properties = make(map[string]*shape, len(v))
+		sh.counts = make(map[string]int, len(v))
+
+		for name, pv := range v {
+			sh.properties[name] = valueShape(pv)
+			sh.counts[name] = 1
+		}
+
+		return sh
+	}
+
+	return &shape{types: map[openapi3.SchemaType]bool{}}
+}
+
+// merge combines shapes of samples, receiver may be nil.
+func (s *shape) merge(o *shape) *shape {
+	if s == nil {
+		return o
+	}
+
+	if o == nil {
+		return s
+	}
+
+	for t := range o.types {
+		s.types[t] = true
+	}
+
+	s.nullable = s.nullable || o.nullable
+
+	switch {
+	case s.format == nil:
+		s.format = o.format
+	case o.format != nil && *s.format != *o.format:
+		f := ""
+		s.format = &f
+	}
+
+	if o.objects > 0 {
+		if s.properties == nil {
+			s.properties = map[string]*shape{}
+			s.counts = map[string]int{}
+		}
+
+		s.objects += o.objects
+
+		for name, ps := range o.properties {
+			s.properties[name] = s.properties[name].merge(ps)
+			s.counts[name] += o.counts[name]
+		}
+	}
+
+	s.items = s.items.merge(o.items)
+
+	return s
+}
+
+func (s *shape) schemaOrRef() *openapi3.SchemaOrRef {
+	return &openapi3.SchemaOrRef{Schema: s.schema()}
+}
+
+func (s *shape) schema() *openapi3.Schema {
+	res := &openapi3.Schema{}
+
+	if s == nil {
+		return res
+	}
+
+	types := s.types
+	if types[openapi3.SchemaTypeInteger] && types[openapi3.SchemaTypeNumber] {
+		types = make(map[openapi3.SchemaType]bool, len(s.types))
+		for t := range s.types {
+			if t != openapi3.SchemaTypeInteger {
+				types[t] = true
+			}
+		}
+	}
+
+	if len(types) != 1 {
+		// Values of conflicting or unknown (only null samples) types are described with an empty schema.
+		return res
+	}
+
+	if s.nullable {
+		res.WithNullable(true)
+	}
+
+	for t := range types {
+		res.WithType(t)
+	}
+
+	switch *res.Type {
+	case openapi3.SchemaTypeString:
+		if s.format != nil && *s.format != "" {
+			res.WithFormat(*s.format)
+		}
+	case openapi3.SchemaTypeArray:
+		res.WithItems(*s.items.schemaOrRef())
+	case openapi3.SchemaTypeObject:
+		names := make([]string, 0, len(s.properties))
+		for name := range s.properties {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			res.WithPropertiesItem(name, *s.properties[name].schemaOrRef())
+
+			if s.counts[name] == s.objects {
+				res.Required = append(res.Required, name)
+			}
+		}
+	}
+
+	return res
+}
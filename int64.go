@@ -0,0 +1,23 @@
+package openapi
+
+// MaxSafeInteger is the largest integer that JSON clients with IEEE 754 double numbers can represent exactly.
+const MaxSafeInteger = 1<<53 - 1
+
+// Int64Policy defines how reflected 64-bit integers are described to avoid precision loss in JSON clients.
+type Int64Policy int
+
+// Int64Policy values enumeration.
+const (
+	// Int64Keep leaves 64-bit integers as JSON numbers, this is the default.
+	Int64Keep = Int64Policy(iota)
+
+	// Int64AsString describes int64 and uint64 fields as strings with `format: int64` (or `uint64`),
+	// field `example`, `default`, `const` and `enum` values are converted to strings.
+	//
+	// This policy fits APIs that encode such fields with `json:",string"`.
+	Int64AsString
+
+	// Int64Fail fails reflection if field `example`, `default`, `const` or `enum` of integer
+	// exceeds MaxSafeInteger by absolute value.
+	Int64Fail
+)
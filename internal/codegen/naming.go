@@ -0,0 +1,57 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are kept upper case in Go names, e.g. `userId` is `UserID`.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true, "GUID": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true, "QPS": true,
+	"RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true, "URI": true, "URL": true,
+	"UTF8": true, "VM": true, "XML": true, "XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// GoName converts a string into exported Go identifier, common initialisms are upper case.
+func GoName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r))
+	})
+
+	var sb strings.Builder
+
+	for _, w := range words {
+		for _, part := range splitCamel(w) {
+			if u := strings.ToUpper(part); initialisms[u] {
+				sb.WriteString(u)
+			} else {
+				sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+			}
+		}
+	}
+
+	name := sb.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+
+	return name
+}
+
+// splitCamel splits word on lower to upper case transitions, e.g. `userId` is `user` and `Id`.
+func splitCamel(w string) []string {
+	var parts []string
+
+	start := 0
+
+	for i := 1; i < len(w); i++ {
+		if unicode.IsUpper(rune(w[i])) && !unicode.IsUpper(rune(w[i-1])) {
+			parts = append(parts, w[start:i])
+			start = i
+		}
+	}
+
+	return append(parts, w[start:])
+}
@@ -0,0 +1,221 @@
+// Package codegen renders Go types of OpenAPI 3.0 schemas for generated clients and servers.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+const maxDepth = 8
+
+// Types renders Go types of schemas, referenced component schemas are collected to be declared once.
+type Types struct {
+	spec       *openapi3.Spec
+	components map[string]bool
+}
+
+// NewTypes creates a renderer of schemas of spec.
+func NewTypes(s *openapi3.Spec) *Types {
+	return &Types{spec: s, components: map[string]bool{}}
+}
+
+// Schema resolves local reference to component schema, nil if it can not be resolved.
+func (t *Types) Schema(sr *openapi3.SchemaOrRef) *openapi3.Schema {
+	for i := 0; sr != nil && sr.SchemaReference != nil; i++ {
+		c := t.spec.Components
+		if c == nil || c.Schemas == nil || i > maxDepth {
+			return nil
+		}
+
+		next, ok := c.Schemas.MapOfSchemaOrRefValues[strings.TrimPrefix(sr.SchemaReference.Ref, "#/components/schemas/")]
+		if !ok {
+			return nil
+		}
+
+		sr = &next
+	}
+
+	if sr == nil {
+		return nil
+	}
+
+	return sr.Schema
+}
+
+// GoType returns Go type of schema, referenced components are named types.
+func (t *Types) GoType(sr *openapi3.SchemaOrRef) string {
+	return t.goType(sr, 0)
+}
+
+func (t *Types) goType(sr *openapi3.SchemaOrRef, depth int) string {
+	if sr == nil || depth > maxDepth {
+		return "json.RawMessage"
+	}
+
+	if sr.SchemaReference != nil {
+		name := strings.TrimPrefix(sr.SchemaReference.Ref, "#/components/schemas/")
+		if name == sr.SchemaReference.Ref {
+			return "json.RawMessage"
+		}
+
+		t.components[name] = true
+
+		return GoName(name)
+	}
+
+	s := sr.Schema
+	if s == nil {
+		return "json.RawMessage"
+	}
+
+	var res string
+
+	switch schemaType(s) {
+	case openapi3.SchemaTypeString:
+		res = "string"
+	case openapi3.SchemaTypeInteger:
+		res = "int64"
+	case openapi3.SchemaTypeNumber:
+		res = "float64"
+	case openapi3.SchemaTypeBoolean:
+		res = "bool"
+	case openapi3.SchemaTypeArray:
+		res = "[]" + t.goType(s.Items, depth+1)
+	case openapi3.SchemaTypeObject:
+		res = t.structType(s, depth)
+	default:
+		return "json.RawMessage"
+	}
+
+	if s.Nullable != nil && *s.Nullable && !strings.HasPrefix(res, "[]") && !strings.HasPrefix(res, "map[") {
+		res = "*" + res
+	}
+
+	return res
+}
+
+func schemaType(s *openapi3.Schema) openapi3.SchemaType {
+	if s.Type != nil {
+		return *s.Type
+	}
+
+	if s.Properties != nil {
+		return openapi3.SchemaTypeObject
+	}
+
+	return ""
+}
+
+// isStruct tells if schema is rendered as a struct.
+func isStruct(s *openapi3.Schema) bool {
+	return s != nil && schemaType(s) == openapi3.SchemaTypeObject && s.Properties != nil && s.Properties.Len() > 0
+}
+
+func (t *Types) structType(s *openapi3.Schema, depth int) string {
+	if !isStruct(s) {
+		if ap := s.AdditionalProperties; ap != nil && ap.SchemaOrRef != nil {
+			return "map[string]" + t.goType(ap.SchemaOrRef, depth+1)
+		}
+
+		return "map[string]json.RawMessage"
+	}
+
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("struct {\n")
+
+	fields := map[string]int{}
+
+	for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		field := GoName(pair.Key)
+
+		fields[field]++
+		if n := fields[field]; n > 1 {
+			field += strconv.Itoa(n)
+		}
+
+		tag := pair.Key
+		if !required[pair.Key] {
+			tag += ",omitempty"
+		}
+
+		if pair.Value.Schema != nil && pair.Value.Schema.Description != nil {
+			sb.WriteString("// " + strings.ReplaceAll(*pair.Value.Schema.Description, "\n", "\n// ") + "\n")
+		}
+
+		ft := t.goType(&pair.Value, depth+1)
+
+		// Referenced structs are pointers, so that recursive components are valid Go types,
+		// optional structs are pointers to be omitted when empty.
+		if !strings.HasPrefix(ft, "*") && isStruct(t.Schema(&pair.Value)) &&
+			(pair.Value.SchemaReference != nil || !required[pair.Key]) {
+			ft = "*" + ft
+		}
+
+		fmt.Fprintf(&sb, "%s %s `json:%q`\n", field, ft, tag)
+	}
+
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+// Render declares types of referenced component schemas, including transitive references.
+func (t *Types) Render(buf *bytes.Buffer) error {
+	done := map[string]bool{}
+
+	for {
+		var pending []string
+
+		for name := range t.components {
+			if !done[name] {
+				pending = append(pending, name)
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		sort.Strings(pending)
+
+		for _, name := range pending {
+			done[name] = true
+
+			var sr openapi3.SchemaOrRef
+
+			if c := t.spec.Components; c != nil && c.Schemas != nil {
+				sr = c.Schemas.MapOfSchemaOrRefValues[name]
+			}
+
+			if sr.Schema == nil && sr.SchemaReference == nil {
+				return fmt.Errorf("unresolved schema reference %s", name)
+			}
+
+			fmt.Fprintf(buf, "\n// %s is a schema of %s component.\n", GoName(name), name)
+
+			if sr.Schema != nil && sr.Schema.Description != nil {
+				fmt.Fprintf(buf, "//\n// %s\n", strings.ReplaceAll(*sr.Schema.Description, "\n", "\n// "))
+			}
+
+			// Nullability of named type is kept at usage.
+			if sr.Schema != nil && sr.Schema.Nullable != nil {
+				cp := *sr.Schema
+				cp.Nullable = nil
+				sr.Schema = &cp
+			}
+
+			fmt.Fprintf(buf, "type %s %s\n", GoName(name), t.goType(&sr, 0))
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// InterceptContext aborts reflection with an error of done context.
+//
+// Context is read on every schema, nil context is never done.
+func InterceptContext(ctx func() context.Context) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+		if c := ctx(); c != nil {
+			return false, c.Err()
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/refl"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// InterceptDefault checks that value of `default` field tag matches type of field.
+//
+// Value is parsed by jsonschema-go according to schema type, invalid values, e.g. `default:"1.5"` of an int field
+// or `default:"{\"unknown\":1}"` of a struct field, fail reflection instead of documenting mismatching default.
+// Types with custom unmarshaling and interfaces are not checked.
+func InterceptDefault() func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if !params.Processed || params.PropertySchema == nil {
+			return nil
+		}
+
+		tag, ok := params.Field.Tag.Lookup("default")
+		if !ok || params.PropertySchema.Default == nil {
+			return nil
+		}
+
+		t := refl.DeepIndirect(params.Field.Type)
+		if t.Kind() == reflect.Interface || t.Implements(jsonUnmarshalerType) || t.Implements(textUnmarshalerType) ||
+			reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+			return nil
+		}
+
+		// Strings are not quoted in tags, e.g. `default:"abc"` or `default:"[a,b]"` of []string.
+		if t.Kind() == reflect.String ||
+			(t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.String && !strings.HasPrefix(tag, `["`)) {
+			return nil
+		}
+
+		d := json.NewDecoder(strings.NewReader(tag))
+		d.DisallowUnknownFields()
+
+		if err := d.Decode(reflect.New(t).Interface()); err != nil {
+			return fmt.Errorf("%s: invalid default %q for %s: %w", params.Name, tag, t, err)
+		}
+
+		return nil
+	})
+}
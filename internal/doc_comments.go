@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// InterceptDocComments uses doc comments of types and fields as descriptions
+// of schemas and properties that have no `description` tag.
+func InterceptDocComments(docComments func() openapi.DocComments) func(rc *jsonschema.ReflectContext) {
+	return func(rc *jsonschema.ReflectContext) {
+		dc := docComments()
+		if dc == nil {
+			return
+		}
+
+		// Struct types of schemas that are being reflected, to find declaring types of properties.
+		parents := map[*jsonschema.Schema]reflect.Type{}
+
+		jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+			if !params.Value.IsValid() {
+				return false, nil
+			}
+
+			t := derefType(params.Value.Type())
+
+			if !params.Processed {
+				if t.Kind() == reflect.Struct {
+					parents[params.Schema] = t
+				}
+
+				return false, nil
+			}
+
+			delete(parents, params.Schema)
+
+			if params.Schema.Description == nil && t.Name() != "" {
+				if doc := dc.Type(t.PkgPath(), declName(t)); doc != "" {
+					params.Schema.WithDescription(doc)
+				}
+			}
+
+			return false, nil
+		})(rc)
+
+		jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+			if !params.Processed || params.PropertySchema == nil || params.Field.Tag.Get("description") != "" {
+				return nil
+			}
+
+			parent, ok := parents[params.ParentSchema]
+			if !ok {
+				return nil
+			}
+
+			if t := declaringType(parent, params.Field.Name); t != nil {
+				if doc := dc.Field(t.PkgPath(), declName(t), params.Field.Name); doc != "" {
+					params.PropertySchema.WithDescription(doc)
+				}
+			}
+
+			return nil
+		})(rc)
+	}
+}
+
+// declaringType finds struct type that declares field, it differs from t for promoted fields of embedded structs.
+func declaringType(t reflect.Type, fieldName string) reflect.Type {
+	sf, ok := t.FieldByName(fieldName)
+	if !ok {
+		return nil
+	}
+
+	for _, i := range sf.Index[:len(sf.Index)-1] {
+		t = derefType(t.Field(i).Type)
+	}
+
+	return t
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+// declName returns name of type declaration, type arguments of generic types are omitted.
+func declName(t reflect.Type) string {
+	name, _, _ := strings.Cut(t.Name(), "[")
+
+	return name
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+var typeOfDuration = reflect.TypeOf(time.Duration(0))
+
+// InterceptDuration applies duration policy to reflected time.Duration properties.
+func InterceptDuration(policy func() openapi.DurationPolicy) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if policy() != openapi.DurationString || !params.Processed || params.PropertySchema == nil ||
+			refl.DeepIndirect(params.Field.Type) != typeOfDuration {
+			return nil
+		}
+
+		s := params.PropertySchema
+
+		// Numeric keywords do not apply to strings.
+		s.Minimum, s.Maximum, s.ExclusiveMinimum, s.ExclusiveMaximum, s.MultipleOf = nil, nil, nil, nil, nil
+
+		nullable := s.HasType(jsonschema.Null)
+		s.Type = nil
+		s.AddType(jsonschema.String)
+
+		if nullable {
+			s.AddType(jsonschema.Null)
+		}
+
+		if _, ok := params.Field.Tag.Lookup("format"); !ok {
+			s.WithFormat("duration")
+		}
+
+		forEachValue(s, func(v *interface{}) {
+			if ns, ok := nanoseconds(*v); ok {
+				*v = isoDuration(time.Duration(ns))
+			}
+		})
+
+		return nil
+	})
+}
+
+func nanoseconds(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), n == float64(int64(n))
+	}
+
+	return 0, false
+}
+
+// isoDuration formats duration in ISO 8601, e.g. `PT1H2M3.5S`.
+func isoDuration(d time.Duration) string {
+	var sb strings.Builder
+
+	if d < 0 {
+		sb.WriteByte('-')
+
+		d = -d
+	}
+
+	sb.WriteString("PT")
+
+	rest := d
+
+	if h := d / time.Hour; h > 0 {
+		sb.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+		d -= h * time.Hour
+	}
+
+	if m := d / time.Minute; m > 0 {
+		sb.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+		d -= m * time.Minute
+	}
+
+	// Zero duration has seconds only.
+	if d > 0 || rest == 0 {
+		sb.WriteString(strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S")
+	}
+
+	return sb.String()
+}
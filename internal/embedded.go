@@ -0,0 +1,261 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/refl"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+var typeOfEmbedReferencer = reflect.TypeOf((*jsonschema.EmbedReferencer)(nil)).Elem()
+
+// InterceptEmbedded controls JSON schemas of anonymous embedded structures.
+//
+// Embedded structures without property name (no tag or a tag like `json:",inline"`) are flattened
+// into parent schema like in encoding/json. If allOf returns true, they are instead added to `allOf`
+// of parent schema as references to their own definitions, tag option `inline` keeps a field flattened.
+// Definitions of embedded types are reported to collect.
+// Fields with `refer` tag or types implementing jsonschema.EmbedReferencer are handled by jsonschema-go.
+func InterceptEmbedded(
+	r *jsonschema.Reflector,
+	allOf func() bool,
+	collect func(name string, schema jsonschema.Schema),
+) func(*jsonschema.ReflectContext) {
+	// Embedded types in progress, to avoid endless reflection of mutually recursive types.
+	active := map[reflect.Type]bool{}
+
+	return func(rc *jsonschema.ReflectContext) {
+		// Embedded field with empty property name in tag is otherwise reflected by jsonschema-go
+		// as property named after the field instead of being flattened like in encoding/json.
+		jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+			if params.Processed {
+				return nil
+			}
+
+			if _, _, ok := embeddedTag(params.Context, params.Field); ok {
+				return jsonschema.ErrSkipProperty
+			}
+
+			return nil
+		})(rc)
+
+		jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+			if !params.Processed || !params.Value.IsValid() {
+				return false, nil
+			}
+
+			t := refl.DeepIndirect(params.Value.Type())
+			if t.Kind() != reflect.Struct {
+				return false, nil
+			}
+
+			v := reflect.Indirect(params.Value)
+			if v.Kind() != reflect.Struct {
+				v = reflect.Zero(t)
+			}
+
+			rc := params.Context
+			direct := directPropertyNames(rc, t)
+
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+
+				tag, tagged, ok := embeddedTag(rc, field)
+				if !ok || active[field.Type] {
+					continue
+				}
+
+				compose := allOf() && !hasTagOption(tag, "inline") && !rc.InlineRefs
+
+				// Untagged fields are already flattened.
+				if !tagged && !compose {
+					continue
+				}
+
+				fv := reflect.Zero(field.Type)
+				if f := v.Field(i); f.CanInterface() {
+					fv = f
+				}
+
+				active[field.Type] = true
+				err := embed(r, rc, params.Schema, fv.Interface(), direct, compose, collect)
+
+				delete(active, field.Type)
+
+				if err != nil {
+					return false, err
+				}
+			}
+
+			if params.Schema.Properties != nil && params.Schema.Properties.Len() == 0 {
+				params.Schema.Properties = nil
+			}
+
+			return false, nil
+		})(rc)
+	}
+}
+
+// embeddedTag checks if field is an embedded structure without property name and returns its tag.
+func embeddedTag(rc *jsonschema.ReflectContext, field reflect.StructField) (tag string, tagged bool, ok bool) {
+	if rc.PropertyNameTag != tagJSON || !field.Anonymous || refl.DeepIndirect(field.Type).Kind() != reflect.Struct {
+		return "", false, false
+	}
+
+	if _, ok := field.Tag.Lookup("refer"); ok || field.Type.Implements(typeOfEmbedReferencer) {
+		return "", false, false
+	}
+
+	tag, tagged = propertyTag(rc, field)
+	if strings.Split(tag, ",")[0] != "" {
+		return "", false, false
+	}
+
+	return tag, tagged, true
+}
+
+func embed(
+	r *jsonschema.Reflector,
+	rc *jsonschema.ReflectContext,
+	parent *jsonschema.Schema,
+	value interface{},
+	direct map[string]bool,
+	compose bool,
+	collect func(name string, schema jsonschema.Schema),
+) error {
+	prefix := rc.DefinitionsPrefix
+	if prefix == "" {
+		prefix = componentsSchemas
+	}
+
+	defs := map[string]jsonschema.Schema{}
+
+	s, err := r.Reflect(value,
+		jsonschema.DefinitionsPrefix(prefix),
+		jsonschema.CollectDefinitions(func(name string, schema jsonschema.Schema) {
+			defs[name] = schema
+
+			collect(name, schema)
+		}),
+		jsonschema.PropertyNameTag(rc.PropertyNameTag, rc.PropertyNameAdditionalTags...),
+		jsonschema.PropertyNameMapping(rc.PropertyNameMapping),
+		func(erc *jsonschema.ReflectContext) {
+			erc.ProcessWithoutTags = rc.ProcessWithoutTags
+			erc.InlineRefs = rc.InlineRefs
+			erc.RootRef = compose
+
+			if rc.DefName != nil {
+				erc.DefName = rc.DefName
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if compose && s.Ref != nil {
+		def := defs[strings.TrimPrefix(*s.Ref, prefix)]
+
+		if def.Properties != nil {
+			for p := def.Properties.Oldest(); p != nil; p = p.Next() {
+				if !direct[p.Key] {
+					removeProperty(parent, p.Key)
+				}
+			}
+		}
+
+		parent.AllOf = append(parent.AllOf, s.ToSchemaOrBool())
+
+		return nil
+	}
+
+	if s.Properties == nil {
+		return nil
+	}
+
+	if parent.Properties == nil {
+		parent.Properties = orderedmap.New[string, jsonschema.SchemaOrBool]()
+	}
+
+	for p := s.Properties.Oldest(); p != nil; p = p.Next() {
+		if _, exists := parent.Properties.Get(p.Key); exists {
+			continue
+		}
+
+		parent.Properties.Set(p.Key, p.Value)
+
+		if hasString(s.Required, p.Key) && !hasString(parent.Required, p.Key) {
+			parent.Required = append(parent.Required, p.Key)
+		}
+	}
+
+	return nil
+}
+
+// directPropertyNames returns property names of fields declared directly in a structure,
+// they shadow properties of embedded structures.
+func directPropertyNames(rc *jsonschema.ReflectContext, t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			continue
+		}
+
+		tag, _ := propertyTag(rc, field)
+		name := strings.Split(tag, ",")[0]
+
+		if name == "" {
+			name = field.Name
+		}
+
+		names[name] = true
+	}
+
+	return names
+}
+
+func propertyTag(rc *jsonschema.ReflectContext, field reflect.StructField) (string, bool) {
+	if tag, ok := rc.PropertyNameMapping[field.Name]; ok {
+		return tag, true
+	}
+
+	if tag, ok := field.Tag.Lookup(rc.PropertyNameTag); ok {
+		return tag, true
+	}
+
+	for _, t := range rc.PropertyNameAdditionalTags {
+		if tag, ok := field.Tag.Lookup(t); ok {
+			return tag, true
+		}
+	}
+
+	return "", false
+}
+
+func hasTagOption(tag, option string) bool {
+	for _, o := range strings.Split(tag, ",")[1:] {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeProperty(s *jsonschema.Schema, name string) {
+	if s.Properties != nil {
+		s.Properties.Delete(name)
+	}
+
+	for i, r := range s.Required {
+		if r == name {
+			s.Required = append(s.Required[:i:i], s.Required[i+1:]...)
+
+			break
+		}
+	}
+}
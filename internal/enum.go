@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+// InterceptEnums sets `enum` of types registered in enums.
+func InterceptEnums(enums func() openapi.EnumRegistry) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+		if params.Processed || !params.Value.IsValid() {
+			return false, nil
+		}
+
+		if values, ok := enums()[refl.DeepIndirect(params.Value.Type())]; ok {
+			params.Schema.Enum = values
+		}
+
+		return false, nil
+	})
+}
@@ -0,0 +1,25 @@
+package internal
+
+import "github.com/swaggest/openapi-go"
+
+// MediaTypeExamples returns named examples of content unit, structure examples are overridden by option examples.
+func MediaTypeExamples(cu openapi.ContentUnit) map[string]interface{} {
+	ep, ok := cu.Structure.(openapi.ExampleProvider)
+	if !ok && len(cu.Examples) == 0 {
+		return nil
+	}
+
+	res := map[string]interface{}{}
+
+	if ok {
+		for name, value := range ep.Examples() {
+			res[name] = value
+		}
+	}
+
+	for name, value := range cu.Examples {
+		res[name] = value
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// InterceptGenericDefName names instantiated generic types with a mangler of type arguments,
+// default names are kept if mangler is nil.
+func InterceptGenericDefName(mangler func() openapi.TypeArgsMangler) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptDefName(func(t reflect.Type, defaultDefName string) string {
+		m := mangler()
+		name := t.Name()
+
+		if m == nil || !strings.Contains(name, "[") {
+			return defaultDefName
+		}
+
+		prefix := ""
+		if t.PkgPath() != "main" {
+			prefix = camel(path.Base(t.PkgPath()))
+		}
+
+		return prefix + typeArgName(name, m)
+	})
+}
+
+// typeArgName converts a type string like `pkg.Page[github.com/org/pkg.User]` into a name.
+func typeArgName(s string, m openapi.TypeArgsMangler) string {
+	s = strings.TrimLeft(s, "*")
+
+	switch {
+	case strings.HasPrefix(s, "[]"):
+		return m("List", []string{typeArgName(s[2:], m)})
+	case strings.HasPrefix(s, "map["):
+		if end := closingBracket(s, 3); end > 0 {
+			return m("Map", []string{typeArgName(s[4:end], m), typeArgName(s[end+1:], m)})
+		}
+	}
+
+	pos := strings.Index(s, "[")
+	if pos < 0 || !strings.HasSuffix(s, "]") {
+		return ident(s)
+	}
+
+	var args []string
+	for _, a := range splitTypeArgs(s[pos+1 : len(s)-1]) {
+		args = append(args, typeArgName(a, m))
+	}
+
+	return m(ident(s[:pos]), args)
+}
+
+// ident strips package path and local type index of a type name.
+func ident(s string) string {
+	if pos := strings.LastIndex(s, "/"); pos >= 0 {
+		s = s[pos+1:]
+	}
+
+	if pos := strings.LastIndex(s, "."); pos >= 0 {
+		s = s[pos+1:]
+	}
+
+	if pos := strings.Index(s, "·"); pos >= 0 {
+		s = s[:pos]
+	}
+
+	return camel(s)
+}
+
+// camel converts identifier to upper camel case, e.g. `openapi3_test` to `Openapi3Test`.
+func camel(s string) string {
+	var sb strings.Builder
+
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		sb.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+
+	return sb.String()
+}
+
+// splitTypeArgs splits a list of type arguments by top-level commas.
+func splitTypeArgs(s string) []string {
+	var (
+		args  []string
+		depth int
+		start int
+	)
+
+	for i, r := range s {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(args, s[start:])
+}
+
+func closingBracket(s string, open int) int {
+	depth := 0
+
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
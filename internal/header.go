@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// IsIgnoredHeader reports whether header parameter is ignored by OpenAPI.
+//
+// `Accept` and `Content-Type` are described by media types and `Authorization` by security schemes.
+func IsIgnoredHeader(in openapi.In, name string) bool {
+	if in != openapi.InHeader {
+		return false
+	}
+
+	switch http.CanonicalHeaderKey(name) {
+	case "Accept", "Content-Type", "Authorization":
+		return true
+	}
+
+	return false
+}
+
+// SameParameter reports whether parameter names are same, header names are case-insensitive.
+func SameParameter(in openapi.In, name1, name2 string) bool {
+	if in == openapi.InHeader {
+		return strings.EqualFold(name1, name2)
+	}
+
+	return name1 == name2
+}
@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+// XDiscriminator is a key of schema ExtraProperties with OpenAPI discriminator,
+// it is converted to `discriminator` keyword of OpenAPI schema.
+const XDiscriminator = "discriminator"
+
+// InterceptImplementations reflects registered interface types as `oneOf` (or `anyOf`) of implementations.
+//
+// Definitions of implementations are reported to collect.
+// Interface fields of implementations that refer to the interface being reflected are left empty.
+func InterceptImplementations(
+	r *jsonschema.Reflector,
+	registry func() openapi.ImplementationRegistry,
+	collect func(name string, schema jsonschema.Schema),
+) func(rc *jsonschema.ReflectContext) {
+	// Interfaces in progress, to avoid endless reflection of recursive types.
+	active := map[reflect.Type]bool{}
+
+	// Empty definitions of interfaces in progress are skipped, so that they do not shadow complete ones.
+	collectVariant := func(name string, schema jsonschema.Schema) {
+		if schema.ReflectType != nil && active[refl.DeepIndirect(schema.ReflectType)] {
+			return
+		}
+
+		collect(name, schema)
+	}
+
+	return jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+		if params.Processed || !params.Value.IsValid() {
+			return false, nil
+		}
+
+		t := refl.DeepIndirect(params.Value.Type())
+		if t.Kind() != reflect.Interface {
+			return false, nil
+		}
+
+		impl, ok := registry()[t]
+		if !ok || active[t] {
+			return false, nil
+		}
+
+		active[t] = true
+		defer delete(active, t)
+
+		rc := params.Context
+		s := params.Schema
+
+		variants := make([]jsonschema.SchemaOrBool, 0, len(impl.Samples))
+		refs := map[reflect.Type]string{}
+
+		addVariant := func(sample interface{}) error {
+			vs, err := reflectVariant(r, rc, sample, collectVariant)
+			if err != nil {
+				return err
+			}
+
+			if vs.Ref != nil {
+				refs[reflect.TypeOf(sample)] = *vs.Ref
+			}
+
+			variants = append(variants, vs.ToSchemaOrBool())
+
+			return nil
+		}
+
+		for _, sample := range impl.Samples {
+			if err := addVariant(sample); err != nil {
+				return false, err
+			}
+		}
+
+		values := make([]string, 0, len(impl.Mapping))
+		for value := range impl.Mapping {
+			values = append(values, value)
+		}
+
+		sort.Strings(values)
+
+		mapping := map[string]string{}
+
+		for _, value := range values {
+			sample := impl.Mapping[value]
+
+			if _, ok := refs[reflect.TypeOf(sample)]; !ok {
+				if err := addVariant(sample); err != nil {
+					return false, err
+				}
+			}
+
+			if ref, ok := refs[reflect.TypeOf(sample)]; ok {
+				mapping[value] = ref
+			}
+		}
+
+		// Nil interface is described by variants, reflected pointer type is not nullable.
+		s.Type = nil
+
+		if impl.AnyOf {
+			s.AnyOf = variants
+		} else {
+			s.OneOf = variants
+		}
+
+		if impl.Discriminator != "" {
+			d := map[string]interface{}{
+				"propertyName": impl.Discriminator,
+			}
+
+			if len(mapping) > 0 {
+				d["mapping"] = mapping
+			}
+
+			s.WithExtraPropertiesItem(XDiscriminator, d)
+		}
+
+		return true, nil
+	})
+}
+
+// reflectVariant reflects sample as a reference to its definition.
+func reflectVariant(
+	r *jsonschema.Reflector,
+	rc *jsonschema.ReflectContext,
+	sample interface{},
+	collect func(name string, schema jsonschema.Schema),
+) (jsonschema.Schema, error) {
+	prefix := rc.DefinitionsPrefix
+	if prefix == "" {
+		prefix = componentsSchemas
+	}
+
+	// Definitions with other prefixes (e.g. of auxiliary reflections) do not belong to components.
+	if prefix != componentsSchemas {
+		collect = rc.CollectDefinitions
+		if collect == nil {
+			collect = func(string, jsonschema.Schema) {}
+		}
+	}
+
+	return r.Reflect(sample,
+		jsonschema.DefinitionsPrefix(prefix),
+		jsonschema.CollectDefinitions(collect),
+		jsonschema.PropertyNameTag(rc.PropertyNameTag, rc.PropertyNameAdditionalTags...),
+		func(vrc *jsonschema.ReflectContext) {
+			vrc.ProcessWithoutTags = rc.ProcessWithoutTags
+			vrc.InlineRefs = rc.InlineRefs
+			vrc.RootRef = !rc.InlineRefs
+
+			if rc.DefName != nil {
+				vrc.DefName = rc.DefName
+			}
+		},
+	)
+}
@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// InterceptInt64 applies int64 policy to reflected properties, items of arrays and values of maps.
+func InterceptInt64(policy func() openapi.Int64Policy) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		p := policy()
+		if p == openapi.Int64Keep || !params.Processed || params.PropertySchema == nil {
+			return nil
+		}
+
+		if err := applyInt64(p, params.PropertySchema, params.Field.Type); err != nil {
+			return fmt.Errorf("field %s: %w", params.Field.Name, err)
+		}
+
+		return nil
+	})
+}
+
+// applyInt64 applies policy to schema of type t, or to schema of its elements if t is array, slice or map.
+func applyInt64(policy openapi.Int64Policy, s *jsonschema.Schema, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds have no elements.
+	case reflect.Array, reflect.Slice:
+		if s.Items != nil && s.Items.SchemaOrBool != nil && s.Items.SchemaOrBool.TypeObject != nil {
+			return applyInt64(policy, s.Items.SchemaOrBool.TypeObject, t.Elem())
+		}
+
+		return nil
+	case reflect.Map:
+		if s.AdditionalProperties != nil && s.AdditionalProperties.TypeObject != nil {
+			return applyInt64(policy, s.AdditionalProperties.TypeObject, t.Elem())
+		}
+
+		return nil
+	}
+
+	if !s.HasType(jsonschema.Integer) {
+		return nil
+	}
+
+	if policy == openapi.Int64Fail {
+		return checkSafeIntegers(s)
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Only 64-bit integers are converted.
+	case reflect.Int64:
+		s.WithFormat("int64")
+	case reflect.Uint64:
+		s.WithFormat("uint64")
+	default:
+		return nil
+	}
+
+	// Numeric keywords do not apply to strings.
+	s.Minimum, s.Maximum, s.ExclusiveMinimum, s.ExclusiveMaximum, s.MultipleOf = nil, nil, nil, nil, nil
+
+	nullable := s.HasType(jsonschema.Null)
+	s.Type = nil
+	s.AddType(jsonschema.String)
+
+	if nullable {
+		s.AddType(jsonschema.Null)
+	}
+
+	forEachValue(s, func(v *interface{}) {
+		if *v != nil {
+			*v = integerString(*v)
+		}
+	})
+
+	return nil
+}
+
+func checkSafeIntegers(s *jsonschema.Schema) error {
+	var err error
+
+	forEachValue(s, func(v *interface{}) {
+		if err == nil && !isSafeInteger(*v) {
+			err = fmt.Errorf("value %s exceeds safe integer range of JSON numbers (%d)", integerString(*v), openapi.MaxSafeInteger)
+		}
+	})
+
+	return err
+}
+
+// forEachValue calls fn with pointers to default, const, example and enum values of schema.
+func forEachValue(s *jsonschema.Schema, fn func(v *interface{})) {
+	if s.Default != nil {
+		fn(s.Default)
+	}
+
+	if s.Const != nil {
+		fn(s.Const)
+	}
+
+	for i := range s.Examples {
+		fn(&s.Examples[i])
+	}
+
+	for i := range s.Enum {
+		fn(&s.Enum[i])
+	}
+}
+
+func isSafeInteger(v interface{}) bool {
+	switch n := v.(type) {
+	case int64:
+		return n >= -openapi.MaxSafeInteger && n <= openapi.MaxSafeInteger
+	case int:
+		return int64(n) >= -openapi.MaxSafeInteger && int64(n) <= openapi.MaxSafeInteger
+	case uint64:
+		return n <= openapi.MaxSafeInteger
+	case float64:
+		return math.Abs(n) <= openapi.MaxSafeInteger
+	case json.Number:
+		i, err := n.Int64()
+
+		return err == nil && isSafeInteger(i)
+	}
+
+	return true
+}
+
+func integerString(v interface{}) string {
+	switch n := v.(type) {
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case string:
+		return n
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,13 @@
+package internal
+
+import "strings"
+
+// EscapePointerToken escapes JSON Pointer reference token, see RFC 6901.
+func EscapePointerToken(t string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(t, "~", "~0"), "/", "~1")
+}
+
+// UnescapePointerToken decodes escaped JSON Pointer reference token, see RFC 6901.
+func UnescapePointerToken(t string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+}
@@ -66,13 +66,7 @@ func ReflectRequestBody(
 		hasTaggedFields = refl.HasTaggedFields(input, t)
 	}
 
-	hasJSONSchemaStruct := false
-
-	refl.WalkFieldsRecursively(reflect.ValueOf(input), func(v reflect.Value, _ reflect.StructField, _ []reflect.StructField) {
-		if v.Type() == reflect.TypeOf(jsonschema.Struct{}) {
-			hasJSONSchemaStruct = true
-		}
-	})
+	hasJSONSchemaStruct := hasFieldOfType(reflect.TypeOf(input), reflect.TypeOf(jsonschema.Struct{}), map[reflect.Type]bool{})
 
 	// Form data can not have map or array as body.
 	if !hasTaggedFields && len(mapping) == 0 && tag != tagJSON {
@@ -127,6 +121,10 @@ func ReflectRequestBody(
 			return defaultDefName
 		}),
 		jsonschema.RootRef,
+		InterceptPropertyNames(),
+		InterceptXML(),
+		InterceptWriteOnly(),
+		InterceptDefault(),
 		jsonschema.PropertyNameMapping(mapping),
 		jsonschema.PropertyNameTag(tag, additionalTags...),
 		sanitizeDefName,
@@ -136,35 +134,22 @@ func ReflectRequestBody(
 					return
 				}
 
-				vv := reflect.Zero(params.Schema.ReflectType).Interface()
-
-				foundFiles := false
-				if _, ok := vv.([]multipart.File); ok {
-					foundFiles = true
-				}
-
-				if _, ok := vv.([]*multipart.FileHeader); ok {
-					foundFiles = true
-				}
-
-				if foundFiles {
+				if t := params.Schema.ReflectType; t.Kind() == reflect.Slice && isFileType(t, tag) {
 					params.Schema.RemoveType(jsonschema.Null)
 				}
 			}
 		}),
 		jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (stop bool, err error) {
-			vv := params.Value.Interface()
-
-			foundFile := false
-			if _, ok := vv.(*multipart.File); ok {
-				foundFile = true
+			if !params.Value.IsValid() {
+				return false, nil
 			}
 
-			if _, ok := vv.(*multipart.FileHeader); ok {
-				foundFile = true
+			t := params.Value.Type()
+			for t.Kind() == reflect.Ptr {
+				t = t.Elem()
 			}
 
-			if foundFile {
+			if isFileType(t, tag) && (t.Kind() != reflect.Slice || t == typeOfBytes) {
 				params.Schema.AddType(jsonschema.String)
 				params.Schema.RemoveType(jsonschema.Null)
 				params.Schema.WithFormat("binary")
@@ -190,6 +175,74 @@ func ReflectRequestBody(
 	return &sch, hasFileUpload, nil
 }
 
+var (
+	typeOfBytes      = reflect.TypeOf([]byte(nil))
+	typeOfFile       = reflect.TypeOf((*multipart.File)(nil)).Elem()
+	typeOfFileHeader = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// isFileType checks if type is an uploaded file or a list of files,
+// []byte is also a file in form data.
+func isFileType(t reflect.Type, tag string) bool {
+	for {
+		if t == typeOfFile || t == typeOfFileHeader || (t == typeOfBytes && tag != tagJSON) {
+			return true
+		}
+
+		if t.Kind() != reflect.Ptr && t.Kind() != reflect.Slice {
+			return false
+		}
+
+		t = t.Elem()
+	}
+}
+
+// InterceptFileFields reports names of top-level request body properties with uploaded files.
+func InterceptFileFields(f func(name string)) func(*jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if !params.Processed || len(params.Path) != 1 {
+			return nil
+		}
+
+		if isFileType(params.Field.Type, params.Context.PropertyNameTag) {
+			f(params.Name)
+		}
+
+		return nil
+	})
+}
+
+// hasFieldOfType checks struct fields recursively for a target type,
+// every struct type is visited once, so self-referencing types do not recurse infinitely.
+func hasFieldOfType(t, target reflect.Type, visited map[reflect.Type]bool) bool {
+	if t == nil {
+		return false
+	}
+
+	t = refl.DeepIndirect(t)
+
+	if t.Kind() != reflect.Struct || visited[t] {
+		return false
+	}
+
+	visited[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Unexported non-anonymous fields are not traversed.
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		if refl.DeepIndirect(field.Type) == target || hasFieldOfType(field.Type, target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReflectJSONResponse reflects JSON schema of response.
 func ReflectJSONResponse(
 	r *jsonschema.Reflector,
@@ -207,6 +260,10 @@ func ReflectJSONResponse(
 
 	reflOptions = append(reflOptions,
 		jsonschema.RootRef,
+		InterceptPropertyNames(),
+		InterceptXML(),
+		InterceptWriteOnly(),
+		InterceptDefault(),
 		sanitizeDefName,
 	)
 
@@ -302,6 +359,75 @@ func ReflectParametersIn(
 		},
 		sanitizeDefName,
 		jsonschema.SkipEmbeddedMapsSlices,
+		InterceptDefault(),
 		jsonschema.InterceptProp(interceptProp),
 	)
 }
+
+// InterceptRequired marks reflected properties as required according to the policy.
+//
+// Fields with explicit `required` tag are left intact, pointer fields are left intact
+// if nullability policy makes them optional.
+func InterceptRequired(
+	policy openapi.RequiredPolicy,
+	nullability openapi.NullabilityPolicy,
+) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if policy == openapi.RequiredExplicit || !params.Processed || params.ParentSchema == nil {
+			return nil
+		}
+
+		if _, ok := params.Field.Tag.Lookup("required"); ok {
+			return nil
+		}
+
+		if hasOmitEmpty(params.Context, params.Field) {
+			return nil
+		}
+
+		if (policy == openapi.RequiredUnlessOptional || nullability.PointersOptional()) &&
+			params.Field.Type.Kind() == reflect.Ptr {
+			return nil
+		}
+
+		for _, name := range params.ParentSchema.Required {
+			if name == params.Name {
+				return nil
+			}
+		}
+
+		params.ParentSchema.Required = append(params.ParentSchema.Required, params.Name)
+
+		return nil
+	})
+}
+
+// InterceptRequestDefName suffixes names of struct definitions of request bodies with `Request`,
+// so that request and response components are separate if their required policies differ.
+func InterceptRequestDefName(enabled bool) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptDefName(func(t reflect.Type, defaultDefName string) string {
+		if !enabled {
+			return defaultDefName
+		}
+
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return defaultDefName
+		}
+
+		return defaultDefName + "Request"
+	})
+}
+
+func hasOmitEmpty(rc *jsonschema.ReflectContext, field reflect.StructField) bool {
+	for _, tag := range append([]string{rc.PropertyNameTag}, rc.PropertyNameAdditionalTags...) {
+		if v, ok := field.Tag.Lookup(tag); ok {
+			return strings.Contains(v, ",omitempty")
+		}
+	}
+
+	return false
+}
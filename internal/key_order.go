@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// KeyOrder keeps order of object keys of a JSON document by JSON Pointer.
+type KeyOrder map[string][]string
+
+// RecordKeyOrder collects order of object keys of a JSON document.
+func RecordKeyOrder(data []byte) (KeyOrder, error) {
+	n, err := parseJSONNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ko := KeyOrder{}
+	n.record("", ko)
+
+	return ko, nil
+}
+
+// Apply rewrites objects of a JSON document to follow recorded order of keys.
+//
+// Keys that are missing in recorded order are placed after known keys in their original order.
+func (ko KeyOrder) Apply(data []byte) ([]byte, error) {
+	if len(ko) == 0 {
+		return data, nil
+	}
+
+	n, err := parseJSONNode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	n.write(&buf, "", ko)
+
+	return buf.Bytes(), nil
+}
+
+// AppendKey returns order with key moved or added to the end, so that a key that was deleted
+// and added again is not listed twice.
+//
+// Order is never modified in place, so copies of structures that share it are not affected.
+func AppendKey(order []string, key string) []string {
+	for i, k := range order {
+		if k == key {
+			res := make([]string, 0, len(order))
+			res = append(res, order[:i]...)
+			res = append(res, order[i+1:]...)
+
+			return append(res, key)
+		}
+	}
+
+	return append(order[:len(order):len(order)], key)
+}
+
+// RenameKey returns order with key replaced by newKey at its position.
+func RenameKey(order []string, key, newKey string) []string {
+	res := make([]string, len(order))
+
+	for i, k := range order {
+		if k == key {
+			k = newKey
+		}
+
+		res[i] = k
+	}
+
+	return res
+}
+
+type jsonNode struct {
+	keys   []string
+	fields map[string]*jsonNode
+	items  []*jsonNode
+	isObj  bool
+	isArr  bool
+	scalar json.RawMessage
+}
+
+func parseJSONNode(data []byte) (*jsonNode, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	return decodeJSONNode(d)
+}
+
+func decodeJSONNode(d *json.Decoder) (*jsonNode, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &jsonNode{}
+
+	switch t {
+	case json.Delim('{'):
+		n.isObj = true
+		n.fields = map[string]*jsonNode{}
+
+		for d.More() {
+			kt, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			k, ok := kt.(string)
+			if !ok {
+				return nil, errors.New("object key expected")
+			}
+
+			v, err := decodeJSONNode(d)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, dup := n.fields[k]; !dup {
+				n.keys = append(n.keys, k)
+			}
+
+			n.fields[k] = v
+		}
+
+		_, err = d.Token()
+	case json.Delim('['):
+		n.isArr = true
+
+		for d.More() {
+			v, err := decodeJSONNode(d)
+			if err != nil {
+				return nil, err
+			}
+
+			n.items = append(n.items, v)
+		}
+
+		_, err = d.Token()
+	default:
+		n.scalar, err = json.Marshal(t)
+	}
+
+	return n, err
+}
+
+func (n *jsonNode) record(ptr string, ko KeyOrder) {
+	switch {
+	case n.isObj:
+		ko[ptr] = n.keys
+
+		for _, k := range n.keys {
+			n.fields[k].record(ptr+"/"+EscapePointerToken(k), ko)
+		}
+	case n.isArr:
+		for i, item := range n.items {
+			item.record(ptr+"/"+strconv.Itoa(i), ko)
+		}
+	}
+}
+
+func (n *jsonNode) write(buf *bytes.Buffer, ptr string, ko KeyOrder) {
+	switch {
+	case n.isObj:
+		buf.WriteByte('{')
+
+		for i, k := range orderKeys(n.keys, ko[ptr]) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			kj, _ := json.Marshal(k) //nolint:errchkjson // String is always marshaled.
+			buf.Write(kj)
+			buf.WriteByte(':')
+			n.fields[k].write(buf, ptr+"/"+EscapePointerToken(k), ko)
+		}
+
+		buf.WriteByte('}')
+	case n.isArr:
+		buf.WriteByte('[')
+
+		for i, item := range n.items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			item.write(buf, ptr+"/"+strconv.Itoa(i), ko)
+		}
+
+		buf.WriteByte(']')
+	default:
+		buf.Write(n.scalar)
+	}
+}
+
+func orderKeys(keys, order []string) []string {
+	if len(order) == 0 {
+		return keys
+	}
+
+	present := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		present[k] = true
+	}
+
+	res := make([]string, 0, len(keys))
+	known := make(map[string]bool, len(order))
+
+	for _, k := range order {
+		if present[k] && !known[k] {
+			res = append(res, k)
+			known[k] = true
+		}
+	}
+
+	for _, k := range keys {
+		if !known[k] {
+			res = append(res, k)
+		}
+	}
+
+	return res
+}
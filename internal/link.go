@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var runtimeExpression = regexp.MustCompile(
+	`^\$(url|method|statusCode|(request|response)\.(header\.[!#$%&'*+.^_` + "`" + `|~0-9A-Za-z-]+|` +
+		`query\.[^{}]+|path\.[^{}]+|body(#(/[^{}]*)*)?))$`)
+
+// CheckRuntimeExpression validates runtime expressions of a link value, e.g. "$response.body#/id".
+//
+// Values that do not start with `$` are constants, expressions can also be embedded in strings
+// with braces, e.g. "/things/{$response.body#/id}".
+func CheckRuntimeExpression(value string) error {
+	if strings.HasPrefix(value, "$") {
+		if !runtimeExpression.MatchString(value) {
+			return fmt.Errorf("invalid runtime expression %q", value)
+		}
+
+		return nil
+	}
+
+	for rest := value; ; {
+		start := strings.Index(rest, "{$")
+		if start < 0 {
+			return nil
+		}
+
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return fmt.Errorf("unclosed runtime expression in %q", value)
+		}
+
+		if expr := rest[start+1 : start+end]; !runtimeExpression.MatchString(expr) {
+			return fmt.Errorf("invalid runtime expression %q", expr)
+		}
+
+		rest = rest[start+end+1:]
+	}
+}
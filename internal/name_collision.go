@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+// InterceptNameCollision resolves definition names of distinct types that collide.
+//
+// Names are tracked across reflections, so that components of whole document are unique.
+func InterceptNameCollision(strategy func() *openapi.NameCollisionStrategy) func(rc *jsonschema.ReflectContext) {
+	type use struct {
+		t    reflect.Type
+		name string
+	}
+
+	var (
+		owners    = map[string]reflect.Type{}
+		assigned  = map[use]string{}
+		collision = map[reflect.Type]error{}
+	)
+
+	take := func(u use, name string) string {
+		owners[name] = u.t
+		assigned[u] = name
+
+		return name
+	}
+
+	suffixed := func(name string) string {
+		for i := 2; ; i++ {
+			if _, taken := owners[name+strconv.Itoa(i)]; !taken {
+				return name + strconv.Itoa(i)
+			}
+		}
+	}
+
+	defName := jsonschema.InterceptDefName(func(t reflect.Type, defaultDefName string) string {
+		st := strategy()
+		if st == nil {
+			return defaultDefName
+		}
+
+		u := use{t: t, name: defaultDefName}
+
+		if name, ok := assigned[u]; ok {
+			return name
+		}
+
+		owner, taken := owners[defaultDefName]
+		if !taken || owner == t {
+			return take(u, defaultDefName)
+		}
+
+		switch *st {
+		case openapi.NameCollisionError:
+			collision[t] = fmt.Errorf("component name %s of %s collides with %s",
+				defaultDefName, refl.GoType(t), refl.GoType(owner))
+
+			return defaultDefName
+		case openapi.NameCollisionPackage:
+			elems := strings.Split(t.PkgPath(), "/")
+			name := defaultDefName
+			last := len(elems) - 1
+
+			// Package name is usually already a part of default name.
+			if strings.HasPrefix(name, camel(elems[last])) {
+				last--
+			}
+
+			for i := last; i >= 0; i-- {
+				name = camel(elems[i]) + name
+
+				if _, taken := owners[name]; !taken {
+					return take(u, name)
+				}
+			}
+		}
+
+		return take(u, suffixed(defaultDefName))
+	})
+
+	check := jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+		if params.Processed || !params.Value.IsValid() {
+			return false, nil
+		}
+
+		return false, collision[refl.DeepIndirect(params.Value.Type())]
+	})
+
+	return func(rc *jsonschema.ReflectContext) {
+		defName(rc)
+		check(rc)
+	}
+}
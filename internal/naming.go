@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"reflect"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// InterceptSchemaNamer names definitions with a custom namer.
+func InterceptSchemaNamer(namer func() openapi.SchemaNamer) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptDefName(func(t reflect.Type, defaultDefName string) string {
+		n := namer()
+		if n == nil {
+			return defaultDefName
+		}
+
+		if name := n(t); name != "" {
+			return name
+		}
+
+		return defaultDefName
+	})
+}
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"reflect"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// InterceptNullabilityPolicy removes nullability of reflected pointer fields if policy makes them optional only.
+//
+// Policy is read on every property, so it can be changed after reflector is set up.
+func InterceptNullabilityPolicy(policy func() openapi.NullabilityPolicy) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if policy() != openapi.PointerOptional || !params.Processed || params.PropertySchema == nil ||
+			params.Field.Type.Kind() != reflect.Ptr {
+			return nil
+		}
+
+		if _, ok := params.Field.Tag.Lookup("nullable"); ok {
+			return nil
+		}
+
+		if s := params.PropertySchema; s.Ref == nil && s.HasType(jsonschema.Null) {
+			s.RemoveType(jsonschema.Null)
+		}
+
+		return nil
+	})
+}
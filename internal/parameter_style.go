@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/swaggest/openapi-go"
+)
+
+var parameterStyles = map[openapi.In][]string{
+	openapi.InPath:   {"matrix", "label", "simple"},
+	openapi.InQuery:  {"form", "spaceDelimited", "pipeDelimited", "deepObject"},
+	openapi.InHeader: {"simple"},
+	openapi.InCookie: {"form"},
+}
+
+// CheckParameterStyle validates that serialization style is defined for parameter location.
+func CheckParameterStyle(in openapi.In, style string) error {
+	for _, s := range parameterStyles[in] {
+		if s == style {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("style %q is not allowed in %s, use one of %v", style, in, parameterStyles[in])
+}
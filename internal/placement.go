@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"reflect"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+// SchemaPlacement applies placement of content unit, or of reflector if content unit has default placement.
+//
+// Recursive structures can not be inlined, so they keep references.
+func SchemaPlacement(reflector openapi.SchemaPlacement, cu openapi.ContentUnit) func(rc *jsonschema.ReflectContext) {
+	return func(rc *jsonschema.ReflectContext) {
+		p := cu.SchemaPlacement
+		if p == openapi.SchemaPlacementDefault {
+			p = reflector
+		}
+
+		if p == openapi.SchemaInline && !isRecursive(reflect.TypeOf(cu.Structure), map[reflect.Type]bool{}) {
+			rc.InlineRefs = true
+		}
+	}
+}
+
+// isRecursive checks if type refers to itself with fields or elements.
+func isRecursive(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == nil {
+		return false
+	}
+
+	if visiting[t] {
+		return true
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds have no nested types.
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return isRecursive(t.Elem(), visiting)
+	case reflect.Map:
+		return isRecursive(t.Key(), visiting) || isRecursive(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+
+			// Unexported non-anonymous fields are not traversed.
+			if f.PkgPath != "" && !f.Anonymous {
+				continue
+			}
+
+			if isRecursive(f.Type, visiting) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
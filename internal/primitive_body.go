@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/refl"
+)
+
+// ReflectPrimitiveBody reflects schema of a scalar or []byte structure of a body with non-JSON content type.
+//
+// Nil schema is returned for other structures and content types.
+func ReflectPrimitiveBody(r *jsonschema.Reflector, cu openapi.ContentUnit) (*jsonschema.Schema, error) {
+	if cu.Structure == nil || cu.ContentType == "" || strings.HasSuffix(cu.ContentType, "json") {
+		return nil, nil
+	}
+
+	t := refl.DeepIndirect(reflect.TypeOf(cu.Structure))
+
+	var schema jsonschema.Schema
+
+	switch t.Kind() { //nolint:exhaustive // Only scalars and bytes are primitive bodies.
+	case reflect.Slice:
+		if t.Elem().Kind() != reflect.Uint8 {
+			return nil, nil
+		}
+
+		schema.AddType(jsonschema.String)
+		schema.WithFormat("binary")
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		s, err := r.Reflect(reflect.Zero(t).Interface(), jsonschema.InlineRefs, sanitizeDefName)
+		if err != nil {
+			return nil, err
+		}
+
+		schema = s
+	default:
+		return nil, nil
+	}
+
+	if cu.Format != "" {
+		schema.WithFormat(cu.Format)
+	}
+
+	return &schema, nil
+}
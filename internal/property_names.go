@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"github.com/swaggest/jsonschema-go"
+)
+
+// InterceptPropertyNames constrains keys of map properties with `propertyNamePattern` field tag.
+func InterceptPropertyNames() func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if !params.Processed || params.PropertySchema == nil {
+			return nil
+		}
+
+		pattern, ok := params.Field.Tag.Lookup("propertyNamePattern")
+		if !ok || !params.PropertySchema.HasType(jsonschema.Object) {
+			return nil
+		}
+
+		names := jsonschema.Schema{}
+		names.AddType(jsonschema.String)
+		names.WithPattern(pattern)
+
+		params.PropertySchema.WithPropertyNames(names.ToSchemaOrBool())
+
+		return nil
+	})
+}
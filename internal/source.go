@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// CaptureSource finds registration site of an operation outside of library packages.
+func CaptureSource(oc openapi.OperationContext, libPkgs ...string) openapi.OperationSource {
+	src := openapi.OperationSource{}
+
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		if !isLibFrame(frame.Function, libPkgs) {
+			src.File = relPath(frame.File)
+			src.Line = frame.Line
+			src.Function = frame.Function
+
+			break
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	for _, cu := range oc.Request() {
+		if cu.Structure != nil {
+			src.Request = append(src.Request, typeName(cu.Structure))
+		}
+	}
+
+	for _, cu := range oc.Response() {
+		if cu.Structure != nil {
+			src.Response = append(src.Response, typeName(cu.Structure))
+		}
+	}
+
+	return src
+}
+
+// SourceExtension converts source to a vendor extension value.
+func SourceExtension(src openapi.OperationSource) map[string]interface{} {
+	ext := map[string]interface{}{
+		"file":     src.String(),
+		"function": src.Function,
+	}
+
+	if len(src.Request) > 0 {
+		ext["request"] = src.Request
+	}
+
+	if len(src.Response) > 0 {
+		ext["response"] = src.Response
+	}
+
+	return ext
+}
+
+func isLibFrame(function string, libPkgs []string) bool {
+	if strings.HasPrefix(function, "runtime.") {
+		return true
+	}
+
+	internalPkg := reflect.TypeOf(OperationContext{}).PkgPath()
+
+	for _, p := range append(libPkgs, internalPkg) {
+		if strings.HasPrefix(function, p+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func relPath(file string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return file
+	}
+
+	if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return file
+}
+
+func typeName(v interface{}) string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.String()
+}
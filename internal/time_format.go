@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+)
+
+const (
+	formatDateTime = "date-time"
+	formatDate     = "date"
+
+	layoutOffset = "2006-01-02T15:04:05.999999999-07:00"
+	layoutDate   = "2006-01-02"
+)
+
+// InterceptTimeFormat applies time format to values of `date-time` and `date` properties.
+//
+// Values that are not RFC 3339 date-times (or dates) are left intact.
+func InterceptTimeFormat(timeFormat func() openapi.TimeFormat) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		f := timeFormat()
+		if f == openapi.TimeKeep || !params.Processed || params.PropertySchema == nil ||
+			params.PropertySchema.Format == nil {
+			return nil
+		}
+
+		s := params.PropertySchema
+		format := *s.Format
+
+		if format != formatDateTime && format != formatDate {
+			return nil
+		}
+
+		if f == openapi.TimeDate || format == formatDate {
+			s.WithFormat(formatDate)
+		}
+
+		forEachValue(s, func(v *interface{}) {
+			t, ok := timeValue(*v)
+			if !ok {
+				return
+			}
+
+			switch {
+			case *s.Format == formatDate:
+				*v = t.Format(layoutDate)
+			case f == openapi.TimeUTC:
+				*v = t.UTC().Format(time.RFC3339Nano)
+			case f == openapi.TimeOffset:
+				*v = t.Format(layoutOffset)
+			}
+		})
+
+		return nil
+	})
+}
+
+func timeValue(v interface{}) (time.Time, bool) {
+	switch tv := v.(type) {
+	case time.Time:
+		return tv, true
+	case *time.Time:
+		if tv != nil {
+			return *tv, true
+		}
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, tv); err == nil {
+			return t, true
+		}
+
+		if t, err := time.Parse(layoutDate, tv); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
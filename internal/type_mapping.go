@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"database/sql"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// TypeMapping maps type of a sample to schema.
+type TypeMapping struct {
+	Sample interface{}
+	Schema jsonschema.Schema
+}
+
+// SQLNullTypeMappings describes null types of database/sql as nullable scalars instead of
+// objects with value and `Valid` fields.
+func SQLNullTypeMappings() []TypeMapping {
+	nullable := func(t jsonschema.SimpleType, format string) jsonschema.Schema {
+		s := jsonschema.Schema{}
+		s.AddType(t)
+		s.AddType(jsonschema.Null)
+
+		if format != "" {
+			s.WithFormat(format)
+		}
+
+		return s
+	}
+
+	return []TypeMapping{
+		{Sample: sql.NullString{}, Schema: nullable(jsonschema.String, "")},
+		{Sample: sql.NullBool{}, Schema: nullable(jsonschema.Boolean, "")},
+		{Sample: sql.NullByte{}, Schema: nullable(jsonschema.Integer, "")},
+		{Sample: sql.NullInt16{}, Schema: nullable(jsonschema.Integer, "int32")},
+		{Sample: sql.NullInt32{}, Schema: nullable(jsonschema.Integer, "int32")},
+		{Sample: sql.NullInt64{}, Schema: nullable(jsonschema.Integer, "int64")},
+		{Sample: sql.NullFloat64{}, Schema: nullable(jsonschema.Number, "double")},
+		{Sample: sql.NullTime{}, Schema: nullable(jsonschema.String, "date-time")},
+	}
+}
@@ -0,0 +1,196 @@
+package internal
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// validatorFormats maps go-playground/validator format tags to schema formats.
+var validatorFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid4":    "uuid",
+	"url":      "uri",
+	"uri":      "uri",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"datetime": "date-time",
+}
+
+// InterceptValidatorTags translates `validate` field tags of go-playground/validator into schema constraints.
+//
+// Supported rules are `required`, `min`, `max`, `len`, `gt`, `gte`, `lt`, `lte`, `oneof` and formats,
+// rules after `dive` apply to items and are ignored.
+func InterceptValidatorTags(enabled func() bool) func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if !params.Processed || params.PropertySchema == nil || !enabled() {
+			return nil
+		}
+
+		tag, ok := params.Field.Tag.Lookup("validate")
+		if !ok {
+			return nil
+		}
+
+		s := params.PropertySchema
+
+		for _, rule := range strings.Split(tag, ",") {
+			name, arg := rule, ""
+			if pos := strings.Index(rule, "="); pos >= 0 {
+				name, arg = rule[:pos], rule[pos+1:]
+			}
+
+			if name == "dive" {
+				break
+			}
+
+			if name == "required" {
+				if params.ParentSchema != nil && !hasString(params.ParentSchema.Required, params.Name) {
+					params.ParentSchema.Required = append(params.ParentSchema.Required, params.Name)
+				}
+
+				continue
+			}
+
+			// Referenced schemas are shared, constraints can only apply to inline schemas.
+			if s.Ref != nil {
+				continue
+			}
+
+			if f, ok := validatorFormats[name]; ok {
+				s.WithFormat(f)
+
+				continue
+			}
+
+			if name == "oneof" {
+				s.Enum = oneOfValues(s, arg)
+
+				continue
+			}
+
+			if n, err := strconv.ParseFloat(arg, 64); err == nil {
+				applyValidatorBound(s, name, n)
+			}
+		}
+
+		return nil
+	})
+}
+
+func applyValidatorBound(s *jsonschema.Schema, name string, n float64) {
+	minLen, maxLen := lengthBounds(name, int64(n))
+
+	switch {
+	case s.HasType(jsonschema.String):
+		if minLen != nil {
+			s.MinLength = *minLen
+		}
+
+		if maxLen != nil {
+			s.MaxLength = maxLen
+		}
+	case s.HasType(jsonschema.Array):
+		if minLen != nil {
+			s.MinItems = *minLen
+		}
+
+		if maxLen != nil {
+			s.MaxItems = maxLen
+		}
+	case s.HasType(jsonschema.Object):
+		if minLen != nil {
+			s.MinProperties = *minLen
+		}
+
+		if maxLen != nil {
+			s.MaxProperties = maxLen
+		}
+	case s.HasType(jsonschema.Integer) || s.HasType(jsonschema.Number):
+		switch name {
+		case "min", "gte":
+			s.WithMinimum(n)
+		case "max", "lte":
+			s.WithMaximum(n)
+		case "gt":
+			s.WithMinimum(n).WithExclusiveMinimum(n)
+		case "lt":
+			s.WithMaximum(n).WithExclusiveMaximum(n)
+		case "len":
+			s.WithMinimum(n).WithMaximum(n)
+		}
+	}
+}
+
+// lengthBounds returns length limits of strings, arrays and objects by rule,
+// exclusive `gt` and `lt` are converted to inclusive limits.
+func lengthBounds(name string, n int64) (minLen, maxLen *int64) {
+	switch name {
+	case "min", "gte":
+		return &n, nil
+	case "gt":
+		n++
+
+		return &n, nil
+	case "max", "lte":
+		return nil, &n
+	case "lt":
+		if n > 0 {
+			n--
+		}
+
+		return nil, &n
+	case "len":
+		return &n, &n
+	}
+
+	return nil, nil
+}
+
+// oneOfItem matches values of `oneof` rule, values with spaces are enclosed in single quotes.
+var oneOfItem = regexp.MustCompile(`'[^']*'|\S+`)
+
+func oneOfValues(s *jsonschema.Schema, arg string) []interface{} {
+	items := oneOfItem.FindAllString(arg, -1)
+	values := make([]interface{}, 0, len(items))
+
+	for _, item := range items {
+		if len(item) > 1 && strings.HasPrefix(item, "'") && strings.HasSuffix(item, "'") {
+			item = item[1 : len(item)-1]
+		}
+
+		if s.HasType(jsonschema.Integer) {
+			if i, err := strconv.ParseInt(item, 10, 64); err == nil {
+				values = append(values, i)
+
+				continue
+			}
+		}
+
+		if s.HasType(jsonschema.Number) {
+			if f, err := strconv.ParseFloat(item, 64); err == nil {
+				values = append(values, f)
+
+				continue
+			}
+		}
+
+		values = append(values, item)
+	}
+
+	return values
+}
+
+func hasString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/refl"
+)
+
+// InterceptWriteOnly sets `writeOnly` of properties with `writeOnly:"true"` field tag, e.g. for passwords.
+//
+// Field tag `readOnly:"true"` is supported by jsonschema-go, properties can not be both read-only and write-only.
+func InterceptWriteOnly() func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+		if !params.Processed || params.PropertySchema == nil {
+			return nil
+		}
+
+		writeOnly := false
+		if err := refl.ReadBoolTag(params.Field.Tag, "writeOnly", &writeOnly); err != nil {
+			return err
+		}
+
+		if !writeOnly {
+			return nil
+		}
+
+		if params.PropertySchema.ReadOnly != nil && *params.PropertySchema.ReadOnly {
+			return errors.New(params.Name + ": property can not be both readOnly and writeOnly")
+		}
+
+		params.PropertySchema.WithExtraPropertiesItem("writeOnly", true)
+
+		return nil
+	})
+}
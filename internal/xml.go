@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/refl"
+)
+
+// XMLKeyword is a name of schema property with XML object of OpenAPI.
+const XMLKeyword = "xml"
+
+var xmlNameType = reflect.TypeOf(xml.Name{})
+
+// InterceptXML describes XML representation of schemas with `xml` field tags of encoding/xml.
+//
+// Element name and namespace of a struct are taken from tag of `XMLName` field,
+// properties get `name`, `namespace` and `attribute` from their tags,
+// arrays with `parent>child` tags are wrapped in parent element with items named after child.
+// Referenced schemas are shared, so field tags only apply to inline schemas.
+func InterceptXML() func(rc *jsonschema.ReflectContext) {
+	return func(rc *jsonschema.ReflectContext) {
+		jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+			if !params.Processed || !params.Value.IsValid() {
+				return false, nil
+			}
+
+			t := refl.DeepIndirect(params.Value.Type())
+			if t.Kind() != reflect.Struct {
+				return false, nil
+			}
+
+			f, ok := t.FieldByName("XMLName")
+			if !ok || f.Type != xmlNameType {
+				return false, nil
+			}
+
+			if x := xmlObject(f.Tag.Get("xml"), ""); len(x) > 0 {
+				params.Schema.WithExtraPropertiesItem(XMLKeyword, x)
+			}
+
+			return false, nil
+		})(rc)
+
+		jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+			if !params.Processed || params.PropertySchema == nil || params.PropertySchema.Ref != nil {
+				return nil
+			}
+
+			tag, ok := params.Field.Tag.Lookup("xml")
+			if !ok {
+				return nil
+			}
+
+			s := params.PropertySchema
+			name, flags := tag, ""
+
+			if pos := strings.Index(tag, ","); pos >= 0 {
+				name, flags = tag[:pos], tag[pos:]
+			}
+
+			if name == "-" {
+				return nil
+			}
+
+			path := strings.Split(name, ">")
+
+			if s.HasType(jsonschema.Array) && s.Items != nil && s.Items.SchemaOrBool != nil {
+				items := s.Items.SchemaOrBool.TypeObject
+				item := path[len(path)-1]
+
+				if len(path) > 1 {
+					x := xmlObject(path[0], params.Name)
+					x["wrapped"] = true
+
+					s.WithExtraPropertiesItem(XMLKeyword, x)
+				}
+
+				if items != nil && items.Ref == nil {
+					if x := xmlObject(item+flags, ""); len(x) > 0 {
+						items.WithExtraPropertiesItem(XMLKeyword, x)
+					}
+				}
+
+				return nil
+			}
+
+			if x := xmlObject(path[len(path)-1]+flags, params.Name); len(x) > 0 {
+				s.WithExtraPropertiesItem(XMLKeyword, x)
+			}
+
+			return nil
+		})(rc)
+	}
+}
+
+// xmlObject makes XML object from `[namespace ]name[,attr]` tag, name is omitted if it matches default.
+func xmlObject(tag, defaultName string) map[string]interface{} {
+	x := map[string]interface{}{}
+	name, flags := tag, ""
+
+	if pos := strings.Index(tag, ","); pos >= 0 {
+		name, flags = tag[:pos], tag[pos+1:]
+	}
+
+	if pos := strings.LastIndex(name, " "); pos >= 0 {
+		x["namespace"] = name[:pos]
+		name = name[pos+1:]
+	}
+
+	if name != "" && name != defaultName {
+		x["name"] = name
+	}
+
+	for _, f := range strings.Split(flags, ",") {
+		if f == "attr" {
+			x["attribute"] = true
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,28 @@
+package openapi
+
+import "encoding/json"
+
+// JSONCodec marshals and unmarshals JSON values with behavior compatible with encoding/json.
+//
+// Compatible configurations of faster engines, e.g. jsoniter.ConfigCompatibleWithStandardLibrary
+// or sonic.ConfigStd, implement this interface.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSON is used to load and serialize documents of openapi3 and openapi31 packages.
+//
+// It can be replaced with a faster engine during initialization of application,
+// it should not be changed concurrently with marshaling.
+var JSON JSONCodec = stdJSON{} //nolint:gochecknoglobals // Entities have no other way to receive codec.
+
+type stdJSON struct{}
+
+func (stdJSON) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSON) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,197 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Budget limits size of a document to keep memory usage of gateways and tools predictable.
+//
+// Zero limits are not checked.
+type Budget struct {
+	// MaxOperations limits number of operations.
+	MaxOperations int
+
+	// MaxBytes limits size of document in JSON.
+	MaxBytes int
+
+	// MaxSchemaDepth limits nesting of schemas, references are followed,
+	// e.g. object with array of objects of strings has depth 4.
+	MaxSchemaDepth int
+}
+
+// Rule reports exceeded limits of budget as warnings.
+func (b Budget) Rule() Rule {
+	return Rule{
+		Name:     "size-budget",
+		Severity: Warning,
+		Check:    b.check,
+	}
+}
+
+// Check returns error with all exceeded limits of budget, it can fail generation of oversized documents.
+func (b Budget) Check(s *openapi3.Spec) error {
+	var msgs []string
+
+	b.check(s, func(pointer, message string) {
+		msgs = append(msgs, pointer+": "+message)
+	})
+
+	if len(msgs) > 0 {
+		return errors.New("size budget exceeded:\n" + strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+func (b Budget) check(s *openapi3.Spec, report Reporter) {
+	if b.MaxOperations > 0 {
+		cnt := 0
+
+		eachOperation(s, func(_, _ string, _ openapi3.PathItem, _ openapi3.Operation) {
+			cnt++
+		})
+
+		if cnt > b.MaxOperations {
+			report(Pointer("paths"), strconv.Itoa(cnt)+" operations exceed budget of "+strconv.Itoa(b.MaxOperations))
+		}
+	}
+
+	if b.MaxBytes > 0 {
+		if j, err := json.Marshal(s); err == nil && len(j) > b.MaxBytes {
+			report("", strconv.Itoa(len(j))+" bytes exceed budget of "+strconv.Itoa(b.MaxBytes))
+		}
+	}
+
+	if b.MaxSchemaDepth > 0 {
+		d := depths{s: s, known: map[string]int{}, visiting: map[string]bool{}}
+
+		eachBudgetSchema(s, func(ptr string, sr *openapi3.SchemaOrRef) {
+			if depth := d.of(sr); depth > b.MaxSchemaDepth {
+				report(ptr, "schema depth "+strconv.Itoa(depth)+" exceeds budget of "+strconv.Itoa(b.MaxSchemaDepth))
+			}
+		})
+	}
+}
+
+// eachBudgetSchema calls fn for component schemas and inline schemas of request and response bodies.
+func eachBudgetSchema(s *openapi3.Spec, fn func(ptr string, sr *openapi3.SchemaOrRef)) {
+	if s.Components != nil && s.Components.Schemas != nil {
+		names := make([]string, 0, len(s.Components.Schemas.MapOfSchemaOrRefValues))
+		for name := range s.Components.Schemas.MapOfSchemaOrRefValues {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			// Reference detects recursion of component.
+			sr := openapi3.SchemaOrRef{SchemaReference: &openapi3.SchemaReference{Ref: "#/components/schemas/" + name}}
+			fn(Pointer("components", "schemas", name), &sr)
+		}
+	}
+
+	inline := func(ptr []string, content map[string]openapi3.MediaType) {
+		for _, ct := range sortedContentTypes(content) {
+			if sr := content[ct].Schema; sr != nil && sr.Schema != nil {
+				fn(Pointer(append(ptr[:len(ptr):len(ptr)], "content", ct, "schema")...), sr)
+			}
+		}
+	}
+
+	eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+		if op.RequestBody != nil && op.RequestBody.RequestBody != nil {
+			inline([]string{"paths", path, method, "requestBody"}, op.RequestBody.RequestBody.Content)
+		}
+	})
+
+	eachResponse(s, func(ptr []string, _ string, resp openapi3.Response) {
+		inline(ptr, resp.Content)
+	})
+}
+
+// depths measures nesting of schemas with memoization of components.
+type depths struct {
+	s        *openapi3.Spec
+	known    map[string]int
+	visiting map[string]bool
+}
+
+func (d depths) of(sr *openapi3.SchemaOrRef) int {
+	if sr == nil {
+		return 0
+	}
+
+	if sr.SchemaReference != nil {
+		ref := sr.SchemaReference.Ref
+		if depth, ok := d.known[ref]; ok {
+			return depth
+		}
+
+		// Recursive references do not add depth.
+		if d.visiting[ref] {
+			return 0
+		}
+
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if name == ref || d.s.Components == nil || d.s.Components.Schemas == nil {
+			return 1
+		}
+
+		c, ok := d.s.Components.Schemas.MapOfSchemaOrRefValues[name]
+		if !ok {
+			return 1
+		}
+
+		d.visiting[ref] = true
+		depth := d.of(&c)
+		delete(d.visiting, ref)
+
+		d.known[ref] = depth
+
+		return depth
+	}
+
+	sch := sr.Schema
+	if sch == nil {
+		return 0
+	}
+
+	nested := 0
+
+	deeper := func(sr *openapi3.SchemaOrRef) {
+		if depth := d.of(sr); depth > nested {
+			nested = depth
+		}
+	}
+
+	if sch.Properties != nil {
+		for p := sch.Properties.Oldest(); p != nil; p = p.Next() {
+			sr := p.Value
+			deeper(&sr)
+		}
+	}
+
+	if sch.AdditionalProperties != nil {
+		deeper(sch.AdditionalProperties.SchemaOrRef)
+	}
+
+	deeper(sch.Items)
+	deeper(sch.Not)
+
+	for _, list := range [][]openapi3.SchemaOrRef{sch.AllOf, sch.AnyOf, sch.OneOf} {
+		for i := range list {
+			// Composition does not nest values.
+			if depth := d.of(&list[i]) - 1; depth > nested {
+				nested = depth
+			}
+		}
+	}
+
+	return 1 + nested
+}
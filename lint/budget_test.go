@@ -0,0 +1,63 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestBudget(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /orders:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {$ref: '#/components/schemas/Order'}}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Order'}
+      responses: {"204": {description: No Content}}
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id: {type: string}
+        items: {type: array, items: {$ref: '#/components/schemas/Item'}}
+        parent: {$ref: '#/components/schemas/Order'}
+    Item:
+      allOf:
+        - type: object
+          properties: {sku: {type: string}}
+`)))
+
+	b := lint.Budget{MaxOperations: 1, MaxBytes: 100, MaxSchemaDepth: 4}
+
+	var msgs []string
+	for _, f := range lint.Run(&s, b.Rule()) {
+		msgs = append(msgs, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: : 674 bytes exceed budget of 100 (size-budget)",
+		"warning: /paths: 2 operations exceed budget of 1 (size-budget)",
+		"warning: /paths/~1orders/get/responses/200/content/application~1json/schema: " +
+			"schema depth 5 exceeds budget of 4 (size-budget)",
+	}, msgs)
+
+	assert.EqualError(t, lint.Budget{MaxOperations: 1}.Check(&s),
+		"size budget exceeded:\n/paths: 2 operations exceed budget of 1")
+	assert.NoError(t, lint.Budget{MaxOperations: 2, MaxSchemaDepth: 5}.Check(&s))
+}
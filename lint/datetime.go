@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Styles of date-time values.
+const (
+	dateTimeUTC    = "UTC with trailing Z"
+	dateTimeOffset = "numeric offset"
+	dateTimeDate   = "date only"
+)
+
+// DateTimeConsistency reports `date-time` values of `example`, `default` and `enum`
+// that are written in a different style than most of such values in the document.
+//
+// Styles are UTC with trailing Z, numeric offset and date only, see openapi.TimeFormat
+// to control style of reflected values.
+var DateTimeConsistency = Rule{
+	Name:     "date-time-consistency",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		j, err := json.Marshal(s)
+		if err != nil {
+			return
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(j, &v); err != nil {
+			return
+		}
+
+		type value struct {
+			ptr   string
+			style string
+		}
+
+		var (
+			values []value
+			counts = map[string]int{}
+		)
+
+		walkDateTimeValues(nil, v, func(ptr []string, val string) {
+			if style := dateTimeStyle(val); style != "" {
+				values = append(values, value{ptr: Pointer(ptr...), style: style})
+				counts[style]++
+			}
+		})
+
+		common := ""
+		for _, style := range []string{dateTimeUTC, dateTimeOffset, dateTimeDate} {
+			if counts[style] > counts[common] {
+				common = style
+			}
+		}
+
+		for _, val := range values {
+			if val.style != common {
+				report(val.ptr, "date-time value uses "+val.style+", most values ("+strconv.Itoa(counts[common])+
+					") use "+common)
+			}
+		}
+	},
+}
+
+func walkDateTimeValues(ptr []string, v interface{}, fn func(ptr []string, val string)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		isDateTime := v["format"] == "date-time"
+
+		for _, k := range keys {
+			p := append(ptr[:len(ptr):len(ptr)], k)
+
+			switch k {
+			case "example", "default":
+				if s, ok := v[k].(string); ok && isDateTime {
+					fn(p, s)
+				}
+			case "enum":
+				items, _ := v[k].([]interface{})
+				for i, item := range items {
+					if s, ok := item.(string); ok && isDateTime {
+						fn(append(p[:len(p):len(p)], strconv.Itoa(i)), s)
+					}
+				}
+			case "examples", "const":
+				// Values are not schemas.
+			default:
+				walkDateTimeValues(p, v[k], fn)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			walkDateTimeValues(append(ptr[:len(ptr):len(ptr)], strconv.Itoa(i)), item, fn)
+		}
+	}
+}
+
+// dateTimeStyle returns style of value, empty for values that are not RFC 3339 dates or date-times.
+func dateTimeStyle(val string) string {
+	if _, err := time.Parse("2006-01-02", val); err == nil {
+		return dateTimeDate
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, val); err != nil {
+		return ""
+	}
+
+	if strings.HasSuffix(val, "Z") || strings.HasSuffix(val, "z") {
+		return dateTimeUTC
+	}
+
+	return dateTimeOffset
+}
@@ -0,0 +1,50 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestDateTimeConsistency(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /events:
+    get:
+      parameters:
+        - name: since
+          in: query
+          schema: {type: string, format: date-time, example: "2024-01-02T03:04:05+02:00"}
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Event:
+      type: object
+      properties:
+        at: {type: string, format: date-time, example: "2024-01-02T03:04:05Z"}
+        deadline: {type: string, format: date-time, default: "2024-01-03T00:00:00Z"}
+        day: {type: string, format: date-time, enum: ["2024-01-02", "2024-01-03T00:00:00Z"]}
+        note: {type: string, example: "2024-01-02T03:04:05+02:00"}
+`)))
+
+	findings := lint.Run(&s, lint.DateTimeConsistency)
+
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Event/properties/day/enum/0: date-time value uses date only, " +
+			"most values (3) use UTC with trailing Z (date-time-consistency)",
+		"warning: /paths/~1events/get/parameters/0/schema/example: date-time value uses numeric offset, " +
+			"most values (3) use UTC with trailing Z (date-time-consistency)",
+	}, lines)
+}
@@ -0,0 +1,97 @@
+package lint
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// HeaderCaseConflict reports response headers with names that differ only by case.
+//
+// HTTP header names are case-insensitive, so such headers can not be distinguished by clients.
+var HeaderCaseConflict = Rule{
+	Name:     "header-case-conflict",
+	Severity: Error,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachResponse(s, func(ptr []string, _ string, resp openapi3.Response) {
+			seen := map[string]string{}
+
+			for _, name := range sortedHeaders(resp) {
+				canonical := http.CanonicalHeaderKey(name)
+
+				if prev, ok := seen[canonical]; ok {
+					report(Pointer(append(ptr, "headers", name)...),
+						"header "+name+" conflicts with "+prev+", header names are case-insensitive")
+
+					continue
+				}
+
+				seen[canonical] = name
+			}
+		})
+	},
+}
+
+// forbiddenResponseHeaders are controlled by transport and must not be documented per response.
+var forbiddenResponseHeaders = map[string]string{
+	"Content-Length":    "is managed by HTTP transport",
+	"Content-Type":      "is ignored, use response content instead",
+	"Authorization":     "is a request header",
+	"Transfer-Encoding": "is managed by HTTP transport",
+}
+
+// ForbiddenResponseHeaders reports response headers that should not be declared.
+var ForbiddenResponseHeaders = Rule{
+	Name:     "forbidden-response-header",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachResponse(s, func(ptr []string, _ string, resp openapi3.Response) {
+			for _, name := range sortedHeaders(resp) {
+				if reason, ok := forbiddenResponseHeaders[http.CanonicalHeaderKey(name)]; ok {
+					report(Pointer(append(ptr, "headers", name)...), "response header "+name+" "+reason)
+				}
+			}
+		})
+	},
+}
+
+// MissingLocationHeader reports 201 and redirect responses without Location header.
+var MissingLocationHeader = Rule{
+	Name:     "missing-location-header",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachResponse(s, func(ptr []string, code string, resp openapi3.Response) {
+			switch code {
+			case "201", "301", "302", "303", "307", "308":
+			default:
+				return
+			}
+
+			for name := range resp.Headers {
+				if strings.EqualFold(name, "Location") {
+					return
+				}
+			}
+
+			report(Pointer(ptr...), code+" response should declare Location header")
+		})
+	},
+}
+
+// HeaderRules are rules for response headers.
+func HeaderRules() []Rule {
+	return []Rule{HeaderCaseConflict, ForbiddenResponseHeaders, MissingLocationHeader}
+}
+
+func sortedHeaders(resp openapi3.Response) []string {
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,64 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestHeaderRules(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things:
+    post:
+      responses:
+        "201":
+          description: Created
+          headers:
+            X-Request-Id: {schema: {type: string}}
+            x-request-id: {schema: {type: string}}
+            Content-Length: {schema: {type: integer}}
+    get:
+      responses:
+        "302":
+          $ref: '#/components/responses/Redirect'
+        "200":
+          description: OK
+          headers:
+            location: {schema: {type: string}}
+components:
+  responses:
+    Redirect:
+      description: Found
+      headers:
+        Authorization: {schema: {type: string}}
+`)))
+
+	findings := lint.Run(&s, lint.HeaderRules()...)
+
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: /paths/~1things/get/responses/302: 302 response should declare Location header (missing-location-header)",
+		"warning: /paths/~1things/get/responses/302/headers/Authorization: " +
+			"response header Authorization is a request header (forbidden-response-header)",
+		"warning: /paths/~1things/post/responses/201: 201 response should declare Location header (missing-location-header)",
+		"warning: /paths/~1things/post/responses/201/headers/Content-Length: " +
+			"response header Content-Length is managed by HTTP transport (forbidden-response-header)",
+		"error: /paths/~1things/post/responses/201/headers/x-request-id: " +
+			"header x-request-id conflicts with X-Request-Id, header names are case-insensitive (header-case-conflict)",
+	}, lines)
+
+	findings = lint.Run(&s, lint.MissingLocationHeader.WithSeverity(lint.Info))
+	require.Len(t, findings, 2)
+	assert.Equal(t, lint.Info, findings[0].Severity)
+}
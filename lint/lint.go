@@ -0,0 +1,187 @@
+// Package lint checks OpenAPI documents against style and consistency rules.
+package lint
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Severity defines importance of a finding.
+type Severity int
+
+// Severity levels.
+const (
+	Error Severity = iota
+	Warning
+	Info
+)
+
+// String returns severity name.
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Info:
+		return "info"
+	}
+
+	return "unknown"
+}
+
+// Finding describes a rule violation.
+type Finding struct {
+	Rule     string
+	Severity Severity
+
+	// Pointer is a JSON Pointer to the offending entity, e.g. "/paths/~1things/get/responses/201".
+	Pointer string
+	Message string
+}
+
+// String returns finding as a single line.
+func (f Finding) String() string {
+	return f.Severity.String() + ": " + f.Pointer + ": " + f.Message + " (" + f.Rule + ")"
+}
+
+// Reporter records a violation at JSON Pointer.
+type Reporter func(pointer, message string)
+
+// Rule checks a document.
+type Rule struct {
+	// Name identifies the rule in findings.
+	Name string
+
+	// Severity is assigned to findings of the rule.
+	Severity Severity
+
+	// Check inspects the document and reports violations.
+	Check func(s *openapi3.Spec, report Reporter)
+}
+
+// WithSeverity returns a copy of rule with changed severity.
+func (r Rule) WithSeverity(s Severity) Rule {
+	r.Severity = s
+
+	return r
+}
+
+// Run checks document with rules and returns findings ordered by pointer.
+func Run(s *openapi3.Spec, rules ...Rule) []Finding {
+	findings, _ := RunContext(context.Background(), s, rules...)
+
+	return findings
+}
+
+// RunContext checks document with rules and returns findings ordered by pointer.
+//
+// Context is checked before every rule, findings of completed rules are returned with context error.
+func RunContext(ctx context.Context, s *openapi3.Spec, rules ...Rule) ([]Finding, error) {
+	var (
+		findings []Finding
+		err      error
+	)
+
+	for _, r := range rules {
+		r := r
+
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		r.Check(s, func(pointer, message string) {
+			findings = append(findings, Finding{
+				Rule:     r.Name,
+				Severity: r.Severity,
+				Pointer:  pointer,
+				Message:  message,
+			})
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Pointer < findings[j].Pointer
+	})
+
+	return findings, err
+}
+
+// Pointer builds JSON Pointer from reference tokens.
+func Pointer(tokens ...string) string {
+	var sb strings.Builder
+
+	for _, t := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(internal.EscapePointerToken(t))
+	}
+
+	return sb.String()
+}
+
+// eachOperation calls fn for every operation in a stable order.
+func eachOperation(s *openapi3.Spec, fn func(path, method string, pi openapi3.PathItem, op openapi3.Operation)) {
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			fn(path, method, pi, pi.MapOfOperationValues[method])
+		}
+	}
+}
+
+// eachResponse calls fn for every operation response in a stable order, references are resolved.
+func eachResponse(s *openapi3.Spec, fn func(ptr []string, code string, resp openapi3.Response)) {
+	eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+		codes := make([]string, 0, len(op.Responses.MapOfResponseOrRefValues))
+		for code := range op.Responses.MapOfResponseOrRefValues {
+			codes = append(codes, code)
+		}
+
+		sort.Strings(codes)
+
+		for _, code := range codes {
+			if r := resolveResponse(s, op.Responses.MapOfResponseOrRefValues[code]); r != nil {
+				fn([]string{"paths", path, method, "responses", code}, code, *r)
+			}
+		}
+
+		if op.Responses.Default != nil {
+			if r := resolveResponse(s, *op.Responses.Default); r != nil {
+				fn([]string{"paths", path, method, "responses", "default"}, "default", *r)
+			}
+		}
+	})
+}
+
+func resolveResponse(s *openapi3.Spec, rr openapi3.ResponseOrRef) *openapi3.Response {
+	if rr.Response != nil {
+		return rr.Response
+	}
+
+	if rr.ResponseReference == nil || s.Components == nil || s.Components.Responses == nil {
+		return nil
+	}
+
+	name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+	return s.Components.Responses.MapOfResponseOrRefValues[name].Response
+}
@@ -0,0 +1,335 @@
+package lint
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// NamingConvention defines parameter name style.
+type NamingConvention int
+
+// NamingConvention values enumeration.
+const (
+	// SnakeCase is like `user_id`.
+	SnakeCase NamingConvention = iota
+
+	// CamelCase is like `userId`.
+	CamelCase
+)
+
+// Format converts name to the convention.
+func (c NamingConvention) Format(name string) string {
+	words := splitName(name)
+
+	if c == SnakeCase {
+		return strings.Join(words, "_")
+	}
+
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+
+	return strings.Join(words, "")
+}
+
+// String returns convention name.
+func (c NamingConvention) String() string {
+	if c == SnakeCase {
+		return "snake_case"
+	}
+
+	return "camelCase"
+}
+
+// splitName splits name into lower case words on separators and case changes.
+func splitName(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	runes := []rune(name)
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+
+			continue
+		}
+
+		// Boundaries: "userId" -> user|Id, "HTTPServer" -> HTTP|Server.
+		if unicode.IsUpper(r) && i > 0 && len(word) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	flush()
+
+	return words
+}
+
+// ParameterNaming reports path and query parameters with names that do not follow convention.
+func ParameterNaming(c NamingConvention) Rule {
+	return Rule{
+		Name:     "parameter-naming",
+		Severity: Warning,
+		Check: func(s *openapi3.Spec, report Reporter) {
+			eachNamedParameter(s, func(ptr []string, p *openapi3.Parameter) {
+				if expected := c.Format(p.Name); expected != p.Name {
+					report(Pointer(ptr...), "parameter "+p.Name+" should be "+c.String()+": "+expected)
+				}
+			})
+		},
+	}
+}
+
+// Rename describes an applied fix.
+type Rename struct {
+	Pointer string
+	Old     string
+	New     string
+}
+
+// FixParameterNaming renames path and query parameters to follow convention.
+//
+// Path templates are updated together with path parameters, renames are returned in pointer order.
+// Path parameters are not renamed if updated path template would collide with another path,
+// such paths are reported as findings.
+func FixParameterNaming(s *openapi3.Spec, c NamingConvention) ([]Rename, []Finding) {
+	type candidate struct {
+		ptr      []string
+		param    *openapi3.Parameter
+		expected string
+	}
+
+	var candidates []candidate
+
+	eachNamedParameter(s, func(ptr []string, p *openapi3.Parameter) {
+		if expected := c.Format(p.Name); expected != p.Name {
+			candidates = append(candidates, candidate{ptr: ptr, param: p, expected: expected})
+		}
+	})
+
+	blockedPaths := map[string]string{}
+	blockedComponents := map[string]bool{}
+
+	var newPaths map[string]string
+
+	// Blocked component parameters change templates of other paths, so collisions are checked until stable.
+	for {
+		pathRenames := map[string]map[string]string{}
+		componentRenames := map[string][2]string{}
+
+		for _, cd := range candidates {
+			if cd.param.In != openapi3.ParameterInPath {
+				continue
+			}
+
+			if cd.ptr[0] == "paths" {
+				if _, blocked := blockedPaths[cd.ptr[1]]; !blocked {
+					addPathRename(pathRenames, cd.ptr[1], cd.param.Name, cd.expected)
+				}
+			} else if ref := "#/components/parameters/" + cd.ptr[2]; !blockedComponents[ref] {
+				componentRenames[ref] = [2]string{cd.param.Name, cd.expected}
+			}
+		}
+
+		// Path templates of operations that refer to renamed component parameters.
+		for path, pi := range s.Paths.MapOfPathItemValues {
+			if _, blocked := blockedPaths[path]; blocked {
+				continue
+			}
+
+			for _, ref := range parameterRefs(pi) {
+				if r, ok := componentRenames[ref]; ok {
+					addPathRename(pathRenames, path, r[0], r[1])
+				}
+			}
+		}
+
+		newPaths = map[string]string{}
+		targets := map[string]int{}
+
+		for path, names := range pathRenames {
+			newPath := path
+			for old, n := range names {
+				newPath = strings.ReplaceAll(newPath, "{"+old+"}", "{"+n+"}")
+			}
+
+			if newPath != path {
+				newPaths[path] = newPath
+				targets[newPath]++
+			}
+		}
+
+		changed := false
+
+		for path, newPath := range newPaths {
+			if _, exists := s.Paths.MapOfPathItemValues[newPath]; !exists && targets[newPath] == 1 {
+				continue
+			}
+
+			blockedPaths[path] = newPath
+			changed = true
+
+			for _, ref := range parameterRefs(s.Paths.MapOfPathItemValues[path]) {
+				if _, ok := componentRenames[ref]; ok {
+					blockedComponents[ref] = true
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	var (
+		renames  []Rename
+		findings []Finding
+	)
+
+	for _, cd := range candidates {
+		if cd.param.In == openapi3.ParameterInPath {
+			if _, blocked := blockedPaths[cd.ptr[1]]; cd.ptr[0] == "paths" && blocked {
+				continue
+			}
+
+			if cd.ptr[0] == "components" && blockedComponents["#/components/parameters/"+cd.ptr[2]] {
+				continue
+			}
+		}
+
+		renames = append(renames, Rename{Pointer: Pointer(cd.ptr...), Old: cd.param.Name, New: cd.expected})
+		cd.param.Name = cd.expected
+	}
+
+	for path, newPath := range newPaths {
+		if err := s.RenamePath(path, newPath); err != nil {
+			findings = append(findings, Finding{
+				Rule:     "parameter-naming",
+				Severity: Warning,
+				Pointer:  Pointer("paths", path),
+				Message:  "path is not renamed: " + err.Error(),
+			})
+		}
+	}
+
+	for path, newPath := range blockedPaths {
+		findings = append(findings, Finding{
+			Rule:     "parameter-naming",
+			Severity: Warning,
+			Pointer:  Pointer("paths", path),
+			Message:  "path parameters are not renamed, path " + newPath + " collides with another path",
+		})
+	}
+
+	sort.SliceStable(renames, func(i, j int) bool {
+		return renames[i].Pointer < renames[j].Pointer
+	})
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Pointer < findings[j].Pointer
+	})
+
+	return renames, findings
+}
+
+// parameterRefs returns references of parameters of path item and its operations.
+func parameterRefs(pi openapi3.PathItem) []string {
+	var refs []string
+
+	params := append([]openapi3.ParameterOrRef{}, pi.Parameters...)
+	for _, op := range pi.MapOfOperationValues {
+		params = append(params, op.Parameters...)
+	}
+
+	for _, pr := range params {
+		if pr.ParameterReference != nil {
+			refs = append(refs, pr.ParameterReference.Ref)
+		}
+	}
+
+	return refs
+}
+
+func addPathRename(pathRenames map[string]map[string]string, path, oldName, newName string) {
+	if pathRenames[path] == nil {
+		pathRenames[path] = map[string]string{}
+	}
+
+	pathRenames[path][oldName] = newName
+}
+
+// eachNamedParameter calls fn with pointers to path and query parameters, including shared components.
+func eachNamedParameter(s *openapi3.Spec, fn func(ptr []string, p *openapi3.Parameter)) {
+	visit := func(ptr []string, params []openapi3.ParameterOrRef) {
+		for i, pr := range params {
+			p := pr.Parameter
+			if p == nil || (p.In != openapi3.ParameterInPath && p.In != openapi3.ParameterInQuery) {
+				continue
+			}
+
+			fn(append(ptr, "parameters", strconv.Itoa(i)), p)
+		}
+	}
+
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+		visit([]string{"paths", path}, pi.Parameters)
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			visit([]string{"paths", path, method}, pi.MapOfOperationValues[method].Parameters)
+		}
+	}
+
+	if s.Components == nil || s.Components.Parameters == nil {
+		return
+	}
+
+	names := make([]string, 0, len(s.Components.Parameters.MapOfParameterOrRefValues))
+	for name := range s.Components.Parameters.MapOfParameterOrRefValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := s.Components.Parameters.MapOfParameterOrRefValues[name].Parameter
+		if p != nil && (p.In == openapi3.ParameterInPath || p.In == openapi3.ParameterInQuery) {
+			fn([]string{"components", "parameters", name}, p)
+		}
+	}
+}
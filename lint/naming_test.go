@@ -0,0 +1,112 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestNamingConvention_Format(t *testing.T) {
+	for name, expected := range map[string][2]string{
+		"userId":     {"user_id", "userId"},
+		"user_id":    {"user_id", "userId"},
+		"page-size":  {"page_size", "pageSize"},
+		"HTTPStatus": {"http_status", "httpStatus"},
+		"id2Fa":      {"id2_fa", "id2Fa"},
+	} {
+		assert.Equal(t, expected[0], lint.SnakeCase.Format(name), name)
+		assert.Equal(t, expected[1], lint.CamelCase.Format(name), name)
+	}
+}
+
+func TestFixParameterNaming(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /status: {}
+  /orgs/{orgId}/users/{user_id}:
+    parameters:
+      - $ref: '#/components/parameters/OrgID'
+    get:
+      parameters:
+        - {name: user_id, in: path, required: true, schema: {type: string}}
+        - {name: pageSize, in: query, schema: {type: integer}}
+        - {name: X-Request-Id, in: header, schema: {type: string}}
+      responses:
+        "200": {description: OK}
+  /version: {}
+components:
+  parameters:
+    OrgID: {name: orgId, in: path, required: true, schema: {type: string}}
+`)))
+
+	findings := lint.Run(&s, lint.ParameterNaming(lint.SnakeCase))
+	require.Len(t, findings, 2)
+	assert.Equal(t, "warning: /components/parameters/OrgID: parameter orgId should be snake_case: org_id (parameter-naming)",
+		findings[0].String())
+
+	renames, findings := lint.FixParameterNaming(&s, lint.SnakeCase)
+	assert.Empty(t, findings)
+	assert.Equal(t, []lint.Rename{
+		{Pointer: "/components/parameters/OrgID", Old: "orgId", New: "org_id"},
+		{Pointer: "/paths/~1orgs~1{orgId}~1users~1{user_id}/get/parameters/1", Old: "pageSize", New: "page_size"},
+	}, renames)
+
+	assert.Empty(t, lint.Run(&s, lint.ParameterNaming(lint.SnakeCase)))
+
+	pi, ok := s.Paths.MapOfPathItemValues["/orgs/{org_id}/users/{user_id}"]
+	require.True(t, ok)
+	assert.Equal(t, "page_size", pi.MapOfOperationValues["get"].Parameters[1].Parameter.Name)
+
+	y, err := s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(y), "paths:\n  /status: {}\n  /orgs/{org_id}/users/{user_id}:\n    parameters:\n")
+	assert.Contains(t, string(y), "          description: OK\n  /version: {}\ncomponents:\n")
+
+	renames, findings = lint.FixParameterNaming(&s, lint.CamelCase)
+	assert.Empty(t, findings)
+	assert.Len(t, renames, 3)
+
+	_, ok = s.Paths.MapOfPathItemValues["/orgs/{orgId}/users/{userId}"]
+	assert.True(t, ok)
+}
+
+func TestFixParameterNaming_collision(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{userId}:
+    get:
+      parameters:
+        - {name: userId, in: path, required: true, schema: {type: string}}
+        - {name: pageSize, in: query, schema: {type: integer}}
+      responses:
+        "200": {description: OK}
+  /users/{user_id}:
+    delete:
+      parameters:
+        - {name: user_id, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: No Content}
+`)))
+
+	renames, findings := lint.FixParameterNaming(&s, lint.SnakeCase)
+	assert.Equal(t, []lint.Rename{
+		{Pointer: "/paths/~1users~1{userId}/get/parameters/1", Old: "pageSize", New: "page_size"},
+	}, renames)
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, "warning: /paths/~1users~1{userId}: path parameters are not renamed, "+
+		"path /users/{user_id} collides with another path (parameter-naming)", findings[0].String())
+
+	pi := s.Paths.MapOfPathItemValues["/users/{userId}"]
+	assert.Equal(t, "userId", pi.MapOfOperationValues["get"].Parameters[0].Parameter.Name)
+	assert.NotNil(t, s.Paths.MapOfPathItemValues["/users/{user_id}"].MapOfOperationValues["delete"])
+}
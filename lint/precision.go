@@ -0,0 +1,88 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// MultipleOfPrecision reports `multipleOf` values without exact binary floating point representation.
+//
+// Validators that divide by such a value, e.g. `0.1`, may reject valid numbers
+// because of rounding errors, a scaled integer or `multipleOf: 1` with a smaller unit is safer.
+var MultipleOfPrecision = Rule{
+	Name:     "multiple-of-precision",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		j, err := json.Marshal(s)
+		if err != nil {
+			return
+		}
+
+		d := json.NewDecoder(bytes.NewReader(j))
+		d.UseNumber()
+
+		var v interface{}
+		if err := d.Decode(&v); err != nil {
+			return
+		}
+
+		walkMultipleOf(nil, v, func(ptr []string, m json.Number) {
+			if !isExactFloat(m) {
+				report(Pointer(ptr...), "multipleOf "+m.String()+" is not exact in binary floating point, "+
+					"validators may reject valid values")
+			}
+		})
+	},
+}
+
+func walkMultipleOf(ptr []string, v interface{}, fn func(ptr []string, m json.Number)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			switch k {
+			case "multipleOf":
+				if m, ok := v[k].(json.Number); ok {
+					fn(append(ptr[:len(ptr):len(ptr)], k), m)
+				}
+			case "example", "examples", "default", "enum", "const":
+				// Values are not schemas.
+			case "properties":
+				if props, ok := v[k].(map[string]interface{}); ok {
+					for name, p := range props {
+						walkMultipleOf(append(ptr[:len(ptr):len(ptr)], k, name), p, fn)
+					}
+				}
+			default:
+				walkMultipleOf(append(ptr[:len(ptr):len(ptr)], k), v[k], fn)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			walkMultipleOf(append(ptr[:len(ptr):len(ptr)], strconv.Itoa(i)), item, fn)
+		}
+	}
+}
+
+// isExactFloat checks that decimal number is equal to its nearest float64.
+func isExactFloat(n json.Number) bool {
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return true
+	}
+
+	f, _ := r.Float64()
+
+	return new(big.Rat).SetFloat64(f).Cmp(r) == 0
+}
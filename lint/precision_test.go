@@ -0,0 +1,42 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestMultipleOfPrecision(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Price:
+      type: object
+      properties:
+        amount: {type: number, multipleOf: 0.01}
+        ratio: {type: number, multipleOf: 0.25}
+        count: {type: integer, multipleOf: 5}
+        multipleOf: {type: number, multipleOf: 1.1}
+`)))
+
+	findings := lint.Run(&s, lint.MultipleOfPrecision)
+
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: /components/schemas/Price/properties/amount/multipleOf: multipleOf 0.01 is not exact " +
+			"in binary floating point, validators may reject valid values (multiple-of-precision)",
+		"warning: /components/schemas/Price/properties/multipleOf/multipleOf: multipleOf 1.1 is not exact " +
+			"in binary floating point, validators may reject valid values (multiple-of-precision)",
+	}, lines)
+}
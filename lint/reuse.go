@@ -0,0 +1,268 @@
+package lint
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// SchemaGroup is a set of near-identical inline response schemas.
+type SchemaGroup struct {
+	// Pointers are JSON Pointers to schemas, ordered.
+	Pointers []string
+
+	// Schema is the first schema of the group.
+	Schema openapi3.Schema
+
+	refs []*openapi3.SchemaOrRef
+}
+
+// FindDuplicateResponseSchemas groups inline response schemas of objects and arrays that are equal
+// regardless of titles, descriptions and examples.
+//
+// Only groups with at least minCount schemas are returned.
+func FindDuplicateResponseSchemas(s *openapi3.Spec, minCount int) []SchemaGroup {
+	groups := map[string]*SchemaGroup{}
+
+	eachInlineResponseSchema(s, func(ptr string, sr *openapi3.SchemaOrRef) {
+		t := schemaType(sr.Schema)
+		if t != openapi3.SchemaTypeObject && t != openapi3.SchemaTypeArray {
+			return
+		}
+
+		key, err := schemaFingerprint(sr.Schema)
+		if err != nil {
+			return
+		}
+
+		g := groups[key]
+		if g == nil {
+			g = &SchemaGroup{Schema: *sr.Schema}
+			groups[key] = g
+		}
+
+		g.Pointers = append(g.Pointers, ptr)
+		g.refs = append(g.refs, sr)
+	})
+
+	var res []SchemaGroup
+
+	for _, g := range groups {
+		if len(g.Pointers) >= minCount {
+			res = append(res, *g)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Pointers[0] < res[j].Pointers[0]
+	})
+
+	return res
+}
+
+// DuplicateResponseSchemas reports inline response schemas that are repeated at least minCount times.
+func DuplicateResponseSchemas(minCount int) Rule {
+	return Rule{
+		Name:     "duplicate-response-schema",
+		Severity: Info,
+		Check: func(s *openapi3.Spec, report Reporter) {
+			for _, g := range FindDuplicateResponseSchemas(s, minCount) {
+				for _, ptr := range g.Pointers[1:] {
+					report(ptr, "schema is repeated "+strconv.Itoa(len(g.Pointers))+
+						" times, same as "+g.Pointers[0]+", consider extracting it to components")
+				}
+			}
+		},
+	}
+}
+
+// ExtractDuplicateResponseSchemas moves repeated inline response schemas to components
+// and replaces them with references.
+//
+// Name provides component name for a group, nil name uses schema title or `SharedResponseN`.
+// Names of extracted components are returned.
+func ExtractDuplicateResponseSchemas(s *openapi3.Spec, minCount int, name func(g SchemaGroup) string) []string {
+	groups := FindDuplicateResponseSchemas(s, minCount)
+	if len(groups) == 0 {
+		return nil
+	}
+
+	schemas := s.ComponentsEns().SchemasEns()
+	if schemas.MapOfSchemaOrRefValues == nil {
+		schemas.MapOfSchemaOrRefValues = map[string]openapi3.SchemaOrRef{}
+	}
+
+	res := make([]string, 0, len(groups))
+
+	for i, g := range groups {
+		n := ""
+		if name != nil {
+			n = name(g)
+		}
+
+		if n == "" && g.Schema.Title != nil {
+			n = camelTitle(*g.Schema.Title)
+		}
+
+		if n == "" {
+			n = "SharedResponse" + strconv.Itoa(i+1)
+		}
+
+		base := n
+		for try := 2; ; try++ {
+			if _, exists := schemas.MapOfSchemaOrRefValues[n]; !exists {
+				break
+			}
+
+			n = base + strconv.Itoa(try)
+		}
+
+		schemas.MapOfSchemaOrRefValues[n] = openapi3.SchemaOrRef{Schema: &g.Schema}
+
+		for _, sr := range g.refs {
+			sr.Schema = nil
+			sr.SchemaReference = &openapi3.SchemaReference{Ref: "#/components/schemas/" + n}
+		}
+
+		res = append(res, n)
+	}
+
+	return res
+}
+
+func camelTitle(s string) string {
+	var sb strings.Builder
+
+	for _, w := range splitName(s) {
+		sb.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+
+	return sb.String()
+}
+
+func schemaType(s *openapi3.Schema) openapi3.SchemaType {
+	if s.Type != nil {
+		return *s.Type
+	}
+
+	if s.Properties != nil {
+		return openapi3.SchemaTypeObject
+	}
+
+	return ""
+}
+
+// schemaFingerprint is a canonical JSON of a schema without documentation keywords.
+func schemaFingerprint(s *openapi3.Schema) (string, error) {
+	j, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(j, &v); err != nil {
+		return "", err
+	}
+
+	j, err = json.Marshal(stripDocs(v))
+	if err != nil {
+		return "", err
+	}
+
+	return string(j), nil
+}
+
+// stripDocs removes documentation keywords of schema and its subschemas.
+//
+// Keys of properties and definitions maps are names rather than keywords, so they are kept,
+// values of keywords like enum or default are kept as is.
+func stripDocs(v interface{}) interface{} {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	for k, val := range schema {
+		switch k {
+		case "title", "description", "example", "examples", "externalDocs":
+			delete(schema, k)
+		case "items", "not", "additionalProperties":
+			schema[k] = stripDocs(val)
+		case "allOf", "anyOf", "oneOf":
+			if list, ok := val.([]interface{}); ok {
+				for i, item := range list {
+					list[i] = stripDocs(item)
+				}
+			}
+		case "properties", "patternProperties", "definitions", "$defs":
+			if named, ok := val.(map[string]interface{}); ok {
+				for name, item := range named {
+					named[name] = stripDocs(item)
+				}
+			}
+		}
+	}
+
+	return schema
+}
+
+// eachInlineResponseSchema calls fn for response content schemas of operations and shared responses.
+func eachInlineResponseSchema(s *openapi3.Spec, fn func(ptr string, sr *openapi3.SchemaOrRef)) {
+	visit := func(ptr []string, r *openapi3.Response) {
+		for _, ct := range sortedContentTypes(r.Content) {
+			if sr := r.Content[ct].Schema; sr != nil && sr.Schema != nil {
+				fn(Pointer(append(ptr, "content", ct, "schema")...), sr)
+			}
+		}
+	}
+
+	eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+		codes := make([]string, 0, len(op.Responses.MapOfResponseOrRefValues))
+		for code := range op.Responses.MapOfResponseOrRefValues {
+			codes = append(codes, code)
+		}
+
+		sort.Strings(codes)
+
+		for _, code := range codes {
+			if r := op.Responses.MapOfResponseOrRefValues[code].Response; r != nil {
+				visit([]string{"paths", path, method, "responses", code}, r)
+			}
+		}
+
+		if op.Responses.Default != nil && op.Responses.Default.Response != nil {
+			visit([]string{"paths", path, method, "responses", "default"}, op.Responses.Default.Response)
+		}
+	})
+
+	if s.Components == nil || s.Components.Responses == nil {
+		return
+	}
+
+	names := make([]string, 0, len(s.Components.Responses.MapOfResponseOrRefValues))
+	for name := range s.Components.Responses.MapOfResponseOrRefValues {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if r := s.Components.Responses.MapOfResponseOrRefValues[name].Response; r != nil {
+			visit([]string{"components", "responses", name}, r)
+		}
+	}
+}
+
+func sortedContentTypes(content map[string]openapi3.MediaType) []string {
+	cts := make([]string, 0, len(content))
+	for ct := range content {
+		cts = append(cts, ct)
+	}
+
+	sort.Strings(cts)
+
+	return cts
+}
@@ -0,0 +1,114 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestExtractDuplicateResponseSchemas(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {type: string}}
+        "400":
+          description: Bad Request
+          content:
+            application/json:
+              schema:
+                title: Error
+                type: object
+                properties: {message: {type: string, description: Error message.}}
+  /users:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {type: string}
+        "400":
+          description: Bad Request
+          content:
+            application/json:
+              schema:
+                type: object
+                properties: {message: {type: string, example: oops}}
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                type: object
+                properties: {message: {type: string}}
+`)))
+
+	findings := lint.Run(&s, lint.DuplicateResponseSchemas(2))
+	require.Len(t, findings, 2)
+	assert.Equal(t, "info: /paths/~1users/get/responses/400/content/application~1json/schema: "+
+		"schema is repeated 3 times, same as /paths/~1things/get/responses/400/content/application~1json/schema, "+
+		"consider extracting it to components (duplicate-response-schema)", findings[0].String())
+
+	assert.Equal(t, []string{"Error"}, lint.ExtractDuplicateResponseSchemas(&s, 2, nil))
+	assert.Empty(t, lint.FindDuplicateResponseSchemas(&s, 2))
+
+	assertjson.EqMarshal(t, `{
+	  "schemas":{
+		"Error":{
+		  "title":"Error","type":"object",
+		  "properties":{"message":{"type":"string","description":"Error message."}}
+		}
+	  }
+	}`, s.Components)
+
+	assertjson.EqMarshal(t, `{
+	  "description":"Error",
+	  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error"}}}
+	}`, s.Paths.MapOfPathItemValues["/users"].MapOfOperationValues["get"].Responses.Default)
+}
+
+func TestFindDuplicateResponseSchemas_propertyNamedTitle(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /books:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties: {title: {type: string, description: Book title.}}
+  /chapters:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties: {title: {type: integer}}
+`)))
+
+	// Properties named as documentation keywords are part of schema.
+	assert.Empty(t, lint.FindDuplicateResponseSchemas(&s, 2))
+	assert.Empty(t, lint.ExtractDuplicateResponseSchemas(&s, 2, nil))
+}
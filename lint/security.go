@@ -0,0 +1,61 @@
+package lint
+
+import (
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// MissingSecurity reports operations without security requirements in a document that declares
+// security schemes.
+//
+// Operations with empty requirement `{}` are treated as deliberately public and are not reported,
+// use OptionalSecurity to review them.
+var MissingSecurity = Rule{
+	Name:     "missing-security",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		if s.Components == nil || s.Components.SecuritySchemes == nil ||
+			len(s.Components.SecuritySchemes.MapOfSecuritySchemeOrRefValues) == 0 {
+			return
+		}
+
+		eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+			if len(effectiveSecurity(s, op)) == 0 {
+				report(Pointer("paths", path, method), "operation has no security requirements")
+			}
+		})
+	},
+}
+
+// OptionalSecurity reports operations that accept requests without credentials
+// because of empty security requirement `{}`.
+//
+// It is meant to be enabled explicitly to review optional authentication.
+var OptionalSecurity = Rule{
+	Name:     "optional-security",
+	Severity: Info,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+			reqs := effectiveSecurity(s, op)
+			if len(reqs) < 2 {
+				return
+			}
+
+			for _, req := range reqs {
+				if len(req) == 0 {
+					report(Pointer("paths", path, method), "authentication is optional")
+
+					return
+				}
+			}
+		})
+	},
+}
+
+// effectiveSecurity returns requirements of operation or inherited top-level requirements.
+func effectiveSecurity(s *openapi3.Spec, op openapi3.Operation) []map[string][]string {
+	if op.Security != nil {
+		return op.Security
+	}
+
+	return s.Security
+}
@@ -0,0 +1,54 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSecurityRules(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /inherited:
+    get:
+      responses: {"204": {description: No Content}}
+  /optional:
+    get:
+      security: [{apiKey: []}, {}]
+      responses: {"204": {description: No Content}}
+  /public:
+    get:
+      security: [{}]
+      responses: {"204": {description: No Content}}
+  /unsecured:
+    get:
+      security: []
+      responses: {"204": {description: No Content}}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+`)))
+
+	findings := lint.Run(&s, lint.MissingSecurity, lint.OptionalSecurity)
+
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: /paths/~1inherited/get: operation has no security requirements (missing-security)",
+		"info: /paths/~1optional/get: authentication is optional (optional-security)",
+		"warning: /paths/~1unsecured/get: operation has no security requirements (missing-security)",
+	}, lines)
+
+	s.Security = []map[string][]string{{"apiKey": {}}}
+
+	assert.Len(t, lint.Run(&s, lint.MissingSecurity), 1)
+}
@@ -0,0 +1,171 @@
+package lint
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// OperationSummary reports operations without summary.
+var OperationSummary = Rule{
+	Name:     "operation-summary",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+			if op.Summary == nil || strings.TrimSpace(*op.Summary) == "" {
+				report(Pointer("paths", path, method), "operation has no summary")
+			}
+		})
+	},
+}
+
+// DeclaredTags reports operation tags that are missing in top-level `tags` of document.
+var DeclaredTags = Rule{
+	Name:     "declared-tags",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		declared := make(map[string]bool, len(s.Tags))
+		for _, t := range s.Tags {
+			declared[t.Name] = true
+		}
+
+		eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+			for i, tag := range op.Tags {
+				if !declared[tag] {
+					report(Pointer("paths", path, method, "tags", strconv.Itoa(i)), "tag "+tag+" is not declared")
+				}
+			}
+		})
+	},
+}
+
+// ClientErrorResponses reports operations that document no 4xx (or default) response.
+var ClientErrorResponses = Rule{
+	Name:     "client-error-responses",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		eachOperation(s, func(path, method string, _ openapi3.PathItem, op openapi3.Operation) {
+			if op.Responses.Default != nil {
+				return
+			}
+
+			for code := range op.Responses.MapOfResponseOrRefValues {
+				if strings.HasPrefix(code, "4") {
+					return
+				}
+			}
+
+			report(Pointer("paths", path, method, "responses"), "operation has no 4xx response")
+		})
+	},
+}
+
+// EmptyDescriptions reports blank descriptions of info, tags, operations, parameters and responses.
+var EmptyDescriptions = Rule{
+	Name:     "empty-description",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		check := func(description *string, ptr ...string) {
+			if description != nil && strings.TrimSpace(*description) == "" {
+				report(Pointer(append(ptr, "description")...), "description is empty")
+			}
+		}
+
+		check(s.Info.Description, "info")
+
+		for i, t := range s.Tags {
+			check(t.Description, "tags", strconv.Itoa(i))
+		}
+
+		checkParams := func(params []openapi3.ParameterOrRef, ptr ...string) {
+			for i, p := range params {
+				if p.Parameter != nil {
+					check(p.Parameter.Description, append(ptr, "parameters", strconv.Itoa(i))...)
+				}
+			}
+		}
+
+		checkResponses := func(responses map[string]openapi3.ResponseOrRef, ptr ...string) {
+			for _, code := range sortedKeys(responses) {
+				if r := responses[code].Response; r != nil {
+					check(&r.Description, append(ptr, code)...)
+				}
+			}
+		}
+
+		visitedPaths := map[string]bool{}
+
+		eachOperation(s, func(path, method string, pi openapi3.PathItem, op openapi3.Operation) {
+			if !visitedPaths[path] {
+				visitedPaths[path] = true
+
+				checkParams(pi.Parameters, "paths", path)
+			}
+
+			check(op.Description, "paths", path, method)
+			checkParams(op.Parameters, "paths", path, method)
+
+			responses := op.Responses.MapOfResponseOrRefValues
+			if op.Responses.Default != nil {
+				responses = make(map[string]openapi3.ResponseOrRef, len(responses)+1)
+				for code, r := range op.Responses.MapOfResponseOrRefValues {
+					responses[code] = r
+				}
+
+				responses["default"] = *op.Responses.Default
+			}
+
+			checkResponses(responses, "paths", path, method, "responses")
+		})
+
+		if s.Components != nil && s.Components.Responses != nil {
+			checkResponses(s.Components.Responses.MapOfResponseOrRefValues, "components", "responses")
+		}
+	},
+}
+
+var kebabCaseSegment = regexp.MustCompile(`^[a-z0-9]+([-.][a-z0-9]+)*$`)
+
+// KebabCasePaths reports path segments (except parameter placeholders) that are not kebab-case, e.g. `/userProfiles`.
+var KebabCasePaths = Rule{
+	Name:     "kebab-case-paths",
+	Severity: Warning,
+	Check: func(s *openapi3.Spec, report Reporter) {
+		for _, path := range sortedKeys(s.Paths.MapOfPathItemValues) {
+			for _, segment := range strings.Split(path, "/") {
+				if segment == "" || strings.HasPrefix(segment, "{") || kebabCaseSegment.MatchString(segment) {
+					continue
+				}
+
+				report(Pointer("paths", path), "path segment "+segment+" should be kebab-case: "+
+					strings.Join(splitName(segment), "-"))
+			}
+		}
+	},
+}
+
+// Default returns a recommended set of rules.
+func Default() []Rule {
+	return append([]Rule{
+		OperationSummary,
+		DeclaredTags,
+		ClientErrorResponses,
+		EmptyDescriptions,
+		KebabCasePaths,
+		MissingSecurity,
+	}, HeaderRules()...)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,65 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/lint"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestDefault(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0, description: " "}
+tags:
+  - name: users
+paths:
+  /users/{id}:
+    parameters:
+      - {name: id, in: path, required: true, description: "", schema: {type: string}}
+    get:
+      summary: Get user
+      tags: [users, accounts]
+      responses:
+        "200": {description: OK}
+        "404": {description: Not Found}
+  /userProfiles.json:
+    get:
+      responses:
+        "200": {description: ""}
+        default: {description: Error}
+components:
+  responses:
+    Error: {description: ""}
+`)))
+
+	findings := lint.Run(&s, lint.Default()...)
+
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+
+	assert.Equal(t, []string{
+		"warning: /components/responses/Error/description: description is empty (empty-description)",
+		"warning: /info/description: description is empty (empty-description)",
+		"warning: /paths/~1userProfiles.json: path segment userProfiles.json should be kebab-case: " +
+			"user-profiles-json (kebab-case-paths)",
+		"warning: /paths/~1userProfiles.json/get: operation has no summary (operation-summary)",
+		"warning: /paths/~1userProfiles.json/get/responses/200/description: description is empty (empty-description)",
+		"warning: /paths/~1users~1{id}/get/tags/1: tag accounts is not declared (declared-tags)",
+		"warning: /paths/~1users~1{id}/parameters/0/description: description is empty (empty-description)",
+	}, lines)
+
+	s.Paths.MapOfPathItemValues["/users/{id}"].MapOfOperationValues["get"].Responses.MapOfResponseOrRefValues["200"] =
+		openapi3.ResponseOrRef{}
+	delete(s.Paths.MapOfPathItemValues["/users/{id}"].MapOfOperationValues["get"].Responses.MapOfResponseOrRefValues,
+		"404")
+
+	findings = lint.Run(&s, lint.ClientErrorResponses)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "/paths/~1users~1{id}/get/responses", findings[0].Pointer)
+}
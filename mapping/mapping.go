@@ -0,0 +1,426 @@
+// Package mapping pairs structurally similar schemas of two OpenAPI documents
+// and reports field-level differences, to assist mapping payloads between APIs.
+package mapping
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// DiffKind classifies a field difference.
+type DiffKind string
+
+// DiffKind values.
+const (
+	OnlyLeft         = DiffKind("only-left")
+	OnlyRight        = DiffKind("only-right")
+	Renamed          = DiffKind("renamed")
+	TypeMismatch     = DiffKind("type-mismatch")
+	RequiredMismatch = DiffKind("required-mismatch")
+)
+
+// FieldDiff is a difference of paired schemas.
+type FieldDiff struct {
+	Kind DiffKind
+
+	// Left and Right are dot-separated property paths, e.g. "address.zip_code",
+	// items of arrays are denoted with "[]", empty if property is missing on a side.
+	Left  string
+	Right string
+
+	// LeftType and RightType are types of properties, e.g. "string(date-time)", "array<integer>" or "object".
+	LeftType  string
+	RightType string
+
+	// LeftRequired and RightRequired tell if properties are required.
+	LeftRequired  bool
+	RightRequired bool
+}
+
+// String describes difference as a single line.
+func (d FieldDiff) String() string {
+	switch d.Kind {
+	case OnlyLeft:
+		return string(d.Kind) + ": " + d.Left + " " + d.LeftType
+	case OnlyRight:
+		return string(d.Kind) + ": " + d.Right + " " + d.RightType
+	case Renamed:
+		return string(d.Kind) + ": " + d.Left + " -> " + d.Right
+	case TypeMismatch:
+		return string(d.Kind) + ": " + d.Left + " " + d.LeftType + " -> " + d.Right + " " + d.RightType
+	case RequiredMismatch:
+		return string(d.Kind) + ": " + d.Left + " " + requiredName(d.LeftRequired) +
+			" -> " + d.Right + " " + requiredName(d.RightRequired)
+	}
+
+	return string(d.Kind)
+}
+
+func requiredName(required bool) string {
+	if required {
+		return "required"
+	}
+
+	return "optional"
+}
+
+// Pair is a matched couple of component schemas.
+type Pair struct {
+	// Left and Right are component schema names.
+	Left  string
+	Right string
+
+	// Score is a structural similarity between 0 and 1.
+	Score float64
+
+	Diffs []FieldDiff
+}
+
+// Options controls matching.
+type Options struct {
+	// MinScore is a minimal similarity of paired schemas, default 0.5.
+	MinScore float64
+
+	// Normalize converts property name into a comparable form,
+	// default is lower case without `_`, `-` and `.`, so that `user_id` matches `userId`.
+	Normalize func(name string) string
+}
+
+// Match pairs object component schemas of left and right documents by similarity of their properties.
+//
+// Each schema is paired at most once, best scores are paired first.
+// Pairs are ordered by left schema name.
+func Match(left, right *openapi3.Spec, options Options) []Pair {
+	if options.MinScore == 0 {
+		options.MinScore = 0.5
+	}
+
+	if options.Normalize == nil {
+		options.Normalize = normalize
+	}
+
+	ls := objects(left, options.Normalize)
+	rs := objects(right, options.Normalize)
+
+	var candidates []Pair
+
+	for _, l := range ls {
+		for _, r := range rs {
+			score := similarity(l, r)
+
+			if strings.EqualFold(l.name, r.name) {
+				score += 0.05
+			}
+
+			if score > 1 {
+				score = 1
+			}
+
+			if score >= options.MinScore {
+				candidates = append(candidates, Pair{Left: l.name, Right: r.name, Score: score})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.Score != cj.Score {
+			return ci.Score > cj.Score
+		}
+
+		if ci.Left != cj.Left {
+			return ci.Left < cj.Left
+		}
+
+		return ci.Right < cj.Right
+	})
+
+	leftUsed := map[string]bool{}
+	rightUsed := map[string]bool{}
+
+	var pairs []Pair
+
+	for _, c := range candidates {
+		if leftUsed[c.Left] || rightUsed[c.Right] {
+			continue
+		}
+
+		leftUsed[c.Left] = true
+		rightUsed[c.Right] = true
+
+		c.Diffs = diff(ls[c.Left], rs[c.Right], "", "")
+		pairs = append(pairs, c)
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Left < pairs[j].Left
+	})
+
+	return pairs
+}
+
+// object is a flattened view of an object schema.
+type object struct {
+	name  string
+	props []property
+}
+
+type property struct {
+	name     string
+	key      string
+	typ      string
+	required bool
+	object   *object
+}
+
+func objects(s *openapi3.Spec, norm func(string) string) map[string]*object {
+	res := map[string]*object{}
+
+	if s == nil || s.Components == nil || s.Components.Schemas == nil {
+		return res
+	}
+
+	for name, sr := range s.Components.Schemas.MapOfSchemaOrRefValues {
+		sr := sr
+
+		o := newObject(s, &sr, norm, map[string]bool{})
+		if o == nil || len(o.props) == 0 {
+			continue
+		}
+
+		o.name = name
+		res[name] = o
+	}
+
+	return res
+}
+
+func newObject(s *openapi3.Spec, sr *openapi3.SchemaOrRef, norm func(string) string, visited map[string]bool) *object {
+	sch, ref := resolve(s, sr)
+	if sch == nil || visited[ref] {
+		return nil
+	}
+
+	if ref != "" {
+		visited[ref] = true
+		defer delete(visited, ref)
+	}
+
+	o := &object{}
+	collectProps(s, sch, o, norm, visited)
+
+	if len(o.props) == 0 {
+		return nil
+	}
+
+	return o
+}
+
+func collectProps(s *openapi3.Spec, sch *openapi3.Schema, o *object, norm func(string) string, visited map[string]bool) {
+	for _, sr := range sch.AllOf {
+		sr := sr
+
+		if sub, _ := resolve(s, &sr); sub != nil {
+			collectProps(s, sub, o, norm, visited)
+		}
+	}
+
+	if sch.Properties == nil {
+		return
+	}
+
+	required := map[string]bool{}
+	for _, r := range sch.Required {
+		required[r] = true
+	}
+
+	for p := sch.Properties.Oldest(); p != nil; p = p.Next() {
+		sr := p.Value
+
+		prop := property{
+			name:     p.Key,
+			key:      norm(p.Key),
+			typ:      typeName(s, &sr),
+			required: required[p.Key],
+			object:   newObject(s, itemsOf(s, &sr), norm, visited),
+		}
+
+		o.props = append(o.props, prop)
+	}
+}
+
+// itemsOf returns deepest items schema of arrays.
+func itemsOf(s *openapi3.Spec, sr *openapi3.SchemaOrRef) *openapi3.SchemaOrRef {
+	for {
+		sch, _ := resolve(s, sr)
+		if sch == nil || sch.Items == nil {
+			return sr
+		}
+
+		sr = sch.Items
+	}
+}
+
+func resolve(s *openapi3.Spec, sr *openapi3.SchemaOrRef) (*openapi3.Schema, string) {
+	if sr == nil {
+		return nil, ""
+	}
+
+	if sr.SchemaReference == nil {
+		return sr.Schema, ""
+	}
+
+	ref := sr.SchemaReference.Ref
+	name := strings.TrimPrefix(ref, "#/components/schemas/")
+
+	if name == ref || s.Components == nil || s.Components.Schemas == nil {
+		return nil, ref
+	}
+
+	c, ok := s.Components.Schemas.MapOfSchemaOrRefValues[name]
+	if !ok || c.Schema == nil {
+		return nil, ref
+	}
+
+	return c.Schema, ref
+}
+
+func typeName(s *openapi3.Spec, sr *openapi3.SchemaOrRef) string {
+	sch, _ := resolve(s, sr)
+	if sch == nil {
+		return "any"
+	}
+
+	t := ""
+
+	switch {
+	case sch.Type != nil:
+		t = string(*sch.Type)
+	case sch.Properties != nil || len(sch.AllOf) > 0:
+		t = string(openapi3.SchemaTypeObject)
+	default:
+		return "any"
+	}
+
+	if t == string(openapi3.SchemaTypeArray) {
+		return t + "<" + typeName(s, sch.Items) + ">"
+	}
+
+	if sch.Format != nil && *sch.Format != "" {
+		t += "(" + *sch.Format + ")"
+	}
+
+	return t
+}
+
+// similarity is a Dice coefficient of normalized property names, properties of different types count half.
+func similarity(l, r *object) float64 {
+	rp := map[string]property{}
+	for _, p := range r.props {
+		rp[p.key] = p
+	}
+
+	matched := 0.0
+
+	for _, p := range l.props {
+		q, ok := rp[p.key]
+		if !ok {
+			continue
+		}
+
+		if baseType(p.typ) == baseType(q.typ) {
+			matched++
+		} else {
+			matched += 0.5
+		}
+	}
+
+	return 2 * matched / float64(len(l.props)+len(r.props))
+}
+
+// baseType strips format, so that "string(uuid)" and "string" are compatible for scoring.
+func baseType(t string) string {
+	if pos := strings.Index(t, "("); pos > 0 {
+		return t[:pos]
+	}
+
+	return t
+}
+
+func diff(l, r *object, lp, rp string) []FieldDiff {
+	var diffs []FieldDiff
+
+	rProps := map[string]property{}
+	for _, p := range r.props {
+		rProps[p.key] = p
+	}
+
+	seen := map[string]bool{}
+
+	for _, p := range l.props {
+		lpath := lp + p.name
+
+		q, ok := rProps[p.key]
+		if !ok {
+			diffs = append(diffs, FieldDiff{Kind: OnlyLeft, Left: lpath, LeftType: p.typ})
+
+			continue
+		}
+
+		seen[p.key] = true
+		rpath := rp + q.name
+
+		if p.name != q.name {
+			diffs = append(diffs, FieldDiff{Kind: Renamed, Left: lpath, Right: rpath, LeftType: p.typ, RightType: q.typ})
+		}
+
+		if p.typ != q.typ {
+			diffs = append(diffs, FieldDiff{Kind: TypeMismatch, Left: lpath, Right: rpath, LeftType: p.typ, RightType: q.typ})
+		}
+
+		if p.required != q.required {
+			diffs = append(diffs, FieldDiff{
+				Kind: RequiredMismatch, Left: lpath, Right: rpath, LeftType: p.typ, RightType: q.typ,
+				LeftRequired: p.required, RightRequired: q.required,
+			})
+		}
+
+		if p.object != nil && q.object != nil {
+			diffs = append(diffs, diff(p.object, q.object, lpath+arrayMarks(p.typ)+".", rpath+arrayMarks(q.typ)+".")...)
+		}
+	}
+
+	for _, q := range r.props {
+		if !seen[q.key] {
+			diffs = append(diffs, FieldDiff{Kind: OnlyRight, Right: rp + q.name, RightType: q.typ})
+		}
+	}
+
+	return diffs
+}
+
+func arrayMarks(typ string) string {
+	return strings.Repeat("[]", strings.Count(typ, "array<"))
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(name))
+}
+
+// Report formats pairs as text, a line of schema names and score is followed by indented differences.
+func Report(pairs []Pair) string {
+	var b strings.Builder
+
+	for _, p := range pairs {
+		b.WriteString(p.Left + " <-> " + p.Right + " (" + strconv.FormatFloat(p.Score, 'f', 2, 64) + ")\n")
+
+		for _, d := range p.Diffs {
+			b.WriteString("  " + d.String() + "\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package mapping_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/mapping"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestMatch(t *testing.T) {
+	ours := openapi3.Spec{}
+	require.NoError(t, ours.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Ours, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Address:
+      type: object
+      properties:
+        city: {type: string}
+        zip_code: {type: string}
+    Customer:
+      type: object
+      required: [id, email]
+      properties:
+        id: {type: string, format: uuid}
+        email: {type: string}
+        created_at: {type: string, format: date-time}
+        addresses: {type: array, items: {$ref: '#/components/schemas/Address'}}
+    Error:
+      type: object
+      properties:
+        message: {type: string}
+    Status: {type: string, enum: [active, blocked]}
+`)))
+
+	theirs := openapi3.Spec{}
+	require.NoError(t, theirs.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Theirs, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Client:
+      type: object
+      required: [id]
+      properties:
+        id: {type: integer}
+        email: {type: string}
+        createdAt: {type: string, format: date-time}
+        phone: {type: string}
+        addresses:
+          type: array
+          items:
+            type: object
+            properties:
+              city: {type: string}
+              zipCode: {type: integer}
+    Location:
+      type: object
+      properties:
+        city: {type: string}
+        zipCode: {type: string}
+        country: {type: string}
+    Problem:
+      type: object
+      properties:
+        title: {type: string}
+        detail: {type: string}
+`)))
+
+	pairs := mapping.Match(&ours, &theirs, mapping.Options{})
+	require.Len(t, pairs, 2)
+
+	assert.Equal(t, "Address", pairs[0].Left)
+	assert.Equal(t, "Location", pairs[0].Right)
+	assert.InDelta(t, 0.8, pairs[0].Score, 0.001)
+
+	assert.Equal(t, "Customer", pairs[1].Left)
+	assert.Equal(t, "Client", pairs[1].Right)
+	assert.Equal(t, mapping.FieldDiff{
+		Kind: mapping.RequiredMismatch, Left: "email", Right: "email",
+		LeftType: "string", RightType: "string", LeftRequired: true,
+	}, pairs[1].Diffs[1])
+
+	assert.Equal(t, `Address <-> Location (0.80)
+  renamed: zip_code -> zipCode
+  only-right: country string
+Customer <-> Client (0.78)
+  type-mismatch: id string(uuid) -> id integer
+  required-mismatch: email required -> email optional
+  renamed: created_at -> createdAt
+  renamed: addresses[].zip_code -> addresses[].zipCode
+  type-mismatch: addresses[].zip_code string -> addresses[].zipCode integer
+  only-right: phone string
+`, mapping.Report(pairs))
+
+	assert.Empty(t, mapping.Match(&ours, &theirs, mapping.Options{MinScore: 0.9}))
+}
@@ -0,0 +1,494 @@
+// Package mock serves fake responses of OpenAPI 3.0 document operations, e.g. for frontend development
+// against not yet implemented APIs.
+//
+// Responses are built from examples of document, or generated from schemas if there are no examples:
+//
+//	http.ListenAndServe(":8080", mock.New(spec))
+//
+// Clients select responses with Prefer header, e.g. `Prefer: code=404, example=notFound`:
+//   - `code` is a status code of documented response, default is the lowest 2xx code,
+//   - `example` is a name of example in `examples` of response media type,
+//   - `dynamic=true` generates body from schema even if examples are available.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// maxDepth limits nesting of generated values of recursive schemas.
+const maxDepth = 16
+
+// Handler serves fake responses of document operations, it is safe for concurrent use.
+type Handler struct {
+	spec  *openapi3.Spec
+	table openapi3.TelemetryTable
+}
+
+var _ http.Handler = &Handler{}
+
+// New creates a mock handler of document operations.
+//
+// Document should not be changed while handler is in use.
+func New(s *openapi3.Spec) *Handler {
+	return &Handler{spec: s, table: s.TelemetryTable()}
+}
+
+// preference is a parsed Prefer header.
+type preference struct {
+	code    string
+	example string
+	dynamic bool
+}
+
+func parsePrefer(values []string) preference {
+	var p preference
+
+	for _, v := range values {
+		for _, token := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ';' }) {
+			name, value, _ := strings.Cut(strings.TrimSpace(token), "=")
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "code":
+				p.code = value
+			case "example":
+				p.example = value
+			case "dynamic":
+				p.dynamic = value == "true"
+			}
+		}
+	}
+
+	return p
+}
+
+// ServeHTTP serves fake response of matched operation, or 404 Not Found for unknown operations.
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rt, ok := h.table.Match(r.Method, r.URL.Path)
+	if !ok {
+		http.NotFound(rw, r)
+
+		return
+	}
+
+	op := h.spec.Paths.MapOfPathItemValues[rt.Route].MapOfOperationValues[strings.ToLower(rt.Method)]
+	pref := parsePrefer(r.Header.Values("Prefer"))
+
+	status, resp, err := h.response(op.Responses, pref.code)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	for _, name := range sortedKeys(resp.Headers) {
+		if hdr := h.header(resp.Headers[name]); hdr != nil {
+			if v, ok := h.headerValue(hdr); ok {
+				rw.Header().Set(name, v)
+			}
+		}
+	}
+
+	contentType, media, ok := negotiate(resp.Content, r.Header.Get("Accept"))
+	if !ok {
+		rw.WriteHeader(status)
+
+		return
+	}
+
+	body, err := h.body(media, contentType, pref)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(status)
+	_, _ = rw.Write(body)
+}
+
+// response finds documented response by status code, ranges (e.g. 4XX) and default response are used as fallback.
+func (h *Handler) response(responses openapi3.Responses, code string) (int, *openapi3.Response, error) {
+	codes := sortedKeys(responses.MapOfResponseOrRefValues)
+
+	if code == "" {
+		for _, c := range codes {
+			if strings.HasPrefix(c, "2") {
+				code = c
+
+				break
+			}
+		}
+	}
+
+	if code == "" && len(codes) > 0 && responses.Default == nil {
+		code = codes[0]
+	}
+
+	status := http.StatusOK
+
+	if code != "" {
+		n, err := strconv.Atoi(strings.ReplaceAll(strings.ToUpper(code), "X", "0"))
+		if err != nil || n < 100 || n > 599 {
+			return 0, nil, fmt.Errorf("invalid preferred status code %q", code)
+		}
+
+		status = n
+	}
+
+	candidates := []string{code, strings.ToUpper(code[:1]) + "XX"}
+	if code == "" {
+		candidates = nil
+	}
+
+	for _, c := range candidates {
+		if rr, ok := responses.MapOfResponseOrRefValues[c]; ok {
+			if resp := h.resolveResponse(rr); resp != nil {
+				return status, resp, nil
+			}
+		}
+	}
+
+	if responses.Default != nil {
+		if resp := h.resolveResponse(*responses.Default); resp != nil {
+			return status, resp, nil
+		}
+	}
+
+	return 0, nil, fmt.Errorf("response %s is not documented", code)
+}
+
+func (h *Handler) resolveResponse(rr openapi3.ResponseOrRef) *openapi3.Response {
+	if rr.ResponseReference != nil {
+		if c := h.spec.Components; c != nil && c.Responses != nil {
+			name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+			return c.Responses.MapOfResponseOrRefValues[name].Response
+		}
+
+		return nil
+	}
+
+	return rr.Response
+}
+
+func (h *Handler) header(hr openapi3.HeaderOrRef) *openapi3.Header {
+	if hr.HeaderReference != nil {
+		if c := h.spec.Components; c != nil && c.Headers != nil {
+			name := strings.TrimPrefix(hr.HeaderReference.Ref, "#/components/headers/")
+
+			return c.Headers.MapOfHeaderOrRefValues[name].Header
+		}
+
+		return nil
+	}
+
+	return hr.Header
+}
+
+func (h *Handler) headerValue(hdr *openapi3.Header) (string, bool) {
+	var v interface{}
+
+	switch {
+	case hdr.Example != nil:
+		v = *hdr.Example
+	case hdr.Schema != nil:
+		v = h.generate(h.schema(hdr.Schema), 0)
+	default:
+		return "", false
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// negotiate selects response media type accepted by client, JSON is preferred if any type is acceptable.
+func negotiate(content map[string]openapi3.MediaType, accept string) (string, openapi3.MediaType, bool) {
+	if len(content) == 0 {
+		return "", openapi3.MediaType{}, false
+	}
+
+	types := sortedKeys(content)
+
+	sort.SliceStable(types, func(i, j int) bool {
+		return isJSON(types[i]) && !isJSON(types[j])
+	})
+
+	for _, a := range strings.Split(accept, ",") {
+		a, _, _ = strings.Cut(strings.TrimSpace(a), ";")
+
+		for _, t := range types {
+			if a == t || a == "*/*" || (strings.HasSuffix(a, "/*") && strings.HasPrefix(t, strings.TrimSuffix(a, "*"))) {
+				return t, content[t], true
+			}
+		}
+	}
+
+	return types[0], content[types[0]], true
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func (h *Handler) body(media openapi3.MediaType, contentType string, pref preference) ([]byte, error) {
+	var (
+		value interface{}
+		found bool
+	)
+
+	if !pref.dynamic {
+		value, found = h.example(media, pref.example)
+	}
+
+	if !found && pref.example != "" && !pref.dynamic {
+		return nil, fmt.Errorf("example %s is not documented", pref.example)
+	}
+
+	if !found {
+		value = h.generate(h.schema(media.Schema), 0)
+	}
+
+	if s, ok := value.(string); ok && !isJSON(contentType) {
+		return []byte(s), nil
+	}
+
+	return json.Marshal(value)
+}
+
+// example returns named or first example of media type.
+func (h *Handler) example(media openapi3.MediaType, name string) (interface{}, bool) {
+	if name == "" {
+		if media.Example != nil {
+			return *media.Example, true
+		}
+
+		if names := sortedKeys(media.Examples); len(names) > 0 {
+			name = names[0]
+		}
+	}
+
+	er, ok := media.Examples[name]
+	if !ok {
+		return nil, false
+	}
+
+	e := er.Example
+
+	if er.ExampleReference != nil {
+		e = nil
+
+		if c := h.spec.Components; c != nil && c.Examples != nil {
+			e = c.Examples.MapOfExampleOrRefValues[strings.TrimPrefix(er.ExampleReference.Ref, "#/components/examples/")].Example
+		}
+	}
+
+	if e == nil || e.Value == nil {
+		return nil, false
+	}
+
+	return *e.Value, true
+}
+
+// schema resolves local reference to component schema.
+func (h *Handler) schema(sr *openapi3.SchemaOrRef) *openapi3.Schema {
+	for i := 0; sr != nil && sr.SchemaReference != nil; i++ {
+		c := h.spec.Components
+		if c == nil || c.Schemas == nil || i > maxDepth {
+			return nil
+		}
+
+		next, ok := c.Schemas.MapOfSchemaOrRefValues[strings.TrimPrefix(sr.SchemaReference.Ref, "#/components/schemas/")]
+		if !ok {
+			return nil
+		}
+
+		sr = &next
+	}
+
+	if sr == nil {
+		return nil
+	}
+
+	return sr.Schema
+}
+
+// generate returns a fake value that satisfies schema constraints.
+func (h *Handler) generate(s *openapi3.Schema, depth int) interface{} {
+	if s == nil || depth > maxDepth {
+		return nil
+	}
+
+	switch {
+	case s.Example != nil:
+		return *s.Example
+	case s.Default != nil:
+		return *s.Default
+	case len(s.Enum) > 0:
+		return s.Enum[0]
+	}
+
+	if len(s.AllOf) > 0 {
+		res := map[string]interface{}{}
+
+		for _, sr := range s.AllOf {
+			v := h.generate(h.schema(&sr), depth+1)
+
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				return v
+			}
+
+			for k, pv := range obj {
+				res[k] = pv
+			}
+		}
+
+		return res
+	}
+
+	for _, variants := range [][]openapi3.SchemaOrRef{s.OneOf, s.AnyOf} {
+		if len(variants) > 0 {
+			return h.generate(h.schema(&variants[0]), depth+1)
+		}
+	}
+
+	switch schemaType(s) {
+	case openapi3.SchemaTypeString:
+		return fakeString(s)
+	case openapi3.SchemaTypeInteger, openapi3.SchemaTypeNumber:
+		return fakeNumber(s)
+	case openapi3.SchemaTypeBoolean:
+		return true
+	case openapi3.SchemaTypeArray:
+		n := int64(1)
+		if s.MinItems != nil && *s.MinItems > n {
+			n = *s.MinItems
+		}
+
+		res := make([]interface{}, 0, n)
+		for i := int64(0); i < n; i++ {
+			res = append(res, h.generate(h.schema(s.Items), depth+1))
+		}
+
+		return res
+	case openapi3.SchemaTypeObject:
+		res := map[string]interface{}{}
+
+		if s.Properties != nil {
+			for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+				prop := h.schema(&pair.Value)
+				if prop != nil && prop.WriteOnly != nil && *prop.WriteOnly {
+					continue
+				}
+
+				if v := h.generate(prop, depth+1); v != nil {
+					res[pair.Key] = v
+				}
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+func schemaType(s *openapi3.Schema) openapi3.SchemaType {
+	if s.Type != nil {
+		return *s.Type
+	}
+
+	if s.Properties != nil {
+		return openapi3.SchemaTypeObject
+	}
+
+	if s.Items != nil {
+		return openapi3.SchemaTypeArray
+	}
+
+	return ""
+}
+
+func fakeString(s *openapi3.Schema) string {
+	if s.Format != nil {
+		switch *s.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		case "date":
+			return "2006-01-02"
+		case "uuid":
+			return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+		case "email":
+			return "user@example.com"
+		case "uri", "url":
+			return "https://example.com/"
+		case "byte":
+			return "c3RyaW5n"
+		}
+	}
+
+	str := "string"
+
+	if s.MinLength != nil && int64(len(str)) < *s.MinLength {
+		str += strings.Repeat("x", int(*s.MinLength)-len(str))
+	}
+
+	if s.MaxLength != nil && int64(len(str)) > *s.MaxLength {
+		str = str[:*s.MaxLength]
+	}
+
+	return str
+}
+
+func fakeNumber(s *openapi3.Schema) float64 {
+	v := 0.0
+
+	if s.Minimum != nil {
+		v = *s.Minimum
+		if s.ExclusiveMinimum != nil && *s.ExclusiveMinimum {
+			v++
+		}
+	} else if s.Maximum != nil && *s.Maximum < v {
+		v = *s.Maximum
+		if s.ExclusiveMaximum != nil && *s.ExclusiveMaximum {
+			v--
+		}
+	}
+
+	if s.Type != nil && *s.Type == openapi3.SchemaTypeInteger {
+		v = math.Ceil(v)
+	}
+
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		v = math.Ceil(v / *s.MultipleOf) * *s.MultipleOf
+	}
+
+	return v
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,121 @@
+package mock_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/mock"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{id}:
+    get:
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Rate-Limit: {schema: {type: integer, minimum: 10}}
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/User'}
+        "404":
+          $ref: '#/components/responses/NotFound'
+  /users:
+    post:
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/User'}
+              examples:
+                john: {value: {id: 1, name: John}}
+                jane: {value: {id: 2, name: Jane}}
+            text/plain:
+              schema: {type: string, example: created}
+        default:
+          description: Error
+components:
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              error: {type: string, enum: [not found]}
+  schemas:
+    User:
+      type: object
+      properties:
+        id: {type: integer, minimum: 1}
+        name: {type: string, minLength: 8}
+        email: {type: string, format: email}
+        password: {type: string, writeOnly: true}
+        tags: {type: array, minItems: 2, items: {type: string}}
+`)))
+
+	h := mock.New(&s)
+
+	serve := func(method, target string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for i := 0; i < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	rw := serve(http.MethodGet, "/users/123")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.Equal(t, "10", rw.Header().Get("X-Rate-Limit"))
+	assertjson.Equal(t, []byte(`{
+	  "id":1,"name":"stringxx","email":"user@example.com","tags":["string","string"]
+	}`), rw.Body.Bytes())
+
+	rw = serve(http.MethodGet, "/users/123", "Prefer", "code=404")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+	assertjson.Equal(t, []byte(`{"error":"not found"}`), rw.Body.Bytes())
+
+	rw = serve(http.MethodGet, "/users/123", "Prefer", "code=500")
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Equal(t, "response 500 is not documented\n", rw.Body.String())
+
+	rw = serve(http.MethodPost, "/users")
+	assert.Equal(t, http.StatusCreated, rw.Code)
+	assertjson.Equal(t, []byte(`{"id":2,"name":"Jane"}`), rw.Body.Bytes())
+
+	rw = serve(http.MethodPost, "/users", "Prefer", "example=john")
+	assertjson.Equal(t, []byte(`{"id":1,"name":"John"}`), rw.Body.Bytes())
+
+	rw = serve(http.MethodPost, "/users", "Prefer", "dynamic=true")
+	assertjson.Equal(t, []byte(`{
+	  "id":1,"name":"stringxx","email":"user@example.com","tags":["string","string"]
+	}`), rw.Body.Bytes())
+
+	rw = serve(http.MethodPost, "/users", "Accept", "text/*")
+	assert.Equal(t, "text/plain", rw.Header().Get("Content-Type"))
+	assert.Equal(t, "created", rw.Body.String())
+
+	// Default response without content.
+	rw = serve(http.MethodPost, "/users", "Prefer", "code=409")
+	assert.Equal(t, http.StatusConflict, rw.Code)
+	assert.Empty(t, rw.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/orders").Code)
+}
@@ -0,0 +1,20 @@
+package openapi
+
+// NameCollisionStrategy defines how component names of distinct types with same name are resolved,
+// e.g. types `User` of packages `billing/models` and `crm/models` are both named `ModelsUser`.
+//
+// First reflected type keeps the name, strategy applies to later types.
+type NameCollisionStrategy int
+
+// NameCollisionStrategy values enumeration.
+const (
+	// NameCollisionSuffix appends a number to name, e.g. `ModelsUser2`.
+	NameCollisionSuffix = NameCollisionStrategy(iota)
+
+	// NameCollisionPackage prefixes name with parent elements of package path until it is unique,
+	// e.g. `CrmModelsUser`, types of same package get a number suffix.
+	NameCollisionPackage
+
+	// NameCollisionError fails reflection of a type with colliding name.
+	NameCollisionError
+)
@@ -0,0 +1,9 @@
+package openapi
+
+import "reflect"
+
+// SchemaNamer returns a name of component schema for a type, e.g. to strip `DTO` suffixes,
+// convert names to snake_case or prefix them with API version.
+//
+// Empty result keeps default name, that is made of package and type names.
+type SchemaNamer func(t reflect.Type) string
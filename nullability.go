@@ -0,0 +1,26 @@
+package openapi
+
+// NullabilityPolicy defines how reflected pointer fields are described.
+//
+// Client generators differ in interpretation, some map `nullable` to optional values
+// and some map optional (not required) properties to pointers, policy aligns schema with the target.
+// Explicit `nullable:"..."` and `required:"..."` field tags always take precedence over the policy.
+type NullabilityPolicy int
+
+// NullabilityPolicy values enumeration.
+const (
+	// PointerNullable makes pointer fields nullable, required properties are controlled by RequiredPolicy,
+	// this is the default.
+	PointerNullable = NullabilityPolicy(iota)
+
+	// PointerOptional makes pointer fields optional, they are not nullable and not required by RequiredPolicy.
+	PointerOptional
+
+	// PointerNullableOptional makes pointer fields nullable and not required by RequiredPolicy.
+	PointerNullableOptional
+)
+
+// PointersOptional tells if pointer fields are never required by RequiredPolicy.
+func (p NullabilityPolicy) PointersOptional() bool {
+	return p == PointerOptional || p == PointerNullableOptional
+}
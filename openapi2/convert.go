@@ -0,0 +1,606 @@
+package openapi2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+const (
+	componentsSchemas = "#/components/schemas/"
+	definitions       = "#/definitions/"
+)
+
+// simpleKeywords are schema keywords allowed in non-body parameters and headers.
+var simpleKeywords = map[string]bool{
+	"type": true, "format": true, "items": true, "default": true, "enum": true, "multipleOf": true,
+	"maximum": true, "exclusiveMaximum": true, "minimum": true, "exclusiveMinimum": true,
+	"maxLength": true, "minLength": true, "pattern": true, "maxItems": true, "minItems": true, "uniqueItems": true,
+}
+
+// Convert downgrades OpenAPI 3.0 document to Swagger 2.0.
+//
+// Features without Swagger 2.0 counterpart are approximated: cookie parameters are skipped,
+// nullable, oneOf and anyOf are exported as x- extensions, HTTP bearer security becomes an API key header.
+func Convert(s *openapi3.Spec) (*Spec, error) {
+	c := converter{spec: s}
+
+	return c.convert()
+}
+
+type converter struct {
+	spec *openapi3.Spec
+}
+
+func (c converter) convert() (*Spec, error) {
+	s := c.spec
+	res := &Spec{
+		Swagger:      "2.0",
+		Info:         s.Info,
+		Tags:         s.Tags,
+		ExternalDocs: s.ExternalDocs,
+		Security:     s.Security,
+		Paths:        map[string]PathItem{},
+	}
+
+	if len(s.Servers) > 0 {
+		if u, err := url.Parse(s.Servers[0].URL); err == nil {
+			res.Host = u.Host
+			res.BasePath = strings.TrimSuffix(u.Path, "/")
+
+			if u.Scheme != "" {
+				res.Schemes = []string{u.Scheme}
+			}
+		}
+	}
+
+	if cs := s.Components; cs != nil {
+		if cs.Schemas != nil {
+			res.Definitions = make(map[string]Schema, len(cs.Schemas.MapOfSchemaOrRefValues))
+
+			for name, sr := range cs.Schemas.MapOfSchemaOrRefValues {
+				sr := sr
+
+				schema, err := convertSchema(&sr)
+				if err != nil {
+					return nil, fmt.Errorf("schema %s: %w", name, err)
+				}
+
+				res.Definitions[name] = schema
+			}
+		}
+
+		if cs.SecuritySchemes != nil {
+			res.SecurityDefinitions = make(map[string]SecurityScheme)
+
+			for name, ss := range cs.SecuritySchemes.MapOfSecuritySchemeOrRefValues {
+				if ss.SecurityScheme != nil {
+					res.SecurityDefinitions[name] = convertSecurityScheme(*ss.SecurityScheme)
+				}
+			}
+		}
+	}
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		item := make(PathItem, len(pi.MapOfOperationValues))
+
+		for method, op := range pi.MapOfOperationValues {
+			o, err := c.operation(pi.Parameters, op)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+
+			item[method] = o
+		}
+
+		res.Paths[path] = item
+	}
+
+	return res, nil
+}
+
+func (c converter) operation(common []openapi3.ParameterOrRef, op openapi3.Operation) (Operation, error) {
+	res := Operation{
+		Tags:         op.Tags,
+		ExternalDocs: op.ExternalDocs,
+		Security:     op.Security,
+		Responses:    map[string]Response{},
+	}
+
+	if op.Summary != nil {
+		res.Summary = *op.Summary
+	}
+
+	if op.Description != nil {
+		res.Description = *op.Description
+	}
+
+	if op.ID != nil {
+		res.ID = *op.ID
+	}
+
+	if op.Deprecated != nil {
+		res.Deprecated = *op.Deprecated
+	}
+
+	for _, pr := range append(append([]openapi3.ParameterOrRef{}, common...), op.Parameters...) {
+		p, err := c.parameter(pr)
+		if err != nil {
+			return res, err
+		}
+
+		if p != nil {
+			res.Parameters = append(res.Parameters, *p)
+		}
+	}
+
+	if err := c.requestBody(&res, op.RequestBody); err != nil {
+		return res, err
+	}
+
+	responses := make(map[string]openapi3.ResponseOrRef, len(op.Responses.MapOfResponseOrRefValues)+1)
+	for code, r := range op.Responses.MapOfResponseOrRefValues {
+		responses[code] = r
+	}
+
+	if op.Responses.Default != nil {
+		responses["default"] = *op.Responses.Default
+	}
+
+	produces := map[string]bool{}
+
+	for code, rr := range responses {
+		r, err := c.response(rr, produces)
+		if err != nil {
+			return res, fmt.Errorf("response %s: %w", code, err)
+		}
+
+		res.Responses[code] = r
+	}
+
+	res.Produces = sortedKeys(produces)
+
+	return res, nil
+}
+
+func (c converter) parameter(pr openapi3.ParameterOrRef) (*Parameter, error) {
+	p := pr.Parameter
+
+	if pr.ParameterReference != nil {
+		name := strings.TrimPrefix(pr.ParameterReference.Ref, "#/components/parameters/")
+
+		if cs := c.spec.Components; cs != nil && cs.Parameters != nil {
+			p = cs.Parameters.MapOfParameterOrRefValues[name].Parameter
+		}
+
+		if p == nil {
+			return nil, fmt.Errorf("unresolved parameter reference %s", pr.ParameterReference.Ref)
+		}
+	}
+
+	if p == nil || p.In == openapi3.ParameterInCookie {
+		return nil, nil
+	}
+
+	res := Parameter{
+		Name: p.Name,
+		In:   string(p.In),
+	}
+
+	if p.Description != nil {
+		res.Description = *p.Description
+	}
+
+	if p.Required != nil {
+		res.Required = *p.Required
+	}
+
+	simple, err := c.simpleSchema(p.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+	}
+
+	if simple["type"] == "array" {
+		simple["collectionFormat"] = collectionFormat(p)
+	}
+
+	res.Simple = simple
+
+	return &res, nil
+}
+
+func collectionFormat(p *openapi3.Parameter) string {
+	explode := p.In == openapi3.ParameterInQuery
+	if p.Explode != nil {
+		explode = *p.Explode
+	}
+
+	style := ""
+	if p.Style != nil {
+		style = *p.Style
+	}
+
+	switch {
+	case style == "spaceDelimited":
+		return "ssv"
+	case style == "pipeDelimited":
+		return "pipes"
+	case explode && (p.In == openapi3.ParameterInQuery):
+		return "multi"
+	}
+
+	return "csv"
+}
+
+func (c converter) requestBody(op *Operation, rb *openapi3.RequestBodyOrRef) error {
+	if rb == nil {
+		return nil
+	}
+
+	body := rb.RequestBody
+
+	if rb.RequestBodyReference != nil {
+		name := strings.TrimPrefix(rb.RequestBodyReference.Ref, "#/components/requestBodies/")
+
+		if cs := c.spec.Components; cs != nil && cs.RequestBodies != nil {
+			body = cs.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody
+		}
+
+		if body == nil {
+			return fmt.Errorf("unresolved request body reference %s", rb.RequestBodyReference.Ref)
+		}
+	}
+
+	if body == nil || len(body.Content) == 0 {
+		return nil
+	}
+
+	op.Consumes = contentTypes(body.Content)
+
+	required := body.Required != nil && *body.Required
+
+	for _, ct := range op.Consumes {
+		if ct != "application/x-www-form-urlencoded" && ct != "multipart/form-data" {
+			continue
+		}
+
+		// Form fields are described with formData parameters.
+		return c.formData(op, body.Content[ct].Schema)
+	}
+
+	mt := body.Content[op.Consumes[0]]
+	if m, ok := body.Content["application/json"]; ok {
+		mt = m
+	}
+
+	schema, err := convertSchema(mt.Schema)
+	if err != nil {
+		return fmt.Errorf("request body: %w", err)
+	}
+
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:     "body",
+		In:       "body",
+		Required: required,
+		Schema:   schema,
+	})
+
+	return nil
+}
+
+func (c converter) formData(op *Operation, sr *openapi3.SchemaOrRef) error {
+	s := c.resolve(sr)
+	if s == nil || s.Properties == nil {
+		return nil
+	}
+
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		prop := pair.Value
+
+		simple, err := c.simpleSchema(&prop)
+		if err != nil {
+			return fmt.Errorf("form field %s: %w", pair.Key, err)
+		}
+
+		if simple["format"] == "binary" {
+			simple = Schema{"type": "file"}
+		}
+
+		p := Parameter{
+			Name:     pair.Key,
+			In:       "formData",
+			Required: required[pair.Key],
+			Simple:   simple,
+		}
+
+		if ps := c.resolve(&prop); ps != nil && ps.Description != nil {
+			p.Description = *ps.Description
+		}
+
+		if simple["type"] == "array" {
+			simple["collectionFormat"] = "multi"
+		}
+
+		op.Parameters = append(op.Parameters, p)
+	}
+
+	return nil
+}
+
+func (c converter) response(rr openapi3.ResponseOrRef, produces map[string]bool) (Response, error) {
+	r := rr.Response
+
+	if rr.ResponseReference != nil {
+		name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+		if cs := c.spec.Components; cs != nil && cs.Responses != nil {
+			r = cs.Responses.MapOfResponseOrRefValues[name].Response
+		}
+
+		if r == nil {
+			return Response{}, fmt.Errorf("unresolved response reference %s", rr.ResponseReference.Ref)
+		}
+	}
+
+	if r == nil {
+		return Response{}, nil
+	}
+
+	res := Response{Description: r.Description}
+
+	if len(r.Content) > 0 {
+		cts := contentTypes(r.Content)
+		for _, ct := range cts {
+			produces[ct] = true
+		}
+
+		mt := r.Content[cts[0]]
+		if m, ok := r.Content["application/json"]; ok {
+			mt = m
+		}
+
+		schema, err := convertSchema(mt.Schema)
+		if err != nil {
+			return res, err
+		}
+
+		res.Schema = schema
+	}
+
+	for name, hr := range r.Headers {
+		if hr.Header == nil {
+			continue
+		}
+
+		h, err := c.simpleSchema(hr.Header.Schema)
+		if err != nil {
+			return res, fmt.Errorf("header %s: %w", name, err)
+		}
+
+		if hr.Header.Description != nil {
+			h["description"] = *hr.Header.Description
+		}
+
+		if res.Headers == nil {
+			res.Headers = make(map[string]Schema)
+		}
+
+		res.Headers[name] = h
+	}
+
+	return res, nil
+}
+
+func (c converter) resolve(sr *openapi3.SchemaOrRef) *openapi3.Schema {
+	for i := 0; sr != nil && i < 10; i++ {
+		if sr.Schema != nil {
+			return sr.Schema
+		}
+
+		if sr.SchemaReference == nil || c.spec.Components == nil || c.spec.Components.Schemas == nil {
+			return nil
+		}
+
+		next, ok := c.spec.Components.Schemas.MapOfSchemaOrRefValues[strings.TrimPrefix(sr.SchemaReference.Ref, componentsSchemas)]
+		if !ok {
+			return nil
+		}
+
+		sr = &next
+	}
+
+	return nil
+}
+
+// simpleSchema inlines referenced schema and keeps keywords allowed outside of body.
+func (c converter) simpleSchema(sr *openapi3.SchemaOrRef) (Schema, error) {
+	s := c.resolve(sr)
+	if s == nil {
+		return Schema{"type": "string"}, nil
+	}
+
+	full, err := convertSchema(&openapi3.SchemaOrRef{Schema: s})
+	if err != nil {
+		return nil, err
+	}
+
+	res := Schema{}
+
+	for k, v := range full {
+		if simpleKeywords[k] || strings.HasPrefix(k, "x-") {
+			res[k] = v
+		}
+	}
+
+	if s.Items != nil {
+		items, err := c.simpleSchema(s.Items)
+		if err != nil {
+			return nil, err
+		}
+
+		res["items"] = items
+	}
+
+	if _, ok := res["type"]; !ok {
+		res["type"] = "string"
+	}
+
+	return res, nil
+}
+
+func convertSchema(sr *openapi3.SchemaOrRef) (Schema, error) {
+	if sr == nil {
+		return nil, nil
+	}
+
+	j, err := json.Marshal(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	var v map[string]interface{}
+
+	if err := json.Unmarshal(j, &v); err != nil {
+		return nil, err
+	}
+
+	return Schema(downgradeSchema(v).(map[string]interface{})), nil
+}
+
+// downgradeSchema rewrites OpenAPI 3.0 schema keywords to Swagger 2.0.
+func downgradeSchema(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(v))
+
+		for k, val := range v {
+			switch k {
+			case "$ref":
+				if ref, ok := val.(string); ok {
+					val = strings.Replace(ref, componentsSchemas, definitions, 1)
+				}
+			case "nullable", "oneOf", "anyOf", "not", "writeOnly", "deprecated":
+				k = "x-" + k
+			case "discriminator":
+				if d, ok := val.(map[string]interface{}); ok {
+					val = d["propertyName"]
+				}
+			}
+
+			if k != "$ref" {
+				val = downgradeSchema(val)
+			}
+
+			res[k] = val
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(v))
+		for i, item := range v {
+			res[i] = downgradeSchema(item)
+		}
+
+		return res
+	}
+
+	return v
+}
+
+func convertSecurityScheme(ss openapi3.SecurityScheme) SecurityScheme {
+	res := SecurityScheme{}
+
+	switch {
+	case ss.APIKeySecurityScheme != nil:
+		res.Type = "apiKey"
+		res.Name = ss.APIKeySecurityScheme.Name
+		res.In = string(ss.APIKeySecurityScheme.In)
+		res.Description = deref(ss.APIKeySecurityScheme.Description)
+	case ss.HTTPSecurityScheme != nil:
+		res.Description = deref(ss.HTTPSecurityScheme.Description)
+
+		if strings.EqualFold(ss.HTTPSecurityScheme.Scheme, "basic") {
+			res.Type = "basic"
+		} else {
+			res.Type = "apiKey"
+			res.Name = "Authorization"
+			res.In = "header"
+		}
+	case ss.OAuth2SecurityScheme != nil:
+		res.Type = "oauth2"
+		res.Description = deref(ss.OAuth2SecurityScheme.Description)
+
+		f := ss.OAuth2SecurityScheme.Flows
+
+		switch {
+		case f.AuthorizationCode != nil:
+			res.Flow = "accessCode"
+			res.AuthorizationURL = f.AuthorizationCode.AuthorizationURL
+			res.TokenURL = f.AuthorizationCode.TokenURL
+			res.Scopes = f.AuthorizationCode.Scopes
+		case f.Implicit != nil:
+			res.Flow = "implicit"
+			res.AuthorizationURL = f.Implicit.AuthorizationURL
+			res.Scopes = f.Implicit.Scopes
+		case f.Password != nil:
+			res.Flow = "password"
+			res.TokenURL = f.Password.TokenURL
+			res.Scopes = f.Password.Scopes
+		case f.ClientCredentials != nil:
+			res.Flow = "application"
+			res.TokenURL = f.ClientCredentials.TokenURL
+			res.Scopes = f.ClientCredentials.Scopes
+		}
+	case ss.OpenIDConnectSecurityScheme != nil:
+		// OpenID Connect discovery is not supported by Swagger 2.0, token is passed as a header.
+		res.Type = "apiKey"
+		res.Name = "Authorization"
+		res.In = "header"
+		res.Description = deref(ss.OpenIDConnectSecurityScheme.Description)
+	}
+
+	return res
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func sortedKeys(m map[string]bool) []string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func contentTypes(content map[string]openapi3.MediaType) []string {
+	keys := make([]string, 0, len(content))
+	for k := range content {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,126 @@
+// Package openapi2 provides entities and helpers to manage Swagger 2.0 schema.
+package openapi2
+
+import (
+	"encoding/json"
+
+	"github.com/goccy/go-yaml"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Spec is a Swagger 2.0 document.
+//
+// Info, Tag and ExternalDocumentation objects have the same shape as in OpenAPI 3.0.
+type Spec struct {
+	Swagger             string                          `json:"swagger"`
+	Info                openapi3.Info                   `json:"info"`
+	Host                string                          `json:"host,omitempty"`
+	BasePath            string                          `json:"basePath,omitempty"`
+	Schemes             []string                        `json:"schemes,omitempty"`
+	Consumes            []string                        `json:"consumes,omitempty"`
+	Produces            []string                        `json:"produces,omitempty"`
+	Paths               map[string]PathItem             `json:"paths"`
+	Definitions         map[string]Schema               `json:"definitions,omitempty"`
+	SecurityDefinitions map[string]SecurityScheme       `json:"securityDefinitions,omitempty"`
+	Security            []map[string][]string           `json:"security,omitempty"`
+	Tags                []openapi3.Tag                  `json:"tags,omitempty"`
+	ExternalDocs        *openapi3.ExternalDocumentation `json:"externalDocs,omitempty"`
+}
+
+// MarshalYAML produces YAML bytes.
+func (s *Spec) MarshalYAML() ([]byte, error) {
+	j, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.JSONToYAML(j)
+}
+
+// PathItem maps lower case HTTP methods to operations.
+type PathItem map[string]Operation
+
+// Operation describes an API operation.
+type Operation struct {
+	Tags         []string                        `json:"tags,omitempty"`
+	Summary      string                          `json:"summary,omitempty"`
+	Description  string                          `json:"description,omitempty"`
+	ExternalDocs *openapi3.ExternalDocumentation `json:"externalDocs,omitempty"`
+	ID           string                          `json:"operationId,omitempty"`
+	Consumes     []string                        `json:"consumes,omitempty"`
+	Produces     []string                        `json:"produces,omitempty"`
+	Parameters   []Parameter                     `json:"parameters,omitempty"`
+	Responses    map[string]Response             `json:"responses"`
+	Deprecated   bool                            `json:"deprecated,omitempty"`
+	Security     []map[string][]string           `json:"security,omitempty"`
+}
+
+type marshalOperation Operation
+
+// MarshalJSON encodes operation, empty non-nil Security is kept as `[]` to opt out of document security.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	if o.Security == nil || len(o.Security) > 0 {
+		return json.Marshal(marshalOperation(o))
+	}
+
+	return json.Marshal(struct {
+		marshalOperation
+		Security []map[string][]string `json:"security"`
+	}{marshalOperation: marshalOperation(o), Security: o.Security})
+}
+
+// Parameter describes an operation parameter.
+//
+// Schema is used for body parameters, other parameters keep type and validation keywords in Simple.
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema,omitempty"`
+	Simple      Schema `json:"-"`
+}
+
+type marshalParameter Parameter
+
+// MarshalJSON encodes parameter with inlined type and validation keywords.
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	res := make(map[string]interface{}, len(p.Simple)+5)
+
+	for k, v := range p.Simple {
+		res[k] = v
+	}
+
+	j, err := json.Marshal(marshalParameter(p))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(j, &res); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(res)
+}
+
+// Response describes an operation response.
+type Response struct {
+	Description string            `json:"description"`
+	Schema      Schema            `json:"schema,omitempty"`
+	Headers     map[string]Schema `json:"headers,omitempty"`
+}
+
+// SecurityScheme describes a security definition.
+type SecurityScheme struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	In               string            `json:"in,omitempty"`
+	Flow             string            `json:"flow,omitempty"`
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes,omitempty"`
+}
+
+// Schema is a JSON Schema subset of Swagger 2.0.
+type Schema map[string]interface{}
@@ -0,0 +1,26 @@
+package openapi2
+
+import (
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Reflector builds Swagger 2.0 documents from Go request and response structures.
+//
+// Operations are reflected with embedded openapi3.Reflector, so the same structures and field tags apply,
+// the OpenAPI 3.0 document is converted to Swagger 2.0 with Swagger method.
+type Reflector struct {
+	openapi3.Reflector
+}
+
+// NewReflector creates an instance of Swagger 2.0 reflector.
+func NewReflector() *Reflector {
+	r := &Reflector{}
+	r.SpecEns()
+
+	return r
+}
+
+// Swagger converts reflected operations to Swagger 2.0 document.
+func (r *Reflector) Swagger() (*Spec, error) {
+	return Convert(r.SpecEns())
+}
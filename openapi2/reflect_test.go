@@ -0,0 +1,123 @@
+package openapi2_test
+
+import (
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi2"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestReflector_Swagger(t *testing.T) {
+	r := openapi2.NewReflector()
+	r.SpecEns().Info.WithTitle("Things API").WithVersion("1.2.3")
+	r.SpecEns().WithServers(openapi3.Server{URL: "https://api.example.com/v1"})
+	r.SpecEns().SetHTTPBasicSecurity("basicAuth", "Admin access.")
+
+	type thing struct {
+		ID   int     `json:"id"`
+		Name *string `json:"name"`
+	}
+
+	type updateReq struct {
+		ID    int      `path:"id"`
+		Tags  []string `query:"tags"`
+		Trace string   `header:"X-Trace"`
+		Name  string   `json:"name" required:"true"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/things/{id}")
+	require.NoError(t, err)
+	oc.SetID("updateThing")
+	oc.AddReqStructure(updateReq{})
+	oc.AddRespStructure(thing{})
+	oc.AddRespStructure(nil, openapi.WithHTTPStatus(http.StatusNotFound))
+	oc.AddSecurity("basicAuth")
+	require.NoError(t, r.AddOperation(oc))
+
+	type uploadReq struct {
+		Title  string                `formData:"title"`
+		Upload *multipart.FileHeader `formData:"upload"`
+	}
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/files")
+	require.NoError(t, err)
+	oc.AddReqStructure(uploadReq{})
+	require.NoError(t, r.AddOperation(oc))
+
+	s, err := r.Swagger()
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "swagger":"2.0","info":{"title":"Things API","version":"1.2.3"},
+	  "host":"api.example.com","basePath":"/v1","schemes":["https"],
+	  "paths":{
+		"/files":{
+		  "post":{
+			"consumes":["multipart/form-data"],
+			"parameters":[
+			  {"in":"formData","name":"title","type":"string"},
+			  {"in":"formData","name":"upload","type":"file"}
+			],
+			"responses":{"204":{"description":"No Content"}}
+		  }
+		},
+		"/things/{id}":{
+		  "put":{
+			"operationId":"updateThing","consumes":["application/json"],"produces":["application/json"],
+			"parameters":[
+			  {"collectionFormat":"multi","in":"query","items":{"type":"string"},"name":"tags","type":"array"},
+			  {"in":"path","name":"id","required":true,"type":"integer"},
+			  {"in":"header","name":"X-Trace","type":"string"},
+			  {
+				"in":"body","name":"body",
+				"schema":{"$ref":"#/definitions/Openapi2TestUpdateReq"}
+			  }
+			],
+			"responses":{
+			  "200":{"description":"OK","schema":{"$ref":"#/definitions/Openapi2TestThing"}},
+			  "404":{"description":"Not Found"}
+			},
+			"security":[{"basicAuth":[]}]
+		  }
+		}
+	  },
+	  "definitions":{
+		"FormDataOpenapi2TestUploadReq":{
+		  "properties":{"title":{"type":"string"},"upload":{"$ref":"#/definitions/MultipartFileHeader"}},
+		  "type":"object"
+		},
+		"MultipartFileHeader":{"format":"binary","type":"string"},
+		"Openapi2TestThing":{
+		  "properties":{"id":{"type":"integer"},"name":{"type":"string","x-nullable":true}},
+		  "type":"object"
+		},
+		"Openapi2TestUpdateReq":{
+		  "properties":{"name":{"type":"string"}},"required":["name"],"type":"object"
+		}
+	  },
+	  "securityDefinitions":{"basicAuth":{"description":"Admin access.","type":"basic"}}
+	}`, s)
+}
+
+func TestConvert_securityOptOut(t *testing.T) {
+	s := openapi3.Spec{Openapi: "3.0.3", Security: []map[string][]string{{"basicAuth": {}}}}
+	s.Info.WithTitle("Things API").WithVersion("1.2.3")
+
+	require.NoError(t, s.AddOperation(http.MethodGet, "/health", openapi3.Operation{
+		Security: []map[string][]string{},
+	}))
+
+	sw, err := openapi2.Convert(&s)
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{
+	  "swagger":"2.0","info":{"title":"Things API","version":"1.2.3"},
+	  "paths":{"/health":{"get":{"responses":{"204":{"description":"No Content"}},"security":[]}}},
+	  "security":[{"basicAuth":[]}]
+	}`, sw)
+}
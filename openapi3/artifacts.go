@@ -0,0 +1,274 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArtifactsConfig controls contents of Reflector.Artifacts.
+type ArtifactsConfig struct {
+	// SplitByTag adds `tags/{tag}.json` and `tags/{tag}.yaml` documents with operations of a single tag.
+	SplitByTag bool
+
+	// DocsHTML renders `index.html` for a relative path of JSON document, no page is added if nil.
+	DocsHTML func(specPath string) []byte
+}
+
+// Artifacts returns a read-only file system with generated documents.
+//
+// It contains `openapi.json` and `openapi.yaml`, and optionally per-tag documents and a docs page,
+// so that a build system can copy a single tree.
+func (r *Reflector) Artifacts(options ...func(cfg *ArtifactsConfig)) (fs.FS, error) {
+	cfg := ArtifactsConfig{}
+
+	for _, o := range options {
+		o(&cfg)
+	}
+
+	files := map[string][]byte{}
+
+	if err := addSpecFiles(files, "openapi", r.SpecEns()); err != nil {
+		return nil, err
+	}
+
+	if cfg.SplitByTag {
+		for _, tag := range r.SpecEns().operationTags() {
+			s, err := r.SpecEns().filterByTag(tag)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := addSpecFiles(files, "tags/"+artifactName(tag), s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cfg.DocsHTML != nil {
+		files["index.html"] = cfg.DocsHTML("openapi.json")
+	}
+
+	return artifactFS(files), nil
+}
+
+func addSpecFiles(files map[string][]byte, name string, s *Spec) error {
+	j, err := s.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	y, err := jSONToYAML(j)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, j, "", "  "); err != nil {
+		return err
+	}
+
+	files[name+".json"] = buf.Bytes()
+	files[name+".yaml"] = y
+
+	return nil
+}
+
+var artifactNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+func artifactName(tag string) string {
+	return strings.Trim(artifactNameSanitizer.ReplaceAllString(tag, "-"), "-.")
+}
+
+func (s *Spec) operationTags() []string {
+	seen := map[string]bool{}
+
+	for _, pi := range s.Paths.MapOfPathItemValues {
+		for _, op := range pi.MapOfOperationValues {
+			for _, tag := range op.Tags {
+				seen[tag] = true
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
+
+// filterByTag returns a copy of spec with operations of a tag, components are kept as is.
+func (s *Spec) filterByTag(tag string) (*Spec, error) {
+	j, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &Spec{}
+	if err := cp.UnmarshalJSON(j); err != nil {
+		return nil, err
+	}
+
+	for p, pi := range cp.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			if !hasTag(op.Tags, tag) {
+				delete(pi.MapOfOperationValues, method)
+			}
+		}
+
+		if len(pi.MapOfOperationValues) == 0 {
+			delete(cp.Paths.MapOfPathItemValues, p)
+		}
+	}
+
+	for _, t := range cp.Tags {
+		if t.Name == tag {
+			cp.Tags = []Tag{t}
+
+			return cp, nil
+		}
+	}
+
+	cp.Tags = nil
+
+	return cp, nil
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// artifactFS is an in-memory file system of generated files keyed by slash-separated paths.
+type artifactFS map[string][]byte
+
+// Open implements fs.FS.
+func (a artifactFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if data, ok := a[name]; ok {
+		return &artifactFile{info: artifactInfo{name: path.Base(name), size: int64(len(data))}, r: bytes.NewReader(data)}, nil
+	}
+
+	var entries []fs.DirEntry
+
+	seen := map[string]bool{}
+	prefix := name + "/"
+
+	if name == "." {
+		prefix = ""
+	}
+
+	for p, data := range a {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(p, prefix)
+		if pos := strings.Index(rest, "/"); pos >= 0 {
+			rest = rest[:pos]
+			if !seen[rest] {
+				entries = append(entries, artifactInfo{name: rest, dir: true})
+			}
+		} else {
+			entries = append(entries, artifactInfo{name: rest, size: int64(len(data))})
+		}
+
+		seen[rest] = true
+	}
+
+	if len(entries) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	return &artifactDir{info: artifactInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+type artifactInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i artifactInfo) Name() string               { return i.name }
+func (i artifactInfo) Size() int64                { return i.size }
+func (i artifactInfo) ModTime() time.Time         { return time.Time{} }
+func (i artifactInfo) IsDir() bool                { return i.dir }
+func (i artifactInfo) Sys() interface{}           { return nil }
+func (i artifactInfo) Type() fs.FileMode          { return i.Mode().Type() }
+func (i artifactInfo) Info() (fs.FileInfo, error) { return i, nil }
+
+func (i artifactInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+
+	return 0o444
+}
+
+type artifactFile struct {
+	info artifactInfo
+	r    *bytes.Reader
+}
+
+func (f *artifactFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *artifactFile) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *artifactFile) Close() error               { return nil }
+
+type artifactDir struct {
+	info    artifactInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *artifactDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *artifactDir) Close() error               { return nil }
+
+func (d *artifactDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *artifactDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+
+	if n <= 0 {
+		d.offset = len(d.entries)
+
+		return rest, nil
+	}
+
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+
+	if n > len(rest) {
+		n = len(rest)
+	}
+
+	d.offset += n
+
+	return rest[:n], nil
+}
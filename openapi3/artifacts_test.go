@@ -0,0 +1,57 @@
+package openapi3_test
+
+import (
+	"io/fs"
+	"net/http"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestReflector_Artifacts(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.SpecEns().Info.WithTitle("Things API").WithVersion("1.0.0")
+
+	for _, tag := range []string{"Things", "User accounts"} {
+		oc, err := r.NewOperationContext(http.MethodGet, "/"+tag)
+		require.NoError(t, err)
+		oc.SetTags(tag)
+		require.NoError(t, r.AddOperation(oc))
+	}
+
+	a, err := r.Artifacts(func(cfg *openapi3.ArtifactsConfig) {
+		cfg.SplitByTag = true
+		cfg.DocsHTML = func(specPath string) []byte {
+			return []byte(`<redoc spec-url="` + specPath + `"></redoc>`)
+		}
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, fstest.TestFS(a,
+		"index.html", "openapi.json", "openapi.yaml",
+		"tags/Things.json", "tags/Things.yaml", "tags/User-accounts.json", "tags/User-accounts.yaml",
+	))
+
+	y, err := fs.ReadFile(a, "tags/Things.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.0.3
+info:
+  title: Things API
+  version: 1.0.0
+paths:
+  /Things:
+    get:
+      tags:
+      - Things
+      responses:
+        "204":
+          description: No Content
+`, string(y))
+
+	j, err := fs.ReadFile(a, "openapi.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(j), "\n  \"paths\": {\n")
+}
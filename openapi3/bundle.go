@@ -0,0 +1,254 @@
+package openapi3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// Bundle pulls externally referenced components into `components` of spec and rewrites references,
+// so that document is self-contained.
+//
+// External path items are inlined, as OpenAPI 3.0 has no reusable path items.
+// Names of components are taken from last token of JSON Pointer (or document name),
+// and suffixed with a number if taken by a different component.
+func Bundle(spec *Spec, loader *Loader) error {
+	return BundleContext(context.Background(), spec, loader)
+}
+
+// BundleContext bundles spec until context is done, see Bundle.
+func BundleContext(ctx context.Context, spec *Spec, loader *Loader) error {
+	data, err := spec.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	var doc map[string]interface{}
+
+	// Numbers are kept exact with json.Number, JSONCodec has no option for that.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	if err := d.Decode(&doc); err != nil {
+		return err
+	}
+
+	b := bundler{
+		ctx:    ctx,
+		loader: loader,
+		doc:    doc,
+		refs:   map[string]string{},
+	}
+
+	for k, v := range doc {
+		if doc[k], err = b.walk(v, "", []string{k}); err != nil {
+			return err
+		}
+	}
+
+	if data, err = openapi.JSON.Marshal(doc); err != nil {
+		return err
+	}
+
+	keyOrder := spec.keyOrder
+
+	if err := spec.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	spec.keyOrder = keyOrder
+
+	return nil
+}
+
+type bundler struct {
+	ctx    context.Context
+	loader *Loader
+	doc    map[string]interface{}
+	refs   map[string]string // Local references by absolute external references.
+}
+
+func (b *bundler) walk(v interface{}, base string, at []string) (interface{}, error) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := x["$ref"].(string); ok {
+			return b.ref(ref, base, at)
+		}
+
+		for k, item := range x {
+			next, err := b.walk(item, base, append(at[:len(at):len(at)], k))
+			if err != nil {
+				return nil, err
+			}
+
+			x[k] = next
+		}
+	case []interface{}:
+		for i, item := range x {
+			next, err := b.walk(item, base, append(at[:len(at):len(at)], strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+
+			x[i] = next
+		}
+	}
+
+	return v, nil
+}
+
+func (b *bundler) ref(ref, base string, at []string) (interface{}, error) {
+	if base == "" && strings.HasPrefix(ref, "#") {
+		return map[string]interface{}{"$ref": ref}, nil
+	}
+
+	if err := b.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r, err := b.loader.Resolve(b.ctx, base, ref)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s at %s: %w", ref, pointerOf(at), err)
+	}
+
+	kind, err := componentKind(at, r.Pointer)
+	if err != nil {
+		return nil, fmt.Errorf("bundle %s at %s: %w", ref, pointerOf(at), err)
+	}
+
+	if kind == "" { // Path item.
+		return b.walk(deepCopy(r.Value), r.Location, at)
+	}
+
+	if local, ok := b.refs[r.Ref()]; ok {
+		return map[string]interface{}{"$ref": local}, nil
+	}
+
+	components := b.components(kind)
+	name := componentName(r)
+
+	for i := 2; ; i++ {
+		if _, taken := components[name]; !taken {
+			break
+		}
+
+		name = componentName(r) + strconv.Itoa(i)
+	}
+
+	local := "#/components/" + kind + "/" + internal.EscapePointerToken(name)
+	b.refs[r.Ref()] = local
+	components[name] = nil // Reserved for recursive references.
+
+	if components[name], err = b.walk(deepCopy(r.Value), r.Location, []string{"components", kind, name}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"$ref": local}, nil
+}
+
+func (b *bundler) components(kind string) map[string]interface{} {
+	c, ok := b.doc["components"].(map[string]interface{})
+	if !ok {
+		c = map[string]interface{}{}
+		b.doc["components"] = c
+	}
+
+	m, ok := c[kind].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		c[kind] = m
+	}
+
+	return m
+}
+
+var componentPointerRegex = regexp.MustCompile(`^/components/([a-zA-Z]+)/[^/]+$`)
+
+// componentKind returns components section for a reference at location in document,
+// empty kind means path item.
+func componentKind(at []string, pointer string) (string, error) {
+	if m := componentPointerRegex.FindStringSubmatch(pointer); m != nil {
+		return m[1], nil
+	}
+
+	last, parent := at[len(at)-1], ""
+	if len(at) > 1 {
+		parent = at[len(at)-2]
+	}
+
+	if len(at) == 2 && parent == "paths" {
+		return "", nil
+	}
+
+	switch parent {
+	case "properties", "allOf", "oneOf", "anyOf", "schemas":
+		return "schemas", nil
+	case "parameters", "responses", "headers", "examples", "links", "callbacks", "securitySchemes", "requestBodies":
+		return parent, nil
+	}
+
+	switch last {
+	case "schema", "items", "not", "additionalProperties":
+		return "schemas", nil
+	case "requestBody":
+		return "requestBodies", nil
+	}
+
+	return "", fmt.Errorf("unexpected reference location")
+}
+
+var invalidComponentNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+
+// componentName derives name from last token of JSON Pointer or from document name.
+func componentName(r ResolvedRef) string {
+	name := r.Pointer[strings.LastIndex(r.Pointer, "/")+1:]
+	name = internal.UnescapePointerToken(name)
+
+	if name == "" {
+		name = path.Base(r.Location)
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	return invalidComponentNameChars.ReplaceAllString(name, "_")
+}
+
+func pointerOf(at []string) string {
+	tokens := make([]string, 0, len(at))
+
+	for _, t := range at {
+		tokens = append(tokens, internal.EscapePointerToken(t))
+	}
+
+	return "/" + strings.Join(tokens, "/")
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(x))
+
+		for k, item := range x {
+			m[k] = deepCopy(item)
+		}
+
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(x))
+
+		for i, item := range x {
+			s[i] = deepCopy(item)
+		}
+
+		return s
+	default:
+		return v
+	}
+}
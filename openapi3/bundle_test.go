@@ -0,0 +1,143 @@
+package openapi3_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "common"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "errors.yaml"), []byte(`
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        code: {type: integer}
+        details:
+          type: array
+          items: {$ref: '#/components/schemas/Error'}
+  responses:
+    NotFound:
+      description: Not found
+      content:
+        application/json:
+          schema: {$ref: '#/components/schemas/Error'}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "params.yaml"), []byte(`
+Limit: {name: limit, in: query, schema: {type: integer}}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.yaml"), []byte(`
+get:
+  parameters:
+    - $ref: 'common/params.yaml#/Limit'
+  responses:
+    "200":
+      description: OK
+      content:
+        application/json:
+          schema: {$ref: 'user.json'}
+    "404": {$ref: 'common/errors.yaml#/components/responses/NotFound'}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.json"), []byte(`{"type": "object"}`), 0o600))
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users:
+    $ref: 'users.yaml'
+  /things:
+    get:
+      responses:
+        "500":
+          description: Failure
+          content:
+            application/json:
+              schema: {$ref: 'common/errors.yaml#/components/schemas/Error'}
+        default: {$ref: '#/components/responses/Failure'}
+components:
+  schemas:
+    Error: {type: string}
+  responses:
+    Failure: {description: Failure}
+`)))
+
+	require.NoError(t, openapi3.Bundle(&s, openapi3.NewLoader(openapi3.FileLoader{Dir: dir})))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"API","version":"1.0.0"},
+	  "paths":{
+		"/users":{
+		  "get":{
+			"parameters":[{"$ref":"#/components/parameters/Limit"}],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/user"}}}
+			  },
+			  "404":{"$ref":"#/components/responses/NotFound"}
+			}
+		  }
+		},
+		"/things":{
+		  "get":{
+			"responses":{
+			  "500":{
+				"description":"Failure",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error2"}}}
+			  },
+			  "default":{"$ref":"#/components/responses/Failure"}
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Error":{"type":"string"},
+		  "Error2":{
+			"type":"object",
+			"properties":{
+			  "code":{"type":"integer"},
+			  "details":{"type":"array","items":{"$ref":"#/components/schemas/Error2"}}
+			}
+		  },
+		  "user":{"type":"object"}
+		},
+		"responses":{
+		  "Failure":{"description":"Failure"},
+		  "NotFound":{
+			"description":"Not found",
+			"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error2"}}}
+		  }
+		},
+		"parameters":{"Limit":{"name":"limit","in":"query","schema":{"type":"integer"}}}
+	  }
+	}`, s)
+}
+
+func TestBundle_unknownDocument(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things:
+    get:
+      responses:
+        "200": {$ref: 'missing.yaml#/OK'}
+`)))
+
+	err := openapi3.Bundle(&s, openapi3.NewLoader(openapi3.FileLoader{Dir: t.TempDir()}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bundle missing.yaml#/OK at /paths/~1things/get/responses/200: load missing.yaml: ")
+}
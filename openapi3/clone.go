@@ -0,0 +1,169 @@
+package openapi3
+
+import (
+	"reflect"
+
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+var propertiesType = reflect.TypeOf((*orderedmap.OrderedMap[string, SchemaOrRef])(nil))
+
+// Clone returns a deep copy of spec, e.g. to derive a filtered variant without changing original.
+//
+// Values of extensions, examples and defaults are copied with their Go types, order of properties,
+// order of paths and components and order of keys of loaded document are kept.
+func (s *Spec) Clone() *Spec {
+	if s == nil {
+		return nil
+	}
+
+	c := cloneValue(reflect.ValueOf(s)).Interface().(*Spec) //nolint:forcetypeassert // Type is preserved.
+
+	// Orders are not modified in place, so they can be shared.
+	c.Paths.order = s.Paths.order
+
+	if s.Components != nil {
+		cloneComponentsOrder(c.Components, s.Components)
+	}
+
+	if s.keyOrder != nil {
+		c.keyOrder = make(map[string][]string, len(s.keyOrder))
+
+		for k, keys := range s.keyOrder {
+			c.keyOrder[k] = append([]string(nil), keys...)
+		}
+	}
+
+	return c
+}
+
+func cloneComponentsOrder(dst, src *Components) {
+	if src.Schemas != nil {
+		dst.Schemas.order = src.Schemas.order
+	}
+
+	if src.Responses != nil {
+		dst.Responses.order = src.Responses.order
+	}
+
+	if src.Parameters != nil {
+		dst.Parameters.order = src.Parameters.order
+	}
+
+	if src.Examples != nil {
+		dst.Examples.order = src.Examples.order
+	}
+
+	if src.RequestBodies != nil {
+		dst.RequestBodies.order = src.RequestBodies.order
+	}
+
+	if src.Headers != nil {
+		dst.Headers.order = src.Headers.order
+	}
+
+	if src.SecuritySchemes != nil {
+		dst.SecuritySchemes.order = src.SecuritySchemes.order
+	}
+
+	if src.Links != nil {
+		dst.Links.order = src.Links.order
+	}
+
+	if src.Callbacks != nil {
+		dst.Callbacks.order = src.Callbacks.order
+	}
+}
+
+// Clone returns a deep copy of schema.
+func (s *Schema) Clone() *Schema {
+	if s == nil {
+		return nil
+	}
+
+	return cloneValue(reflect.ValueOf(s)).Interface().(*Schema) //nolint:forcetypeassert // Type is preserved.
+}
+
+// cloneValue copies exported data of a value recursively.
+func cloneValue(v reflect.Value) reflect.Value {
+	t := v.Type()
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds are copied by value.
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		if t == propertiesType {
+			return reflect.ValueOf(cloneProperties(v.Interface().(*orderedmap.OrderedMap[string, SchemaOrRef])))
+		}
+
+		c := reflect.New(t.Elem())
+		c.Elem().Set(cloneValue(v.Elem()))
+
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		c := reflect.New(t).Elem()
+		c.Set(cloneValue(v.Elem()))
+
+		return c
+	case reflect.Struct:
+		c := reflect.New(t).Elem()
+
+		for i := 0; i < t.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() {
+				f.Set(cloneValue(v.Field(i)))
+			}
+		}
+
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		c := reflect.MakeSlice(t, v.Len(), v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		c := reflect.MakeMapWithSize(t, v.Len())
+
+		for iter := v.MapRange(); iter.Next(); {
+			c.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+
+		return c
+	case reflect.Array:
+		c := reflect.New(t).Elem()
+
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneValue(v.Index(i)))
+		}
+
+		return c
+	default:
+		return v
+	}
+}
+
+func cloneProperties(p *orderedmap.OrderedMap[string, SchemaOrRef]) *orderedmap.OrderedMap[string, SchemaOrRef] {
+	c := orderedmap.New[string, SchemaOrRef]()
+
+	for pair := p.Oldest(); pair != nil; pair = pair.Next() {
+		c.Set(pair.Key, cloneValue(reflect.ValueOf(pair.Value)).Interface().(SchemaOrRef)) //nolint:forcetypeassert
+	}
+
+	return c
+}
@@ -0,0 +1,76 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_Clone(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0, x-team: {name: core}}
+paths:
+  /things:
+    get:
+      tags: [things]
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        zeta: {type: string}
+        alpha: {type: integer}
+`)))
+
+	before, err := s.MarshalYAML()
+	require.NoError(t, err)
+
+	c := s.Clone()
+
+	c.Info.Title = "Clone"
+	c.Info.MapOfAnything["x-team"].(map[string]interface{})["name"] = "edge"
+	c.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["get"].Tags[0] = "changed"
+	delete(c.Paths.MapOfPathItemValues, "/things")
+	c.Components.Schemas.MapOfSchemaOrRefValues["Thing"].Schema.Properties.Delete("zeta")
+
+	after, err := s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"Clone","version":"1.0.0","x-team":{"name":"edge"}},"paths":{},
+	  "components":{"schemas":{"Thing":{"type":"object","properties":{"alpha":{"type":"integer"}}}}}
+	}`, c)
+}
+
+func TestSchema_Clone(t *testing.T) {
+	var def interface{} = int64(10)
+
+	s := (&openapi3.Schema{}).WithType(openapi3.SchemaTypeObject).
+		WithPropertiesItem("b", openapi3.SchemaOrRef{Schema: (&openapi3.Schema{Default: &def}).WithType(openapi3.SchemaTypeInteger)}).
+		WithPropertiesItem("a", openapi3.SchemaOrRef{SchemaReference: &openapi3.SchemaReference{Ref: "#/components/schemas/A"}})
+
+	c := s.Clone()
+
+	b, found := c.Properties.Get("b")
+	require.True(t, found)
+	assert.Equal(t, int64(10), *b.Schema.Default)
+	assert.NotSame(t, b.Schema, s.Properties.GetPair("b").Value.Schema)
+
+	*b.Schema.Type = openapi3.SchemaTypeString
+	assert.Equal(t, openapi3.SchemaTypeInteger, *s.Properties.GetPair("b").Value.Schema.Type)
+
+	keys := make([]string, 0)
+	for pair := c.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		keys = append(keys, pair.Key)
+	}
+
+	assert.Equal(t, []string{"b", "a"}, keys)
+}
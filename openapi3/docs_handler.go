@@ -0,0 +1,229 @@
+package openapi3
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go/docs"
+)
+
+// UI is a renderer of documentation pages.
+type UI string
+
+// Supported documentation renderers.
+const (
+	SwaggerUI = UI("swagger-ui")
+	ReDoc     = UI("redoc")
+	Stoplight = UI("stoplight")
+)
+
+// DocsOption configures documentation handler.
+type DocsOption func(h *docsHandler)
+
+// DocsUI selects documentation renderer, default SwaggerUI.
+func DocsUI(ui UI) DocsOption {
+	return func(h *docsHandler) {
+		h.ui = ui
+	}
+}
+
+// DocsTitle sets title of documentation page, default is a title of document.
+func DocsTitle(title string) DocsOption {
+	return func(h *docsHandler) {
+		h.title = title
+	}
+}
+
+// DocsAssetsURL sets base URL of renderer scripts and styles, e.g. to serve them from own host,
+// default is a pinned release of renderer on unpkg.com.
+//
+// Assets are expected with file names of renderer distribution, e.g. `swagger-ui-bundle.js`
+// of `swagger-ui-dist` or `redoc.standalone.js`.
+func DocsAssetsURL(baseURL string) DocsOption {
+	return func(h *docsHandler) {
+		h.assetsURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// DocsAssetIntegrity sets subresource integrity hashes of assets by file name,
+// e.g. "swagger-ui-bundle.js": "sha384-...", so that browser rejects modified scripts and styles.
+func DocsAssetIntegrity(hashes map[string]string) DocsOption {
+	return func(h *docsHandler) {
+		h.integrity = hashes
+	}
+}
+
+// NewDocsHandler creates a handler of documentation page and document of spec.
+//
+// Routes are relative to handler mount point, use http.StripPrefix to mount it under a path:
+//   - `/` is a documentation page,
+//   - `/openapi.json` is a document in JSON,
+//   - `/openapi.yaml` is a document in YAML.
+//
+// For example:
+//
+//	http.Handle("/docs/", http.StripPrefix("/docs", openapi3.NewDocsHandler(spec, openapi3.DocsUI(openapi3.ReDoc))))
+//
+// Document is marshaled on every request, so that changes of spec are visible without restart.
+func NewDocsHandler(s *Spec, options ...DocsOption) http.Handler {
+	h := &docsHandler{spec: s, ui: SwaggerUI}
+
+	for _, o := range options {
+		o(h)
+	}
+
+	if h.assetsURL == "" {
+		h.assetsURL = defaultAssetsURLs[h.ui]
+	}
+
+	return h
+}
+
+type docsHandler struct {
+	spec      *Spec
+	ui        UI
+	title     string
+	assetsURL string
+	integrity map[string]string
+}
+
+// defaultAssetsURLs are pinned to exact releases, so that page does not change with a new release of renderer.
+var defaultAssetsURLs = map[UI]string{
+	SwaggerUI: "https://unpkg.com/swagger-ui-dist@5.17.14",
+	ReDoc:     "https://unpkg.com/redoc@2.1.5/bundles",
+	Stoplight: "https://unpkg.com/@stoplight/elements@8.0.0",
+}
+
+var docsTemplates = map[UI]*template.Template{
+	SwaggerUI: template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css"{{with index .Integrity "swagger-ui.css"}} integrity="{{.}}" crossorigin="anonymous"{{end}}>
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="{{.AssetsURL}}/swagger-ui-bundle.js"{{with index .Integrity "swagger-ui-bundle.js"}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+<script>
+window.ui = SwaggerUIBundle({url: {{.SpecURL}}, dom_id: "#swagger-ui", deepLinking: true});
+</script>
+</body>
+</html>
+`)),
+	ReDoc: template.Must(template.New("redoc").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<redoc spec-url="{{.SpecURL}}"></redoc>
+<script src="{{.AssetsURL}}/redoc.standalone.js"{{with index .Integrity "redoc.standalone.js"}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+</body>
+</html>
+`)),
+	Stoplight: template.Must(template.New("stoplight").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<link rel="stylesheet" href="{{.AssetsURL}}/styles.min.css"{{with index .Integrity "styles.min.css"}} integrity="{{.}}" crossorigin="anonymous"{{end}}>
+<script src="{{.AssetsURL}}/web-components.min.js"{{with index .Integrity "web-components.min.js"}} integrity="{{.}}" crossorigin="anonymous"{{end}}></script>
+</head>
+<body>
+<elements-api apiDescriptionUrl="{{.SpecURL}}" router="hash" layout="sidebar"></elements-api>
+</body>
+</html>
+`)),
+}
+
+// ServeHTTP serves documentation page and document.
+func (h *docsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	switch strings.TrimPrefix(r.URL.Path, "/") {
+	case "":
+		h.servePage(rw, r)
+	case "openapi.json":
+		docs.ServeSpec(rw, r, specProvider{spec: h.spec}, "openapi.json", false)
+	case "openapi.yaml", "openapi.yml":
+		docs.ServeSpec(rw, r, specProvider{spec: h.spec}, "openapi.yaml", true)
+	default:
+		http.NotFound(rw, r)
+	}
+}
+
+// specProvider marshals spec on every request, document name is a file name of format.
+type specProvider struct {
+	spec *Spec
+}
+
+func (p specProvider) Names(_ context.Context) ([]string, error) {
+	return []string{"openapi.json", "openapi.yaml"}, nil
+}
+
+func (p specProvider) Spec(_ context.Context, name string) ([]byte, error) {
+	switch name {
+	case "openapi.json":
+		return p.spec.MarshalJSON()
+	case "openapi.yaml":
+		return p.spec.MarshalYAML()
+	}
+
+	return nil, docs.ErrNotFound
+}
+
+func (h *docsHandler) servePage(rw http.ResponseWriter, r *http.Request) {
+	tmpl, ok := docsTemplates[h.ui]
+	if !ok {
+		http.Error(rw, "unknown documentation UI: "+string(h.ui), http.StatusInternalServerError)
+
+		return
+	}
+
+	data := struct {
+		Title     string
+		AssetsURL string
+		Integrity map[string]string
+		SpecURL   string
+	}{
+		Title:     h.title,
+		AssetsURL: h.assetsURL,
+		Integrity: h.integrity,
+		SpecURL:   "./openapi.json",
+	}
+
+	if data.Title == "" {
+		data.Title = h.spec.Info.Title
+	}
+
+	if r.URL.Path == "" {
+		// Handler is mounted without trailing slash, relative links need the last path segment.
+		uri := r.RequestURI
+		if pos := strings.IndexAny(uri, "?#"); pos >= 0 {
+			uri = uri[:pos]
+		}
+
+		data.SpecURL = "./" + uri[strings.LastIndex(uri, "/")+1:] + "/openapi.json"
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = rw.Write(buf.Bytes())
+}
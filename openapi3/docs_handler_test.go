@@ -0,0 +1,59 @@
+package openapi3_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestNewDocsHandler(t *testing.T) {
+	s := &openapi3.Spec{Openapi: "3.0.3"}
+	s.Info.WithTitle("Users API").WithVersion("1.0.0")
+
+	serve := func(h http.Handler, method, target string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(method, target, nil))
+
+		return rw
+	}
+
+	h := http.StripPrefix("/docs", openapi3.NewDocsHandler(s))
+
+	rw := serve(h, http.MethodGet, "/docs/")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "<title>Users API</title>")
+	assert.Contains(t, rw.Body.String(), `<script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"></script>`)
+	assert.Contains(t, rw.Body.String(), `SwaggerUIBundle({url: "./openapi.json", dom_id: "#swagger-ui"`)
+
+	rw = serve(h, http.MethodGet, "/docs/openapi.json")
+	assert.Equal(t, "application/json; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"Users API","version":"1.0.0"},"paths":{}}`, rw.Body.String())
+
+	rw = serve(h, http.MethodGet, "/docs/openapi.yaml")
+	assert.Equal(t, "application/yaml; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "title: Users API")
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/docs/openapi.json?version=3.1").Code)
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/docs/unknown").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(h, http.MethodPost, "/docs/").Code)
+
+	h = http.StripPrefix("/docs", openapi3.NewDocsHandler(s,
+		openapi3.DocsUI(openapi3.ReDoc),
+		openapi3.DocsTitle("Reference"),
+		openapi3.DocsAssetsURL("/static/redoc/"),
+		openapi3.DocsAssetIntegrity(map[string]string{"redoc.standalone.js": "sha384-abc"}),
+	))
+
+	rw = serve(h, http.MethodGet, "/docs")
+	assert.Contains(t, rw.Body.String(), "<title>Reference</title>")
+	assert.Contains(t, rw.Body.String(), `<redoc spec-url="./docs/openapi.json"></redoc>`)
+	assert.Contains(t, rw.Body.String(), `<script src="/static/redoc/redoc.standalone.js" integrity="sha384-abc" crossorigin="anonymous"></script>`)
+
+	rw = serve(openapi3.NewDocsHandler(s, openapi3.DocsUI(openapi3.Stoplight)), http.MethodGet, "/")
+	assert.Contains(t, rw.Body.String(), `<elements-api apiDescriptionUrl="./openapi.json" router="hash" layout="sidebar"></elements-api>`)
+}
@@ -0,0 +1,46 @@
+package openapi3_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_Encode(t *testing.T) {
+	s := openapi3.Spec{Openapi: "3.0.3"}
+	s.Info.WithTitle("API").WithVersion("1.0.0").WithDescription(`Returns <b>"Tom & Jerry"</b> \u003c`)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, s.Encode(&buf))
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"API",`+
+		`"description":"Returns \u003cb\u003e\"Tom \u0026 Jerry\"\u003c/b\u003e \\u003c",`+
+		`"version":"1.0.0"},"paths":{}}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, s.Encode(&buf, openapi3.WithEncodeIndent("  "), openapi3.WithEncodeEscapeHTML(false),
+		openapi3.WithEncodeTrailingNewline()))
+	assert.Equal(t, `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "API",
+    "description": "Returns <b>\"Tom & Jerry\"</b> \\u003c",
+    "version": "1.0.0"
+  },
+  "paths": {}
+}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, s.Encode(&buf, openapi3.WithEncodeYAML()))
+	assert.Equal(t, `openapi: 3.0.3
+info:
+  title: API
+  description: "Returns <b>\"Tom & Jerry\"</b> \\u003c"
+  version: 1.0.0
+paths: {}
+`, buf.String())
+}
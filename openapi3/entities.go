@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/swaggest/openapi-go"
 	orderedmap "github.com/wk8/go-ordered-map/v2"
 	"reflect"
 	"regexp"
@@ -26,6 +27,8 @@ type Spec struct {
 	Paths         Paths                  `json:"paths"` // Required.
 	Components    *Components            `json:"components,omitempty"`
 	MapOfAnything map[string]interface{} `json:"-"` // Key must match pattern: `^x-`.
+
+	keyOrder map[string][]string // Order of keys of loaded document.
 }
 
 // WithOpenapi sets Openapi value.
@@ -136,14 +139,14 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSpec(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -170,7 +173,7 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -194,13 +197,19 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = Spec(ms)
+	s.keyOrder = recordKeyOrder(data)
 
 	return nil
 }
 
 // MarshalJSON encodes JSON.
 func (s Spec) MarshalJSON() ([]byte, error) {
-	return marshalUnion(marshalSpec(s), s.MapOfAnything)
+	j, err := marshalUnion(marshalSpec(s), s.MapOfAnything)
+	if err != nil || s.keyOrder == nil {
+		return j, err
+	}
+
+	return applyKeyOrder(s.keyOrder, j)
 }
 
 // Info structure is generated from "#/definitions/Info".
@@ -307,14 +316,14 @@ func (i *Info) UnmarshalJSON(data []byte) error {
 
 	mi := marshalInfo(*i)
 
-	err = json.Unmarshal(data, &mi)
+	err = openapi.JSON.Unmarshal(data, &mi)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -341,7 +350,7 @@ func (i *Info) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -431,14 +440,14 @@ func (c *Contact) UnmarshalJSON(data []byte) error {
 
 	mc := marshalContact(*c)
 
-	err = json.Unmarshal(data, &mc)
+	err = openapi.JSON.Unmarshal(data, &mc)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -459,7 +468,7 @@ func (c *Contact) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -545,14 +554,14 @@ func (l *License) UnmarshalJSON(data []byte) error {
 
 	ml := marshalLicense(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -579,7 +588,7 @@ func (l *License) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -667,14 +676,14 @@ func (e *ExternalDocumentation) UnmarshalJSON(data []byte) error {
 
 	me := marshalExternalDocumentation(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -701,7 +710,7 @@ func (e *ExternalDocumentation) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -806,14 +815,14 @@ func (s *Server) UnmarshalJSON(data []byte) error {
 
 	ms := marshalServer(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -840,7 +849,7 @@ func (s *Server) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -934,14 +943,14 @@ func (s *ServerVariable) UnmarshalJSON(data []byte) error {
 
 	ms := marshalServerVariable(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -968,7 +977,7 @@ func (s *ServerVariable) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1071,14 +1080,14 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 
 	mt := marshalTag(*t)
 
-	err = json.Unmarshal(data, &mt)
+	err = openapi.JSON.Unmarshal(data, &mt)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1105,7 +1114,7 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1229,14 +1238,14 @@ func (p *PathItem) UnmarshalJSON(data []byte) error {
 
 	mp := marshalPathItem(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1257,7 +1266,7 @@ func (p *PathItem) UnmarshalJSON(data []byte) error {
 
 			var val Operation
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1274,7 +1283,7 @@ func (p *PathItem) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1336,14 +1345,14 @@ func (p *ParameterReference) UnmarshalJSON(data []byte) error {
 
 	mp := marshalParameterReference(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1578,24 +1587,24 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 
 	mp := marshalParameter(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(data, &mp.SchemaXORContent)
+	err = openapi.JSON.Unmarshal(data, &mp.SchemaXORContent)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(data, &mp.Location)
+	err = openapi.JSON.Unmarshal(data, &mp.Location)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1629,7 +1638,7 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2045,14 +2054,14 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSchema(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2087,7 +2096,7 @@ func (s *Schema) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2149,14 +2158,14 @@ func (s *SchemaReference) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSchemaReference(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2229,7 +2238,7 @@ func (s *SchemaOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.Schema)
+	err = openapi.JSON.Unmarshal(data, &s.Schema)
 	if err != nil {
 		oneOfErrors["Schema"] = err
 		s.Schema = nil
@@ -2237,7 +2246,7 @@ func (s *SchemaOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.SchemaReference)
+	err = openapi.JSON.Unmarshal(data, &s.SchemaReference)
 	if err != nil {
 		oneOfErrors["SchemaReference"] = err
 		s.SchemaReference = nil
@@ -2291,7 +2300,7 @@ func (s *SchemaAdditionalProperties) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.SchemaOrRef)
+	err = openapi.JSON.Unmarshal(data, &s.SchemaOrRef)
 	if err != nil {
 		oneOfErrors["SchemaOrRef"] = err
 		s.SchemaOrRef = nil
@@ -2299,7 +2308,7 @@ func (s *SchemaAdditionalProperties) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.Bool)
+	err = openapi.JSON.Unmarshal(data, &s.Bool)
 	if err != nil {
 		oneOfErrors["Bool"] = err
 		s.Bool = nil
@@ -2360,14 +2369,14 @@ func (d *Discriminator) UnmarshalJSON(data []byte) error {
 
 	md := marshalDiscriminator(*d)
 
-	err = json.Unmarshal(data, &md)
+	err = openapi.JSON.Unmarshal(data, &md)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2456,14 +2465,14 @@ func (x *XML) UnmarshalJSON(data []byte) error {
 
 	mx := marshalXML(*x)
 
-	err = json.Unmarshal(data, &mx)
+	err = openapi.JSON.Unmarshal(data, &mx)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2484,7 +2493,7 @@ func (x *XML) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2613,14 +2622,14 @@ func (m *MediaType) UnmarshalJSON(data []byte) error {
 
 	mm := marshalMediaType(*m)
 
-	err = json.Unmarshal(data, &mm)
+	err = openapi.JSON.Unmarshal(data, &mm)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2648,7 +2657,7 @@ func (m *MediaType) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2710,14 +2719,14 @@ func (e *ExampleReference) UnmarshalJSON(data []byte) error {
 
 	me := marshalExampleReference(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2812,14 +2821,14 @@ func (e *Example) UnmarshalJSON(data []byte) error {
 
 	me := marshalExample(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2847,7 +2856,7 @@ func (e *Example) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2923,7 +2932,7 @@ func (e *ExampleOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &e.ExampleReference)
+	err = openapi.JSON.Unmarshal(data, &e.ExampleReference)
 	if err != nil {
 		oneOfErrors["ExampleReference"] = err
 		e.ExampleReference = nil
@@ -2931,7 +2940,7 @@ func (e *ExampleOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &e.Example)
+	err = openapi.JSON.Unmarshal(data, &e.Example)
 	if err != nil {
 		oneOfErrors["Example"] = err
 		e.Example = nil
@@ -3017,14 +3026,14 @@ func (e *Encoding) UnmarshalJSON(data []byte) error {
 
 	me := marshalEncoding(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3193,14 +3202,14 @@ func (h *Header) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHeader(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3234,7 +3243,7 @@ func (h *Header) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3295,14 +3304,14 @@ func (h *HasSchema) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHasSchema(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3345,14 +3354,14 @@ func (h *HasContent) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHasContent(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3412,14 +3421,14 @@ func (s *SchemaXORContent) UnmarshalJSON(data []byte) error {
 
 	var not SchemaXORContentNot
 
-	if json.Unmarshal(data, &not) == nil {
+	if openapi.JSON.Unmarshal(data, &not) == nil {
 		return errors.New("not constraint failed for SchemaXORContent")
 	}
 
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.HasSchema)
+	err = openapi.JSON.Unmarshal(data, &s.HasSchema)
 	if err != nil {
 		oneOfErrors["HasSchema"] = err
 		s.HasSchema = nil
@@ -3427,7 +3436,7 @@ func (s *SchemaXORContent) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.HasContent)
+	err = openapi.JSON.Unmarshal(data, &s.HasContent)
 	if err != nil {
 		oneOfErrors["HasContent"] = err
 		s.HasContent = nil
@@ -3478,14 +3487,14 @@ func (s *SchemaXORContentNot) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSchemaXORContentNot(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3528,14 +3537,14 @@ func (p *PathParameter) UnmarshalJSON(data []byte) error {
 
 	mp := marshalPathParameter(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3594,14 +3603,14 @@ func (q *QueryParameter) UnmarshalJSON(data []byte) error {
 
 	mq := marshalQueryParameter(*q)
 
-	err = json.Unmarshal(data, &mq)
+	err = openapi.JSON.Unmarshal(data, &mq)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3638,7 +3647,7 @@ func (h *HeaderParameter) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3679,7 +3688,7 @@ func (c *CookieParameter) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3784,7 +3793,7 @@ func (p *ParameterLocation) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 4)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &p.PathParameter)
+	err = openapi.JSON.Unmarshal(data, &p.PathParameter)
 	if err != nil {
 		oneOfErrors["PathParameter"] = err
 		p.PathParameter = nil
@@ -3792,7 +3801,7 @@ func (p *ParameterLocation) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.QueryParameter)
+	err = openapi.JSON.Unmarshal(data, &p.QueryParameter)
 	if err != nil {
 		oneOfErrors["QueryParameter"] = err
 		p.QueryParameter = nil
@@ -3800,7 +3809,7 @@ func (p *ParameterLocation) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.HeaderParameter)
+	err = openapi.JSON.Unmarshal(data, &p.HeaderParameter)
 	if err != nil {
 		oneOfErrors["HeaderParameter"] = err
 		p.HeaderParameter = nil
@@ -3808,7 +3817,7 @@ func (p *ParameterLocation) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.CookieParameter)
+	err = openapi.JSON.Unmarshal(data, &p.CookieParameter)
 	if err != nil {
 		oneOfErrors["CookieParameter"] = err
 		p.CookieParameter = nil
@@ -3871,7 +3880,7 @@ func (p *ParameterOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &p.ParameterReference)
+	err = openapi.JSON.Unmarshal(data, &p.ParameterReference)
 	if err != nil {
 		oneOfErrors["ParameterReference"] = err
 		p.ParameterReference = nil
@@ -3879,7 +3888,7 @@ func (p *ParameterOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.Parameter)
+	err = openapi.JSON.Unmarshal(data, &p.Parameter)
 	if err != nil {
 		oneOfErrors["Parameter"] = err
 		p.Parameter = nil
@@ -4061,14 +4070,14 @@ func (o *Operation) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOperation(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4095,7 +4104,7 @@ func (o *Operation) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4157,14 +4166,14 @@ func (r *RequestBodyReference) UnmarshalJSON(data []byte) error {
 
 	mr := marshalRequestBodyReference(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4266,14 +4275,14 @@ func (r *RequestBody) UnmarshalJSON(data []byte) error {
 
 	mr := marshalRequestBody(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4300,7 +4309,7 @@ func (r *RequestBody) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4376,7 +4385,7 @@ func (r *RequestBodyOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &r.RequestBodyReference)
+	err = openapi.JSON.Unmarshal(data, &r.RequestBodyReference)
 	if err != nil {
 		oneOfErrors["RequestBodyReference"] = err
 		r.RequestBodyReference = nil
@@ -4384,7 +4393,7 @@ func (r *RequestBodyOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &r.RequestBody)
+	err = openapi.JSON.Unmarshal(data, &r.RequestBody)
 	if err != nil {
 		oneOfErrors["RequestBody"] = err
 		r.RequestBody = nil
@@ -4472,14 +4481,14 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 	mr := marshalResponses(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4500,7 +4509,7 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 			var val ResponseOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4517,7 +4526,7 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4579,14 +4588,14 @@ func (r *ResponseReference) UnmarshalJSON(data []byte) error {
 
 	mr := marshalResponseReference(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4718,14 +4727,14 @@ func (r *Response) UnmarshalJSON(data []byte) error {
 
 	mr := marshalResponse(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4752,7 +4761,7 @@ func (r *Response) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4814,14 +4823,14 @@ func (h *HeaderReference) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHeaderReference(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4894,7 +4903,7 @@ func (h *HeaderOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &h.HeaderReference)
+	err = openapi.JSON.Unmarshal(data, &h.HeaderReference)
 	if err != nil {
 		oneOfErrors["HeaderReference"] = err
 		h.HeaderReference = nil
@@ -4902,7 +4911,7 @@ func (h *HeaderOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &h.Header)
+	err = openapi.JSON.Unmarshal(data, &h.Header)
 	if err != nil {
 		oneOfErrors["Header"] = err
 		h.Header = nil
@@ -4951,14 +4960,14 @@ func (l *LinkReference) UnmarshalJSON(data []byte) error {
 
 	ml := marshalLinkReference(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5089,20 +5098,20 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 	var not LinkNot
 
-	if json.Unmarshal(data, &not) == nil {
+	if openapi.JSON.Unmarshal(data, &not) == nil {
 		return errors.New("not constraint failed for Link")
 	}
 
 	ml := marshalLink(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5130,7 +5139,7 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5196,14 +5205,14 @@ func (l *LinkNot) UnmarshalJSON(data []byte) error {
 
 	ml := marshalLinkNot(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5262,7 +5271,7 @@ func (l *LinkOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &l.LinkReference)
+	err = openapi.JSON.Unmarshal(data, &l.LinkReference)
 	if err != nil {
 		oneOfErrors["LinkReference"] = err
 		l.LinkReference = nil
@@ -5270,7 +5279,7 @@ func (l *LinkOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &l.Link)
+	err = openapi.JSON.Unmarshal(data, &l.Link)
 	if err != nil {
 		oneOfErrors["Link"] = err
 		l.Link = nil
@@ -5333,7 +5342,7 @@ func (r *ResponseOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &r.ResponseReference)
+	err = openapi.JSON.Unmarshal(data, &r.ResponseReference)
 	if err != nil {
 		oneOfErrors["ResponseReference"] = err
 		r.ResponseReference = nil
@@ -5341,7 +5350,7 @@ func (r *ResponseOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &r.Response)
+	err = openapi.JSON.Unmarshal(data, &r.Response)
 	if err != nil {
 		oneOfErrors["Response"] = err
 		r.Response = nil
@@ -5390,14 +5399,14 @@ func (c *CallbackReference) UnmarshalJSON(data []byte) error {
 
 	mc := marshalCallbackReference(*c)
 
-	err = json.Unmarshal(data, &mc)
+	err = openapi.JSON.Unmarshal(data, &mc)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5473,7 +5482,7 @@ func (c *Callback) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5490,7 +5499,7 @@ func (c *Callback) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5510,7 +5519,7 @@ func (c *Callback) UnmarshalJSON(data []byte) error {
 
 		var val PathItem
 
-		err = json.Unmarshal(rawValue, &val)
+		err = openapi.JSON.Unmarshal(rawValue, &val)
 		if err != nil {
 			return err
 		}
@@ -5569,7 +5578,7 @@ func (c *CallbackOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &c.CallbackReference)
+	err = openapi.JSON.Unmarshal(data, &c.CallbackReference)
 	if err != nil {
 		oneOfErrors["CallbackReference"] = err
 		c.CallbackReference = nil
@@ -5577,7 +5586,7 @@ func (c *CallbackOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &c.Callback)
+	err = openapi.JSON.Unmarshal(data, &c.Callback)
 	if err != nil {
 		oneOfErrors["Callback"] = err
 		c.Callback = nil
@@ -5601,6 +5610,8 @@ func (c CallbackOrRef) MarshalJSON() ([]byte, error) {
 type Paths struct {
 	MapOfPathItemValues map[string]PathItem    `json:"-"` // Key must match pattern: `^\/`.
 	MapOfAnything       map[string]interface{} `json:"-"` // Key must match pattern: `^x-`.
+
+	order []string // Insertion order of path items.
 }
 
 // WithMapOfPathItemValues sets MapOfPathItemValues value.
@@ -5615,6 +5626,10 @@ func (p *Paths) WithMapOfPathItemValuesItem(key string, val PathItem) *Paths {
 		p.MapOfPathItemValues = make(map[string]PathItem, 1)
 	}
 
+	if _, found := p.MapOfPathItemValues[key]; !found {
+		p.order = appendKey(p.order, key)
+	}
+
 	p.MapOfPathItemValues[key] = val
 
 	return p
@@ -5643,7 +5658,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5660,7 +5675,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 			var val PathItem
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5677,7 +5692,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5705,7 +5720,12 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (p Paths) MarshalJSON() ([]byte, error) {
-	return marshalUnion(p.MapOfPathItemValues, p.MapOfAnything)
+	j, err := marshalUnion(p.MapOfPathItemValues, p.MapOfAnything)
+	if err != nil || len(p.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": p.order}, j)
 }
 
 // Components structure is generated from "#/definitions/Components".
@@ -5894,14 +5914,14 @@ func (c *Components) UnmarshalJSON(data []byte) error {
 
 	mc := marshalComponents(*c)
 
-	err = json.Unmarshal(data, &mc)
+	err = openapi.JSON.Unmarshal(data, &mc)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5922,7 +5942,7 @@ func (c *Components) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5958,6 +5978,8 @@ func (c Components) MarshalJSON() ([]byte, error) {
 // ComponentsSchemas structure is generated from "#/definitions/Components->schemas".
 type ComponentsSchemas struct {
 	MapOfSchemaOrRefValues map[string]SchemaOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfSchemaOrRefValues sets MapOfSchemaOrRefValues value.
@@ -5972,6 +5994,10 @@ func (c *ComponentsSchemas) WithMapOfSchemaOrRefValuesItem(key string, val Schem
 		c.MapOfSchemaOrRefValues = make(map[string]SchemaOrRef, 1)
 	}
 
+	if _, found := c.MapOfSchemaOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfSchemaOrRefValues[key] = val
 
 	return c
@@ -5983,7 +6009,7 @@ func (c *ComponentsSchemas) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6000,7 +6026,7 @@ func (c *ComponentsSchemas) UnmarshalJSON(data []byte) error {
 
 			var val SchemaOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6018,12 +6044,19 @@ func (c *ComponentsSchemas) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsSchemas) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfSchemaOrRefValues)
+	j, err := marshalUnion(c.MapOfSchemaOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsResponses structure is generated from "#/definitions/Components->responses".
 type ComponentsResponses struct {
 	MapOfResponseOrRefValues map[string]ResponseOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfResponseOrRefValues sets MapOfResponseOrRefValues value.
@@ -6038,6 +6071,10 @@ func (c *ComponentsResponses) WithMapOfResponseOrRefValuesItem(key string, val R
 		c.MapOfResponseOrRefValues = make(map[string]ResponseOrRef, 1)
 	}
 
+	if _, found := c.MapOfResponseOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfResponseOrRefValues[key] = val
 
 	return c
@@ -6049,7 +6086,7 @@ func (c *ComponentsResponses) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6066,7 +6103,7 @@ func (c *ComponentsResponses) UnmarshalJSON(data []byte) error {
 
 			var val ResponseOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6084,12 +6121,19 @@ func (c *ComponentsResponses) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsResponses) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfResponseOrRefValues)
+	j, err := marshalUnion(c.MapOfResponseOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsParameters structure is generated from "#/definitions/Components->parameters".
 type ComponentsParameters struct {
 	MapOfParameterOrRefValues map[string]ParameterOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfParameterOrRefValues sets MapOfParameterOrRefValues value.
@@ -6104,6 +6148,10 @@ func (c *ComponentsParameters) WithMapOfParameterOrRefValuesItem(key string, val
 		c.MapOfParameterOrRefValues = make(map[string]ParameterOrRef, 1)
 	}
 
+	if _, found := c.MapOfParameterOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfParameterOrRefValues[key] = val
 
 	return c
@@ -6115,7 +6163,7 @@ func (c *ComponentsParameters) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6132,7 +6180,7 @@ func (c *ComponentsParameters) UnmarshalJSON(data []byte) error {
 
 			var val ParameterOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6150,12 +6198,19 @@ func (c *ComponentsParameters) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsParameters) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfParameterOrRefValues)
+	j, err := marshalUnion(c.MapOfParameterOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsExamples structure is generated from "#/definitions/Components->examples".
 type ComponentsExamples struct {
 	MapOfExampleOrRefValues map[string]ExampleOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfExampleOrRefValues sets MapOfExampleOrRefValues value.
@@ -6170,6 +6225,10 @@ func (c *ComponentsExamples) WithMapOfExampleOrRefValuesItem(key string, val Exa
 		c.MapOfExampleOrRefValues = make(map[string]ExampleOrRef, 1)
 	}
 
+	if _, found := c.MapOfExampleOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfExampleOrRefValues[key] = val
 
 	return c
@@ -6181,7 +6240,7 @@ func (c *ComponentsExamples) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6198,7 +6257,7 @@ func (c *ComponentsExamples) UnmarshalJSON(data []byte) error {
 
 			var val ExampleOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6216,12 +6275,19 @@ func (c *ComponentsExamples) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsExamples) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfExampleOrRefValues)
+	j, err := marshalUnion(c.MapOfExampleOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsRequestBodies structure is generated from "#/definitions/Components->requestBodies".
 type ComponentsRequestBodies struct {
 	MapOfRequestBodyOrRefValues map[string]RequestBodyOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfRequestBodyOrRefValues sets MapOfRequestBodyOrRefValues value.
@@ -6236,6 +6302,10 @@ func (c *ComponentsRequestBodies) WithMapOfRequestBodyOrRefValuesItem(key string
 		c.MapOfRequestBodyOrRefValues = make(map[string]RequestBodyOrRef, 1)
 	}
 
+	if _, found := c.MapOfRequestBodyOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfRequestBodyOrRefValues[key] = val
 
 	return c
@@ -6247,7 +6317,7 @@ func (c *ComponentsRequestBodies) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6264,7 +6334,7 @@ func (c *ComponentsRequestBodies) UnmarshalJSON(data []byte) error {
 
 			var val RequestBodyOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6282,12 +6352,19 @@ func (c *ComponentsRequestBodies) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsRequestBodies) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfRequestBodyOrRefValues)
+	j, err := marshalUnion(c.MapOfRequestBodyOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsHeaders structure is generated from "#/definitions/Components->headers".
 type ComponentsHeaders struct {
 	MapOfHeaderOrRefValues map[string]HeaderOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfHeaderOrRefValues sets MapOfHeaderOrRefValues value.
@@ -6302,6 +6379,10 @@ func (c *ComponentsHeaders) WithMapOfHeaderOrRefValuesItem(key string, val Heade
 		c.MapOfHeaderOrRefValues = make(map[string]HeaderOrRef, 1)
 	}
 
+	if _, found := c.MapOfHeaderOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfHeaderOrRefValues[key] = val
 
 	return c
@@ -6313,7 +6394,7 @@ func (c *ComponentsHeaders) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6330,7 +6411,7 @@ func (c *ComponentsHeaders) UnmarshalJSON(data []byte) error {
 
 			var val HeaderOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6348,7 +6429,12 @@ func (c *ComponentsHeaders) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsHeaders) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfHeaderOrRefValues)
+	j, err := marshalUnion(c.MapOfHeaderOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // SecuritySchemeReference structure is generated from "#/definitions/SecuritySchemeReference".
@@ -6380,14 +6466,14 @@ func (s *SecuritySchemeReference) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeReference(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6481,14 +6567,14 @@ func (a *APIKeySecurityScheme) UnmarshalJSON(data []byte) error {
 
 	ma := marshalAPIKeySecurityScheme(*a)
 
-	err = json.Unmarshal(data, &ma)
+	err = openapi.JSON.Unmarshal(data, &ma)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6521,7 +6607,7 @@ func (a *APIKeySecurityScheme) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6652,7 +6738,7 @@ func (h *HTTPSecurityScheme) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHTTPSecurityScheme(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
@@ -6660,7 +6746,7 @@ func (h *HTTPSecurityScheme) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &mh.Bearer)
+	err = openapi.JSON.Unmarshal(data, &mh.Bearer)
 	if err != nil {
 		oneOfErrors["Bearer"] = err
 		mh.Bearer = nil
@@ -6668,7 +6754,7 @@ func (h *HTTPSecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &mh.NonBearer)
+	err = openapi.JSON.Unmarshal(data, &mh.NonBearer)
 	if err != nil {
 		oneOfErrors["NonBearer"] = err
 		mh.NonBearer = nil
@@ -6682,7 +6768,7 @@ func (h *HTTPSecurityScheme) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6715,7 +6801,7 @@ func (h *HTTPSecurityScheme) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -6764,7 +6850,7 @@ func (b *Bearer) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6801,7 +6887,7 @@ func (b *NonBearer) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6880,14 +6966,14 @@ func (o *OAuth2SecurityScheme) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOAuth2SecurityScheme(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -6920,7 +7006,7 @@ func (o *OAuth2SecurityScheme) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7057,14 +7143,14 @@ func (o *OAuthFlows) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOAuthFlows(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7085,7 +7171,7 @@ func (o *OAuthFlows) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7193,14 +7279,14 @@ func (i *ImplicitOAuthFlow) UnmarshalJSON(data []byte) error {
 
 	mi := marshalImplicitOAuthFlow(*i)
 
-	err = json.Unmarshal(data, &mi)
+	err = openapi.JSON.Unmarshal(data, &mi)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7227,7 +7313,7 @@ func (i *ImplicitOAuthFlow) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7334,14 +7420,14 @@ func (p *PasswordOAuthFlow) UnmarshalJSON(data []byte) error {
 
 	mp := marshalPasswordOAuthFlow(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7368,7 +7454,7 @@ func (p *PasswordOAuthFlow) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7475,14 +7561,14 @@ func (c *ClientCredentialsFlow) UnmarshalJSON(data []byte) error {
 
 	mc := marshalClientCredentialsFlow(*c)
 
-	err = json.Unmarshal(data, &mc)
+	err = openapi.JSON.Unmarshal(data, &mc)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7509,7 +7595,7 @@ func (c *ClientCredentialsFlow) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7627,14 +7713,14 @@ func (a *AuthorizationCodeOAuthFlow) UnmarshalJSON(data []byte) error {
 
 	ma := marshalAuthorizationCodeOAuthFlow(*a)
 
-	err = json.Unmarshal(data, &ma)
+	err = openapi.JSON.Unmarshal(data, &ma)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7661,7 +7747,7 @@ func (a *AuthorizationCodeOAuthFlow) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7751,14 +7837,14 @@ func (o *OpenIDConnectSecurityScheme) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOpenIDConnectSecurityScheme(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -7791,7 +7877,7 @@ func (o *OpenIDConnectSecurityScheme) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -7902,7 +7988,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 4)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.APIKeySecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.APIKeySecurityScheme)
 	if err != nil {
 		oneOfErrors["APIKeySecurityScheme"] = err
 		s.APIKeySecurityScheme = nil
@@ -7910,7 +7996,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.HTTPSecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.HTTPSecurityScheme)
 	if err != nil {
 		oneOfErrors["HTTPSecurityScheme"] = err
 		s.HTTPSecurityScheme = nil
@@ -7918,7 +8004,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.OAuth2SecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.OAuth2SecurityScheme)
 	if err != nil {
 		oneOfErrors["OAuth2SecurityScheme"] = err
 		s.OAuth2SecurityScheme = nil
@@ -7926,7 +8012,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.OpenIDConnectSecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.OpenIDConnectSecurityScheme)
 	if err != nil {
 		oneOfErrors["OpenIDConnectSecurityScheme"] = err
 		s.OpenIDConnectSecurityScheme = nil
@@ -7989,7 +8075,7 @@ func (s *SecuritySchemeOrRef) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.SecuritySchemeReference)
+	err = openapi.JSON.Unmarshal(data, &s.SecuritySchemeReference)
 	if err != nil {
 		oneOfErrors["SecuritySchemeReference"] = err
 		s.SecuritySchemeReference = nil
@@ -7997,7 +8083,7 @@ func (s *SecuritySchemeOrRef) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.SecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.SecurityScheme)
 	if err != nil {
 		oneOfErrors["SecurityScheme"] = err
 		s.SecurityScheme = nil
@@ -8020,6 +8106,8 @@ func (s SecuritySchemeOrRef) MarshalJSON() ([]byte, error) {
 // ComponentsSecuritySchemes structure is generated from "#/definitions/Components->securitySchemes".
 type ComponentsSecuritySchemes struct {
 	MapOfSecuritySchemeOrRefValues map[string]SecuritySchemeOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfSecuritySchemeOrRefValues sets MapOfSecuritySchemeOrRefValues value.
@@ -8034,6 +8122,10 @@ func (c *ComponentsSecuritySchemes) WithMapOfSecuritySchemeOrRefValuesItem(key s
 		c.MapOfSecuritySchemeOrRefValues = make(map[string]SecuritySchemeOrRef, 1)
 	}
 
+	if _, found := c.MapOfSecuritySchemeOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfSecuritySchemeOrRefValues[key] = val
 
 	return c
@@ -8045,7 +8137,7 @@ func (c *ComponentsSecuritySchemes) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -8062,7 +8154,7 @@ func (c *ComponentsSecuritySchemes) UnmarshalJSON(data []byte) error {
 
 			var val SecuritySchemeOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -8080,12 +8172,19 @@ func (c *ComponentsSecuritySchemes) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsSecuritySchemes) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfSecuritySchemeOrRefValues)
+	j, err := marshalUnion(c.MapOfSecuritySchemeOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsLinks structure is generated from "#/definitions/Components->links".
 type ComponentsLinks struct {
 	MapOfLinkOrRefValues map[string]LinkOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfLinkOrRefValues sets MapOfLinkOrRefValues value.
@@ -8100,6 +8199,10 @@ func (c *ComponentsLinks) WithMapOfLinkOrRefValuesItem(key string, val LinkOrRef
 		c.MapOfLinkOrRefValues = make(map[string]LinkOrRef, 1)
 	}
 
+	if _, found := c.MapOfLinkOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfLinkOrRefValues[key] = val
 
 	return c
@@ -8111,7 +8214,7 @@ func (c *ComponentsLinks) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -8128,7 +8231,7 @@ func (c *ComponentsLinks) UnmarshalJSON(data []byte) error {
 
 			var val LinkOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -8146,12 +8249,19 @@ func (c *ComponentsLinks) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsLinks) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfLinkOrRefValues)
+	j, err := marshalUnion(c.MapOfLinkOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ComponentsCallbacks structure is generated from "#/definitions/Components->callbacks".
 type ComponentsCallbacks struct {
 	MapOfCallbackOrRefValues map[string]CallbackOrRef `json:"-"` // Key must match pattern: `^[a-zA-Z0-9\.\-_]+$`.
+
+	order []string // Insertion order of components.
 }
 
 // WithMapOfCallbackOrRefValues sets MapOfCallbackOrRefValues value.
@@ -8166,6 +8276,10 @@ func (c *ComponentsCallbacks) WithMapOfCallbackOrRefValuesItem(key string, val C
 		c.MapOfCallbackOrRefValues = make(map[string]CallbackOrRef, 1)
 	}
 
+	if _, found := c.MapOfCallbackOrRefValues[key]; !found {
+		c.order = appendKey(c.order, key)
+	}
+
 	c.MapOfCallbackOrRefValues[key] = val
 
 	return c
@@ -8177,7 +8291,7 @@ func (c *ComponentsCallbacks) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -8194,7 +8308,7 @@ func (c *ComponentsCallbacks) UnmarshalJSON(data []byte) error {
 
 			var val CallbackOrRef
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -8212,7 +8326,12 @@ func (c *ComponentsCallbacks) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (c ComponentsCallbacks) MarshalJSON() ([]byte, error) {
-	return marshalUnion(c.MapOfCallbackOrRefValues)
+	j, err := marshalUnion(c.MapOfCallbackOrRefValues)
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": c.order}, j)
 }
 
 // ParameterIn is an enum type.
@@ -8238,14 +8357,14 @@ func (i ParameterIn) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected ParameterIn value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *ParameterIn) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8294,14 +8413,14 @@ func (i SchemaType) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected SchemaType value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *SchemaType) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8348,14 +8467,14 @@ func (i EncodingStyle) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected EncodingStyle value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *EncodingStyle) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8398,14 +8517,14 @@ func (i PathParameterStyle) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected PathParameterStyle value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *PathParameterStyle) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8449,14 +8568,14 @@ func (i QueryParameterStyle) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected QueryParameterStyle value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *QueryParameterStyle) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8499,14 +8618,14 @@ func (i APIKeySecuritySchemeIn) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected APIKeySecuritySchemeIn value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *APIKeySecuritySchemeIn) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -8532,7 +8651,7 @@ func marshalUnion(maps ...interface{}) ([]byte, error) {
 	isObject := true
 
 	for _, m := range maps {
-		j, err := json.Marshal(m)
+		j, err := openapi.JSON.Marshal(m)
 		if err != nil {
 			return nil, err
 		}
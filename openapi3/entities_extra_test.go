@@ -1,9 +1,13 @@
 package openapi3_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi3"
 )
 
@@ -93,3 +97,236 @@ paths:
 
 	require.NoError(t, s.UnmarshalYAML([]byte(spec)))
 }
+
+func TestSpec_UnmarshalYAML_keyOrder(t *testing.T) {
+	var s openapi3.Spec
+
+	spec := `openapi: 3.0.3
+info:
+  version: 1.0.0
+  title: Orders
+paths:
+  /orders:
+    post:
+      responses:
+        "201":
+          description: Created
+    get:
+      responses:
+        "200":
+          description: OK
+  /carts:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        total:
+          type: number
+        id:
+          type: string
+    Cart:
+      type: object
+`
+
+	require.NoError(t, s.UnmarshalYAML([]byte(spec)))
+
+	s.Info.WithDescription("Orders API.")
+
+	y, err := s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(spec, "  title: Orders\n", "  title: Orders\n  description: Orders API.\n", 1), string(y))
+
+	s.ResetKeyOrder()
+
+	y, err = s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(y), "paths:\n  /carts:")
+}
+
+type countingJSON struct {
+	marshal, unmarshal int
+}
+
+func (c *countingJSON) Marshal(v interface{}) ([]byte, error) {
+	c.marshal++
+
+	return json.Marshal(v)
+}
+
+func (c *countingJSON) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshal++
+
+	return json.Unmarshal(data, v)
+}
+
+func TestSpec_customJSON(t *testing.T) {
+	c := &countingJSON{}
+	prev := openapi.JSON
+	openapi.JSON = c
+
+	defer func() {
+		openapi.JSON = prev
+	}()
+
+	var s openapi3.Spec
+
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+paths:
+  /orders:
+    get:
+      responses: {200: {description: OK}}
+`)))
+	assert.Positive(t, c.unmarshal)
+
+	j, err := s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Positive(t, c.marshal)
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"Shop","version":"1.0.0"},`+
+		`"paths":{"/orders":{"get":{"responses":{"200":{"description":"OK"}}}}}}`, string(j))
+}
+
+func TestPaths_MarshalJSON_insertionOrder(t *testing.T) {
+	var s openapi3.Spec
+
+	s.Paths.WithMapOfPathItemValuesItem("/users", openapi3.PathItem{})
+	s.Paths.WithMapOfPathItemValuesItem("/orders", openapi3.PathItem{})
+	s.Paths.WithMapOfPathItemValuesItem("/users", openapi3.PathItem{Summary: new(string)})
+	s.Paths.MapOfPathItemValues["/carts"] = openapi3.PathItem{}
+
+	j, err := json.Marshal(s.Paths)
+	require.NoError(t, err)
+	assert.Equal(t, `{"/users":{"summary":""},"/orders":{},"/carts":{}}`, string(j))
+}
+
+func TestPaths_MarshalJSON_deletedKey(t *testing.T) {
+	var s openapi3.Spec
+
+	s.Paths.WithMapOfPathItemValuesItem("/users", openapi3.PathItem{})
+	s.Paths.WithMapOfPathItemValuesItem("/orders", openapi3.PathItem{})
+	delete(s.Paths.MapOfPathItemValues, "/users")
+	s.Paths.WithMapOfPathItemValuesItem("/users", openapi3.PathItem{})
+
+	j, err := json.Marshal(s.Paths)
+	require.NoError(t, err)
+	assert.Equal(t, `{"/orders":{},"/users":{}}`, string(j))
+
+	schemas := s.ComponentsEns().SchemasEns()
+	schemas.WithMapOfSchemaOrRefValuesItem("User", openapi3.SchemaOrRef{})
+	schemas.WithMapOfSchemaOrRefValuesItem("Order", openapi3.SchemaOrRef{})
+	delete(schemas.MapOfSchemaOrRefValues, "User")
+	schemas.WithMapOfSchemaOrRefValuesItem("User", openapi3.SchemaOrRef{})
+
+	j, err = json.Marshal(schemas)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Order":{},"User":{}}`, string(j))
+}
+
+func TestSpec_SortAlphabetically(t *testing.T) {
+	var s openapi3.Spec
+
+	require.NoError(t, s.UnmarshalYAML([]byte(`openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users: {}
+  /orders: {}
+components:
+  schemas:
+    User: {type: object}
+    Order: {type: object}
+`)))
+
+	s.Paths.WithMapOfPathItemValuesItem("/carts", openapi3.PathItem{})
+	s.Components.Schemas.WithMapOfSchemaOrRefValuesItem("Cart", openapi3.SchemaOrRef{})
+	s.Components.SecuritySchemesEns().
+		WithMapOfSecuritySchemeOrRefValuesItem("oauth", openapi3.SecuritySchemeOrRef{}).
+		WithMapOfSecuritySchemeOrRefValuesItem("apiKey", openapi3.SecuritySchemeOrRef{})
+
+	j, err := s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"API","version":"1.0.0"},`+
+		`"paths":{"/users":{},"/orders":{},"/carts":{}},"components":{"schemas":{"User":{"type":"object"},`+
+		`"Order":{"type":"object"},"Cart":{}},"securitySchemes":{"oauth":{},"apiKey":{}}}}`, string(j))
+
+	s.SortAlphabetically()
+
+	j, err = s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.0.3","info":{"title":"API","version":"1.0.0"},`+
+		`"paths":{"/carts":{},"/orders":{},"/users":{}},"components":{"schemas":{"Cart":{},`+
+		`"Order":{"type":"object"},"User":{"type":"object"}},"securitySchemes":{"apiKey":{},"oauth":{}}}}`, string(j))
+}
+
+func TestSpec_UnmarshalYAML_strict(t *testing.T) {
+	var s openapi3.Spec
+
+	require.NoError(t, s.UnmarshalYAML([]byte(`openapi: 3.0.3
+info: {title: API, version: 1.0.0, x-team: users}
+paths:
+  /users:
+    get:
+      responses:
+        200: {description: OK}
+`)))
+	assert.Equal(t, "users", s.Info.MapOfAnything["x-team"])
+
+	assert.EqualError(t, s.UnmarshalYAML([]byte(`openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users:
+    get:
+      responses:
+        "200": {description: OK}
+        "200": {description: Found}
+`)), "duplicate key: /paths/~1users/get/responses/200")
+
+	assert.EqualError(t, s.UnmarshalYAML([]byte(`info: {title: API, version: 1.0.0}`)),
+		"required key missing: openapi")
+
+	assert.EqualError(t, s.UnmarshalYAML([]byte(`openapi: 3.0.3
+info: {title: API, version: 1.0.0, team: users}
+paths: {}
+`)), "additional properties not allowed in Info: [team]")
+}
+
+func TestSpec_MarshalYAMLWithComments(t *testing.T) {
+	s := openapi3.Spec{Openapi: "3.0.3"}
+	s.Info.WithTitle("API").WithVersion("1.0.0")
+	s.WithTags(openapi3.Tag{Name: "users"})
+	s.WithSecurity(map[string][]string{"apiKey": {}})
+	s.Paths.WithMapOfPathItemValuesItem("/users", openapi3.PathItem{})
+	s.ComponentsEns().SecuritySchemesEns().WithMapOfSecuritySchemeOrRefValuesItem("apiKey", openapi3.SecuritySchemeOrRef{
+		SecurityScheme: &openapi3.SecurityScheme{APIKeySecurityScheme: &openapi3.APIKeySecurityScheme{
+			Name: "X-API-Key", In: openapi3.APIKeySecuritySchemeInHeader,
+		}},
+	})
+
+	y, err := s.MarshalYAMLWithComments("Code generated by apigen. DO NOT EDIT.\n\nSource: users.go")
+	require.NoError(t, err)
+	assert.Equal(t, `# Code generated by apigen. DO NOT EDIT.
+#
+# Source: users.go
+openapi: 3.0.3
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /users: {}
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: X-API-Key
+      in: header
+security:
+- apiKey: []
+tags:
+- name: users
+`, string(y))
+}
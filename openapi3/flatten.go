@@ -0,0 +1,231 @@
+package openapi3
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// Flatten moves anonymous inline object schemas of parameters, request bodies and responses
+// to `components.schemas` and replaces them with references, e.g. for code generators.
+//
+// Names are made of operation ID (or method and path) and location, e.g. `GetUsersResponse200`,
+// `CreateUserRequest` or `CreateUserRequestAddress` for nested properties, schema titles take precedence.
+// Names of created components are returned.
+func Flatten(spec *Spec) []string {
+	f := flattener{schemas: spec.ComponentsEns().SchemasEns()}
+
+	if f.schemas.MapOfSchemaOrRefValues == nil {
+		f.schemas.MapOfSchemaOrRefValues = map[string]SchemaOrRef{}
+	}
+
+	paths := make([]string, 0, len(spec.Paths.MapOfPathItemValues))
+	for path := range spec.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		f.parameters(camelName(openapi.OperationIDFromPath("", path)), spec.Paths.MapOfPathItemValues[path].Parameters)
+	}
+
+	_ = spec.Operations(func(method, path string, op *Operation) error {
+		name := openapi.OperationIDFromPath(method, path)
+		if op.ID != nil {
+			name = *op.ID
+		}
+
+		name = camelName(name)
+
+		f.parameters(name, op.Parameters)
+
+		if op.RequestBody != nil && op.RequestBody.RequestBody != nil {
+			f.content(name+"Request", op.RequestBody.RequestBody.Content)
+		}
+
+		codes := make([]string, 0, len(op.Responses.MapOfResponseOrRefValues))
+		for code := range op.Responses.MapOfResponseOrRefValues {
+			codes = append(codes, code)
+		}
+
+		sort.Strings(codes)
+
+		for _, code := range codes {
+			f.response(name+"Response"+code, op.Responses.MapOfResponseOrRefValues[code])
+		}
+
+		if op.Responses.Default != nil {
+			f.response(name+"ResponseDefault", *op.Responses.Default)
+		}
+
+		return nil
+	})
+
+	f.components(spec.Components)
+
+	return f.created
+}
+
+type flattener struct {
+	schemas *ComponentsSchemas
+	created []string
+}
+
+// components flattens shared parameters, request bodies and responses, names are prefixed with component names.
+func (f *flattener) components(c *Components) {
+	var names []string
+
+	if c.Parameters != nil {
+		for name := range c.Parameters.MapOfParameterOrRefValues {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if p := c.Parameters.MapOfParameterOrRefValues[name].Parameter; p != nil && p.Schema != nil {
+				f.schema(camelName(name), p.Schema)
+			}
+		}
+	}
+
+	if c.RequestBodies != nil {
+		names = names[:0]
+		for name := range c.RequestBodies.MapOfRequestBodyOrRefValues {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			if rb := c.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody; rb != nil {
+				f.content(camelName(name), rb.Content)
+			}
+		}
+	}
+
+	if c.Responses != nil {
+		names = names[:0]
+		for name := range c.Responses.MapOfResponseOrRefValues {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			f.response(camelName(name), c.Responses.MapOfResponseOrRefValues[name])
+		}
+	}
+}
+
+func (f *flattener) parameters(prefix string, params []ParameterOrRef) {
+	for _, p := range params {
+		if p.Parameter == nil {
+			continue
+		}
+
+		name := prefix + camelName(p.Parameter.Name)
+
+		if p.Parameter.Schema != nil {
+			f.schema(name, p.Parameter.Schema)
+		}
+
+		if len(p.Parameter.Content) > 0 {
+			f.content(name, p.Parameter.Content)
+
+			// Loaded copy of content would override flattened content in marshaled parameter.
+			p.Parameter.SchemaXORContent = nil
+		}
+	}
+}
+
+func (f *flattener) response(name string, ror ResponseOrRef) {
+	if ror.Response != nil {
+		f.content(name, ror.Response.Content)
+	}
+}
+
+func (f *flattener) content(name string, content map[string]MediaType) {
+	cts := make([]string, 0, len(content))
+	for ct := range content {
+		cts = append(cts, ct)
+	}
+
+	sort.Strings(cts)
+
+	for _, ct := range cts {
+		if sr := content[ct].Schema; sr != nil {
+			f.schema(name, sr)
+		}
+	}
+}
+
+// schema hoists inline object schema or looks for object schemas in items of inline arrays.
+func (f *flattener) schema(name string, sr *SchemaOrRef) {
+	s := sr.Schema
+	if s == nil {
+		return
+	}
+
+	if !isObjectSchema(s) {
+		if s.Items != nil {
+			f.schema(name+"Item", s.Items)
+		}
+
+		return
+	}
+
+	if s.Title != nil && camelName(*s.Title) != "" {
+		name = camelName(*s.Title)
+	}
+
+	base := name
+	for i := 2; ; i++ {
+		if _, exists := f.schemas.MapOfSchemaOrRefValues[name]; !exists {
+			break
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+
+	f.schemas.WithMapOfSchemaOrRefValuesItem(name, SchemaOrRef{Schema: s})
+	f.created = append(f.created, name)
+
+	sr.Schema = nil
+	sr.SchemaReference = &SchemaReference{Ref: "#/components/schemas/" + name}
+
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			prop := pair.Value
+			f.schema(name+camelName(pair.Key), &prop)
+			s.Properties.Set(pair.Key, prop)
+		}
+	}
+
+	if s.AdditionalProperties != nil && s.AdditionalProperties.SchemaOrRef != nil {
+		f.schema(name+"Value", s.AdditionalProperties.SchemaOrRef)
+	}
+}
+
+func isObjectSchema(s *Schema) bool {
+	return (s.Type != nil && *s.Type == SchemaTypeObject) || (s.Properties != nil && s.Properties.Len() > 0) ||
+		len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0
+}
+
+// camelName converts identifier to upper camel case, e.g. `getUsers` to `GetUsers`.
+func camelName(s string) string {
+	var sb strings.Builder
+
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+
+		sb.WriteString(string(r))
+	}
+
+	return sb.String()
+}
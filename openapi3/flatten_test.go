@@ -0,0 +1,120 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestFlatten(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+        - name: filter
+          in: query
+          content:
+            application/json:
+              schema: {type: object, properties: {role: {type: string}}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: object
+                  properties:
+                    name: {type: string}
+                    address:
+                      type: object
+                      properties:
+                        city: {type: string}
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        content:
+          application/json:
+            schema: {title: new user, type: object, properties: {name: {type: string}}}
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/CreateUserResponse201'}
+components:
+  schemas:
+    CreateUserResponse201: {type: object}
+`)))
+
+	assert.Equal(t, []string{
+		"NewUser",
+		"GetUsersByIdFilter",
+		"GetUsersByIdResponse200Item",
+		"GetUsersByIdResponse200ItemAddress",
+	}, openapi3.Flatten(&s))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"API","version":"1.0.0"},
+	  "paths":{
+		"/users/{id}":{
+		  "get":{
+			"parameters":[
+			  {"name":"id","in":"path","required":true,"schema":{"type":"integer"}},
+			  {
+				"name":"filter","in":"query",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/GetUsersByIdFilter"}}}
+			  }
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{
+				  "application/json":{
+					"schema":{"type":"array","items":{"$ref":"#/components/schemas/GetUsersByIdResponse200Item"}}
+				  }
+				}
+			  }
+			}
+		  }
+		},
+		"/users":{
+		  "post":{
+			"operationId":"createUser",
+			"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/NewUser"}}}},
+			"responses":{
+			  "201":{
+				"description":"Created",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/CreateUserResponse201"}}}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "CreateUserResponse201":{"type":"object"},
+		  "GetUsersByIdFilter":{"type":"object","properties":{"role":{"type":"string"}}},
+		  "GetUsersByIdResponse200Item":{
+			"type":"object",
+			"properties":{
+			  "name":{"type":"string"},
+			  "address":{"$ref":"#/components/schemas/GetUsersByIdResponse200ItemAddress"}
+			}
+		  },
+		  "GetUsersByIdResponse200ItemAddress":{"type":"object","properties":{"city":{"type":"string"}}},
+		  "NewUser":{"title":"new user","type":"object","properties":{"name":{"type":"string"}}}
+		}
+	  }
+	}`, s)
+}
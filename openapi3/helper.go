@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -49,12 +50,20 @@ func (o *Operation) validatePathParams(pathParams map[string]bool) error {
 		paramIndex[p.Parameter.Name+string(p.Parameter.In)] = true
 	}
 
+	undefined := make([]string, 0, len(pathParams))
+
 	for pathParam := range pathParams {
 		if !paramIndex[pathParam+string(ParameterInPath)] {
-			errs = append(errs, "undefined path parameter: "+pathParam)
+			undefined = append(undefined, pathParam)
 		}
 	}
 
+	sort.Strings(undefined)
+
+	for _, pathParam := range undefined {
+		errs = append(errs, "undefined path parameter: "+pathParam)
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, ", "))
 	}
@@ -94,6 +103,54 @@ func (s *Spec) SetupOperation(method, path string, setup ...func(*Operation) err
 	return nil
 }
 
+// Operations calls fn for every operation ordered by path and method, changes of operation are kept in spec.
+//
+// Method is in upper case, e.g. http.MethodGet, iteration stops at first error of fn.
+func (s *Spec) Operations(fn func(method, path string, op *Operation) error) error {
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			op := pi.MapOfOperationValues[method]
+
+			err := fn(strings.ToUpper(method), path, &op)
+
+			pi.MapOfOperationValues[method] = op
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkOperationID fails if operation ID is already used by another operation.
+func (s *Spec) checkOperationID(method, path, id string) error {
+	return s.Operations(func(m, p string, op *Operation) error {
+		if op.ID != nil && *op.ID == id {
+			return fmt.Errorf("operationId %s of %s %s is already used by %s %s", id, strings.ToUpper(method), path, m, p)
+		}
+
+		return nil
+	})
+}
+
 // AddOperation validates and sets operation by path and method.
 //
 // It will fail if operation with method and path already exists.
@@ -105,6 +162,12 @@ func (s *Spec) AddOperation(method, path string, operation Operation) error {
 		return fmt.Errorf("operation already exists: %s %s", method, path)
 	}
 
+	if operation.ID != nil {
+		if err := s.checkOperationID(method, path, *operation.ID); err != nil {
+			return err
+		}
+	}
+
 	// Add "No Content" response if there are no responses configured.
 	if len(operation.Responses.MapOfResponseOrRefValues) == 0 && operation.Responses.Default == nil {
 		operation.Responses.WithMapOfResponseOrRefValuesItem(strconv.Itoa(http.StatusNoContent), ResponseOrRef{
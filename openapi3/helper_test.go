@@ -1,9 +1,11 @@
 package openapi3_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/assertjson"
 	"github.com/swaggest/openapi-go/openapi3"
@@ -100,3 +102,44 @@ func TestSpec_SetupOperation_uncleanPath(t *testing.T) {
 	  }
 	}`), s)
 }
+
+func TestSpec_Operations(t *testing.T) {
+	s := openapi3.Spec{}
+
+	for _, mp := range [][2]string{
+		{http.MethodPost, "/things"}, {http.MethodGet, "/things"}, {http.MethodDelete, "/things/all"},
+	} {
+		require.NoError(t, s.SetupOperation(mp[0], mp[1], func(op *openapi3.Operation) error {
+			op.WithSummary(mp[0] + " " + mp[1])
+
+			return nil
+		}))
+	}
+
+	var visited []string
+
+	require.NoError(t, s.Operations(func(method, path string, op *openapi3.Operation) error {
+		visited = append(visited, method+" "+path)
+		op.WithTags("things")
+
+		return nil
+	}))
+
+	assert.Equal(t, []string{"GET /things", "POST /things", "DELETE /things/all"}, visited)
+
+	require.NoError(t, s.SetupOperation(http.MethodDelete, "/things/all", func(op *openapi3.Operation) error {
+		assert.Equal(t, []string{"things"}, op.Tags)
+
+		return nil
+	}))
+
+	stop := errors.New("stop")
+	visited = nil
+
+	assert.Equal(t, stop, s.Operations(func(method, path string, _ *openapi3.Operation) error {
+		visited = append(visited, method+" "+path)
+
+		return stop
+	}))
+	assert.Equal(t, []string{"GET /things"}, visited)
+}
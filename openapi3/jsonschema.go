@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go/internal"
 )
 
 type toJSONSchemaContext struct {
@@ -170,6 +171,10 @@ func (s *SchemaOrRef) toJSONSchema(ctx toJSONSchemaContext) jsonschema.SchemaOrB
 		jso.WithExamples(*ss.Example)
 	}
 
+	if ss.XML != nil {
+		jso.WithExtraPropertiesItem(internal.XMLKeyword, xmlMap(*ss.XML))
+	}
+
 	for k, v := range ss.MapOfAnything {
 		jso.WithExtraPropertiesItem(k, v)
 	}
@@ -187,10 +192,11 @@ func (s *SchemaOrRef) FromJSONSchema(schema jsonschema.SchemaOrBool) {
 
 	js := schema.TypeObject
 	if js.Ref != nil {
-		if deprecated, ok := js.ExtraProperties["deprecated"].(bool); ok && deprecated {
+		// Reference can not have siblings in OpenAPI 3.0, annotations of property are kept with allOf.
+		if ann := refAnnotations(js); ann != nil {
 			s.Schema = (&Schema{}).WithAllOf(
 				SchemaOrRef{
-					Schema: (&Schema{}).WithDeprecated(true),
+					Schema: ann,
 				},
 				SchemaOrRef{
 					SchemaReference: &SchemaReference{Ref: *js.Ref},
@@ -261,18 +267,18 @@ func (s *SchemaOrRef) FromJSONSchema(schema jsonschema.SchemaOrBool) {
 		os.Items.FromJSONSchema(*js.Items.SchemaOrBool)
 	}
 
-	if js.ExclusiveMaximum != nil {
+	// JSON Schema exclusive bounds are numbers, OpenAPI 3.0 marks bounds as exclusive with booleans,
+	// stricter bound is kept if both are set.
+	os.Maximum = js.Maximum
+	if js.ExclusiveMaximum != nil && (js.Maximum == nil || *js.ExclusiveMaximum <= *js.Maximum) {
 		os.WithExclusiveMaximum(true)
-		os.Maximum = js.Maximum
-	} else if js.Maximum != nil {
-		os.Maximum = js.Maximum
+		os.Maximum = js.ExclusiveMaximum
 	}
 
-	if js.ExclusiveMinimum != nil {
+	os.Minimum = js.Minimum
+	if js.ExclusiveMinimum != nil && (js.Minimum == nil || *js.ExclusiveMinimum >= *js.Minimum) {
 		os.WithExclusiveMinimum(true)
-		os.Minimum = js.Minimum
-	} else if js.Minimum != nil {
-		os.Minimum = js.Minimum
+		os.Minimum = js.ExclusiveMinimum
 	}
 
 	os.Format = js.Format
@@ -318,6 +324,19 @@ func (s *SchemaOrRef) FromJSONSchema(schema jsonschema.SchemaOrBool) {
 		os.WriteOnly = &writeOnly
 	}
 
+	// OpenAPI 3.0 has no propertyNames keyword, constraint is kept as an extension for documentation.
+	if js.PropertyNames != nil {
+		os.WithMapOfAnythingItem("x-propertyNames", *js.PropertyNames)
+	}
+
+	if d, ok := js.ExtraProperties[internal.XDiscriminator].(map[string]interface{}); ok {
+		os.Discriminator = discriminator(d)
+	}
+
+	if x, ok := js.ExtraProperties[internal.XMLKeyword].(map[string]interface{}); ok {
+		os.XML = xmlObject(x)
+	}
+
 	for name, val := range js.ExtraProperties {
 		if strings.HasPrefix(name, "x-") {
 			if os.MapOfAnything == nil {
@@ -331,6 +350,45 @@ func (s *SchemaOrRef) FromJSONSchema(schema jsonschema.SchemaOrBool) {
 	}
 }
 
+// refAnnotations returns schema with `deprecated`, `readOnly`, `writeOnly` and `default` of a reference
+// or nil if none is set.
+func refAnnotations(js *jsonschema.Schema) *Schema {
+	var (
+		ann = Schema{}
+		set bool
+	)
+
+	if deprecated, ok := js.ExtraProperties["deprecated"].(bool); ok && deprecated {
+		ann.WithDeprecated(true)
+
+		set = true
+	}
+
+	if js.ReadOnly != nil && *js.ReadOnly {
+		ann.WithReadOnly(true)
+
+		set = true
+	}
+
+	if writeOnly, ok := js.ExtraProperties["writeOnly"].(bool); ok && writeOnly {
+		ann.WithWriteOnly(true)
+
+		set = true
+	}
+
+	if js.Default != nil {
+		ann.WithDefault(*js.Default)
+
+		set = true
+	}
+
+	if !set {
+		return nil
+	}
+
+	return &ann
+}
+
 func checkNullable(t jsonschema.SimpleType, os *Schema) {
 	if t == jsonschema.Null {
 		os.WithNullable(true)
@@ -368,3 +426,73 @@ func (s *SchemaOrRef) fromBool(val bool) {
 		Schema: &Schema{},
 	}
 }
+
+func discriminator(d map[string]interface{}) *Discriminator {
+	res := &Discriminator{}
+	res.PropertyName, _ = d["propertyName"].(string)
+
+	switch m := d["mapping"].(type) {
+	case map[string]string:
+		res.Mapping = m
+	case map[string]interface{}:
+		res.Mapping = make(map[string]string, len(m))
+
+		for k, v := range m {
+			res.Mapping[k], _ = v.(string)
+		}
+	}
+
+	return res
+}
+
+func xmlMap(x XML) map[string]interface{} {
+	res := map[string]interface{}{}
+
+	if x.Name != nil {
+		res["name"] = *x.Name
+	}
+
+	if x.Namespace != nil {
+		res["namespace"] = *x.Namespace
+	}
+
+	if x.Prefix != nil {
+		res["prefix"] = *x.Prefix
+	}
+
+	if x.Attribute != nil {
+		res["attribute"] = *x.Attribute
+	}
+
+	if x.Wrapped != nil {
+		res["wrapped"] = *x.Wrapped
+	}
+
+	return res
+}
+
+func xmlObject(x map[string]interface{}) *XML {
+	res := &XML{}
+
+	if v, ok := x["name"].(string); ok {
+		res.Name = &v
+	}
+
+	if v, ok := x["namespace"].(string); ok {
+		res.Namespace = &v
+	}
+
+	if v, ok := x["prefix"].(string); ok {
+		res.Prefix = &v
+	}
+
+	if v, ok := x["attribute"].(bool); ok {
+		res.Attribute = &v
+	}
+
+	if v, ok := x["wrapped"].(bool); ok {
+		res.Wrapped = &v
+	}
+
+	return res
+}
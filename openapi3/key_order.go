@@ -0,0 +1,148 @@
+package openapi3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// recordKeyOrder returns order of object keys of a loaded document, so that marshaled document
+// follows the source and diffs show only intentional edits, nil if document can not be parsed.
+func recordKeyOrder(data []byte) map[string][]string {
+	ko, err := internal.RecordKeyOrder(data)
+	if err != nil {
+		return nil
+	}
+
+	return ko
+}
+
+func applyKeyOrder(keyOrder map[string][]string, data []byte) ([]byte, error) {
+	return internal.KeyOrder(keyOrder).Apply(data)
+}
+
+func appendKey(order []string, key string) []string {
+	return internal.AppendKey(order, key)
+}
+
+// ResetKeyOrder discards order of keys of loaded document, marshaled document is then ordered by default rules.
+func (s *Spec) ResetKeyOrder() {
+	s.keyOrder = nil
+}
+
+// SortAlphabetically discards order of adding and order of loaded document of paths and components,
+// so that they are marshaled sorted by name and documents are reproducible regardless of order of building.
+func (s *Spec) SortAlphabetically() {
+	s.Paths.SortAlphabetically()
+
+	if s.Components != nil {
+		s.Components.SortAlphabetically()
+	}
+
+	for ptr := range s.keyOrder {
+		if ptr == "/paths" || (strings.HasPrefix(ptr, "/components/") && strings.Count(ptr, "/") == 2) {
+			delete(s.keyOrder, ptr)
+		}
+	}
+}
+
+// RenamePath replaces path of path item keeping its position in order of paths,
+// order of keys of loaded path item is kept too.
+func (s *Spec) RenamePath(path, newPath string) error {
+	pi, found := s.Paths.MapOfPathItemValues[path]
+	if !found {
+		return fmt.Errorf("path item not found: %s", path)
+	}
+
+	if _, exists := s.Paths.MapOfPathItemValues[newPath]; exists {
+		return fmt.Errorf("path item already exists: %s", newPath)
+	}
+
+	delete(s.Paths.MapOfPathItemValues, path)
+	s.Paths.MapOfPathItemValues[newPath] = pi
+	s.Paths.order = internal.RenameKey(s.Paths.order, path, newPath)
+
+	if s.keyOrder == nil {
+		return nil
+	}
+
+	if keys, ok := s.keyOrder["/paths"]; ok {
+		s.keyOrder["/paths"] = internal.RenameKey(keys, path, newPath)
+	}
+
+	prefix := "/paths/" + internal.EscapePointerToken(path)
+	newPrefix := "/paths/" + internal.EscapePointerToken(newPath)
+
+	var nested []string
+
+	for ptr := range s.keyOrder {
+		if ptr == prefix || strings.HasPrefix(ptr, prefix+"/") {
+			nested = append(nested, ptr)
+		}
+	}
+
+	for _, ptr := range nested {
+		s.keyOrder[newPrefix+ptr[len(prefix):]] = s.keyOrder[ptr]
+		delete(s.keyOrder, ptr)
+	}
+
+	return nil
+}
+
+// SortAlphabetically discards order of adding path items.
+func (p *Paths) SortAlphabetically() {
+	p.order = nil
+}
+
+// SortAlphabetically discards order of adding components.
+func (c *Components) SortAlphabetically() {
+	if c.Schemas != nil {
+		c.Schemas.order = nil
+	}
+
+	if c.Responses != nil {
+		c.Responses.order = nil
+	}
+
+	if c.Parameters != nil {
+		c.Parameters.order = nil
+	}
+
+	if c.Examples != nil {
+		c.Examples.order = nil
+	}
+
+	if c.RequestBodies != nil {
+		c.RequestBodies.order = nil
+	}
+
+	if c.Headers != nil {
+		c.Headers.order = nil
+	}
+
+	if c.SecuritySchemes != nil {
+		c.SecuritySchemes.order = nil
+	}
+
+	if c.Links != nil {
+		c.Links.order = nil
+	}
+
+	if c.Callbacks != nil {
+		c.Callbacks.order = nil
+	}
+}
+
+// canonicalKeys is an order of document keys as they appear in OpenAPI specification.
+var canonicalKeys = []string{"openapi", "info", "servers", "paths", "components", "security", "tags", "externalDocs"}
+
+// canonicalKeyOrder puts top level keys of built document in canonical order,
+// loaded document keeps order of its source.
+func canonicalKeyOrder(keyOrder map[string][]string, data []byte) ([]byte, error) {
+	if _, loaded := keyOrder[""]; loaded {
+		return data, nil
+	}
+
+	return applyKeyOrder(map[string][]string{"": canonicalKeys}, data)
+}
@@ -0,0 +1,130 @@
+package openapi3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// LinkBuilder configures a link between operations, see Reflector.Link.
+type LinkBuilder struct {
+	link *Link
+	err  error
+}
+
+// Link declares a link from successful responses of operation with ID fromOpID to operation with ID toOpID.
+//
+// Both operations must be added before, link is named after toOpID in all 2XX responses of fromOpID.
+// Problems, e.g. unknown operations or invalid runtime expressions, are reported by LinkBuilder.Err.
+func (r *Reflector) Link(fromOpID, toOpID string) *LinkBuilder {
+	b := &LinkBuilder{}
+
+	from, ok := r.SpecEns().operationByID(fromOpID)
+	if !ok {
+		b.err = fmt.Errorf("link %s to %s: unknown operation %s", fromOpID, toOpID, fromOpID)
+
+		return b
+	}
+
+	if _, ok := r.Spec.operationByID(toOpID); !ok {
+		b.err = fmt.Errorf("link %s to %s: unknown operation %s", fromOpID, toOpID, toOpID)
+
+		return b
+	}
+
+	statuses := make([]string, 0, len(from.Responses.MapOfResponseOrRefValues))
+
+	for status, resp := range from.Responses.MapOfResponseOrRefValues {
+		if strings.HasPrefix(status, "2") && resp.Response != nil {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if len(statuses) == 0 {
+		b.err = fmt.Errorf("link %s to %s: no successful responses in %s", fromOpID, toOpID, fromOpID)
+
+		return b
+	}
+
+	sort.Strings(statuses)
+
+	b.link = (&Link{}).WithOperationID(toOpID)
+
+	for _, status := range statuses {
+		from.Responses.MapOfResponseOrRefValues[status].Response.WithLinksItem(toOpID, LinkOrRef{Link: b.link})
+	}
+
+	return b
+}
+
+// WithParameter sets value of a parameter of linked operation,
+// value is a constant or a runtime expression, e.g. "$response.body#/id".
+func (b *LinkBuilder) WithParameter(name, value string) *LinkBuilder {
+	if b.link == nil {
+		return b
+	}
+
+	if err := internal.CheckRuntimeExpression(value); err != nil {
+		b.fail(fmt.Errorf("parameter %s: %w", name, err))
+
+		return b
+	}
+
+	b.link.WithParametersItem(name, value)
+
+	return b
+}
+
+// WithRequestBody sets request body of linked operation, it is a constant or a runtime expression.
+func (b *LinkBuilder) WithRequestBody(value interface{}) *LinkBuilder {
+	if b.link == nil {
+		return b
+	}
+
+	if s, ok := value.(string); ok {
+		if err := internal.CheckRuntimeExpression(s); err != nil {
+			b.fail(fmt.Errorf("request body: %w", err))
+
+			return b
+		}
+	}
+
+	b.link.WithRequestBody(value)
+
+	return b
+}
+
+// WithDescription sets link description.
+func (b *LinkBuilder) WithDescription(description string) *LinkBuilder {
+	if b.link != nil {
+		b.link.WithDescription(description)
+	}
+
+	return b
+}
+
+// Err returns first problem of link declaration.
+func (b *LinkBuilder) Err() error {
+	return b.err
+}
+
+func (b *LinkBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// operationByID finds operation, responses of found operation are shared with spec.
+func (s *Spec) operationByID(id string) (Operation, bool) {
+	for _, pi := range s.Paths.MapOfPathItemValues {
+		for _, op := range pi.MapOfOperationValues {
+			if op.ID != nil && *op.ID == id {
+				return op, true
+			}
+		}
+	}
+
+	return Operation{}, false
+}
@@ -0,0 +1,66 @@
+package openapi3_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestReflector_Link(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type order struct {
+		ID int `json:"id"`
+	}
+
+	type getReq struct {
+		ID int `path:"id"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.SetID("createOrder")
+	oc.AddRespStructure(order{}, openapi.WithHTTPStatus(http.StatusCreated))
+	oc.AddRespStructure(order{}, openapi.WithHTTPStatus(http.StatusOK))
+	oc.AddRespStructure(nil, openapi.WithHTTPStatus(http.StatusConflict))
+	require.NoError(t, r.AddOperation(oc))
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/orders/{id}")
+	require.NoError(t, err)
+	oc.SetID("getOrder")
+	oc.AddReqStructure(getReq{})
+	oc.AddRespStructure(order{})
+	require.NoError(t, r.AddOperation(oc))
+
+	require.NoError(t, r.Link("createOrder", "getOrder").
+		WithParameter("id", "$response.body#/id").
+		WithDescription("Created order.").
+		Err())
+
+	assertjson.EqMarshal(t, `{
+	  "201":{
+		"description":"Created",
+		"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestOrder"}}},
+		"links":{"getOrder":{"operationId":"getOrder","parameters":{"id":"$response.body#/id"},"description":"Created order."}}
+	  },
+	  "200":{
+		"description":"OK",
+		"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestOrder"}}},
+		"links":{"getOrder":{"operationId":"getOrder","parameters":{"id":"$response.body#/id"},"description":"Created order."}}
+	  },
+	  "409":{"description":"Conflict"}
+	}`, r.Spec.Paths.MapOfPathItemValues["/orders"].MapOfOperationValues["post"].Responses)
+
+	assert.EqualError(t, r.Link("createOrder", "cancelOrder").Err(),
+		"link createOrder to cancelOrder: unknown operation cancelOrder")
+	assert.EqualError(t, r.Link("createOrder", "getOrder").WithParameter("id", "$response.bdy").Err(),
+		`parameter id: invalid runtime expression "$response.bdy"`)
+	assert.EqualError(t, r.Link("createOrder", "getOrder").WithRequestBody("{\"id\": {$request.pth.id}}").Err(),
+		`request body: invalid runtime expression "$request.pth.id"`)
+	assert.NoError(t, r.Link("getOrder", "createOrder").WithRequestBody("copy of {$response.body#/id}").Err())
+}
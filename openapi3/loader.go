@@ -0,0 +1,272 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RefLoader reads a document referenced by location, e.g. file path or URL.
+type RefLoader interface {
+	LoadRef(ctx context.Context, location string) ([]byte, error)
+}
+
+// RefLoaderFunc implements RefLoader with a function.
+type RefLoaderFunc func(ctx context.Context, location string) ([]byte, error)
+
+// LoadRef implements RefLoader.
+func (f RefLoaderFunc) LoadRef(ctx context.Context, location string) ([]byte, error) {
+	return f(ctx, location)
+}
+
+// FileLoader reads documents from disk, relative locations are resolved against Dir.
+type FileLoader struct {
+	Dir string
+}
+
+// LoadRef implements RefLoader.
+func (f FileLoader) LoadRef(_ context.Context, location string) ([]byte, error) {
+	if !filepath.IsAbs(location) {
+		location = filepath.Join(f.Dir, filepath.FromSlash(location))
+	}
+
+	return os.ReadFile(location) //nolint:gosec // Location is referenced by trusted document.
+}
+
+// HTTPLoader reads documents with GET requests, nil Client means http.DefaultClient.
+type HTTPLoader struct {
+	Client *http.Client
+}
+
+// LoadRef implements RefLoader.
+func (h HTTPLoader) LoadRef(ctx context.Context, location string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck // Body is fully read.
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: unexpected status %s", location, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// ResolvedRef is a value of a reference with location of document that contains it.
+type ResolvedRef struct {
+	// Location of document, relative references inside Value are resolved against it.
+	Location string
+
+	// Pointer is a JSON Pointer to Value in document.
+	Pointer string
+
+	// Value is a generic JSON value, e.g. map[string]interface{}.
+	Value interface{}
+}
+
+// Ref returns absolute reference of value.
+func (r ResolvedRef) Ref() string {
+	return r.Location + "#" + r.Pointer
+}
+
+// Loader resolves references to external documents (JSON or YAML), e.g. `./common.yaml#/components/schemas/Error`.
+//
+// Documents are read with RefLoader once and cached by their resolved location, LoadFromData adds
+// a document that is already in memory, e.g. the root one. Loader is safe for concurrent use.
+type Loader struct {
+	refLoader RefLoader
+
+	mu   sync.Mutex
+	docs map[string]interface{}
+}
+
+// NewLoader creates a loader of documents, nil refLoader reads files relative to working directory and HTTP(S) URLs.
+func NewLoader(refLoader RefLoader) *Loader {
+	if refLoader == nil {
+		refLoader = RefLoaderFunc(func(ctx context.Context, location string) ([]byte, error) {
+			if isURL(location) {
+				return HTTPLoader{}.LoadRef(ctx, location)
+			}
+
+			return FileLoader{}.LoadRef(ctx, location)
+		})
+	}
+
+	return &Loader{
+		refLoader: refLoader,
+		docs:      map[string]interface{}{},
+	}
+}
+
+// Resolve finds value of ref, relative location of ref is resolved against base location of referring document.
+//
+// Values that are references themselves (`{"$ref": "..."}`) are followed, circular references fail.
+func (l *Loader) Resolve(ctx context.Context, base, ref string) (ResolvedRef, error) {
+	visited := map[string]bool{}
+
+	for {
+		location, pointer := splitRef(ref)
+		if location == "" {
+			location = base
+		} else {
+			location = joinLocation(base, location)
+		}
+
+		if location == "" {
+			return ResolvedRef{}, fmt.Errorf("%s: local reference without document", ref)
+		}
+
+		r := ResolvedRef{Location: location, Pointer: pointer}
+
+		if visited[r.Ref()] {
+			return ResolvedRef{}, fmt.Errorf("circular reference: %s", r.Ref())
+		}
+
+		visited[r.Ref()] = true
+
+		doc, err := l.load(ctx, location)
+		if err != nil {
+			return ResolvedRef{}, err
+		}
+
+		if r.Value, err = resolveJSONPointer(doc, pointer); err != nil {
+			return ResolvedRef{}, fmt.Errorf("%s: %w", location, err)
+		}
+
+		next, ok := refOf(r.Value)
+		if !ok {
+			return r, nil
+		}
+
+		base, ref = location, next
+	}
+}
+
+// LoadFromData caches document data at location, so that references to location are resolved
+// without reading it with RefLoader, e.g. for a root document that is already in memory.
+//
+// Location is a base of relative references of document, it is cleaned the same way as
+// locations of references, so `./api.yaml` and `api.yaml` denote the same document.
+func (l *Loader) LoadFromData(location string, data []byte) error {
+	if location == "" {
+		return errors.New("empty document location")
+	}
+
+	location = joinLocation("", location)
+
+	doc, err := parseDoc(location, data)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.docs[location] = doc
+	l.mu.Unlock()
+
+	return nil
+}
+
+func (l *Loader) load(ctx context.Context, location string) (interface{}, error) {
+	l.mu.Lock()
+	doc, found := l.docs[location]
+	l.mu.Unlock()
+
+	if found {
+		return doc, nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := l.refLoader.LoadRef(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", location, err)
+	}
+
+	if doc, err = parseDoc(location, data); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.docs[location] = doc
+	l.mu.Unlock()
+
+	return doc, nil
+}
+
+// parseDoc decodes JSON or YAML document into generic value.
+func parseDoc(location string, data []byte) (interface{}, error) {
+	var doc interface{}
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", location, err)
+	}
+
+	return convertMapI2MapS(doc), nil
+}
+
+// refOf returns reference of a Reference Object.
+func refOf(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	ref, ok := m["$ref"].(string)
+
+	return ref, ok
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// joinLocation resolves location against base location of referring document.
+func joinLocation(base, location string) string {
+	if isURL(location) {
+		return location
+	}
+
+	if isURL(base) {
+		b, err := url.Parse(base)
+		if err != nil {
+			return location
+		}
+
+		l, err := url.Parse(location)
+		if err != nil {
+			return location
+		}
+
+		return b.ResolveReference(l).String()
+	}
+
+	if base == "" || path.IsAbs(location) || filepath.IsAbs(location) {
+		return path.Clean(filepath.ToSlash(location))
+	}
+
+	return path.Join(path.Dir(filepath.ToSlash(base)), location)
+}
@@ -0,0 +1,128 @@
+package openapi3_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestLoader_Resolve(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "common"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "errors.yaml"), []byte(`
+components:
+  schemas:
+    Error:
+      $ref: '#/components/schemas/Problem'
+    Problem:
+      type: object
+      properties:
+        detail: {$ref: 'types.yaml#/Text'}
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "types.yaml"), []byte(`
+Text: {type: string}
+Loop: {$ref: '#/Loop'}
+`), 0o600))
+
+	loads := 0
+	l := openapi3.NewLoader(openapi3.RefLoaderFunc(func(ctx context.Context, location string) ([]byte, error) {
+		loads++
+
+		return openapi3.FileLoader{Dir: dir}.LoadRef(ctx, location)
+	}))
+
+	ctx := context.Background()
+
+	r, err := l.Resolve(ctx, "", "./common/errors.yaml#/components/schemas/Error")
+	require.NoError(t, err)
+	assert.Equal(t, "common/errors.yaml#/components/schemas/Problem", r.Ref())
+	assert.Equal(t, map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"detail": map[string]interface{}{"$ref": "types.yaml#/Text"}},
+	}, r.Value)
+
+	r, err = l.Resolve(ctx, r.Location, "types.yaml#/Text")
+	require.NoError(t, err)
+	assert.Equal(t, "common/types.yaml#/Text", r.Ref())
+	assert.Equal(t, map[string]interface{}{"type": "string"}, r.Value)
+
+	_, err = l.Resolve(ctx, "common/errors.yaml", "#/components/schemas/Problem")
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads, "documents are cached")
+
+	_, err = l.Resolve(ctx, "", "common/types.yaml#/Loop")
+	assert.EqualError(t, err, "circular reference: common/types.yaml#/Loop")
+
+	_, err = l.Resolve(ctx, "", "common/missing.yaml#/Text")
+	assert.Error(t, err)
+}
+
+func TestLoader_LoadFromData(t *testing.T) {
+	loaded := map[string]int{}
+	l := openapi3.NewLoader(openapi3.RefLoaderFunc(func(_ context.Context, location string) ([]byte, error) {
+		loaded[location]++
+
+		return []byte(`Text: {type: string}`), nil
+	}))
+
+	ctx := context.Background()
+
+	require.NoError(t, l.LoadFromData("./specs/api.yaml", []byte(`
+Error: {$ref: '#/Problem'}
+Problem: {$ref: 'common.yaml#/Text'}
+`)))
+
+	r, err := l.Resolve(ctx, "specs/api.yaml", "#/Error")
+	require.NoError(t, err)
+	assert.Equal(t, "specs/common.yaml#/Text", r.Ref())
+	assert.Equal(t, map[string]interface{}{"type": "string"}, r.Value)
+
+	r, err = l.Resolve(ctx, "", "specs/./api.yaml#/Problem")
+	require.NoError(t, err)
+	assert.Equal(t, "specs/common.yaml#/Text", r.Ref())
+	assert.Equal(t, map[string]int{"specs/common.yaml": 1}, loaded, "root document is not read")
+
+	_, err = l.Resolve(ctx, "", "#/Error")
+	assert.EqualError(t, err, "#/Error: local reference without document")
+
+	assert.EqualError(t, l.LoadFromData("", []byte(`{}`)), "empty document location")
+}
+
+func TestHTTPLoader_LoadRef(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/specs/api.yaml":
+			_, _ = w.Write([]byte(`Error: {$ref: 'common/error.json'}`))
+		case "/specs/common/error.json":
+			_, _ = w.Write([]byte(`{"type": "object"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	l := openapi3.NewLoader(nil)
+
+	r, err := l.Resolve(context.Background(), "", srv.URL+"/specs/api.yaml#/Error")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/specs/common/error.json#", r.Ref())
+	assert.Equal(t, map[string]interface{}{"type": "object"}, r.Value)
+
+	_, err = l.Resolve(context.Background(), "", srv.URL+"/missing.yaml")
+	assert.EqualError(t, err, "load "+srv.URL+"/missing.yaml: GET "+srv.URL+"/missing.yaml: unexpected status 404 Not Found")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = openapi3.NewLoader(nil).Resolve(ctx, "", srv.URL+"/specs/api.yaml#/Error")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,172 @@
+package openapi3
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal"
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// LookupJSONPointer finds entity of document by JSON Pointer, e.g. "/paths/~1things~1{id}/get/responses/200".
+//
+// Entities are returned as they are stored in spec, e.g. Operation, *Response of ResponseOrRef,
+// *Schema or *SchemaReference of SchemaOrRef, values of extensions are generic JSON values.
+func (s *Spec) LookupJSONPointer(pointer string) (interface{}, error) {
+	if pointer == "" {
+		return s, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %s", errInvalidPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	v := reflect.ValueOf(s)
+
+	for i, token := range tokens {
+		token = internal.UnescapePointerToken(token)
+		v = unwrapEntity(v)
+
+		if !v.IsValid() {
+			return nil, fmt.Errorf("%w: %s, missing key %q", errInvalidPointer, pointer, token)
+		}
+
+		if v.Kind() == reflect.Interface {
+			// Generic JSON value, e.g. extension or example.
+			return resolveJSONPointer(v.Interface(), "/"+strings.Join(tokens[i:], "/"))
+		}
+
+		next, err := lookupToken(v, token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s, %s", errInvalidPointer, pointer, err.Error())
+		}
+
+		v = next
+	}
+
+	v = unwrapEntity(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	return v.Interface(), nil
+}
+
+func lookupToken(v reflect.Value, token string) (reflect.Value, error) {
+	if v.Type() == propertiesType {
+		p, found := v.Interface().(*orderedmap.OrderedMap[string, SchemaOrRef]).Get(token) //nolint:forcetypeassert
+		if !found {
+			return reflect.Value{}, fmt.Errorf("missing key %q", token)
+		}
+
+		return reflect.ValueOf(p), nil
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("missing key %q", token)
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds are scalars.
+	case reflect.Struct:
+		return lookupField(v, token)
+	case reflect.Map:
+		if item := v.MapIndex(reflect.ValueOf(token)); item.IsValid() {
+			return item, nil
+		}
+	case reflect.Slice:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= v.Len() {
+			return reflect.Value{}, fmt.Errorf("bad index %q", token)
+		}
+
+		return v.Index(i), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unexpected scalar at %q", token)
+	}
+
+	return reflect.Value{}, fmt.Errorf("missing key %q", token)
+}
+
+// lookupField finds field by JSON name, or item of maps of patterned keys, e.g. `MapOfPathItemValues`.
+func lookupField(v reflect.Value, token string) (reflect.Value, error) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if name, _, _ := strings.Cut(f.Tag.Get("json"), ","); f.IsExported() && name == token && name != "-" {
+			return v.Field(i), nil
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if !strings.HasPrefix(f.Name, "MapOf") || f.Type.Kind() != reflect.Map {
+			continue
+		}
+
+		if (f.Name == "MapOfAnything") != strings.HasPrefix(token, "x-") {
+			continue
+		}
+
+		if item := v.Field(i).MapIndex(reflect.ValueOf(token)); item.IsValid() {
+			return item, nil
+		}
+	}
+
+	return reflect.Value{}, fmt.Errorf("missing key %q", token)
+}
+
+// unwrapEntity returns non-nil member of union types, e.g. *Schema of SchemaOrRef.
+func unwrapEntity(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		u := v
+		for u.Kind() == reflect.Ptr && !u.IsNil() && u.Type() != propertiesType {
+			u = u.Elem()
+		}
+
+		if u.Kind() == reflect.Interface {
+			return u
+		}
+
+		if u.Kind() != reflect.Struct || !isUnion(u.Type()) {
+			return v
+		}
+
+		v = reflect.Value{}
+
+		for i := 0; i < u.NumField(); i++ {
+			if !u.Field(i).IsNil() {
+				v = u.Field(i)
+
+				break
+			}
+		}
+	}
+
+	return v
+}
+
+func isUnion(t reflect.Type) bool {
+	if t.NumField() == 0 {
+		return false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if f.Tag.Get("json") != "-" || f.Type.Kind() != reflect.Ptr || f.Name == "MapOfAnything" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,71 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_LookupJSONPointer(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0, x-team: {owners: [alice]}}
+paths:
+  /things/{id}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Thing'}
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        tags: {type: array, items: {type: string}, example: [a, b]}
+`)))
+
+	v, err := s.LookupJSONPointer("/paths/~1things~1{id}/get")
+	require.NoError(t, err)
+	assert.IsType(t, openapi3.Operation{}, v)
+
+	v, err = s.LookupJSONPointer("/paths/~1things~1{id}/get/responses/200")
+	require.NoError(t, err)
+	assert.Equal(t, "OK", v.(*openapi3.Response).Description)
+
+	v, err = s.LookupJSONPointer("/paths/~1things~1{id}/get/parameters/0/name")
+	require.NoError(t, err)
+	assert.Equal(t, "id", v)
+
+	v, err = s.LookupJSONPointer("/paths/~1things~1{id}/get/responses/200/content/application~1json/schema")
+	require.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Thing", v.(*openapi3.SchemaReference).Ref)
+
+	v, err = s.LookupJSONPointer("/components/schemas/Thing/properties/tags/items")
+	require.NoError(t, err)
+	assert.Equal(t, openapi3.SchemaTypeString, *v.(*openapi3.Schema).Type)
+
+	v, err = s.LookupJSONPointer("/components/schemas/Thing/properties/tags/example/1")
+	require.NoError(t, err)
+	assert.Equal(t, "b", v)
+
+	v, err = s.LookupJSONPointer("/info/x-team/owners/0")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", v)
+
+	_, err = s.LookupJSONPointer("/paths/~1things~1{id}/post")
+	assert.EqualError(t, err, `invalid JSON pointer: /paths/~1things~1{id}/post, missing key "post"`)
+
+	_, err = s.LookupJSONPointer("/paths/~1things~1{id}/get/parameters/3")
+	assert.EqualError(t, err, `invalid JSON pointer: /paths/~1things~1{id}/get/parameters/3, bad index "3"`)
+
+	_, err = s.LookupJSONPointer("/openapi/version")
+	assert.EqualError(t, err, `invalid JSON pointer: /openapi/version, unexpected scalar at "version"`)
+}
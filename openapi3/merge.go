@@ -0,0 +1,480 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// MergeConflict defines handling of entities that have the same name in merged specs, but different content.
+type MergeConflict int
+
+// MergeConflict values enumeration.
+const (
+	// MergeConflictError fails merge, it is a default.
+	MergeConflictError = MergeConflict(iota)
+
+	// MergeConflictSkip keeps entity of destination.
+	MergeConflictSkip
+
+	// MergeConflictRename adds component of source with a number suffix (e.g. `Error2`) and updates references
+	// and security requirements of source, operations can not be renamed and fail merge,
+	// tags keep description of destination.
+	MergeConflictRename
+)
+
+// MergeOption configures Merge.
+type MergeOption func(o *mergeOptions)
+
+type mergeOptions struct {
+	conflict MergeConflict
+}
+
+// WithMergeConflict sets handling of conflicting entities.
+func WithMergeConflict(c MergeConflict) MergeOption {
+	return func(o *mergeOptions) {
+		o.conflict = c
+	}
+}
+
+// Merge adds paths, components, tags and security requirements of srcs to dst, e.g. to publish
+// a single document of specs built by different services.
+//
+// Equal entities are merged once, conflicting entities are handled according to WithMergeConflict.
+// Sources are not modified, info and servers of dst are kept.
+func Merge(dst *Spec, srcs []*Spec, opts ...MergeOption) error {
+	o := mergeOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for i, src := range srcs {
+		if err := o.merge(dst, src); err != nil {
+			return fmt.Errorf("merge spec %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// onConflict returns error if merge should fail, otherwise entity of source is skipped.
+func (o mergeOptions) onConflict(entity string) error {
+	if o.conflict == MergeConflictError {
+		return fmt.Errorf("conflicting %s", entity)
+	}
+
+	return nil
+}
+
+func (o mergeOptions) merge(dst, src *Spec) error {
+	dstComponents, err := componentsMap(dst.Components)
+	if err != nil {
+		return err
+	}
+
+	srcComponents, err := componentsMap(src.Components)
+	if err != nil {
+		return err
+	}
+
+	renames, err := o.renames(dstComponents, srcComponents)
+	if err != nil {
+		return err
+	}
+
+	if len(renames) > 0 {
+		if src, err = renameComponents(src, renames); err != nil {
+			return err
+		}
+
+		if srcComponents, err = componentsMap(src.Components); err != nil {
+			return err
+		}
+	}
+
+	if len(srcComponents) > 0 {
+		for kind, components := range srcComponents {
+			if dstComponents[kind] == nil {
+				dstComponents[kind] = map[string]json.RawMessage{}
+			}
+
+			for name, c := range components {
+				if _, found := dstComponents[kind][name]; !found {
+					dstComponents[kind][name] = c
+				}
+			}
+		}
+
+		data, err := openapi.JSON.Marshal(dstComponents)
+		if err != nil {
+			return err
+		}
+
+		extensions := dst.ComponentsEns().MapOfAnything
+
+		if err := dst.Components.UnmarshalJSON(data); err != nil {
+			return err
+		}
+
+		dst.Components.MapOfAnything = extensions
+	}
+
+	if err := o.mergePaths(dst, src); err != nil {
+		return err
+	}
+
+	if err := o.mergeTags(dst, src); err != nil {
+		return err
+	}
+
+	for _, req := range src.Security {
+		if !containsEqual(dst.Security, req) {
+			dst.Security = append(dst.Security, req)
+		}
+	}
+
+	return nil
+}
+
+func (o mergeOptions) mergePaths(dst, src *Spec) error {
+	paths := make([]string, 0, len(src.Paths.MapOfPathItemValues))
+	for path := range src.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		srcItem := src.Paths.MapOfPathItemValues[path]
+
+		dstItem, found := dst.Paths.MapOfPathItemValues[path]
+		if !found {
+			dstItem = srcItem
+			dstItem.MapOfOperationValues = nil
+		} else if !jsonEqual(dstItem.Parameters, srcItem.Parameters) {
+			if err := o.onConflict("parameters of path " + path); err != nil {
+				return err
+			}
+		}
+
+		methods := make([]string, 0, len(srcItem.MapOfOperationValues))
+		for method := range srcItem.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			op := srcItem.MapOfOperationValues[method]
+
+			if existing, found := dstItem.MapOfOperationValues[method]; found {
+				if jsonEqual(existing, op) {
+					continue
+				}
+
+				if o.conflict == MergeConflictRename {
+					return fmt.Errorf("conflicting operation %s %s can not be renamed", strings.ToUpper(method), path)
+				}
+
+				if err := o.onConflict(fmt.Sprintf("operation %s %s", strings.ToUpper(method), path)); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if op.ID != nil {
+				if err := dst.checkOperationID(method, path, *op.ID); err != nil {
+					return err
+				}
+			}
+
+			dstItem.WithMapOfOperationValuesItem(method, op)
+		}
+
+		dst.Paths.WithMapOfPathItemValuesItem(path, dstItem)
+	}
+
+	return nil
+}
+
+func (o mergeOptions) mergeTags(dst, src *Spec) error {
+	for _, tag := range src.Tags {
+		i := -1
+
+		for j, t := range dst.Tags {
+			if t.Name == tag.Name {
+				i = j
+
+				break
+			}
+		}
+
+		if i == -1 {
+			dst.Tags = append(dst.Tags, tag)
+
+			continue
+		}
+
+		if jsonEqual(dst.Tags[i], tag) {
+			continue
+		}
+
+		if err := o.onConflict("tag " + tag.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renames returns new names of conflicting source components by kinds.
+//
+// Renames are computed to a fixed point: a component that is equal to destination one, but refers to
+// a renamed component, resolves differently after merge and is renamed too.
+func (o mergeOptions) renames(dstComponents, srcComponents map[string]map[string]json.RawMessage) (
+	map[string]map[string]string, error,
+) {
+	renames := map[string]map[string]string{}
+	taken := map[string]bool{}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, kind := range sortedKeys(srcComponents) {
+			for _, name := range sortedKeys(srcComponents[kind]) {
+				if _, renamed := renames[kind][name]; renamed {
+					continue
+				}
+
+				existing, found := dstComponents[kind][name]
+				if !found {
+					continue
+				}
+
+				if bytes.Equal(existing, srcComponents[kind][name]) && !refersTo(srcComponents[kind][name], renames) {
+					continue
+				}
+
+				if o.conflict != MergeConflictRename {
+					if err := o.onConflict(fmt.Sprintf("component %s/%s", kind, name)); err != nil {
+						return nil, err
+					}
+
+					continue
+				}
+
+				newName := name
+				for i := 2; ; i++ {
+					newName = name + strconv.Itoa(i)
+
+					_, inDst := dstComponents[kind][newName]
+					_, inSrc := srcComponents[kind][newName]
+
+					if !inDst && !inSrc && !taken[kind+"/"+newName] {
+						break
+					}
+				}
+
+				if renames[kind] == nil {
+					renames[kind] = map[string]string{}
+				}
+
+				renames[kind][name] = newName
+				taken[kind+"/"+newName] = true
+				changed = true
+			}
+		}
+	}
+
+	return renames, nil
+}
+
+// refersTo checks if component has references to renamed components.
+func refersTo(component json.RawMessage, renames map[string]map[string]string) bool {
+	if len(renames) == 0 {
+		return false
+	}
+
+	var v interface{}
+	if err := openapi.JSON.Unmarshal(component, &v); err != nil {
+		return false
+	}
+
+	found := false
+
+	walkRefs(v, func(ref string) {
+		for kind, names := range renames {
+			for name := range names {
+				prefix := "#/components/" + kind + "/" + internal.EscapePointerToken(name)
+
+				if ref == prefix || strings.HasPrefix(ref, prefix+"/") {
+					found = true
+				}
+			}
+		}
+	})
+
+	return found
+}
+
+func walkRefs(v interface{}, fn func(ref string)) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := x["$ref"].(string); ok {
+			fn(ref)
+		}
+
+		for _, item := range x {
+			walkRefs(item, fn)
+		}
+	case []interface{}:
+		for _, item := range x {
+			walkRefs(item, fn)
+		}
+	}
+}
+
+// componentsMap returns components by names by kinds, e.g. `schemas`, extensions are omitted.
+func componentsMap(c *Components) (map[string]map[string]json.RawMessage, error) {
+	res := map[string]map[string]json.RawMessage{}
+
+	if c == nil {
+		return res, nil
+	}
+
+	data, err := openapi.JSON.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var kinds map[string]json.RawMessage
+
+	if err := openapi.JSON.Unmarshal(data, &kinds); err != nil {
+		return nil, err
+	}
+
+	for kind, raw := range kinds {
+		if strings.HasPrefix(kind, "x-") {
+			continue
+		}
+
+		var components map[string]json.RawMessage
+
+		if err := openapi.JSON.Unmarshal(raw, &components); err != nil {
+			return nil, err
+		}
+
+		res[kind] = components
+	}
+
+	return res, nil
+}
+
+// renameComponents returns a copy of spec with renamed components, references and security requirements.
+func renameComponents(s *Spec, renames map[string]map[string]string) (*Spec, error) {
+	data, err := openapi.JSON.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]interface{}
+
+	// Numbers are kept exact with json.Number, JSONCodec has no option for that.
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if c, ok := doc["components"].(map[string]interface{}); ok {
+		for kind, names := range renames {
+			if components, ok := c[kind].(map[string]interface{}); ok {
+				for name, newName := range names {
+					components[newName] = components[name]
+					delete(components, name)
+				}
+			}
+		}
+	}
+
+	renameRefs(doc, renames)
+
+	if data, err = openapi.JSON.Marshal(doc); err != nil {
+		return nil, err
+	}
+
+	renamed := &Spec{}
+
+	return renamed, renamed.UnmarshalJSON(data)
+}
+
+func renameRefs(v interface{}, renames map[string]map[string]string) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := x["$ref"].(string); ok {
+			for kind, names := range renames {
+				for name, newName := range names {
+					prefix := "#/components/" + kind + "/" + internal.EscapePointerToken(name)
+
+					if ref == prefix || strings.HasPrefix(ref, prefix+"/") {
+						x["$ref"] = "#/components/" + kind + "/" + internal.EscapePointerToken(newName) + ref[len(prefix):]
+					}
+				}
+			}
+		}
+
+		if reqs, ok := x["security"].([]interface{}); ok {
+			for _, req := range reqs {
+				if m, ok := req.(map[string]interface{}); ok {
+					for name, newName := range renames["securitySchemes"] {
+						if scopes, found := m[name]; found {
+							m[newName] = scopes
+							delete(m, name)
+						}
+					}
+				}
+			}
+		}
+
+		for _, item := range x {
+			renameRefs(item, renames)
+		}
+	case []interface{}:
+		for _, item := range x {
+			renameRefs(item, renames)
+		}
+	}
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ja, err := openapi.JSON.Marshal(a)
+	if err != nil {
+		return false
+	}
+
+	jb, err := openapi.JSON.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(ja, jb)
+}
+
+func containsEqual(reqs []map[string][]string, req map[string][]string) bool {
+	for _, r := range reqs {
+		if jsonEqual(r, req) {
+			return true
+		}
+	}
+
+	return false
+}
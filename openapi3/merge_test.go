@@ -0,0 +1,180 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func mergeSpecs(t *testing.T) (dst, users, orders *openapi3.Spec) {
+	t.Helper()
+
+	dst = &openapi3.Spec{}
+	require.NoError(t, dst.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Gateway, version: 1.0.0}
+paths: {}
+`)))
+
+	users = &openapi3.Spec{}
+	require.NoError(t, users.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Users, version: 1.0.0}
+tags: [{name: users}]
+security: [{bearer: []}]
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      tags: [users]
+      responses:
+        "200": {description: OK}
+        "400": {description: Bad, content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}}
+components:
+  schemas:
+    Error: {type: object, properties: {message: {type: string}}}
+  securitySchemes:
+    bearer: {type: http, scheme: bearer}
+`)))
+
+	orders = &openapi3.Spec{}
+	require.NoError(t, orders.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Orders, version: 1.0.0}
+tags: [{name: orders}]
+security: [{bearer: []}]
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      tags: [orders]
+      responses:
+        "200": {description: OK}
+        "400": {description: Bad, content: {application/json: {schema: {$ref: '#/components/schemas/Error'}}}}
+components:
+  schemas:
+    Error: {type: object, properties: {code: {type: integer}}}
+  securitySchemes:
+    bearer: {type: http, scheme: bearer}
+`)))
+
+	return dst, users, orders
+}
+
+func TestMerge(t *testing.T) {
+	dst, users, orders := mergeSpecs(t)
+
+	err := openapi3.Merge(dst, []*openapi3.Spec{users, orders})
+	assert.EqualError(t, err, "merge spec 1: conflicting component schemas/Error")
+
+	dst, users, orders = mergeSpecs(t)
+
+	require.NoError(t, openapi3.Merge(dst, []*openapi3.Spec{users, orders, users},
+		openapi3.WithMergeConflict(openapi3.MergeConflictRename)))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"Gateway","version":"1.0.0"},
+	  "tags":[{"name":"users"},{"name":"orders"}],
+	  "security":[{"bearer":[]}],
+	  "paths":{
+		"/users":{
+		  "get":{
+			"tags":["users"],"operationId":"listUsers",
+			"responses":{
+			  "200":{"description":"OK"},
+			  "400":{"description":"Bad","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error"}}}}
+			}
+		  }
+		},
+		"/orders":{
+		  "get":{
+			"tags":["orders"],"operationId":"listOrders",
+			"responses":{
+			  "200":{"description":"OK"},
+			  "400":{"description":"Bad","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error2"}}}}
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Error":{"type":"object","properties":{"message":{"type":"string"}}},
+		  "Error2":{"type":"object","properties":{"code":{"type":"integer"}}}
+		},
+		"securitySchemes":{"bearer":{"type":"http","scheme":"bearer"}}
+	  }
+	}`, dst)
+
+	// Sources are not modified.
+	assert.Contains(t, orders.Components.Schemas.MapOfSchemaOrRefValues, "Error")
+}
+
+func TestMerge_skip(t *testing.T) {
+	dst, users, orders := mergeSpecs(t)
+	orders.Paths.MapOfPathItemValues["/users"] = orders.Paths.MapOfPathItemValues["/orders"]
+
+	require.NoError(t, openapi3.Merge(dst, []*openapi3.Spec{users, orders},
+		openapi3.WithMergeConflict(openapi3.MergeConflictSkip)))
+
+	assert.Equal(t, "listUsers", *dst.Paths.MapOfPathItemValues["/users"].MapOfOperationValues["get"].ID)
+	assert.Len(t, dst.Components.Schemas.MapOfSchemaOrRefValues, 1)
+
+	dst, users, orders = mergeSpecs(t)
+	orders.Paths.MapOfPathItemValues["/users"] = orders.Paths.MapOfPathItemValues["/orders"]
+
+	err := openapi3.Merge(dst, []*openapi3.Spec{users, orders},
+		openapi3.WithMergeConflict(openapi3.MergeConflictRename))
+	assert.EqualError(t, err, "merge spec 1: conflicting operation GET /users can not be renamed")
+}
+
+func TestMerge_renameReferring(t *testing.T) {
+	dst := &openapi3.Spec{}
+	require.NoError(t, dst.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Gateway, version: 1.0.0}
+paths:
+  /d:
+    get:
+      responses:
+        "200": {description: OK, content: {application/json: {schema: {$ref: '#/components/schemas/A'}}}}
+components:
+  schemas:
+    A: {type: object, properties: {b: {$ref: '#/components/schemas/B'}}}
+    B: {type: string}
+`)))
+
+	src := &openapi3.Spec{}
+	require.NoError(t, src.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Service, version: 1.0.0}
+paths:
+  /s:
+    get:
+      responses:
+        "200": {description: OK, content: {application/json: {schema: {$ref: '#/components/schemas/A'}}}}
+components:
+  schemas:
+    A: {type: object, properties: {b: {$ref: '#/components/schemas/B'}}}
+    B: {type: integer}
+`)))
+
+	require.NoError(t, openapi3.Merge(dst, []*openapi3.Spec{src},
+		openapi3.WithMergeConflict(openapi3.MergeConflictRename)))
+
+	// A of source is equal by content, but refers to renamed B, so it is renamed too.
+	assertjson.EqMarshal(t, `{
+	  "schemas":{
+		"A":{"type":"object","properties":{"b":{"$ref":"#/components/schemas/B"}}},
+		"A2":{"type":"object","properties":{"b":{"$ref":"#/components/schemas/B2"}}},
+		"B":{"type":"string"},
+		"B2":{"type":"integer"}
+	  }
+	}`, dst.Components)
+
+	assertjson.EqMarshal(t, `{"$ref":"#/components/schemas/A2"}`,
+		dst.Paths.MapOfPathItemValues["/s"].MapOfOperationValues["get"].Responses.
+			MapOfResponseOrRefValues["200"].Response.Content["application/json"].Schema)
+}
@@ -0,0 +1,213 @@
+package openapi3
+
+import (
+	"reflect"
+	"strings"
+
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// xInternal is a vendor extension that marks operations and properties hidden from consumers.
+const xInternal = "x-internal"
+
+// Profile tailors marshaled document for its consumer, see Spec.MarshalProfile.
+type Profile struct {
+	// DropExtensions lists vendor extensions to remove from all objects, name ending with `*` is a prefix.
+	DropExtensions []string
+
+	// DropInternal removes operations and schema properties marked with `x-internal: true`.
+	DropInternal bool
+
+	// RelaxReadWriteOnly removes `readOnly` and `writeOnly` properties from `required`,
+	// so that generated clients do not demand server-managed values in requests and
+	// write-only values in responses.
+	RelaxReadWriteOnly bool
+}
+
+// ClientProfile prepares document for client generators and public docs.
+//
+// It drops source annotations and internal entities, and relaxes required readOnly/writeOnly properties.
+func ClientProfile() Profile {
+	return Profile{
+		DropExtensions:     []string{xSource, xGoSource, xInternal},
+		DropInternal:       true,
+		RelaxReadWriteOnly: true,
+	}
+}
+
+// ServerProfile keeps all details of document that are relevant for request validation and routing.
+func ServerProfile() Profile {
+	return Profile{}
+}
+
+// MarshalProfile produces JSON bytes of a document tailored with profile, document itself is not changed.
+func (s *Spec) MarshalProfile(p Profile) ([]byte, error) {
+	c, err := s.tailored(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MarshalJSON()
+}
+
+// MarshalProfileYAML produces YAML bytes of a document tailored with profile, document itself is not changed.
+func (s *Spec) MarshalProfileYAML(p Profile) ([]byte, error) {
+	c, err := s.tailored(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.MarshalYAML()
+}
+
+func (s *Spec) tailored(p Profile) (*Spec, error) {
+	j, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Spec{}
+	if err := c.UnmarshalJSON(j); err != nil {
+		return nil, err
+	}
+
+	if p.DropInternal {
+		for path, pi := range c.Paths.MapOfPathItemValues {
+			for method, op := range pi.MapOfOperationValues {
+				if isInternal(op.MapOfAnything) {
+					delete(pi.MapOfOperationValues, method)
+				}
+			}
+
+			if len(pi.MapOfOperationValues) == 0 && pi.Ref == nil {
+				delete(c.Paths.MapOfPathItemValues, path)
+			}
+		}
+	}
+
+	p.tailor(reflect.ValueOf(c).Elem())
+
+	return c, nil
+}
+
+func isInternal(ext map[string]interface{}) bool {
+	v, ok := ext[xInternal].(bool)
+
+	return ok && v
+}
+
+var (
+	typeOfSchema     = reflect.TypeOf(Schema{})
+	typeOfMapOfAny   = reflect.TypeOf(map[string]interface{}{})
+	typeOfProperties = reflect.TypeOf((*orderedmap.OrderedMap[string, SchemaOrRef])(nil))
+)
+
+// tailor walks addressable value of document entities.
+func (p Profile) tailor(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive // Other kinds have no nested entities.
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+
+		if v.Type() == typeOfProperties {
+			for pair := v.Interface().(*orderedmap.OrderedMap[string, SchemaOrRef]).Oldest(); pair != nil; pair = pair.Next() {
+				p.tailor(reflect.ValueOf(&pair.Value).Elem())
+			}
+
+			return
+		}
+
+		p.tailor(v.Elem())
+	case reflect.Struct:
+		if v.Type() == typeOfSchema {
+			p.tailorSchema(v.Addr().Interface().(*Schema))
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Field(i)
+
+			if !f.CanSet() {
+				continue
+			}
+
+			if f.Type() == typeOfMapOfAny && v.Type().Field(i).Name == "MapOfAnything" {
+				p.dropExtensions(f.Interface().(map[string]interface{}))
+
+				continue
+			}
+
+			p.tailor(f)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			p.tailor(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() == reflect.Interface {
+			return
+		}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(iter.Value())
+			p.tailor(e)
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+}
+
+func (p Profile) dropExtensions(ext map[string]interface{}) {
+	for name := range ext {
+		for _, d := range p.DropExtensions {
+			if name == d || (strings.HasSuffix(d, "*") && strings.HasPrefix(name, strings.TrimSuffix(d, "*"))) {
+				delete(ext, name)
+
+				break
+			}
+		}
+	}
+}
+
+func (p Profile) tailorSchema(s *Schema) {
+	if s.Properties == nil || (!p.DropInternal && !p.RelaxReadWriteOnly) {
+		return
+	}
+
+	drop := map[string]bool{}
+	relax := map[string]bool{}
+
+	for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+		ps := pair.Value.Schema
+		if ps == nil {
+			continue
+		}
+
+		if p.DropInternal && isInternal(ps.MapOfAnything) {
+			drop[pair.Key] = true
+		}
+
+		if p.RelaxReadWriteOnly && ((ps.ReadOnly != nil && *ps.ReadOnly) || (ps.WriteOnly != nil && *ps.WriteOnly)) {
+			relax[pair.Key] = true
+		}
+	}
+
+	for name := range drop {
+		s.Properties.Delete(name)
+	}
+
+	if len(drop) == 0 && len(relax) == 0 {
+		return
+	}
+
+	required := s.Required[:0:0]
+
+	for _, name := range s.Required {
+		if !drop[name] && !relax[name] {
+			required = append(required, name)
+		}
+	}
+
+	s.Required = required
+}
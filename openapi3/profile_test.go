@@ -0,0 +1,75 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_MarshalProfile(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Shop, version: 1.0.0}
+paths:
+  /orders:
+    post:
+      x-source: {file: orders.go}
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Order'}
+      responses: {200: {description: OK}}
+  /admin/orders:
+    delete:
+      x-internal: true
+      responses: {204: {description: No Content}}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id, item, password, shard]
+      properties:
+        id: {type: integer, readOnly: true}
+        item: {type: string}
+        password: {type: string, writeOnly: true}
+        shard: {type: integer, x-internal: true}
+`)))
+
+	j, err := s.MarshalProfile(openapi3.ClientProfile())
+	require.NoError(t, err)
+	assertjson.Equal(t, []byte(`{
+	  "openapi":"3.0.3","info":{"title":"Shop","version":"1.0.0"},
+	  "paths":{
+		"/orders":{
+		  "post":{
+			"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Order"}}}},
+			"responses":{"200":{"description":"OK"}}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Order":{
+			"required":["item"],"type":"object",
+			"properties":{
+			  "id":{"type":"integer","readOnly":true},"item":{"type":"string"},
+			  "password":{"type":"string","writeOnly":true}
+			}
+		  }
+		}
+	  }
+	}`), j)
+
+	// Document itself is not changed.
+	j2, err := s.MarshalProfile(openapi3.ServerProfile())
+	require.NoError(t, err)
+
+	j, err = s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(j), string(j2))
+	assert.Contains(t, string(j), `"x-internal":true`)
+}
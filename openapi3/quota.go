@@ -0,0 +1,23 @@
+package openapi3
+
+import (
+	"errors"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// SetQuota sets `x-quota` extension of operation with rate limits.
+func SetQuota(oc openapi.OperationContext, q openapi.Quota) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	if err := q.Validate(); err != nil {
+		return err
+	}
+
+	e.Operation().WithMapOfAnythingItem(openapi.XQuota, q)
+
+	return nil
+}
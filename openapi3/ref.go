@@ -0,0 +1,182 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// ResolvePathItemRefs replaces path items that have `$ref` with referenced content.
+//
+// External documents (JSON or YAML) are read with readDoc, for example os.ReadFile,
+// local references (starting with `#`) are resolved against the document that contains them,
+// so that references of a loaded path item keep pointing to its own document.
+func (s *Spec) ResolvePathItemRefs(readDoc func(location string) ([]byte, error)) error {
+	root, err := s.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	l := NewLoader(RefLoaderFunc(func(_ context.Context, location string) ([]byte, error) {
+		if readDoc == nil {
+			return nil, errors.New("missing document reader")
+		}
+
+		return readDoc(location)
+	}))
+
+	if err := l.LoadFromData(rootLocation, root); err != nil {
+		return err
+	}
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		if pi.Ref == nil {
+			continue
+		}
+
+		resolved, err := resolvePathItem(l, *pi.Ref)
+		if err != nil {
+			return fmt.Errorf("resolve path item %s: %w", path, err)
+		}
+
+		s.Paths.MapOfPathItemValues[path] = resolved
+	}
+
+	return nil
+}
+
+// rootLocation is a location of spec document in Loader of ResolvePathItemRefs,
+// it is not a file path or URL, and relative locations are resolved against working directory.
+const rootLocation = "spec:root"
+
+func resolvePathItem(l *Loader, ref string) (PathItem, error) {
+	var pi PathItem
+
+	r, err := l.Resolve(context.Background(), rootLocation, ref)
+	if err != nil {
+		return pi, err
+	}
+
+	v := r.Value
+
+	// References of root document stay local.
+	if r.Location != rootLocation {
+		v = rebaseRefs(v, r.Location)
+	}
+
+	data, err := openapi.JSON.Marshal(v)
+	if err != nil {
+		return pi, err
+	}
+
+	if err := pi.UnmarshalJSON(data); err != nil {
+		return pi, fmt.Errorf("%s: %w", ref, err)
+	}
+
+	return pi, nil
+}
+
+// rebaseRefs returns a copy of value with references relative to location of its document,
+// so that they stay valid when value is moved to root document.
+func rebaseRefs(v interface{}, location string) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(x))
+
+		for k, item := range x {
+			if ref, ok := item.(string); ok && k == "$ref" {
+				refLocation, _ := splitRef(ref)
+				fragment := ref[len(refLocation):]
+
+				if refLocation == "" {
+					refLocation = location
+				} else {
+					refLocation = joinLocation(location, refLocation)
+				}
+
+				res[k] = refLocation + fragment
+
+				continue
+			}
+
+			res[k] = rebaseRefs(item, location)
+		}
+
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(x))
+
+		for i, item := range x {
+			res[i] = rebaseRefs(item, location)
+		}
+
+		return res
+	}
+
+	return v
+}
+
+// ExternalizePathItem replaces path item with a reference to location and returns original path item.
+//
+// Returned path item should be stored in a document at location, so that ResolvePathItemRefs can load it back.
+func (s *Spec) ExternalizePathItem(path, location string) (PathItem, error) {
+	pi, found := s.Paths.MapOfPathItemValues[path]
+	if !found {
+		return PathItem{}, fmt.Errorf("path item not found: %s", path)
+	}
+
+	s.Paths.MapOfPathItemValues[path] = PathItem{Ref: &location}
+
+	return pi, nil
+}
+
+func splitRef(ref string) (location, pointer string) {
+	if pos := strings.Index(ref, "#"); pos >= 0 {
+		return ref[:pos], ref[pos+1:]
+	}
+
+	return ref, ""
+}
+
+var errInvalidPointer = errors.New("invalid JSON pointer")
+
+// resolveJSONPointer finds value in a generic JSON document, see RFC 6901.
+func resolveJSONPointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" {
+		return doc, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %s", errInvalidPointer, pointer)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = internal.UnescapePointerToken(token)
+
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			next, found := v[token]
+			if !found {
+				return nil, fmt.Errorf("%w: %s, missing key %q", errInvalidPointer, pointer, token)
+			}
+
+			doc = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%w: %s, bad index %q", errInvalidPointer, pointer, token)
+			}
+
+			doc = v[i]
+		default:
+			return nil, fmt.Errorf("%w: %s, unexpected scalar at %q", errInvalidPointer, pointer, token)
+		}
+	}
+
+	return doc, nil
+}
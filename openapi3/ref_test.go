@@ -0,0 +1,97 @@
+package openapi3_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_ResolvePathItemRefs(t *testing.T) {
+	docs := map[string][]byte{
+		"users.yaml": []byte(`
+/users:
+  get:
+    summary: List users
+    responses:
+      "200":
+        description: OK
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Users'}
+/admins:
+  $ref: '#/~1users'
+`),
+	}
+
+	readDoc := func(location string) ([]byte, error) {
+		if d, ok := docs[location]; ok {
+			return d, nil
+		}
+
+		return nil, errors.New("not found: " + location)
+	}
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Gateway, version: 1.0.0}
+paths:
+  /users:
+    $ref: 'users.yaml#/~1users'
+  /people:
+    $ref: '#/paths/~1users'
+  /admins:
+    $ref: 'users.yaml#/~1admins'
+`)))
+
+	require.NoError(t, s.ResolvePathItemRefs(readDoc))
+
+	usersGet := `{"get":{"summary":"List users","responses":{"200":{
+	  "description":"OK",
+	  "content":{"application/json":{"schema":{"$ref":"users.yaml#/components/schemas/Users"}}}
+	}}}}`
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"Gateway","version":"1.0.0"},
+	  "paths":{"/admins":`+usersGet+`,"/people":`+usersGet+`,"/users":`+usersGet+`}
+	}`, s)
+}
+
+func TestSpec_ResolvePathItemRefs_circular(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Gateway, version: 1.0.0}
+paths:
+  /a:
+    $ref: '#/paths/~1b'
+  /b:
+    $ref: '#/paths/~1a'
+`)))
+
+	err := s.ResolvePathItemRefs(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular reference")
+}
+
+func TestSpec_ExternalizePathItem(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.SetupOperation("GET", "/users", func(op *openapi3.Operation) error {
+		op.WithSummary("List users")
+
+		return nil
+	}))
+
+	pi, err := s.ExternalizePathItem("/users", "users.json")
+	require.NoError(t, err)
+
+	assertjson.EqMarshal(t, `{"get":{"summary":"List users","responses":{}}}`, pi)
+	assertjson.EqMarshal(t, `{"/users":{"$ref":"users.json"}}`, s.Paths)
+
+	_, err = s.ExternalizePathItem("/missing", "missing.json")
+	assert.Error(t, err)
+}
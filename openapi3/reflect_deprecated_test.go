@@ -60,7 +60,10 @@ func TestReflector_SetRequest_uploadInterface(t *testing.T) {
 		  "post":{
 			"requestBody":{
 			  "content":{
-				"multipart/form-data":{"schema":{"$ref":"#/components/schemas/FormDataOpenapi3TestReq"}}
+				"multipart/form-data":{
+				  "schema":{"$ref":"#/components/schemas/FormDataOpenapi3TestReq"},
+				  "encoding":{"upload1":{"contentType":"application/octet-stream"}}
+				}
 			  }
 			},
 			"responses":{"204":{"description":"No Content"}}
@@ -333,7 +336,8 @@ func TestReflector_SetupRequest(t *testing.T) {
 					  "upload6":{"$ref":"#/components/schemas/MultipartFile"},
 					  "value3":{"type":"number"}
 					}
-				  }
+				  },
+				  "encoding":{"upload6":{"contentType":"application/octet-stream"}}
 				}
 			  }
 			},
@@ -5,9 +5,11 @@ package openapi3_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/assertjson"
 	"github.com/swaggest/openapi-go"
@@ -164,3 +166,56 @@ func Test_Foo(t *testing.T) {
 	  }
 	}`, reflector.Spec)
 }
+
+type Page[T any] struct {
+	Items []T    `json:"items"`
+	Next  string `json:"next"`
+}
+
+type Pair[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
+func TestReflector_GenericNames(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.GenericNames(nil)
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/users")
+	require.NoError(t, err)
+	oc.AddRespStructure(Page[User]{})
+	oc.AddRespStructure(Page[Pair[string, []*User]]{}, openapi.WithHTTPStatus(http.StatusAccepted))
+	require.NoError(t, r.AddOperation(oc))
+
+	r2 := openapi3.NewReflector()
+	r2.GenericNames(func(base string, args []string) string {
+		return base + "_" + strings.Join(args, "_")
+	})
+
+	oc, err = r2.NewOperationContext(http.MethodGet, "/users")
+	require.NoError(t, err)
+	oc.AddRespStructure(Page[map[string]User]{})
+	require.NoError(t, r2.AddOperation(oc))
+
+	var names []string
+	for name := range r.Spec.Components.Schemas.MapOfSchemaOrRefValues {
+		names = append(names, name)
+	}
+
+	for name := range r2.Spec.Components.Schemas.MapOfSchemaOrRefValues {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"Openapi3TestPageOfUser",
+		"Openapi3TestPageOfPairOfStringAndListOfUser",
+		"Openapi3TestPairOfStringAndListOfUser",
+		"Openapi3TestUser",
+		"Openapi3TestPage_Map_String_User",
+		"Openapi3TestUser",
+	}, names)
+}
@@ -1,11 +1,18 @@
 package openapi3_test
 
 import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"math/big"
 	"mime/multipart"
 	"net/http"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +20,7 @@ import (
 	"github.com/swaggest/jsonschema-go"
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
 )
 
 type WeirdResp interface {
@@ -140,7 +148,10 @@ func TestReflector_AddOperation_uploadInterface(t *testing.T) {
 		  "post":{
 			"requestBody":{
 			  "content":{
-				"multipart/form-data":{"schema":{"$ref":"#/components/schemas/FormDataOpenapi3TestReq"}}
+				"multipart/form-data":{
+				  "schema":{"$ref":"#/components/schemas/FormDataOpenapi3TestReq"},
+				  "encoding":{"upload1":{"contentType":"application/octet-stream"}}
+				}
 			  }
 			},
 			"responses":{"204":{"description":"No Content"}}
@@ -451,7 +462,8 @@ func TestReflector_AddOperation_setup_request(t *testing.T) {
 					  "upload6":{"$ref":"#/components/schemas/MultipartFile"},
 					  "value3":{"type":"number"}
 					}
-				  }
+				  },
+				  "encoding":{"upload6":{"contentType":"application/octet-stream"}}
 				}
 			  }
 			},
@@ -1124,6 +1136,45 @@ func TestReflector_AddOperation_defName(t *testing.T) {
 	}`, r.Spec)
 }
 
+func TestReflector_AddTypeMapping(t *testing.T) {
+	type uuid [16]byte
+
+	type decimal struct {
+		value *big.Int
+		exp   int32
+	}
+
+	type account struct {
+		ID      uuid           `json:"id"`
+		Balance decimal        `json:"balance"`
+		Nick    sql.NullString `json:"nick"`
+		Seen    sql.NullTime   `json:"seen"`
+	}
+
+	r := openapi3.NewReflector()
+	r.SQLNullTypes()
+	r.InlineDefinition(sql.NullString{})
+	r.AddTypeMapping(uuid{}, (&openapi3.Schema{}).WithType(openapi3.SchemaTypeString).WithFormat("uuid"))
+	r.AddTypeMapping(decimal{}, "")
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/account")
+	require.NoError(t, err)
+	oc.AddRespStructure(account{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestAccount":{
+		"type":"object",
+		"properties":{
+		  "balance":{"type":"string"},"id":{"$ref":"#/components/schemas/Openapi3TestUuid"},
+		  "nick":{"type":"string","nullable":true},"seen":{"$ref":"#/components/schemas/SqlNullTime"}
+		}
+	  },
+	  "Openapi3TestUuid":{"type":"string","format":"uuid"},
+	  "SqlNullTime":{"type":"string","format":"date-time","nullable":true}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+}
+
 func TestReflector_AddOperation_jsonschemaStruct(t *testing.T) {
 	r := openapi3.NewReflector()
 
@@ -1461,3 +1512,1521 @@ func TestSelfReference(t *testing.T) {
 	  }
 	}`, reflector.SpecSchema())
 }
+
+func TestReflector_RequiredPolicy(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.RequestRequiredPolicy = openapi.RequiredUnlessOptional
+	r.ResponseRequiredPolicy = openapi.RequiredUnlessOmitEmpty
+
+	type req struct {
+		Name     string  `json:"name"`
+		Nickname *string `json:"nickname"`
+		Age      int     `json:"age,omitempty"`
+		Email    string  `json:"email" required:"false"`
+	}
+
+	type resp struct {
+		ID       int     `json:"id"`
+		Nickname *string `json:"nickname"`
+		Age      int     `json:"age,omitempty"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure(resp{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/users":{
+		  "post":{
+			"requestBody":{
+			  "content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestReqRequest"}}
+			  }
+			},
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{
+				  "application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestResp"}}
+				}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Openapi3TestReqRequest":{
+			"required":["name"],"type":"object",
+			"properties":{
+			  "name":{"type":"string"},"nickname":{"type":"string","nullable":true},
+			  "age":{"type":"integer"},"email":{"type":"string"}
+			}
+		  },
+		  "Openapi3TestResp":{
+			"required":["id","nickname"],"type":"object",
+			"properties":{
+			  "id":{"type":"integer"},"nickname":{"type":"string","nullable":true},
+			  "age":{"type":"integer"}
+			}
+		  }
+		}
+	  }
+	}`, r.SpecSchema())
+}
+
+func TestReflector_RequiredPolicy_sharedType(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name,omitempty"`
+	}
+
+	r := openapi3.NewReflector()
+	r.ResponseRequiredPolicy = openapi.RequiredUnlessOmitEmpty
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/users")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(user{})
+	oc.AddRespStructure(user{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestUser":{
+		"required":["id"],"type":"object",
+		"properties":{"id":{"type":"integer"},"name":{"type":"string"}}
+	  },
+	  "Openapi3TestUserRequest":{
+		"type":"object","properties":{"id":{"type":"integer"},"name":{"type":"string"}}
+	  }
+	}`, r.Spec.Components.Schemas)
+
+	// Equal policies share a component.
+	r = openapi3.NewReflector()
+
+	oc, err = r.NewOperationContext(http.MethodPut, "/users")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(user{})
+	oc.AddRespStructure(user{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assert.Len(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, 1)
+	assert.Contains(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, "Openapi3TestUser")
+}
+
+func TestReflector_Nullability(t *testing.T) {
+	type resp struct {
+		ID       int     `json:"id"`
+		Nickname *string `json:"nickname"`
+		Age      *int    `json:"age,omitempty"`
+		Alias    *string `json:"alias" nullable:"true"`
+	}
+
+	for _, tc := range []struct {
+		policy openapi.NullabilityPolicy
+		schema string
+	}{
+		{
+			policy: openapi.PointerNullable,
+			schema: `{
+			  "required":["id","nickname","alias"],"type":"object",
+			  "properties":{
+				"id":{"type":"integer"},"nickname":{"type":"string","nullable":true},
+				"age":{"type":"integer","nullable":true},"alias":{"type":"string","nullable":true}
+			  }
+			}`,
+		},
+		{
+			policy: openapi.PointerOptional,
+			schema: `{
+			  "required":["id"],"type":"object",
+			  "properties":{
+				"id":{"type":"integer"},"nickname":{"type":"string"},
+				"age":{"type":"integer"},"alias":{"type":"string","nullable":true}
+			  }
+			}`,
+		},
+		{
+			policy: openapi.PointerNullableOptional,
+			schema: `{
+			  "required":["id"],"type":"object",
+			  "properties":{
+				"id":{"type":"integer"},"nickname":{"type":"string","nullable":true},
+				"age":{"type":"integer","nullable":true},"alias":{"type":"string","nullable":true}
+			  }
+			}`,
+		},
+	} {
+		r := openapi3.NewReflector()
+		r.ResponseRequiredPolicy = openapi.RequiredUnlessOmitEmpty
+		r.Nullability = tc.policy
+
+		oc, err := r.NewOperationContext(http.MethodGet, "/users")
+		require.NoError(t, err)
+
+		oc.AddRespStructure(resp{})
+		require.NoError(t, r.AddOperation(oc))
+
+		assertjson.EqMarshal(t, tc.schema, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestResp"])
+	}
+}
+
+func TestReflector_JSONSchemaReflector(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	// Access to low-level reflector does not change options, so it is safe for concurrent use.
+	n := len(r.JSONSchemaReflector().DefaultOptions)
+	assert.Len(t, r.JSONSchemaReflector().DefaultOptions, n)
+
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		oc, err := r.NewOperationContext(method, "/users")
+		require.NoError(t, err)
+
+		oc.AddRespStructure(struct {
+			Name *string `json:"name"`
+		}{})
+		require.NoError(t, r.AddOperation(oc))
+	}
+
+	// Interceptors of reflector options are registered once.
+	assert.Len(t, r.JSONSchemaReflector().DefaultOptions, n+14)
+}
+
+func TestReflector_AnnotateSource(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.AnnotateSource = true
+
+	type req struct {
+		ID string `path:"id"`
+	}
+
+	type resp struct {
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id}")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure(resp{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	src, ok := r.Spec.Paths.MapOfPathItemValues["/things/{id}"].MapOfOperationValues["get"].MapOfAnything["x-source"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Contains(t, src["file"], "reflect_test.go:")
+	assert.Equal(t, "github.com/swaggest/openapi-go/openapi3_test.TestReflector_AnnotateSource", src["function"])
+	assert.Equal(t, []string{"openapi3_test.req"}, src["request"])
+	assert.Equal(t, []string{"openapi3_test.resp"}, src["response"])
+
+	y, err := r.Spec.MarshalAnnotatedYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(y), `  /things/{id}:
+    # file: `+src["file"].(string)+`
+    # function: github.com/swaggest/openapi-go/openapi3_test.TestReflector_AnnotateSource
+    # request: [openapi3_test.req]
+    # response: [openapi3_test.resp]
+    get:`)
+	assert.NotContains(t, string(y), "x-source")
+}
+
+func TestReflector_SourceOf(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.EmitGoSource = true
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id:[0-9]+}")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(struct {
+		ID int `path:"id"`
+	}{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	src, found := r.SourceOf("GET", "/things/{id}")
+	require.True(t, found)
+	assert.Contains(t, src.String(), "reflect_test.go:")
+	assert.Equal(t, "github.com/swaggest/openapi-go/openapi3_test.TestReflector_SourceOf", src.Function)
+	assert.Equal(t, src.String(), r.Spec.Paths.MapOfPathItemValues["/things/{id}"].MapOfOperationValues["get"].MapOfAnything["x-go-source"])
+
+	_, found = r.SourceOf("POST", "/things/{id}")
+	assert.False(t, found)
+}
+
+func TestReflector_AddOperation_queryStyle(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type filter struct {
+		Status string `json:"status"`
+		Limit  int    `json:"limit"`
+	}
+
+	type req struct {
+		Filter filter `query:"filter" style:"form" explode:"true"`
+		Deep   filter `query:"deep" style:"deepObject"`
+		Raw    filter `query:"raw"`
+		IDs    []int  `path:"ids" style:"label"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{ids}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[
+	  {
+		"name":"filter","in":"query","style":"form","explode":true,
+		"schema":{"$ref":"#/components/schemas/Openapi3TestFilter"}
+	  },
+	  {
+		"name":"deep","in":"query","style":"deepObject","explode":true,
+		"schema":{"$ref":"#/components/schemas/Openapi3TestFilter"}
+	  },
+	  {
+		"name":"raw","in":"query",
+		"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestFilter"}}}
+	  },
+	  {
+		"name":"ids","in":"path","required":true,"style":"label",
+		"schema":{"items":{"type":"integer"},"type":"array"}
+	  }
+	]`, r.Spec.Paths.MapOfPathItemValues["/things/{ids}"].MapOfOperationValues["get"].Parameters)
+
+	type badReq struct {
+		Trace filter `header:"X-Trace" style:"deepObject"`
+	}
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/bad")
+	require.NoError(t, err)
+	oc.AddReqStructure(badReq{})
+	assert.EqualError(t, r.AddOperation(oc), "setup request get /bad: parameter X-Trace: "+
+		`style "deepObject" is not allowed in header, use one of [simple]`)
+}
+
+func TestReflector_AddOperationContext(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	type order struct {
+		ID    int    `json:"id"`
+		Items []item `json:"items"`
+	}
+
+	r := openapi3.NewReflector()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Cancel in the middle of reflection.
+	r.DefaultOptions = append(r.DefaultOptions, jsonschema.InterceptSchema(
+		func(params jsonschema.InterceptSchemaParams) (bool, error) {
+			if params.Value.Type() == reflect.TypeOf(item{}) {
+				cancel()
+			}
+
+			return false, nil
+		}))
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/orders")
+	require.NoError(t, err)
+	oc.AddRespStructure(order{})
+
+	err = r.AddOperationContext(ctx, oc)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, r.Spec.Paths.MapOfPathItemValues)
+
+	// Done context is not reflected.
+	assert.Equal(t, context.Canceled, r.AddOperationContext(ctx, oc))
+
+	require.NoError(t, r.AddOperationContext(context.Background(), oc))
+	assert.Contains(t, r.Spec.Paths.MapOfPathItemValues, "/orders")
+}
+
+func TestReflector_SchemaPlacement(t *testing.T) {
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	type order struct {
+		ID    int    `json:"id"`
+		Items []item `json:"items,omitempty"`
+	}
+
+	type node struct {
+		Children []node `json:"children,omitempty"`
+	}
+
+	r := openapi3.NewReflector()
+	r.SchemaPlacement = openapi.SchemaInline
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.AddReqStructure(order{})
+	oc.AddRespStructure(item{}, openapi.WithSchemaPlacement(openapi.SchemaRef))
+	oc.AddRespStructure(node{}, openapi.WithHTTPStatus(http.StatusAccepted))
+	require.NoError(t, r.AddOperation(oc))
+
+	op := r.Spec.Paths.MapOfPathItemValues["/orders"].MapOfOperationValues["post"]
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"id":{"type":"integer"},
+		"items":{"type":"array","items":{"type":"object","properties":{"name":{"type":"string"}}}}
+	  }
+	}`, op.RequestBody.RequestBody.Content["application/json"].Schema)
+
+	assertjson.EqMarshal(t, `{"$ref":"#/components/schemas/Openapi3TestItem"}`,
+		op.Responses.MapOfResponseOrRefValues["200"].Response.Content["application/json"].Schema)
+
+	// Recursive structures keep references.
+	assertjson.EqMarshal(t, `{"$ref":"#/components/schemas/Openapi3TestNode"}`,
+		op.Responses.MapOfResponseOrRefValues["202"].Response.Content["application/json"].Schema)
+
+	assert.Len(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, 2)
+}
+
+func TestReflector_SchemaNamer(t *testing.T) {
+	type addressDTO struct {
+		City string `json:"city"`
+	}
+
+	type userDTO struct {
+		Name    string     `json:"name"`
+		Address addressDTO `json:"address"`
+	}
+
+	type problem struct {
+		Title string `json:"title"`
+	}
+
+	r := openapi3.NewReflector()
+	r.SchemaNamer(func(t reflect.Type) string {
+		if !strings.HasSuffix(t.Name(), "DTO") {
+			return ""
+		}
+
+		return "V1" + strings.Title(strings.TrimSuffix(t.Name(), "DTO")) //nolint:staticcheck // ASCII names.
+	})
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/user")
+	require.NoError(t, err)
+	oc.AddRespStructure(userDTO{})
+	oc.AddRespStructure(problem{}, openapi.WithHTTPStatus(http.StatusBadRequest))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestProblem":{"type":"object","properties":{"title":{"type":"string"}}},
+	  "V1Address":{"type":"object","properties":{"city":{"type":"string"}}},
+	  "V1User":{
+		"type":"object",
+		"properties":{"address":{"$ref":"#/components/schemas/V1Address"},"name":{"type":"string"}}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+}
+
+type exampleThing struct {
+	Name string `json:"name"`
+}
+
+func (exampleThing) Examples() map[string]interface{} {
+	return map[string]interface{}{
+		"short": exampleThing{Name: "Bob"},
+		"long":  exampleThing{Name: "Robert"},
+	}
+}
+
+func TestReflector_AddOperation_namedExamples(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(exampleThing{})
+	oc.AddRespStructure(exampleThing{}, openapi.WithExamples(map[string]interface{}{
+		"long":  map[string]interface{}{"name": "Roberta"},
+		"empty": map[string]interface{}{"name": ""},
+	}))
+	require.NoError(t, r.AddOperation(oc))
+
+	op := r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["post"]
+
+	assertjson.EqMarshal(t, `{
+	  "long":{"value":{"name":"Robert"}},"short":{"value":{"name":"Bob"}}
+	}`, op.RequestBody.RequestBody.Content["application/json"].Examples)
+
+	assertjson.EqMarshal(t, `{
+	  "empty":{"value":{"name":""}},"long":{"value":{"name":"Roberta"}},"short":{"value":{"name":"Bob"}}
+	}`, op.Responses.MapOfResponseOrRefValues["200"].Response.Content["application/json"].Examples)
+}
+
+func TestReflector_NameCollision(t *testing.T) {
+	user := func() interface{} {
+		type user struct {
+			ID int `json:"id"`
+		}
+
+		return user{}
+	}
+
+	// Another type with the same name, e.g. from another package.
+	otherUser := func() interface{} {
+		type user struct {
+			Name string `json:"name"`
+		}
+
+		return user{}
+	}
+
+	add := func(r *openapi3.Reflector, path string, resp interface{}) error {
+		oc, err := r.NewOperationContext(http.MethodGet, path)
+		require.NoError(t, err)
+		oc.AddRespStructure(resp)
+
+		return r.AddOperation(oc)
+	}
+
+	r := openapi3.NewReflector()
+	r.NameCollision(openapi.NameCollisionSuffix)
+	require.NoError(t, add(r, "/users", user()))
+	require.NoError(t, add(r, "/other-users", otherUser()))
+	require.NoError(t, add(r, "/users/again", user()))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestUser":{"type":"object","properties":{"id":{"type":"integer"}}},
+	  "Openapi3TestUser2":{"type":"object","properties":{"name":{"type":"string"}}}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+
+	r = openapi3.NewReflector()
+	r.NameCollision(openapi.NameCollisionError)
+	require.NoError(t, add(r, "/users", user()))
+
+	err := add(r, "/other-users", otherUser())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "setup response get /other-users: component name Openapi3TestUser of ")
+	assert.Contains(t, err.Error(), "openapi3_test.user collides with ")
+
+	// Colliding names are prefixed with package path when it is not a part of name yet.
+	r = openapi3.NewReflector()
+	r.DefaultOptions = append(r.DefaultOptions, jsonschema.InterceptDefName(func(t reflect.Type, _ string) string {
+		return t.Name()
+	}))
+	r.NameCollision(openapi.NameCollisionPackage)
+	require.NoError(t, add(r, "/a", openapi3.Contact{}))
+	require.NoError(t, add(r, "/b", openapi31.Contact{}))
+	require.NoError(t, add(r, "/c", user()))
+	require.NoError(t, add(r, "/d", otherUser()))
+
+	var names []string
+	for name := range r.Spec.Components.Schemas.MapOfSchemaOrRefValues {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{"Contact", "Openapi31Contact", "user", "Openapi3Testuser"}, names)
+}
+
+func TestReflector_NameCollision_optionsOrder(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	r := openapi3.NewReflector()
+
+	// Collisions are resolved for names of namer regardless of order of options, repeated option replaces value.
+	r.NameCollision(openapi.NameCollisionSuffix)
+	r.SchemaNamer(func(_ reflect.Type) string { return "Person" })
+	r.SchemaNamer(func(_ reflect.Type) string { return "User" })
+
+	for _, resp := range []interface{}{user{}, openapi3.Contact{}} {
+		oc, err := r.NewOperationContext(http.MethodGet, "/"+reflect.TypeOf(resp).Name())
+		require.NoError(t, err)
+		oc.AddRespStructure(resp)
+		require.NoError(t, r.AddOperation(oc))
+	}
+
+	assert.Len(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, 2)
+	assert.Contains(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, "User")
+	assert.Contains(t, r.Spec.Components.Schemas.MapOfSchemaOrRefValues, "User2")
+}
+
+func TestReflector_DurationPolicy(t *testing.T) {
+	type req struct {
+		Timeout  time.Duration  `query:"timeout" default:"90000000000"`
+		TTL      time.Duration  `json:"ttl" format:"go-duration" example:"0"`
+		Interval *time.Duration `json:"interval,omitempty" example:"3723500000000"`
+		Since    time.Time      `json:"since" format:"date"`
+	}
+
+	r := openapi3.NewReflector()
+	r.DurationPolicy(openapi.DurationString)
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/jobs")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[{
+	  "name":"timeout","in":"query",
+	  "schema":{"type":"string","format":"duration","default":"PT1M30S"}
+	}]`, r.Spec.Paths.MapOfPathItemValues["/jobs"].MapOfOperationValues["post"].Parameters)
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"interval":{"type":"string","format":"duration","example":"PT1H2M3.5S"},
+		"since":{"type":"string","format":"date"},
+		"ttl":{"type":"string","format":"go-duration","example":"PT0S"}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestReq"])
+}
+
+func TestReflector_Int64Policy(t *testing.T) {
+	type order struct {
+		ID      int64             `json:"id" example:"9007199254740993"`
+		Version *uint64           `json:"version,omitempty" default:"1"`
+		Count   int               `json:"count" example:"3"`
+		Items   []int64           `json:"items"`
+		Totals  map[string]*int64 `json:"totals"`
+	}
+
+	r := openapi3.NewReflector()
+	r.Int64Policy = openapi.Int64AsString
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/orders")
+	require.NoError(t, err)
+	oc.AddRespStructure(order{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"count":{"type":"integer","example":3},
+		"id":{"type":"string","format":"int64","example":"9007199254740993"},
+		"version":{"type":"string","format":"uint64","default":"1","nullable":true},
+		"items":{"type":"array","items":{"type":"string","format":"int64"},"nullable":true},
+		"totals":{
+		  "type":"object","additionalProperties":{"type":"string","format":"int64","nullable":true},"nullable":true
+		}
+	  },
+	  "type":"object"
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestOrder"])
+
+	r = openapi3.NewReflector()
+	r.Int64Policy = openapi.Int64Fail
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/orders")
+	require.NoError(t, err)
+	oc.AddRespStructure(order{})
+	assert.EqualError(t, r.AddOperation(oc), "setup response get /orders: field ID: "+
+		"value 9007199254740993 exceeds safe integer range of JSON numbers (9007199254740991)")
+}
+
+func TestReflector_TimeFormat(t *testing.T) {
+	type event struct {
+		At       time.Time  `json:"at" example:"2024-01-02T03:04:05+02:00"`
+		Deadline *time.Time `json:"deadline,omitempty" default:"2024-01-02T00:00:00Z"`
+		Day      time.Time  `json:"day" format:"date" example:"2024-01-02T03:04:05+02:00"`
+		Note     string     `json:"note" example:"2024-01-02T03:04:05+02:00"`
+	}
+
+	for _, tc := range []struct {
+		format   openapi.TimeFormat
+		expected string
+	}{
+		{format: openapi.TimeKeep, expected: `{
+		  "at":{"type":"string","format":"date-time","example":"2024-01-02T03:04:05+02:00"},
+		  "deadline":{"type":"string","format":"date-time","default":"2024-01-02T00:00:00Z","nullable":true},
+		  "day":{"type":"string","format":"date","example":"2024-01-02T03:04:05+02:00"}
+		}`},
+		{format: openapi.TimeUTC, expected: `{
+		  "at":{"type":"string","format":"date-time","example":"2024-01-02T01:04:05Z"},
+		  "deadline":{"type":"string","format":"date-time","default":"2024-01-02T00:00:00Z","nullable":true},
+		  "day":{"type":"string","format":"date","example":"2024-01-02"}
+		}`},
+		{format: openapi.TimeOffset, expected: `{
+		  "at":{"type":"string","format":"date-time","example":"2024-01-02T03:04:05+02:00"},
+		  "deadline":{"type":"string","format":"date-time","default":"2024-01-02T00:00:00+00:00","nullable":true},
+		  "day":{"type":"string","format":"date","example":"2024-01-02"}
+		}`},
+		{format: openapi.TimeDate, expected: `{
+		  "at":{"type":"string","format":"date","example":"2024-01-02"},
+		  "deadline":{"type":"string","format":"date","default":"2024-01-02","nullable":true},
+		  "day":{"type":"string","format":"date","example":"2024-01-02"}
+		}`},
+	} {
+		r := openapi3.NewReflector()
+		r.TimeFormat(tc.format)
+
+		oc, err := r.NewOperationContext(http.MethodGet, "/events")
+		require.NoError(t, err)
+		oc.AddRespStructure(event{})
+		require.NoError(t, r.AddOperation(oc))
+
+		s := r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestEvent"].Schema
+		assertjson.EqMarshal(t, `{"type":"string","example":"2024-01-02T03:04:05+02:00"}`, s.Properties.Value("note"))
+
+		s.Properties.Delete("note")
+		assertjson.EqMarshal(t, tc.expected, s.Properties)
+	}
+}
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+type shapeGroup struct {
+	Kind   string  `json:"kind"`
+	Shapes []shape `json:"shapes"`
+}
+
+func (g shapeGroup) Area() float64 {
+	a := 0.0
+	for _, s := range g.Shapes {
+		a += s.Area()
+	}
+
+	return a
+}
+
+func TestReflector_Implementations(t *testing.T) {
+	type drawing struct {
+		Main   shape   `json:"main"`
+		Others []shape `json:"others"`
+	}
+
+	shapes := openapi.ImplementationRegistry{}
+
+	r := openapi3.NewReflector()
+	r.Implementations(shapes)
+
+	shapes.AddImplementations((*shape)(nil), openapi.Implementations{
+		Samples:       []interface{}{circle{}, shapeGroup{}},
+		Discriminator: "kind",
+	})
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/drawings")
+	require.NoError(t, err)
+	oc.AddReqStructure(drawing{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "discriminator":{"propertyName":"kind"},
+	  "oneOf":[
+		{"$ref":"#/components/schemas/Openapi3TestCircle"},
+		{"$ref":"#/components/schemas/Openapi3TestShapeGroup"}
+	  ]
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestShape"])
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{"kind":{"type":"string"},"radius":{"type":"number"}}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestCircle"])
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"kind":{"type":"string"},
+		"shapes":{"type":"array","items":{"$ref":"#/components/schemas/Openapi3TestShape"},"nullable":true}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestShapeGroup"])
+}
+
+func TestReflector_Implementations_discriminated(t *testing.T) {
+	type req struct {
+		ID int `path:"id"`
+	}
+
+	r := openapi3.NewReflector()
+	r.Implementations(openapi.ImplementationRegistry{
+		reflect.TypeOf((*shape)(nil)).Elem(): openapi.Discriminated("kind", map[string]interface{}{
+			"group":  shapeGroup{},
+			"circle": circle{},
+			"disc":   circle{},
+		}),
+	})
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/shapes/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure((*shape)(nil))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "description":"OK",
+	  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestShape"}}}
+	}`, r.Spec.Paths.MapOfPathItemValues["/shapes/{id}"].MapOfOperationValues["get"].Responses.MapOfResponseOrRefValues["200"].Response)
+
+	assertjson.EqMarshal(t, `{
+	  "discriminator":{
+		"propertyName":"kind",
+		"mapping":{
+		  "circle":"#/components/schemas/Openapi3TestCircle",
+		  "disc":"#/components/schemas/Openapi3TestCircle",
+		  "group":"#/components/schemas/Openapi3TestShapeGroup"
+		}
+	  },
+	  "oneOf":[
+		{"$ref":"#/components/schemas/Openapi3TestCircle"},
+		{"$ref":"#/components/schemas/Openapi3TestShapeGroup"}
+	  ]
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestShape"])
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"kind":{"type":"string"},
+		"shapes":{"type":"array","items":{"$ref":"#/components/schemas/Openapi3TestShape"},"nullable":true}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestShapeGroup"])
+}
+
+type orderStatus string
+
+const (
+	orderStatusNew     orderStatus = "new"
+	orderStatusShipped orderStatus = "shipped"
+)
+
+func (orderStatus) Enum() []interface{} {
+	return []interface{}{orderStatusNew, orderStatusShipped}
+}
+
+type priority int
+
+const (
+	priorityLow priority = iota
+	priorityHigh
+)
+
+func TestReflector_Enums(t *testing.T) {
+	type order struct {
+		Status   orderStatus `json:"status"`
+		Priority priority    `json:"priority"`
+	}
+
+	enums := openapi.EnumRegistry{}
+
+	r := openapi3.NewReflector()
+	r.Enums(enums)
+
+	enums.Add(priorityLow, priorityLow, priorityHigh)
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/orders")
+	require.NoError(t, err)
+	oc.AddRespStructure(order{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestOrder":{
+		"properties":{
+		  "priority":{"$ref":"#/components/schemas/Openapi3TestPriority"},
+		  "status":{"$ref":"#/components/schemas/Openapi3TestOrderStatus"}
+		},
+		"type":"object"
+	  },
+	  "Openapi3TestOrderStatus":{"enum":["new","shipped"],"type":"string"},
+	  "Openapi3TestPriority":{"enum":[0,1],"type":"integer"}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+}
+
+func TestReflector_ValidatorTags(t *testing.T) {
+	type req struct {
+		Name   string            `json:"name" validate:"required,min=1,max=10"`
+		Code   string            `json:"code" validate:"len=5"`
+		Kind   string            `json:"kind" validate:"oneof=a b"`
+		Level  int               `json:"level" validate:"oneof=1 2 3"`
+		Email  string            `json:"email" validate:"omitempty,email"`
+		ID     string            `json:"id" validate:"uuid"`
+		Score  float64           `json:"score" validate:"gt=0,lte=100"`
+		Tags   []string          `json:"tags" validate:"max=3,dive,min=2"`
+		Labels map[string]string `json:"labels" validate:"min=1"`
+		Title  string            `json:"title" validate:"gt=2,lt=50"`
+		Items  []int             `json:"items" validate:"gt=0"`
+		Color  string            `json:"color" validate:"oneof='light blue' red"`
+	}
+
+	r := openapi3.NewReflector()
+	r.ValidatorTags()
+	r.ValidatorTags()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "required":["name"],
+	  "type":"object",
+	  "properties":{
+		"code":{"maxLength":5,"minLength":5,"type":"string"},
+		"email":{"type":"string","format":"email"},
+		"id":{"type":"string","format":"uuid"},
+		"kind":{"enum":["a","b"],"type":"string"},
+		"labels":{
+		  "minProperties":1,"type":"object",
+		  "additionalProperties":{"type":"string"},"nullable":true
+		},
+		"level":{"enum":[1,2,3],"type":"integer"},
+		"name":{"maxLength":10,"minLength":1,"type":"string"},
+		"score":{"maximum":100,"minimum":0,"exclusiveMinimum":true,"type":"number"},
+		"tags":{"maxItems":3,"items":{"type":"string"},"type":"array","nullable":true},
+		"title":{"maxLength":49,"minLength":3,"type":"string"},
+		"items":{"minItems":1,"items":{"type":"integer"},"type":"array","nullable":true},
+		"color":{"enum":["light blue","red"],"type":"string"}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestReq"])
+}
+
+func TestReflector_AddOperation_primitiveBody(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/counter")
+	require.NoError(t, err)
+	oc.AddReqStructure(int64(0), openapi.WithContentType("text/plain"))
+	oc.AddRespStructure(new(float64), openapi.WithContentType("text/plain"))
+	oc.AddRespStructure([]byte(nil), openapi.WithContentType("application/octet-stream"),
+		openapi.WithHTTPStatus(http.StatusAccepted))
+	oc.AddRespStructure("", openapi.WithContentType("text/csv"), openapi.WithHTTPStatus(http.StatusCreated))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{"content":{"text/plain":{"schema":{"type":"integer"}}}},
+	  "responses":{
+		"200":{"description":"OK","content":{"text/plain":{"schema":{"type":"number"}}}},
+		"201":{"description":"Created","content":{"text/csv":{"schema":{"type":"string"}}}},
+		"202":{
+		  "description":"Accepted",
+		  "content":{"application/octet-stream":{"schema":{"type":"string","format":"binary"}}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/counter"].MapOfOperationValues["post"])
+}
+
+func TestReflector_AddOperation_multipleResponseContent(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	type problem struct {
+		Title string `json:"title"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	oc.AddRespStructure([]thing{})
+	oc.AddRespStructure([]thing{}, openapi.WithContentType("application/xml"))
+	oc.AddRespStructure("", openapi.WithContentType("text/csv"))
+	oc.AddRespStructure(problem{}, openapi.WithContentType("application/problem+json"),
+		openapi.WithHTTPStatus(http.StatusBadRequest))
+	oc.AddRespStructure("", openapi.WithContentType("text/plain"), openapi.WithHTTPStatus(http.StatusBadRequest),
+		func(cu *openapi.ContentUnit) {
+			cu.Description = "Invalid request"
+		})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "200":{
+		"description":"OK",
+		"content":{
+		  "application/json":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Openapi3TestThing"}}},
+		  "application/xml":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Openapi3TestThing"}}},
+		  "text/csv":{"schema":{"type":"string"}}
+		}
+	  },
+	  "400":{
+		"description":"Invalid request",
+		"content":{
+		  "application/problem+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestProblem"}},
+		  "text/plain":{"schema":{"type":"string"}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["get"].Responses)
+}
+
+func TestReflector_AddOperation_versionedContentType(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(thing{}, openapi.WithVersionedContentType("application/vnd.myco.{version}+json", "v1", "v2"))
+	oc.AddRespStructure(thing{}, openapi.WithVersionedContentType("application/vnd.myco.{version}+json", "v1", "v2"))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{
+		"content":{
+		  "application/vnd.myco.v1+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}},
+		  "application/vnd.myco.v2+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}}
+		}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{
+			"application/vnd.myco.v1+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}},
+			"application/vnd.myco.v2+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}}
+		  }
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["post"])
+}
+
+func TestReflector_AddOperation_contentType(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.DefaultContentType = "application/vnd.api+json"
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	type req struct {
+		ID   int    `path:"id"`
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/things/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{}, openapi.WithContentType("application/xml"))
+	oc.AddReqStructure(thing{})
+	oc.AddRespStructure(thing{})
+	oc.AddRespStructure(thing{}, openapi.WithContentType("application/x-ndjson"), openapi.WithHTTPStatus(http.StatusAccepted))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+	  "requestBody":{
+		"content":{
+		  "application/vnd.api+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}},
+		  "application/xml":{"schema":{"$ref":"#/components/schemas/Openapi3TestReq"}}
+		}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{"application/vnd.api+json":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}}}
+		},
+		"202":{
+		  "description":"Accepted",
+		  "content":{"application/x-ndjson":{"schema":{"$ref":"#/components/schemas/Openapi3TestThing"}}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things/{id}"].MapOfOperationValues["put"])
+
+	assertjson.EqMarshal(t, `{"type":"object","properties":{"name":{"type":"string"}}}`,
+		r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestThing"])
+}
+
+func TestReflector_AddOperation_operationID(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.OperationIDNamer = openapi.OperationIDFromPath
+
+	type req struct {
+		ID int `path:"id"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/users/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assert.Equal(t, "getUsersById", *r.Spec.Paths.MapOfPathItemValues["/users/{id}"].MapOfOperationValues["get"].ID)
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+	oc.SetID("getUsersById")
+	assert.EqualError(t, r.AddOperation(oc), "operationId getUsersById of POST /users is already used by GET /users/{id}")
+}
+
+func TestReflector_AddOperation_download(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/reports")
+	require.NoError(t, err)
+	oc.AddReqStructure(openapi.Binary("image/png"))
+	oc.AddRespStructure(openapi.Download("application/pdf"))
+	oc.AddRespStructure(nil, openapi.WithHTTPStatus(http.StatusNotFound))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{"content":{"image/png":{"schema":{"type":"string","format":"binary"}}}},
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "headers":{
+			"Content-Disposition":{
+			  "style":"simple","description":"Presentation and file name of downloaded content.","required":true,
+			  "schema":{
+				"type":"string","description":"Presentation and file name of downloaded content.",
+				"example":"attachment; filename=\"file\""
+			  }
+			}
+		  },
+		  "content":{"application/pdf":{"schema":{"type":"string","format":"binary"}}}
+		},
+		"404":{"description":"Not Found"}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/reports"].MapOfOperationValues["put"])
+}
+
+func TestReflector_AddOperation_stream(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type created struct {
+		ID int `json:"id"`
+	}
+
+	type deleted struct {
+		ID     int  `json:"id"`
+		Purged bool `json:"purged"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/events")
+	require.NoError(t, err)
+	oc.AddReqStructure([]created{}, openapi.WithStream(openapi.MediaTypeNDJSON))
+	oc.AddRespStructure(jsonschema.OneOf(created{}, deleted{}), openapi.WithStream(openapi.MediaTypeEventStream))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{
+		"content":{"application/x-ndjson":{"schema":{"$ref":"#/components/schemas/Openapi3TestCreated"}}}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{
+			"text/event-stream":{
+			  "schema":{
+				"oneOf":[
+				  {"$ref":"#/components/schemas/Openapi3TestCreated"},
+				  {"$ref":"#/components/schemas/Openapi3TestDeleted"}
+				]
+			  }
+			}
+		  }
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/events"].MapOfOperationValues["post"])
+}
+
+func TestReflector_AddOperation_headerParameters(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type req struct {
+		RequestID   string    `header:"X-Request-ID" required:"true" format:"uuid" description:"Request trace."`
+		Since       time.Time `header:"If-Modified-Since"`
+		Accept      string    `header:"Accept"`
+		ContentType string    `header:"content-type"`
+		Auth        string    `header:"Authorization"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[
+	  {
+		"name":"X-Request-ID","in":"header","description":"Request trace.","required":true,
+		"schema":{"type":"string","description":"Request trace.","format":"uuid"}
+	  },
+	  {"name":"If-Modified-Since","in":"header","schema":{"type":"string","format":"date-time"}}
+	]`, r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["get"].Parameters)
+
+	type dup struct {
+		ID  string `header:"X-Request-ID"`
+		ID2 string `header:"x-request-id"`
+	}
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/dups")
+	require.NoError(t, err)
+	oc.AddReqStructure(dup{})
+	assert.ErrorContains(t, r.AddOperation(oc), "parameter x-request-id in header is already defined")
+}
+
+func TestReflector_AddOperation_typedDefault(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type window struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	}
+
+	type req struct {
+		Limit  int      `query:"limit" default:"20"`
+		Strict bool     `json:"strict" default:"true"`
+		Ratio  float64  `json:"ratio" default:"0.5"`
+		Name   string   `json:"name" default:"anonymous"`
+		Tags   []string `json:"tags" default:"[a,b]"`
+		Levels []int    `json:"levels" default:"[1,2]"`
+		Window window   `json:"window" default:"{\"from\":1,\"to\":5}"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[{"name":"limit","in":"query","schema":{"default":20,"type":"integer"}}]`,
+		r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["post"].Parameters)
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"strict":{"default":true,"type":"boolean"},"ratio":{"default":0.5,"type":"number"},
+		"name":{"default":"anonymous","type":"string"},
+		"tags":{"default":["a","b"],"type":"array","items":{"type":"string"},"nullable":true},
+		"levels":{"default":[1,2],"type":"array","items":{"type":"integer"},"nullable":true},
+		"window":{"allOf":[{"default":{"from":1,"to":5}},{"$ref":"#/components/schemas/Openapi3TestWindow"}]}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestReq"])
+
+	for _, tc := range []struct {
+		structure interface{}
+		err       string
+	}{
+		{structure: struct {
+			Limit int `query:"limit" default:"1.5"`
+		}{}, err: `limit: invalid default "1.5" for int`},
+		{structure: struct {
+			Strict bool `json:"strict" default:"12"`
+		}{}, err: `strict: invalid default "12" for bool`},
+		{structure: struct {
+			Window window `json:"window" default:"{\"since\":1}"`
+		}{}, err: `window: invalid default "{\"since\":1}" for openapi3_test.window`},
+	} {
+		oc, err := r.NewOperationContext(http.MethodPost, "/invalid")
+		require.NoError(t, err)
+		oc.AddReqStructure(tc.structure)
+		assert.ErrorContains(t, r.AddOperation(oc), tc.err)
+	}
+}
+
+func TestReflector_AddOperation_constraintTags(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type req struct {
+		Limit int      `query:"limit" minimum:"1" maximum:"50"`
+		Count int      `json:"count" minimum:"5" maximum:"100" multipleOf:"5"`
+		Ratio float64  `json:"ratio" exclusiveMinimum:"0" exclusiveMaximum:"1"`
+		Code  string   `json:"code" minLength:"2" maxLength:"8" pattern:"^[A-Z]+$"`
+		Tags  []string `json:"tags" minItems:"1" maxItems:"5" uniqueItems:"true" nullable:"false"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"count":{"multipleOf":5,"maximum":100,"minimum":5,"type":"integer"},
+		"ratio":{"maximum":1,"exclusiveMaximum":true,"minimum":0,"exclusiveMinimum":true,"type":"number"},
+		"code":{"maxLength":8,"minLength":2,"pattern":"^[A-Z]+$","type":"string"},
+		"tags":{"maxItems":5,"minItems":1,"uniqueItems":true,"type":"array","items":{"type":"string"}}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestReq"])
+
+	assertjson.EqMarshal(t, `[{"name":"limit","in":"query","schema":{"maximum":50,"minimum":1,"type":"integer"}}]`,
+		r.Spec.Paths.MapOfPathItemValues["/things"].MapOfOperationValues["post"].Parameters)
+}
+
+func TestReflector_AddOperation_readWriteOnly(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type profile struct {
+		Bio string `json:"bio"`
+	}
+
+	type user struct {
+		ID       int     `json:"id" readOnly:"true"`
+		Password string  `json:"password" writeOnly:"true"`
+		Login    string  `json:"login" deprecated:"true"`
+		Profile  profile `json:"profile" readOnly:"true"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+	oc.AddReqStructure(user{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"id":{"type":"integer","readOnly":true},"password":{"type":"string","writeOnly":true},
+		"login":{"type":"string","deprecated":true},
+		"profile":{"allOf":[{"readOnly":true},{"$ref":"#/components/schemas/Openapi3TestProfile"}]}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestUser"])
+
+	type invalid struct {
+		Token string `json:"token" readOnly:"true" writeOnly:"true"`
+	}
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/tokens")
+	require.NoError(t, err)
+	oc.AddReqStructure(invalid{})
+	assert.ErrorContains(t, r.AddOperation(oc), "token: property can not be both readOnly and writeOnly")
+}
+
+func TestReflector_AddOperation_xml(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type item struct {
+		SKU string `json:"sku" xml:"sku,attr"`
+	}
+
+	type order struct {
+		XMLName xml.Name `json:"-" xml:"urn:shop order"`
+		ID      int      `json:"id" xml:"id,attr"`
+		Note    string   `json:"note" xml:"comment"`
+		Tags    []string `json:"tags" xml:"tags>tag"`
+		Codes   []string `json:"codes" xml:"code"`
+		Items   []item   `json:"items" xml:"items>item"`
+		Secret  string   `json:"secret" xml:"-"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.AddReqStructure(order{}, func(cu *openapi.ContentUnit) {
+		cu.ContentType = "application/xml"
+		cu.AdditionalContentTypes = []string{"application/json"}
+	})
+	oc.AddRespStructure(order{}, openapi.WithContentType("application/xml"))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{
+		"content":{
+		  "application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestOrder"}},
+		  "application/xml":{"schema":{"$ref":"#/components/schemas/Openapi3TestOrder"}}
+		}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{"application/xml":{"schema":{"$ref":"#/components/schemas/Openapi3TestOrder"}}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/orders"].MapOfOperationValues["post"])
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"id":{"type":"integer","xml":{"attribute":true}},
+		"note":{"type":"string","xml":{"name":"comment"}},
+		"tags":{"type":"array","items":{"type":"string","xml":{"name":"tag"}},"nullable":true,"xml":{"wrapped":true}},
+		"codes":{"type":"array","items":{"type":"string","xml":{"name":"code"}},"nullable":true},
+		"items":{
+		  "type":"array","items":{"$ref":"#/components/schemas/Openapi3TestItem"},"nullable":true,
+		  "xml":{"wrapped":true}
+		},
+		"secret":{"type":"string"}
+	  },
+	  "xml":{"name":"order","namespace":"urn:shop"}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestOrder"])
+
+	assertjson.EqMarshal(t, `{"type":"object","properties":{"sku":{"type":"string","xml":{"attribute":true}}}}`,
+		r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestItem"])
+}
+
+type treeNode struct {
+	Name     string     `json:"name"`
+	Children []treeNode `json:"children,omitempty"`
+	Owner    *treeOwner `json:"owner,omitempty"`
+}
+
+type treeOwner struct {
+	Nodes []treeNode `json:"nodes"`
+}
+
+func TestReflector_AddOperation_recursiveTypes(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/tree")
+	require.NoError(t, err)
+	oc.AddReqStructure(treeNode{})
+	oc.AddRespStructure(treeOwner{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi3TestTreeNode":{
+		"properties":{
+		  "children":{"items":{"$ref":"#/components/schemas/Openapi3TestTreeNode"},"type":"array"},
+		  "name":{"type":"string"},
+		  "owner":{"$ref":"#/components/schemas/Openapi3TestTreeOwner"}
+		},
+		"type":"object"
+	  },
+	  "Openapi3TestTreeOwner":{
+		"properties":{
+		  "nodes":{
+			"items":{"$ref":"#/components/schemas/Openapi3TestTreeNode"},"type":"array",
+			"nullable":true
+		  }
+		},
+		"type":"object"
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+}
+
+func TestReflector_AddOperation_maps(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	type resp struct {
+		Items  map[string]item               `json:"items" minProperties:"1" maxProperties:"5" propertyNamePattern:"^[a-z]+$"`
+		Nested map[string]map[string]float64 `json:"nested,omitempty"`
+	}
+
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/items")
+	require.NoError(t, err)
+	oc.AddRespStructure(resp{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"items":{
+		  "maxProperties":5,"minProperties":1,"type":"object",
+		  "additionalProperties":{"$ref":"#/components/schemas/Openapi3TestItem"},"nullable":true,
+		  "x-propertyNames":{"pattern":"^[a-z]+$","type":"string"}
+		},
+		"nested":{
+		  "type":"object",
+		  "additionalProperties":{"type":"object","additionalProperties":{"type":"number"}}
+		}
+	  },
+	  "type":"object"
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestResp"])
+}
+
+func TestReflector_AddOperation_embedded(t *testing.T) {
+	type audit struct {
+		CreatedAt string `json:"createdAt" required:"true"`
+	}
+
+	type base struct {
+		ID   int    `json:"id" required:"true"`
+		Kind string `json:"kind"`
+	}
+
+	type pet struct {
+		base
+		audit `json:",inline"`
+		Kind  string `json:"kind" enum:"cat,dog"`
+	}
+
+	r := openapi3.NewReflector()
+	r.EmbeddedAllOf = true
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/pets")
+	require.NoError(t, err)
+	oc.AddRespStructure(pet{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "required":["createdAt"],
+	  "allOf":[{"$ref":"#/components/schemas/Openapi3TestBase"}],
+	  "type":"object",
+	  "properties":{
+		"createdAt":{"type":"string"},
+		"kind":{"enum":["cat","dog"],"type":"string"}
+	  }
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestPet"])
+
+	assertjson.EqMarshal(t, `{
+	  "required":["id"],
+	  "type":"object",
+	  "properties":{"id":{"type":"integer"},"kind":{"type":"string"}}
+	}`, r.Spec.Components.Schemas.MapOfSchemaOrRefValues["Openapi3TestBase"])
+}
+
+// docAudit holds audit fields.
+type docAudit struct {
+	// CreatedAt is a time of creation.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// docUser is a registered account.
+type docUser struct {
+	docAudit
+
+	// ID is a unique identifier.
+	ID   int    `json:"id"`
+	Name string `json:"name"` // Name is a display name.
+
+	// Email is ignored in favor of tag.
+	Email string `json:"email" description:"Contact address."`
+
+	Manager *docUser `json:"manager,omitempty"`
+}
+
+func TestReflector_DocComments(t *testing.T) {
+	dc := openapi.DocComments{}
+	require.NoError(t, dc.AddPackage(reflect.TypeOf(docUser{}).PkgPath(), "."))
+
+	assert.Equal(t, "docUser is a registered account.", dc.Type(reflect.TypeOf(docUser{}).PkgPath(), "docUser"))
+
+	r := openapi3.NewReflector()
+	r.DocComments(dc)
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/users/me")
+	require.NoError(t, err)
+
+	oc.AddRespStructure(docUser{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/users/me":{
+		  "get":{
+			"responses":{
+			  "200":{
+				"description":"docUser is a registered account.",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestDocUser"}}}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Openapi3TestDocUser":{
+			"description":"docUser is a registered account.",
+			"properties":{
+			  "createdAt":{"description":"CreatedAt is a time of creation.","type":"string","format":"date-time"},
+			  "email":{"description":"Contact address.","type":"string"},
+			  "id":{"description":"ID is a unique identifier.","type":"integer"},
+			  "manager":{"$ref":"#/components/schemas/Openapi3TestDocUser"},
+			  "name":{"description":"Name is a display name.","type":"string"}
+			},
+			"type":"object"
+		  }
+		}
+	  }
+	}`), r.SpecSchema())
+}
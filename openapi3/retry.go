@@ -0,0 +1,19 @@
+package openapi3
+
+import (
+	"errors"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// SetRetry sets `x-retryable` extension of operation with retry semantics for clients.
+func SetRetry(oc openapi.OperationContext, r openapi.Retry) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	e.Operation().WithMapOfAnythingItem(openapi.XRetryable, r)
+
+	return nil
+}
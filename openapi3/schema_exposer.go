@@ -0,0 +1,66 @@
+package openapi3
+
+import (
+	"reflect"
+
+	"github.com/swaggest/jsonschema-go"
+)
+
+// SchemaExposer replaces reflected schema of a type with a custom OpenAPI 3.0 schema.
+//
+// It is checked on values and pointers of request, response and parameter types and their fields,
+// references in returned schema are resolved against `#/components/schemas` of the spec.
+type SchemaExposer interface {
+	OpenAPISchema() Schema
+}
+
+func (r *Reflector) interceptSchemaExposer() func(rc *jsonschema.ReflectContext) {
+	return jsonschema.InterceptSchema(func(params jsonschema.InterceptSchemaParams) (bool, error) {
+		if params.Processed || !params.Value.IsValid() {
+			return false, nil
+		}
+
+		e := schemaExposer(params.Value)
+		if e == nil {
+			return false, nil
+		}
+
+		s := e.OpenAPISchema()
+		sr := SchemaOrRef{Schema: &s}
+		js := sr.ToJSONSchema(r.SpecEns())
+
+		*params.Schema = *js.TypeObjectEns()
+
+		return true, nil
+	})
+}
+
+func schemaExposer(v reflect.Value) SchemaExposer {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		v = reflect.New(v.Type().Elem())
+	}
+
+	if v.CanInterface() {
+		if e, ok := v.Interface().(SchemaExposer); ok {
+			return e
+		}
+	}
+
+	if v.Kind() == reflect.Ptr {
+		return nil
+	}
+
+	if v.CanAddr() {
+		v = v.Addr()
+	} else {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+
+	if e, ok := v.Interface().(SchemaExposer); ok {
+		return e
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package openapi3_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type decimal struct {
+	units int64
+	nanos int32
+}
+
+func (decimal) OpenAPISchema() openapi3.Schema {
+	s := openapi3.Schema{}
+	s.WithType(openapi3.SchemaTypeString).WithPattern(`^-?\d+(\.\d+)?$`).WithExample("12.50")
+
+	return s
+}
+
+type money struct {
+	Amount   decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func (*money) OpenAPISchema() openapi3.Schema {
+	s := openapi3.Schema{}
+	s.WithType(openapi3.SchemaTypeString).WithDescription("Amount with currency code.").WithExample("12.50 EUR")
+
+	return s
+}
+
+func TestReflector_SchemaExposer(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	type req struct {
+		Min   decimal  `query:"min"`
+		Price decimal  `json:"price"`
+		Fee   *decimal `json:"fee,omitempty"`
+	}
+
+	type resp struct {
+		Total money   `json:"total"`
+		Price decimal `json:"price"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure(resp{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/orders":{
+		  "post":{
+			"parameters":[
+			  {"name":"min","in":"query","schema":{"$ref":"#/components/schemas/Openapi3TestDecimal"}}
+			],
+			"requestBody":{
+			  "content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestReq"}}
+			  }
+			},
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{
+				  "application/json":{"schema":{"$ref":"#/components/schemas/Openapi3TestResp"}}
+				}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Openapi3TestDecimal":{"pattern":"^-?\\d+(\\.\\d+)?$","type":"string","example":"12.50"},
+		  "Openapi3TestReq":{
+			"type":"object",
+			"properties":{
+			  "fee":{"$ref":"#/components/schemas/Openapi3TestDecimal"},
+			  "price":{"$ref":"#/components/schemas/Openapi3TestDecimal"}
+			}
+		  },
+		  "Openapi3TestResp":{
+			"type":"object",
+			"properties":{
+			  "price":{"$ref":"#/components/schemas/Openapi3TestDecimal"},
+			  "total":{"description":"Amount with currency code.","type":"string","example":"12.50 EUR"}
+			}
+		  }
+		}
+	  }
+	}`, r.SpecEns())
+}
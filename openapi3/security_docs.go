@@ -0,0 +1,120 @@
+package openapi3
+
+import (
+	"sort"
+	"strings"
+)
+
+// SecurityDocs is a structured description of a security scheme.
+//
+// It is rendered into Markdown to avoid hand-written descriptions duplicated across services.
+type SecurityDocs struct {
+	// Summary is an introductory paragraph.
+	Summary string
+
+	// ObtainCredentials explains how to get a token or a key.
+	ObtainCredentials string
+
+	// PortalURL links to the authentication portal.
+	PortalURL string
+
+	// DocsURL links to detailed documentation.
+	DocsURL string
+
+	// Scopes maps scope names to descriptions, OAuth2 flow scopes are used if empty.
+	Scopes map[string]string
+}
+
+// Markdown renders documentation.
+func (d SecurityDocs) Markdown() string {
+	var parts []string
+
+	if d.Summary != "" {
+		parts = append(parts, d.Summary)
+	}
+
+	if d.ObtainCredentials != "" {
+		parts = append(parts, "**How to obtain credentials**\n\n"+d.ObtainCredentials)
+	}
+
+	if len(d.Scopes) > 0 {
+		names := make([]string, 0, len(d.Scopes))
+		for name := range d.Scopes {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		table := "| Scope | Description |\n|---|---|"
+		for _, name := range names {
+			table += "\n| `" + name + "` | " + strings.ReplaceAll(d.Scopes[name], "|", `\|`) + " |"
+		}
+
+		parts = append(parts, table)
+	}
+
+	var links []string
+
+	if d.PortalURL != "" {
+		links = append(links, "[Authentication portal]("+d.PortalURL+")")
+	}
+
+	if d.DocsURL != "" {
+		links = append(links, "[Documentation]("+d.DocsURL+")")
+	}
+
+	if len(links) > 0 {
+		parts = append(parts, strings.Join(links, " · "))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// WithDocs sets security scheme description rendered from docs.
+func (s *SecurityScheme) WithDocs(d SecurityDocs) *SecurityScheme {
+	switch {
+	case s.APIKeySecurityScheme != nil:
+		s.APIKeySecurityScheme.WithDescription(d.Markdown())
+	case s.HTTPSecurityScheme != nil:
+		s.HTTPSecurityScheme.WithDescription(d.Markdown())
+	case s.OAuth2SecurityScheme != nil:
+		if len(d.Scopes) == 0 {
+			d.Scopes = s.OAuth2SecurityScheme.Flows.scopes()
+		}
+
+		s.OAuth2SecurityScheme.WithDescription(d.Markdown())
+	case s.OpenIDConnectSecurityScheme != nil:
+		s.OpenIDConnectSecurityScheme.WithDescription(d.Markdown())
+	}
+
+	return s
+}
+
+// scopes collects scopes of all flows.
+func (o OAuthFlows) scopes() map[string]string {
+	res := make(map[string]string)
+
+	add := func(scopes map[string]string) {
+		for k, v := range scopes {
+			res[k] = v
+		}
+	}
+
+	if o.Implicit != nil {
+		add(o.Implicit.Scopes)
+	}
+
+	if o.Password != nil {
+		add(o.Password.Scopes)
+	}
+
+	if o.ClientCredentials != nil {
+		add(o.ClientCredentials.Scopes)
+	}
+
+	if o.AuthorizationCode != nil {
+		add(o.AuthorizationCode.Scopes)
+	}
+
+	return res
+}
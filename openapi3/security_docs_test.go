@@ -0,0 +1,50 @@
+package openapi3_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSecurityScheme_WithDocs(t *testing.T) {
+	ss := openapi3.SecurityScheme{
+		OAuth2SecurityScheme: &openapi3.OAuth2SecurityScheme{
+			Flows: openapi3.OAuthFlows{
+				ClientCredentials: &openapi3.ClientCredentialsFlow{
+					TokenURL: "https://auth.example.com/token",
+					Scopes: map[string]string{
+						"things:write": "Modify things.",
+						"things:read":  "Read things.",
+					},
+				},
+			},
+		},
+	}
+
+	ss.WithDocs(openapi3.SecurityDocs{
+		Summary:           "Machine-to-machine access.",
+		ObtainCredentials: "Register a client in the portal.",
+		PortalURL:         "https://auth.example.com",
+		DocsURL:           "https://docs.example.com/auth",
+	})
+
+	assert.Equal(t, `Machine-to-machine access.
+
+**How to obtain credentials**
+
+Register a client in the portal.
+
+| Scope | Description |
+|---|---|
+| `+"`things:read`"+` | Read things. |
+| `+"`things:write`"+` | Modify things. |
+
+[Authentication portal](https://auth.example.com) · [Documentation](https://docs.example.com/auth)`,
+		*ss.OAuth2SecurityScheme.Description)
+
+	ak := openapi3.SecurityScheme{APIKeySecurityScheme: &openapi3.APIKeySecurityScheme{}}
+	ak.WithDocs(openapi3.SecurityDocs{Summary: "Key in header."})
+
+	assert.Equal(t, "Key in header.", *ak.APIKeySecurityScheme.Description)
+}
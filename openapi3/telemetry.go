@@ -0,0 +1,142 @@
+package openapi3
+
+import (
+	"sort"
+	"strings"
+)
+
+// RouteTelemetry describes OpenTelemetry names of an operation.
+type RouteTelemetry struct {
+	// Method is an upper-case HTTP method, value for `http.request.method` attribute.
+	Method string
+
+	// Route is a path template, value for `http.route` attribute.
+	Route string
+
+	// OperationID is an operation identifier from the spec, can be empty.
+	OperationID string
+
+	// SpanName is a server span name, `{method} {route}` as per semantic conventions.
+	SpanName string
+}
+
+// TelemetryTable maps operations to OpenTelemetry semantic conventions.
+//
+// It can be used by tracing middleware to name spans consistently with documentation.
+type TelemetryTable struct {
+	routes []RouteTelemetry
+	index  map[string]RouteTelemetry
+}
+
+// TelemetryTable builds a lookup table of spec operations.
+func (s *Spec) TelemetryTable() TelemetryTable {
+	t := TelemetryTable{
+		index: make(map[string]RouteTelemetry),
+	}
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			rt := RouteTelemetry{
+				Method:   strings.ToUpper(method),
+				Route:    path,
+				SpanName: strings.ToUpper(method) + " " + path,
+			}
+
+			if op.ID != nil {
+				rt.OperationID = *op.ID
+			}
+
+			t.routes = append(t.routes, rt)
+			t.index[rt.SpanName] = rt
+		}
+	}
+
+	// More specific routes (with more literal segments) take precedence in matching.
+	sort.Slice(t.routes, func(i, j int) bool {
+		li, lj := literalSegments(t.routes[i].Route), literalSegments(t.routes[j].Route)
+		if li != lj {
+			return li > lj
+		}
+
+		return t.routes[i].SpanName < t.routes[j].SpanName
+	})
+
+	return t
+}
+
+// Routes returns all operations, most specific routes first.
+func (t TelemetryTable) Routes() []RouteTelemetry {
+	return t.routes
+}
+
+// Lookup finds operation by HTTP method and path template.
+func (t TelemetryTable) Lookup(method, route string) (RouteTelemetry, bool) {
+	rt, found := t.index[strings.ToUpper(method)+" "+route]
+
+	return rt, found
+}
+
+// ByOperationID returns a map of operation ID to telemetry names, operations without ID are skipped.
+func (t TelemetryTable) ByOperationID() map[string]RouteTelemetry {
+	res := make(map[string]RouteTelemetry, len(t.routes))
+
+	for _, rt := range t.routes {
+		if rt.OperationID != "" {
+			res[rt.OperationID] = rt
+		}
+	}
+
+	return res
+}
+
+// Match finds operation by HTTP method and actual URL path, e.g. "/things/123" matches "/things/{id}".
+func (t TelemetryTable) Match(method, urlPath string) (RouteTelemetry, bool) {
+	method = strings.ToUpper(method)
+	segments := strings.Split(urlPath, "/")
+
+	for _, rt := range t.routes {
+		if rt.Method == method && matchSegments(strings.Split(rt.Route, "/"), segments) {
+			return rt, true
+		}
+	}
+
+	return RouteTelemetry{}, false
+}
+
+func isTemplateSegment(s string) bool {
+	return strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}")
+}
+
+func literalSegments(route string) int {
+	cnt := 0
+
+	for _, s := range strings.Split(route, "/") {
+		if !isTemplateSegment(s) {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+func matchSegments(template, actual []string) bool {
+	if len(template) != len(actual) {
+		return false
+	}
+
+	for i, s := range template {
+		if isTemplateSegment(s) {
+			if actual[i] == "" {
+				return false
+			}
+
+			continue
+		}
+
+		if s != actual[i] {
+			return false
+		}
+	}
+
+	return true
+}
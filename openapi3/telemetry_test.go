@@ -0,0 +1,47 @@
+package openapi3_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_TelemetryTable(t *testing.T) {
+	s := openapi3.Spec{}
+
+	require.NoError(t, s.SetupOperation(http.MethodGet, "/things/{id}", func(op *openapi3.Operation) error {
+		op.WithID("getThing").WithParameters(openapi3.Parameter{Name: "id", In: openapi3.ParameterInPath}.ToParameterOrRef())
+
+		return nil
+	}))
+	require.NoError(t, s.SetupOperation(http.MethodGet, "/things/latest"))
+
+	tt := s.TelemetryTable()
+
+	require.Len(t, tt.Routes(), 2)
+
+	rt, found := tt.Match(http.MethodGet, "/things/123")
+	assert.True(t, found)
+	assert.Equal(t, openapi3.RouteTelemetry{
+		Method:      "GET",
+		Route:       "/things/{id}",
+		OperationID: "getThing",
+		SpanName:    "GET /things/{id}",
+	}, rt)
+
+	rt, found = tt.Match(http.MethodGet, "/things/latest")
+	assert.True(t, found)
+	assert.Equal(t, "/things/latest", rt.Route)
+
+	_, found = tt.Match(http.MethodPost, "/things/123")
+	assert.False(t, found)
+
+	rt, found = tt.Lookup("get", "/things/{id}")
+	assert.True(t, found)
+	assert.Equal(t, "getThing", rt.OperationID)
+
+	assert.Equal(t, "GET /things/{id}", tt.ByOperationID()["getThing"].SpanName)
+}
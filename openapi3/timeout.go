@@ -0,0 +1,64 @@
+package openapi3
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// SetTimeout sets `x-timeout-ms` extension of operation with a deadline for clients and servers.
+func SetTimeout(oc openapi.OperationContext, d time.Duration) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	if d < time.Millisecond {
+		return errors.New("timeout must be at least 1ms")
+	}
+
+	e.Operation().WithMapOfAnythingItem(openapi.XTimeoutMs, d.Milliseconds())
+
+	return nil
+}
+
+// TimeoutMiddleware enforces `x-timeout-ms` extension of spec operations on a server.
+//
+// Matched requests get context deadline and 503 Service Unavailable response if handler
+// does not finish in time, as with http.TimeoutHandler. Other requests are passed as is.
+func TimeoutMiddleware(s *Spec) func(http.Handler) http.Handler {
+	table := s.TelemetryTable()
+	timeouts := make(map[string]time.Duration)
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			if d, ok := openapi.TimeoutOf(op.MapOfAnything[openapi.XTimeoutMs]); ok {
+				if rt, found := table.Lookup(method, path); found {
+					timeouts[rt.SpanName] = d
+				}
+			}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		handlers := make(map[string]http.Handler, len(timeouts))
+
+		for name, d := range timeouts {
+			handlers[name] = http.TimeoutHandler(next, d, "")
+		}
+
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if rt, found := table.Match(req.Method, req.URL.Path); found {
+				if h, ok := handlers[rt.SpanName]; ok {
+					h.ServeHTTP(rw, req)
+
+					return
+				}
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package openapi3_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(struct {
+		ID string `path:"id"`
+	}{})
+	require.NoError(t, openapi3.SetTimeout(oc, 10*time.Millisecond))
+	require.NoError(t, r.AddOperation(oc))
+
+	assert.EqualError(t, openapi3.SetTimeout(oc, time.Microsecond), "timeout must be at least 1ms")
+
+	h := openapi3.TimeoutMiddleware(r.SpecEns())(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet && req.URL.Path == "/things/1" {
+			<-req.Context().Done()
+
+			return
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/things/1", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rw.Code)
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/things/2", nil))
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/things/1", nil))
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+}
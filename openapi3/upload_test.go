@@ -38,3 +38,40 @@ func TestNewReflector_uploads(t *testing.T) {
 
 	assertjson.Equal(t, expected, schema)
 }
+
+func TestNewReflector_uploadValues(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/upload")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(struct {
+		File  multipart.FileHeader   `formData:"file"`
+		Files []multipart.FileHeader `formData:"files"`
+		Raw   []byte                 `formData:"raw"`
+		Title string                 `formData:"title"`
+	}{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "content":{
+		"multipart/form-data":{
+		  "schema":{
+			"type":"object",
+			"properties":{
+			  "file":{"$ref":"#/components/schemas/MultipartFileHeader"},
+			  "files":{"type":"array","items":{"$ref":"#/components/schemas/MultipartFileHeader"}},
+			  "raw":{"type":"string","format":"binary"},
+			  "title":{"type":"string"}
+			}
+		  },
+		  "encoding":{
+			"file":{"contentType":"application/octet-stream"},
+			"files":{"contentType":"application/octet-stream"},
+			"raw":{"contentType":"application/octet-stream"}
+		  }
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/upload"].MapOfOperationValues["post"].RequestBody.RequestBody)
+}
@@ -0,0 +1,551 @@
+package openapi3
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// ValidationError describes a problem found at a location in the document.
+type ValidationError struct {
+	// Pointer is a JSON Pointer to the offending entity, e.g. "/components/schemas/Order/default".
+	Pointer string
+	Message string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return e.Pointer + ": " + e.Message
+}
+
+// ValidationErrors is a list of validation errors.
+type ValidationErrors []ValidationError
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+
+	for _, ve := range e {
+		msgs = append(msgs, ve.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) orNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(e, func(i, j int) bool {
+		return e[i].Pointer < e[j].Pointer
+	})
+
+	return e
+}
+
+// ValidateSchemaValues checks that `default` and `example` values match declared schema type and format.
+//
+// It returns ValidationErrors with JSON Pointers of offending values.
+func (s *Spec) ValidateSchemaValues() error {
+	var errs ValidationErrors
+
+	_ = WalkSchemas(s, func(ptr string, schema *Schema) error {
+		if schema.Default != nil {
+			if msg := checkValueType(schema, *schema.Default); msg != "" {
+				errs = append(errs, ValidationError{Pointer: ptr + "/default", Message: msg})
+			}
+		}
+
+		if schema.Example != nil {
+			if msg := checkValueType(schema, *schema.Example); msg != "" {
+				errs = append(errs, ValidationError{Pointer: ptr + "/example", Message: msg})
+			}
+		}
+
+		return nil
+	})
+
+	return errs.orNil()
+}
+
+var (
+	openapiVersionRegex = regexp.MustCompile(`^3\.0\.\d(-.+)?$`)
+	responseCodeRegex   = regexp.MustCompile(`^[1-5](?:\d{2}|XX)$`)
+	componentNameRegex  = regexp.MustCompile(`^[a-zA-Z0-9\.\-_]+$`)
+)
+
+// Validate checks structure of the document without marshaling it.
+//
+// Required fields, openapi version, response codes, component names and parameters
+// (path parameters must be required, schema and content are mutually exclusive)
+// are checked, problems are returned as ValidationErrors with JSON Pointers.
+func (s *Spec) Validate() error {
+	var errs ValidationErrors
+
+	fail := func(ptr, msg string) {
+		errs = append(errs, ValidationError{Pointer: ptr, Message: msg})
+	}
+
+	if s.Openapi == "" {
+		fail("/openapi", "required")
+	} else if !openapiVersionRegex.MatchString(s.Openapi) {
+		fail("/openapi", fmt.Sprintf("version %q does not match %s", s.Openapi, openapiVersionRegex))
+	}
+
+	if s.Info.Title == "" {
+		fail("/info/title", "required")
+	}
+
+	if s.Info.Version == "" {
+		fail("/info/version", "required")
+	}
+
+	if s.Info.License != nil && s.Info.License.Name == "" {
+		fail("/info/license/name", "required")
+	}
+
+	for i, srv := range s.Servers {
+		if srv.URL == "" {
+			fail(fmt.Sprintf("/servers/%d/url", i), "required")
+		}
+	}
+
+	for i, tag := range s.Tags {
+		if tag.Name == "" {
+			fail(fmt.Sprintf("/tags/%d/name", i), "required")
+		}
+	}
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		pathPtr := "/paths/" + internal.EscapePointerToken(path)
+
+		if !strings.HasPrefix(path, "/") {
+			fail(pathPtr, "path must start with /")
+		}
+
+		validateParameters(pathPtr+"/parameters", pi.Parameters, fail)
+
+		for method, op := range pi.MapOfOperationValues {
+			opPtr := pathPtr + "/" + method
+
+			validateParameters(opPtr+"/parameters", op.Parameters, fail)
+			validateResponses(opPtr+"/responses", op.Responses, fail)
+		}
+	}
+
+	if s.Components != nil {
+		validateComponents(s.Components, fail)
+	}
+
+	return errs.orNil()
+}
+
+func validateParameters(ptr string, params []ParameterOrRef, fail func(ptr, msg string)) {
+	for i, p := range params {
+		if p.Parameter == nil {
+			continue
+		}
+
+		validateParameter(fmt.Sprintf("%s/%d", ptr, i), *p.Parameter, fail)
+	}
+}
+
+func validateParameter(ptr string, p Parameter, fail func(ptr, msg string)) {
+	if p.Name == "" {
+		fail(ptr+"/name", "required")
+	}
+
+	if p.In == "" {
+		fail(ptr+"/in", "required")
+	}
+
+	if p.In == ParameterInPath && (p.Required == nil || !*p.Required) {
+		fail(ptr+"/required", "path parameter must be required")
+	}
+
+	switch {
+	case p.Schema != nil && len(p.Content) > 0:
+		fail(ptr, "schema and content are mutually exclusive")
+	case p.Schema == nil && len(p.Content) == 0:
+		fail(ptr, "schema or content is required")
+	case len(p.Content) > 1:
+		fail(ptr+"/content", "content must have exactly one media type")
+	}
+}
+
+func validateResponses(ptr string, responses Responses, fail func(ptr, msg string)) {
+	if responses.Default == nil && len(responses.MapOfResponseOrRefValues) == 0 {
+		fail(ptr, "at least one response is required")
+	}
+
+	if responses.Default != nil {
+		validateResponse(ptr+"/default", *responses.Default, fail)
+	}
+
+	for code, resp := range responses.MapOfResponseOrRefValues {
+		if !responseCodeRegex.MatchString(code) {
+			fail(ptr+"/"+internal.EscapePointerToken(code), fmt.Sprintf("response code %q does not match %s", code, responseCodeRegex))
+		}
+
+		validateResponse(ptr+"/"+internal.EscapePointerToken(code), resp, fail)
+	}
+}
+
+func validateResponse(ptr string, ror ResponseOrRef, fail func(ptr, msg string)) {
+	if ror.Response != nil && ror.Response.Description == "" {
+		fail(ptr+"/description", "required")
+	}
+}
+
+func validateComponents(c *Components, fail func(ptr, msg string)) {
+	names := map[string][]string{}
+
+	if c.Schemas != nil {
+		for name := range c.Schemas.MapOfSchemaOrRefValues {
+			names["schemas"] = append(names["schemas"], name)
+		}
+	}
+
+	if c.Responses != nil {
+		for name, resp := range c.Responses.MapOfResponseOrRefValues {
+			names["responses"] = append(names["responses"], name)
+			validateResponse("/components/responses/"+internal.EscapePointerToken(name), resp, fail)
+		}
+	}
+
+	if c.Parameters != nil {
+		for name, p := range c.Parameters.MapOfParameterOrRefValues {
+			names["parameters"] = append(names["parameters"], name)
+
+			if p.Parameter != nil {
+				validateParameter("/components/parameters/"+internal.EscapePointerToken(name), *p.Parameter, fail)
+			}
+		}
+	}
+
+	if c.RequestBodies != nil {
+		for name := range c.RequestBodies.MapOfRequestBodyOrRefValues {
+			names["requestBodies"] = append(names["requestBodies"], name)
+		}
+	}
+
+	if c.SecuritySchemes != nil {
+		for name := range c.SecuritySchemes.MapOfSecuritySchemeOrRefValues {
+			names["securitySchemes"] = append(names["securitySchemes"], name)
+		}
+	}
+
+	for kind, kindNames := range names {
+		for _, name := range kindNames {
+			if !componentNameRegex.MatchString(name) {
+				fail("/components/"+kind+"/"+internal.EscapePointerToken(name),
+					fmt.Sprintf("component name %q does not match %s", name, componentNameRegex))
+			}
+		}
+	}
+}
+
+// ValidatePathParameters checks that every `{param}` of a path has a matching `in: path` parameter
+// of path item or operation and that every path parameter has a placeholder in path.
+//
+// Local references to `#/components/parameters/` are resolved, problems are returned as ValidationErrors
+// with JSON Pointers of operations or parameters, messages name offending method and path.
+func (s *Spec) ValidatePathParameters() error {
+	var errs ValidationErrors
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		pathPtr := "/paths/" + internal.EscapePointerToken(path)
+
+		_, _, placeholders, err := openapi.SanitizeMethodPath(http.MethodGet, path)
+		if err != nil {
+			errs = append(errs, ValidationError{Pointer: pathPtr, Message: err.Error()})
+
+			continue
+		}
+
+		inPath := make(map[string]bool, len(placeholders))
+		for _, name := range placeholders {
+			inPath[name] = true
+		}
+
+		declared := map[string]bool{}
+
+		for i, p := range pi.Parameters {
+			if name, ok := s.pathParameterName(p); ok {
+				declared[name] = true
+
+				if !inPath[name] {
+					errs = append(errs, ValidationError{
+						Pointer: fmt.Sprintf("%s/parameters/%d", pathPtr, i),
+						Message: fmt.Sprintf("%s: path parameter %s has no placeholder in path", path, name),
+					})
+				}
+			}
+		}
+
+		for method, op := range pi.MapOfOperationValues {
+			opPtr := pathPtr + "/" + method
+			opDeclared := make(map[string]bool, len(declared))
+
+			for name := range declared {
+				opDeclared[name] = true
+			}
+
+			for i, p := range op.Parameters {
+				if name, ok := s.pathParameterName(p); ok {
+					opDeclared[name] = true
+
+					if !inPath[name] {
+						errs = append(errs, ValidationError{
+							Pointer: fmt.Sprintf("%s/parameters/%d", opPtr, i),
+							Message: fmt.Sprintf("%s %s: path parameter %s has no placeholder in path",
+								strings.ToUpper(method), path, name),
+						})
+					}
+				}
+			}
+
+			for _, name := range placeholders {
+				if !opDeclared[name] {
+					errs = append(errs, ValidationError{
+						Pointer: opPtr,
+						Message: fmt.Sprintf("%s %s: undeclared path parameter %s", strings.ToUpper(method), path, name),
+					})
+				}
+			}
+		}
+	}
+
+	return errs.orNil()
+}
+
+// ValidateSecurity checks that security requirements of document and operations refer to schemes
+// of `components.securitySchemes` and that OAuth2 scopes are declared in flows of the scheme.
+//
+// Scopes are not allowed for API key and HTTP schemes, problems are returned as ValidationErrors
+// with JSON Pointers of requirements.
+func (s *Spec) ValidateSecurity() error {
+	var errs ValidationErrors
+
+	validate := func(ptr string, reqs []map[string][]string) {
+		for i, req := range reqs {
+			for _, name := range sortedKeys(req) {
+				reqPtr := fmt.Sprintf("%s/%d/%s", ptr, i, internal.EscapePointerToken(name))
+
+				if msg := s.checkSecurityRequirement(name, req[name]); msg != "" {
+					errs = append(errs, ValidationError{Pointer: reqPtr, Message: msg})
+				}
+			}
+		}
+	}
+
+	validate("/security", s.Security)
+
+	_ = s.Operations(func(method, path string, op *Operation) error {
+		validate("/paths/"+internal.EscapePointerToken(path)+"/"+strings.ToLower(method)+"/security", op.Security)
+
+		return nil
+	})
+
+	return errs.orNil()
+}
+
+// checkSecurityRequirement returns a problem of a requirement of named scheme with scopes.
+func (s *Spec) checkSecurityRequirement(name string, scopes []string) string {
+	var ss SecuritySchemeOrRef
+
+	if s.Components != nil && s.Components.SecuritySchemes != nil {
+		ss = s.Components.SecuritySchemes.MapOfSecuritySchemeOrRefValues[name]
+	}
+
+	if ss.SecuritySchemeReference != nil {
+		return ""
+	}
+
+	if ss.SecurityScheme == nil {
+		return fmt.Sprintf("security scheme %s is not defined in components", name)
+	}
+
+	switch {
+	case ss.SecurityScheme.OAuth2SecurityScheme != nil:
+		declared := oauth2Scopes(ss.SecurityScheme.OAuth2SecurityScheme.Flows)
+
+		for _, scope := range scopes {
+			if !declared[scope] {
+				return fmt.Sprintf("scope %s is not declared in flows of security scheme %s", scope, name)
+			}
+		}
+	case ss.SecurityScheme.OpenIDConnectSecurityScheme != nil:
+		// Scopes are defined by OpenID Connect discovery document.
+	default:
+		if len(scopes) > 0 {
+			return fmt.Sprintf("security scheme %s does not support scopes", name)
+		}
+	}
+
+	return ""
+}
+
+func oauth2Scopes(flows OAuthFlows) map[string]bool {
+	res := map[string]bool{}
+
+	add := func(scopes map[string]string) {
+		for scope := range scopes {
+			res[scope] = true
+		}
+	}
+
+	if flows.Implicit != nil {
+		add(flows.Implicit.Scopes)
+	}
+
+	if flows.Password != nil {
+		add(flows.Password.Scopes)
+	}
+
+	if flows.ClientCredentials != nil {
+		add(flows.ClientCredentials.Scopes)
+	}
+
+	if flows.AuthorizationCode != nil {
+		add(flows.AuthorizationCode.Scopes)
+	}
+
+	return res
+}
+
+// pathParameterName returns name of `in: path` parameter, resolving local component references.
+func (s *Spec) pathParameterName(p ParameterOrRef) (string, bool) {
+	if p.ParameterReference != nil && s.Components != nil && s.Components.Parameters != nil {
+		name := strings.TrimPrefix(p.ParameterReference.Ref, "#/components/parameters/")
+		p = s.Components.Parameters.MapOfParameterOrRefValues[name]
+	}
+
+	if p.Parameter == nil || p.Parameter.In != ParameterInPath {
+		return "", false
+	}
+
+	return p.Parameter.Name, true
+}
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// checkValueType returns a problem description or empty string if value is coherent with schema.
+func checkValueType(schema *Schema, value interface{}) string {
+	if value == nil {
+		if schema.Nullable != nil && *schema.Nullable {
+			return ""
+		}
+
+		if schema.Type == nil {
+			return ""
+		}
+
+		return "null value for non-nullable " + string(*schema.Type)
+	}
+
+	if schema.Type == nil {
+		return ""
+	}
+
+	t := *schema.Type
+	v := reflect.ValueOf(value)
+	got := jsonType(v)
+
+	switch t {
+	case SchemaTypeInteger:
+		if got != "number" {
+			return fmt.Sprintf("%s value for %s", got, t)
+		}
+
+		f := toFloat(v)
+		if f != math.Trunc(f) {
+			return fmt.Sprintf("fractional value %v for integer", value)
+		}
+
+		if schema.Format != nil && *schema.Format == "int32" && (f < math.MinInt32 || f > math.MaxInt32) {
+			return fmt.Sprintf("value %v overflows int32", value)
+		}
+	case SchemaTypeNumber:
+		if got != "number" {
+			return fmt.Sprintf("%s value for %s", got, t)
+		}
+	case SchemaTypeString:
+		if got != "string" {
+			return fmt.Sprintf("%s value for %s", got, t)
+		}
+
+		if schema.Format != nil {
+			return checkStringFormat(*schema.Format, v.String())
+		}
+	case SchemaTypeBoolean, SchemaTypeArray, SchemaTypeObject:
+		if got != string(t) {
+			return fmt.Sprintf("%s value for %s", got, t)
+		}
+	}
+
+	return ""
+}
+
+func checkStringFormat(format, s string) string {
+	var err error
+
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, s)
+	case "date":
+		_, err = time.Parse("2006-01-02", s)
+	case "uuid":
+		if !uuidRegex.MatchString(s) {
+			return fmt.Sprintf("invalid uuid %q", s)
+		}
+	case "byte":
+		_, err = base64.StdEncoding.DecodeString(s)
+	}
+
+	if err != nil {
+		return fmt.Sprintf("invalid %s %q: %v", format, s, err)
+	}
+
+	return ""
+}
+
+func jsonType(v reflect.Value) string {
+	switch v.Kind() { //nolint:exhaustive // Other kinds are not JSON-compatible.
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return v.Kind().String()
+	}
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch v.Kind() { //nolint:exhaustive // Only numbers are expected.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
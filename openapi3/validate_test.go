@@ -0,0 +1,315 @@
+package openapi3_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestSpec_ValidateSchemaValues(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema: {type: integer, format: int32, example: 5000000000}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  createdAt: {type: string, format: date-time, example: "yesterday"}
+                  count: {type: integer, default: "10"}
+                  ratio: {type: number, default: 0.5}
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        id: {type: string, format: uuid, example: "3fa85f64-5717-4562-b3fc-2c963f66afa6"}
+        size: {type: integer, example: 1.5}
+        tags: {type: array, default: []}
+        note: {type: string, nullable: true, default: null}
+`)))
+
+	err := s.ValidateSchemaValues()
+	require.Error(t, err)
+
+	var ve openapi3.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, openapi3.ValidationErrors{
+		{Pointer: "/components/schemas/Thing/properties/size/example", Message: "fractional value 1.5 for integer"},
+		{
+			Pointer: "/paths/~1things~1{id}/get/parameters/0/schema/example",
+			Message: "value 5e+09 overflows int32",
+		},
+		{
+			Pointer: "/paths/~1things~1{id}/get/responses/200/content/application~1json/schema/properties/count/default",
+			Message: "string value for integer",
+		},
+		{
+			Pointer: "/paths/~1things~1{id}/get/responses/200/content/application~1json/schema/properties/createdAt/example",
+			Message: `invalid date-time "yesterday": parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`,
+		},
+	}, ve)
+}
+
+func TestSpec_Validate(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.1.0
+info: {title: API, version: ""}
+tags:
+  - name: ""
+paths:
+  /things/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema: {type: integer}
+      responses:
+        "200":
+          description: ""
+`)))
+
+	s.ComponentsEns().SchemasEns().WithMapOfSchemaOrRefValuesItem("Thing/Item", openapi3.SchemaOrRef{
+		Schema: (&openapi3.Schema{}).WithType(openapi3.SchemaTypeObject),
+	})
+
+	pi := s.Paths.MapOfPathItemValues["/things/{id}"]
+	op := pi.MapOfOperationValues["get"]
+	op.Parameters[0].Parameter.Required = nil
+	op.Parameters = append(op.Parameters, openapi3.ParameterOrRef{Parameter: &openapi3.Parameter{
+		Name:    "q",
+		In:      openapi3.ParameterInQuery,
+		Schema:  &openapi3.SchemaOrRef{Schema: (&openapi3.Schema{}).WithType(openapi3.SchemaTypeString)},
+		Content: map[string]openapi3.MediaType{"application/json": {}},
+	}})
+	op.Responses.MapOfResponseOrRefValues["20X"] = openapi3.ResponseOrRef{
+		Response: (&openapi3.Response{}).WithDescription("OK"),
+	}
+	pi.MapOfOperationValues["get"] = op
+	pi.MapOfOperationValues["delete"] = openapi3.Operation{}
+
+	err := s.Validate()
+	require.Error(t, err)
+
+	var ve openapi3.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, openapi3.ValidationErrors{
+		{
+			Pointer: "/components/schemas/Thing~1Item",
+			Message: `component name "Thing/Item" does not match ^[a-zA-Z0-9\.\-_]+$`,
+		},
+		{Pointer: "/info/version", Message: "required"},
+		{Pointer: "/openapi", Message: `version "3.1.0" does not match ^3\.0\.\d(-.+)?$`},
+		{Pointer: "/paths/~1things~1{id}/delete/responses", Message: "at least one response is required"},
+		{Pointer: "/paths/~1things~1{id}/get/parameters/0/required", Message: "path parameter must be required"},
+		{Pointer: "/paths/~1things~1{id}/get/parameters/1", Message: "schema and content are mutually exclusive"},
+		{Pointer: "/paths/~1things~1{id}/get/responses/200/description", Message: "required"},
+		{Pointer: "/paths/~1things~1{id}/get/responses/20X", Message: `response code "20X" does not match ^[1-5](?:\d{2}|XX)$`},
+		{Pointer: "/tags/0/name", Message: "required"},
+	}, ve)
+}
+
+func TestSpec_Validate_reflected(t *testing.T) {
+	r := openapi3.NewReflector()
+	r.Spec.Info.WithTitle("API").WithVersion("1.0.0")
+
+	type req struct {
+		ID int    `path:"id"`
+		Q  string `query:"q"`
+	}
+
+	type resp struct {
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id}")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure(resp{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assert.NoError(t, r.Spec.Validate())
+}
+
+func TestSpec_ValidatePathParameters(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{userId}/orders/{orderId}:
+    parameters:
+      - $ref: '#/components/parameters/userId'
+      - {name: shopId, in: path, required: true, schema: {type: string}}
+    get:
+      parameters:
+        - {name: orderId, in: path, required: true, schema: {type: string}}
+      responses:
+        "200": {description: OK}
+    delete:
+      parameters:
+        - {name: orderID, in: path, required: true, schema: {type: string}}
+      responses:
+        "204": {description: Deleted}
+  /users/{userId}:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/userId'
+      responses:
+        "200": {description: OK}
+components:
+  parameters:
+    userId: {name: userId, in: path, required: true, schema: {type: string}}
+`)))
+
+	err := s.ValidatePathParameters()
+	require.Error(t, err)
+
+	var ve openapi3.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, openapi3.ValidationErrors{
+		{
+			Pointer: "/paths/~1users~1{userId}~1orders~1{orderId}/delete",
+			Message: "DELETE /users/{userId}/orders/{orderId}: undeclared path parameter orderId",
+		},
+		{
+			Pointer: "/paths/~1users~1{userId}~1orders~1{orderId}/delete/parameters/0",
+			Message: "DELETE /users/{userId}/orders/{orderId}: path parameter orderID has no placeholder in path",
+		},
+		{
+			Pointer: "/paths/~1users~1{userId}~1orders~1{orderId}/parameters/1",
+			Message: "/users/{userId}/orders/{orderId}: path parameter shopId has no placeholder in path",
+		},
+	}, ve)
+}
+
+func TestSpec_ValidateSecurity(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+security:
+  - apiKey: []
+  - session: []
+paths:
+  /orders:
+    get:
+      security:
+        - oauth: [orders:read]
+        - oauth: [orders:write, orders:delete]
+      responses:
+        "200": {description: OK}
+    post:
+      security:
+        - apiKey: [admin]
+          oidc: [openid, profile]
+      responses:
+        "201": {description: Created}
+components:
+  securitySchemes:
+    apiKey: {type: apiKey, in: header, name: X-API-Key}
+    oidc: {type: openIdConnect, openIdConnectUrl: https://example.com/.well-known/openid-configuration}
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://example.com/authorize
+          scopes: {orders:read: Read orders}
+        clientCredentials:
+          tokenUrl: https://example.com/token
+          scopes: {orders:write: Write orders}
+`)))
+
+	err := s.ValidateSecurity()
+	require.Error(t, err)
+
+	var ve openapi3.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, openapi3.ValidationErrors{
+		{
+			Pointer: "/paths/~1orders/get/security/1/oauth",
+			Message: "scope orders:delete is not declared in flows of security scheme oauth",
+		},
+		{
+			Pointer: "/paths/~1orders/post/security/0/apiKey",
+			Message: "security scheme apiKey does not support scopes",
+		},
+		{
+			Pointer: "/security/1/session",
+			Message: "security scheme session is not defined in components",
+		},
+	}, ve)
+}
+
+func TestSpec_ValidateValue(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      additionalProperties: false
+      properties:
+        name: {type: string, minLength: 2}
+        kind: {type: string, enum: [cat, dog]}
+        age: {type: integer, minimum: 0}
+        tags:
+          type: array
+          uniqueItems: true
+          items: {type: string, pattern: '^[a-z]+$'}
+`)))
+
+	schema := openapi3.SchemaOrRef{SchemaReference: &openapi3.SchemaReference{Ref: "#/components/schemas/Pet"}}
+
+	assert.NoError(t, s.ValidateValue(schema, map[string]interface{}{"name": "Tom", "kind": "cat", "age": 3.0}))
+
+	err := s.ValidateValue(schema, map[string]interface{}{
+		"name":  "T",
+		"age":   1.5,
+		"tags":  []interface{}{"a", "B", "a"},
+		"color": "black",
+	})
+	require.Error(t, err)
+
+	var ve openapi3.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+	assert.Equal(t, openapi3.ValidationErrors{
+		{Pointer: "/age", Message: "fractional value 1.5 for integer"},
+		{Pointer: "/color", Message: "additional property is not allowed"},
+		{Pointer: "/kind", Message: "required property is missing"},
+		{Pointer: "/name", Message: "length 1 is less than 2"},
+		{Pointer: "/tags/1", Message: `value "B" does not match pattern ^[a-z]+$`},
+		{Pointer: "/tags/2", Message: "item duplicates item 0"},
+	}, ve)
+
+	err = s.ValidateValue(openapi3.SchemaOrRef{SchemaReference: &openapi3.SchemaReference{Ref: "#/components/schemas/Unknown"}}, 1)
+	assert.EqualError(t, err, ": unresolved reference #/components/schemas/Unknown")
+}
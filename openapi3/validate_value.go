@@ -0,0 +1,294 @@
+package openapi3
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// maxValidationDepth limits nesting of references, e.g. of recursive schemas with cyclic values.
+const maxValidationDepth = 64
+
+// ValidateValue checks generic JSON value (e.g. decoded request body) against schema.
+//
+// Type, format, enum, string, number, array and object constraints and allOf/anyOf/oneOf/not
+// compositions are checked, local references to `#/components/schemas/` are resolved.
+// Problems are returned as ValidationErrors with JSON Pointers into value, e.g. "/items/0/name".
+func (s *Spec) ValidateValue(schema SchemaOrRef, value interface{}) error {
+	var errs ValidationErrors
+
+	s.validateValue("", schema, value, &errs, 0)
+
+	return errs.orNil()
+}
+
+func (s *Spec) validateValue(ptr string, sr SchemaOrRef, value interface{}, errs *ValidationErrors, depth int) {
+	fail := func(ptr, msg string) {
+		*errs = append(*errs, ValidationError{Pointer: ptr, Message: msg})
+	}
+
+	if depth > maxValidationDepth {
+		fail(ptr, "value is nested too deep")
+
+		return
+	}
+
+	schema, err := s.resolveSchema(sr)
+	if err != nil {
+		fail(ptr, err.Error())
+
+		return
+	}
+
+	if schema == nil {
+		return
+	}
+
+	if msg := checkValueType(schema, value); msg != "" {
+		fail(ptr, msg)
+
+		return
+	}
+
+	if value == nil {
+		return
+	}
+
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		fail(ptr, fmt.Sprintf("value %v is not one of enum", value))
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch jsonType(v) {
+	case "string":
+		validateString(ptr, schema, v.String(), fail)
+	case "number":
+		validateNumber(ptr, schema, toFloat(v), fail)
+	case "array":
+		s.validateArray(ptr, schema, v, errs, depth)
+	case "object":
+		if m, ok := value.(map[string]interface{}); ok {
+			s.validateObject(ptr, schema, m, errs, depth)
+		}
+	}
+
+	s.validateComposition(ptr, schema, value, errs, depth)
+}
+
+// resolveSchema follows local references to component schemas.
+func (s *Spec) resolveSchema(sr SchemaOrRef) (*Schema, error) {
+	for i := 0; sr.SchemaReference != nil; i++ {
+		ref := sr.SchemaReference.Ref
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+
+		var (
+			found bool
+			next  SchemaOrRef
+		)
+
+		if name != ref && i < maxValidationDepth && s.Components != nil && s.Components.Schemas != nil {
+			next, found = s.Components.Schemas.MapOfSchemaOrRefValues[name]
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+
+		sr = next
+	}
+
+	return sr.Schema, nil
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if jsonEqual(e, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var patterns sync.Map // Compiled patterns by expressions.
+
+func validateString(ptr string, schema *Schema, str string, fail func(ptr, msg string)) {
+	n := int64(utf8.RuneCountInString(str))
+
+	if schema.MinLength != nil && n < *schema.MinLength {
+		fail(ptr, fmt.Sprintf("length %d is less than %d", n, *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil && n > *schema.MaxLength {
+		fail(ptr, fmt.Sprintf("length %d is greater than %d", n, *schema.MaxLength))
+	}
+
+	if schema.Pattern == nil {
+		return
+	}
+
+	re, ok := patterns.Load(*schema.Pattern)
+	if !ok {
+		compiled, err := regexp.Compile(*schema.Pattern)
+		if err != nil {
+			fail(ptr, fmt.Sprintf("invalid pattern %s: %v", *schema.Pattern, err))
+
+			return
+		}
+
+		re, _ = patterns.LoadOrStore(*schema.Pattern, compiled)
+	}
+
+	if !re.(*regexp.Regexp).MatchString(str) { //nolint:forcetypeassert // Only regexps are stored.
+		fail(ptr, fmt.Sprintf("value %q does not match pattern %s", str, *schema.Pattern))
+	}
+}
+
+func validateNumber(ptr string, schema *Schema, f float64, fail func(ptr, msg string)) {
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum != nil && *schema.ExclusiveMinimum {
+			if f <= *schema.Minimum {
+				fail(ptr, fmt.Sprintf("value %v is not greater than %v", f, *schema.Minimum))
+			}
+		} else if f < *schema.Minimum {
+			fail(ptr, fmt.Sprintf("value %v is less than %v", f, *schema.Minimum))
+		}
+	}
+
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum != nil && *schema.ExclusiveMaximum {
+			if f >= *schema.Maximum {
+				fail(ptr, fmt.Sprintf("value %v is not less than %v", f, *schema.Maximum))
+			}
+		} else if f > *schema.Maximum {
+			fail(ptr, fmt.Sprintf("value %v is greater than %v", f, *schema.Maximum))
+		}
+	}
+
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		q := f / *schema.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			fail(ptr, fmt.Sprintf("value %v is not a multiple of %v", f, *schema.MultipleOf))
+		}
+	}
+}
+
+func (s *Spec) validateArray(ptr string, schema *Schema, v reflect.Value, errs *ValidationErrors, depth int) {
+	n := int64(v.Len())
+
+	fail := func(ptr, msg string) {
+		*errs = append(*errs, ValidationError{Pointer: ptr, Message: msg})
+	}
+
+	if schema.MinItems != nil && n < *schema.MinItems {
+		fail(ptr, fmt.Sprintf("%d items, at least %d expected", n, *schema.MinItems))
+	}
+
+	if schema.MaxItems != nil && n > *schema.MaxItems {
+		fail(ptr, fmt.Sprintf("%d items, at most %d expected", n, *schema.MaxItems))
+	}
+
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		for i := 0; i < v.Len(); i++ {
+			for j := 0; j < i; j++ {
+				if jsonEqual(v.Index(i).Interface(), v.Index(j).Interface()) {
+					fail(fmt.Sprintf("%s/%d", ptr, i), fmt.Sprintf("item duplicates item %d", j))
+				}
+			}
+		}
+	}
+
+	if schema.Items != nil {
+		for i := 0; i < v.Len(); i++ {
+			s.validateValue(fmt.Sprintf("%s/%d", ptr, i), *schema.Items, v.Index(i).Interface(), errs, depth+1)
+		}
+	}
+}
+
+func (s *Spec) validateObject(ptr string, schema *Schema, m map[string]interface{}, errs *ValidationErrors, depth int) {
+	fail := func(ptr, msg string) {
+		*errs = append(*errs, ValidationError{Pointer: ptr, Message: msg})
+	}
+
+	n := int64(len(m))
+
+	if schema.MinProperties != nil && n < *schema.MinProperties {
+		fail(ptr, fmt.Sprintf("%d properties, at least %d expected", n, *schema.MinProperties))
+	}
+
+	if schema.MaxProperties != nil && n > *schema.MaxProperties {
+		fail(ptr, fmt.Sprintf("%d properties, at most %d expected", n, *schema.MaxProperties))
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := m[name]; !ok {
+			fail(ptr+"/"+internal.EscapePointerToken(name), "required property is missing")
+		}
+	}
+
+	for _, name := range sortedKeys(m) {
+		propPtr := ptr + "/" + internal.EscapePointerToken(name)
+
+		if schema.Properties != nil {
+			if prop, ok := schema.Properties.Get(name); ok {
+				s.validateValue(propPtr, prop, m[name], errs, depth+1)
+
+				continue
+			}
+		}
+
+		ap := schema.AdditionalProperties
+
+		switch {
+		case ap == nil:
+		case ap.Bool != nil && !*ap.Bool:
+			fail(propPtr, "additional property is not allowed")
+		case ap.SchemaOrRef != nil:
+			s.validateValue(propPtr, *ap.SchemaOrRef, m[name], errs, depth+1)
+		}
+	}
+}
+
+func (s *Spec) validateComposition(ptr string, schema *Schema, value interface{}, errs *ValidationErrors, depth int) {
+	for _, sub := range schema.AllOf {
+		s.validateValue(ptr, sub, value, errs, depth+1)
+	}
+
+	matches := func(subs []SchemaOrRef) int {
+		n := 0
+
+		for _, sub := range subs {
+			var subErrs ValidationErrors
+
+			if s.validateValue(ptr, sub, value, &subErrs, depth+1); len(subErrs) == 0 {
+				n++
+			}
+		}
+
+		return n
+	}
+
+	if len(schema.AnyOf) > 0 && matches(schema.AnyOf) == 0 {
+		*errs = append(*errs, ValidationError{Pointer: ptr, Message: "value does not match any schema of anyOf"})
+	}
+
+	if len(schema.OneOf) > 0 {
+		if n := matches(schema.OneOf); n != 1 {
+			*errs = append(*errs, ValidationError{
+				Pointer: ptr,
+				Message: fmt.Sprintf("value matches %d schemas of oneOf, exactly one expected", n),
+			})
+		}
+	}
+
+	if schema.Not != nil && matches([]SchemaOrRef{*schema.Not}) == 1 {
+		*errs = append(*errs, ValidationError{Pointer: ptr, Message: "value matches schema of not"})
+	}
+}
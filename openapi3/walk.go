@@ -0,0 +1,246 @@
+package openapi3
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// ErrSkipSchema can be returned by WalkSchemas callback to skip nested schemas of current schema.
+var ErrSkipSchema = errors.New("skip schema")
+
+// WalkSchemas calls fn for every inline schema of components, parameters, request bodies, responses
+// and headers with its JSON Pointer, e.g. "/paths/~1users/get/responses/200/content/application~1json/schema".
+//
+// Nested schemas (properties, items, allOf, etc.) are visited after their parent, references are not followed.
+// Order of visits is stable, walking stops at first error of fn, except ErrSkipSchema.
+func WalkSchemas(spec *Spec, fn func(ptr string, s *Schema) error) error {
+	w := schemaWalker{fn: fn}
+
+	if c := spec.Components; c != nil {
+		if c.Schemas != nil {
+			for _, name := range sortedKeys(c.Schemas.MapOfSchemaOrRefValues) {
+				sor := c.Schemas.MapOfSchemaOrRefValues[name]
+
+				if err := w.schemaOrRef("/components/schemas/"+internal.EscapePointerToken(name), &sor); err != nil {
+					return err
+				}
+			}
+		}
+
+		if c.Parameters != nil {
+			for _, name := range sortedKeys(c.Parameters.MapOfParameterOrRefValues) {
+				if p := c.Parameters.MapOfParameterOrRefValues[name].Parameter; p != nil {
+					if err := w.parameter("/components/parameters/"+internal.EscapePointerToken(name), p); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if c.RequestBodies != nil {
+			for _, name := range sortedKeys(c.RequestBodies.MapOfRequestBodyOrRefValues) {
+				if rb := c.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody; rb != nil {
+					if err := w.content("/components/requestBodies/"+internal.EscapePointerToken(name)+"/content", rb.Content); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if c.Responses != nil {
+			for _, name := range sortedKeys(c.Responses.MapOfResponseOrRefValues) {
+				ror := c.Responses.MapOfResponseOrRefValues[name]
+
+				if err := w.response("/components/responses/"+internal.EscapePointerToken(name), ror); err != nil {
+					return err
+				}
+			}
+		}
+
+		if c.Headers != nil {
+			if err := w.headers("/components/headers", c.Headers.MapOfHeaderOrRefValues); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, path := range sortedKeys(spec.Paths.MapOfPathItemValues) {
+		pi := spec.Paths.MapOfPathItemValues[path]
+		pathPtr := "/paths/" + internal.EscapePointerToken(path)
+
+		if err := w.parameters(pathPtr+"/parameters", pi.Parameters); err != nil {
+			return err
+		}
+
+		for _, method := range sortedKeys(pi.MapOfOperationValues) {
+			if err := w.operation(pathPtr+"/"+method, pi.MapOfOperationValues[method]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type schemaWalker struct {
+	fn func(ptr string, s *Schema) error
+}
+
+func (w schemaWalker) operation(ptr string, op Operation) error {
+	if err := w.parameters(ptr+"/parameters", op.Parameters); err != nil {
+		return err
+	}
+
+	if op.RequestBody != nil && op.RequestBody.RequestBody != nil {
+		if err := w.content(ptr+"/requestBody/content", op.RequestBody.RequestBody.Content); err != nil {
+			return err
+		}
+	}
+
+	for _, code := range sortedKeys(op.Responses.MapOfResponseOrRefValues) {
+		if err := w.response(ptr+"/responses/"+code, op.Responses.MapOfResponseOrRefValues[code]); err != nil {
+			return err
+		}
+	}
+
+	if op.Responses.Default != nil {
+		return w.response(ptr+"/responses/default", *op.Responses.Default)
+	}
+
+	return nil
+}
+
+func (w schemaWalker) parameters(ptr string, params []ParameterOrRef) error {
+	for i, p := range params {
+		if p.Parameter == nil {
+			continue
+		}
+
+		if err := w.parameter(fmt.Sprintf("%s/%d", ptr, i), p.Parameter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w schemaWalker) parameter(ptr string, p *Parameter) error {
+	if err := w.schemaOrRef(ptr+"/schema", p.Schema); err != nil {
+		return err
+	}
+
+	return w.content(ptr+"/content", p.Content)
+}
+
+func (w schemaWalker) response(ptr string, ror ResponseOrRef) error {
+	if ror.Response == nil {
+		return nil
+	}
+
+	if err := w.headers(ptr+"/headers", ror.Response.Headers); err != nil {
+		return err
+	}
+
+	return w.content(ptr+"/content", ror.Response.Content)
+}
+
+func (w schemaWalker) headers(ptr string, headers map[string]HeaderOrRef) error {
+	for _, name := range sortedKeys(headers) {
+		h := headers[name].Header
+		if h == nil {
+			continue
+		}
+
+		hPtr := ptr + "/" + internal.EscapePointerToken(name)
+
+		if err := w.schemaOrRef(hPtr+"/schema", h.Schema); err != nil {
+			return err
+		}
+
+		if err := w.content(hPtr+"/content", h.Content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w schemaWalker) content(ptr string, content map[string]MediaType) error {
+	for _, ct := range sortedKeys(content) {
+		if err := w.schemaOrRef(ptr+"/"+internal.EscapePointerToken(ct)+"/schema", content[ct].Schema); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w schemaWalker) schemaOrRef(ptr string, sor *SchemaOrRef) error {
+	if sor == nil || sor.Schema == nil {
+		return nil
+	}
+
+	s := sor.Schema
+
+	if err := w.fn(ptr, s); err != nil {
+		if errors.Is(err, ErrSkipSchema) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err := w.schemaOrRef(ptr+"/not", s.Not); err != nil {
+		return err
+	}
+
+	for i := range s.AllOf {
+		if err := w.schemaOrRef(fmt.Sprintf("%s/allOf/%d", ptr, i), &s.AllOf[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.OneOf {
+		if err := w.schemaOrRef(fmt.Sprintf("%s/oneOf/%d", ptr, i), &s.OneOf[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range s.AnyOf {
+		if err := w.schemaOrRef(fmt.Sprintf("%s/anyOf/%d", ptr, i), &s.AnyOf[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := w.schemaOrRef(ptr+"/items", s.Items); err != nil {
+		return err
+	}
+
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			if err := w.schemaOrRef(ptr+"/properties/"+internal.EscapePointerToken(pair.Key), &pair.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.AdditionalProperties != nil {
+		return w.schemaOrRef(ptr+"/additionalProperties", s.AdditionalProperties.SchemaOrRef)
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,98 @@
+package openapi3_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func TestWalkSchemas(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: integer}}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                tags: {type: array, items: {type: string}}
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Rate-Limit: {schema: {type: integer}}
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/User'}
+components:
+  schemas:
+    User:
+      allOf:
+        - {$ref: '#/components/schemas/Base'}
+        - {type: object, additionalProperties: {type: string}}
+    Base: {type: object}
+  parameters:
+    limit: {name: limit, in: query, schema: {type: integer}}
+  responses:
+    Error:
+      description: Error
+      content:
+        application/json:
+          schema: {type: object}
+`)))
+
+	var ptrs []string
+
+	require.NoError(t, openapi3.WalkSchemas(&s, func(ptr string, _ *openapi3.Schema) error {
+		ptrs = append(ptrs, ptr)
+
+		return nil
+	}))
+
+	assert.Equal(t, []string{
+		"/components/schemas/Base",
+		"/components/schemas/User",
+		"/components/schemas/User/allOf/1",
+		"/components/schemas/User/allOf/1/additionalProperties",
+		"/components/parameters/limit/schema",
+		"/components/responses/Error/content/application~1json/schema",
+		"/paths/~1users~1{id}/parameters/0/schema",
+		"/paths/~1users~1{id}/post/requestBody/content/application~1json/schema",
+		"/paths/~1users~1{id}/post/requestBody/content/application~1json/schema/properties/tags",
+		"/paths/~1users~1{id}/post/requestBody/content/application~1json/schema/properties/tags/items",
+		"/paths/~1users~1{id}/post/responses/200/headers/X-Rate-Limit/schema",
+	}, ptrs)
+
+	ptrs = nil
+
+	require.NoError(t, openapi3.WalkSchemas(&s, func(ptr string, s *openapi3.Schema) error {
+		ptrs = append(ptrs, ptr)
+
+		if s.Properties != nil || len(s.AllOf) > 0 {
+			return openapi3.ErrSkipSchema
+		}
+
+		return nil
+	}))
+	assert.NotContains(t, ptrs, "/components/schemas/User/allOf/1")
+
+	errStop := errors.New("stop")
+	ptrs = nil
+
+	assert.Equal(t, errStop, openapi3.WalkSchemas(&s, func(ptr string, _ *openapi3.Schema) error {
+		ptrs = append(ptrs, ptr)
+
+		return errStop
+	}))
+	assert.Equal(t, []string{"/components/schemas/Base"}, ptrs)
+}
@@ -0,0 +1,136 @@
+package openapi31
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// EncodeOption configures Spec.Encode.
+type EncodeOption func(o *encodeOptions)
+
+type encodeOptions struct {
+	indent          string
+	yaml            bool
+	keepHTML        bool
+	trailingNewline bool
+}
+
+// WithEncodeIndent enables pretty-printed JSON with an indent, e.g. two spaces.
+func WithEncodeIndent(indent string) EncodeOption {
+	return func(o *encodeOptions) {
+		o.indent = indent
+	}
+}
+
+// WithEncodeYAML enables YAML output, indentation and escaping options are ignored.
+func WithEncodeYAML() EncodeOption {
+	return func(o *encodeOptions) {
+		o.yaml = true
+	}
+}
+
+// WithEncodeEscapeHTML controls escaping of `<`, `>` and `&` in JSON strings, they are escaped by default.
+func WithEncodeEscapeHTML(escape bool) EncodeOption {
+	return func(o *encodeOptions) {
+		o.keepHTML = !escape
+	}
+}
+
+// WithEncodeTrailingNewline terminates JSON output with a newline, YAML output always ends with a newline.
+func WithEncodeTrailingNewline() EncodeOption {
+	return func(o *encodeOptions) {
+		o.trailingNewline = true
+	}
+}
+
+// Encode writes document to w, by default as compact JSON.
+func (s *Spec) Encode(w io.Writer, opts ...EncodeOption) error {
+	o := encodeOptions{}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if o.yaml {
+		data, err = s.MarshalYAML()
+	} else {
+		data, err = o.json(s)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+func (o encodeOptions) json(s *Spec) ([]byte, error) {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if o.keepHTML {
+		data = unescapeHTML(data)
+	}
+
+	if o.indent != "" {
+		var buf bytes.Buffer
+
+		if err := json.Indent(&buf, data, "", o.indent); err != nil {
+			return nil, err
+		}
+
+		data = buf.Bytes()
+	}
+
+	if o.trailingNewline {
+		data = append(data, '\n')
+	}
+
+	return data, nil
+}
+
+// unescapeHTML reverts escaping of `<`, `>` and `&` that JSON encoder applies by default.
+func unescapeHTML(data []byte) []byte {
+	res := make([]byte, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != '\\' || i+1 == len(data) {
+			res = append(res, data[i])
+
+			continue
+		}
+
+		if data[i+1] == 'u' && i+6 <= len(data) {
+			switch string(data[i+2 : i+6]) {
+			case "003c":
+				res = append(res, '<')
+			case "003e":
+				res = append(res, '>')
+			case "0026":
+				res = append(res, '&')
+			default:
+				res = append(res, data[i:i+6]...)
+			}
+
+			i += 5
+
+			continue
+		}
+
+		// Other escape sequences, including escaped backslash, are kept as is.
+		res = append(res, data[i], data[i+1])
+		i++
+	}
+
+	return res
+}
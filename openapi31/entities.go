@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/swaggest/openapi-go"
 	"regexp"
 	"strings"
 )
@@ -29,6 +30,8 @@ type Spec struct {
 	Tags              []Tag                          `json:"tags,omitempty"`
 	ExternalDocs      *ExternalDocumentation         `json:"externalDocs,omitempty"`
 	MapOfAnything     map[string]interface{}         `json:"-"` // Key must match pattern: `^x-`.
+
+	keyOrder map[string][]string // Order of keys of loaded document.
 }
 
 // WithOpenapi sets Openapi value.
@@ -172,14 +175,14 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSpec(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -206,7 +209,7 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -220,13 +223,19 @@ func (s *Spec) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = Spec(ms)
+	s.keyOrder = recordKeyOrder(data)
 
 	return nil
 }
 
 // MarshalJSON encodes JSON.
 func (s Spec) MarshalJSON() ([]byte, error) {
-	return marshalUnion(marshalSpec(s), s.MapOfAnything)
+	j, err := marshalUnion(marshalSpec(s), s.MapOfAnything)
+	if err != nil || s.keyOrder == nil {
+		return j, err
+	}
+
+	return applyKeyOrder(s.keyOrder, j)
 }
 
 // Info structure is generated from "#/$defs/info".
@@ -341,14 +350,14 @@ func (i *Info) UnmarshalJSON(data []byte) error {
 
 	mi := marshalInfo(*i)
 
-	err = json.Unmarshal(data, &mi)
+	err = openapi.JSON.Unmarshal(data, &mi)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -375,7 +384,7 @@ func (i *Info) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -455,14 +464,14 @@ func (c *Contact) UnmarshalJSON(data []byte) error {
 
 	mc := marshalContact(*c)
 
-	err = json.Unmarshal(data, &mc)
+	err = openapi.JSON.Unmarshal(data, &mc)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -483,7 +492,7 @@ func (c *Contact) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -567,14 +576,14 @@ func (l *License) UnmarshalJSON(data []byte) error {
 
 	ml := marshalLicense(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -601,7 +610,7 @@ func (l *License) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -698,14 +707,14 @@ func (s *Server) UnmarshalJSON(data []byte) error {
 
 	ms := marshalServer(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -732,7 +741,7 @@ func (s *Server) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -816,14 +825,14 @@ func (s *ServerVariable) UnmarshalJSON(data []byte) error {
 
 	ms := marshalServerVariable(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -850,7 +859,7 @@ func (s *ServerVariable) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1074,14 +1083,14 @@ func (p *PathItem) UnmarshalJSON(data []byte) error {
 
 	mp := marshalPathItem(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1102,7 +1111,7 @@ func (p *PathItem) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1164,14 +1173,14 @@ func (r *Reference) UnmarshalJSON(data []byte) error {
 
 	mr := marshalReference(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1346,14 +1355,14 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 
 	mp := marshalParameter(*p)
 
-	err = json.Unmarshal(data, &mp)
+	err = openapi.JSON.Unmarshal(data, &mp)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1387,7 +1396,7 @@ func (p *Parameter) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1508,14 +1517,14 @@ func (m *MediaType) UnmarshalJSON(data []byte) error {
 
 	mm := marshalMediaType(*m)
 
-	err = json.Unmarshal(data, &mm)
+	err = openapi.JSON.Unmarshal(data, &mm)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1543,7 +1552,7 @@ func (m *MediaType) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1747,14 +1756,14 @@ func (h *Header) UnmarshalJSON(data []byte) error {
 
 	mh := marshalHeader(*h)
 
-	err = json.Unmarshal(data, &mh)
+	err = openapi.JSON.Unmarshal(data, &mh)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1796,7 +1805,7 @@ func (h *Header) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1887,20 +1896,20 @@ func (e *Example) UnmarshalJSON(data []byte) error {
 
 	var not interface{}
 
-	if json.Unmarshal(data, &not) == nil {
+	if openapi.JSON.Unmarshal(data, &not) == nil {
 		return errors.New("not constraint failed for Example")
 	}
 
 	me := marshalExample(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -1928,7 +1937,7 @@ func (e *Example) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -1994,7 +2003,7 @@ func (e *ExampleOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &e.Reference)
+	err = openapi.JSON.Unmarshal(data, &e.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		e.Reference = nil
@@ -2002,7 +2011,7 @@ func (e *ExampleOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &e.Example)
+	err = openapi.JSON.Unmarshal(data, &e.Example)
 	if err != nil {
 		oneOfErrors["Example"] = err
 		e.Example = nil
@@ -2065,7 +2074,7 @@ func (h *HeaderOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &h.Reference)
+	err = openapi.JSON.Unmarshal(data, &h.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		h.Reference = nil
@@ -2073,7 +2082,7 @@ func (h *HeaderOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &h.Header)
+	err = openapi.JSON.Unmarshal(data, &h.Header)
 	if err != nil {
 		oneOfErrors["Header"] = err
 		h.Header = nil
@@ -2121,7 +2130,7 @@ func (e *EncodingAllOf0) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2138,7 +2147,7 @@ func (e *EncodingAllOf0) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2231,7 +2240,7 @@ func (p *ParameterOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &p.Reference)
+	err = openapi.JSON.Unmarshal(data, &p.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		p.Reference = nil
@@ -2239,7 +2248,7 @@ func (p *ParameterOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.Parameter)
+	err = openapi.JSON.Unmarshal(data, &p.Parameter)
 	if err != nil {
 		oneOfErrors["Parameter"] = err
 		p.Parameter = nil
@@ -2426,14 +2435,14 @@ func (o *Operation) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOperation(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2454,7 +2463,7 @@ func (o *Operation) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2532,14 +2541,14 @@ func (e *ExternalDocumentation) UnmarshalJSON(data []byte) error {
 
 	me := marshalExternalDocumentation(*e)
 
-	err = json.Unmarshal(data, &me)
+	err = openapi.JSON.Unmarshal(data, &me)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2566,7 +2575,7 @@ func (e *ExternalDocumentation) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2661,14 +2670,14 @@ func (r *RequestBody) UnmarshalJSON(data []byte) error {
 
 	mr := marshalRequestBody(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2695,7 +2704,7 @@ func (r *RequestBody) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2761,7 +2770,7 @@ func (r *RequestBodyOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &r.Reference)
+	err = openapi.JSON.Unmarshal(data, &r.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		r.Reference = nil
@@ -2769,7 +2778,7 @@ func (r *RequestBodyOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &r.RequestBody)
+	err = openapi.JSON.Unmarshal(data, &r.RequestBody)
 	if err != nil {
 		oneOfErrors["RequestBody"] = err
 		r.RequestBody = nil
@@ -2857,14 +2866,14 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 	mr := marshalResponses(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -2885,7 +2894,7 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 			var val ResponseOrReference
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -2902,7 +2911,7 @@ func (r *Responses) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3027,14 +3036,14 @@ func (r *Response) UnmarshalJSON(data []byte) error {
 
 	mr := marshalResponse(*r)
 
-	err = json.Unmarshal(data, &mr)
+	err = openapi.JSON.Unmarshal(data, &mr)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3061,7 +3070,7 @@ func (r *Response) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3185,14 +3194,14 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 	ml := marshalLink(*l)
 
-	err = json.Unmarshal(data, &ml)
+	err = openapi.JSON.Unmarshal(data, &ml)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3220,7 +3229,7 @@ func (l *Link) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3286,7 +3295,7 @@ func (l *LinkOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &l.Reference)
+	err = openapi.JSON.Unmarshal(data, &l.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		l.Reference = nil
@@ -3294,7 +3303,7 @@ func (l *LinkOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &l.Link)
+	err = openapi.JSON.Unmarshal(data, &l.Link)
 	if err != nil {
 		oneOfErrors["Link"] = err
 		l.Link = nil
@@ -3357,7 +3366,7 @@ func (r *ResponseOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &r.Reference)
+	err = openapi.JSON.Unmarshal(data, &r.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		r.Reference = nil
@@ -3365,7 +3374,7 @@ func (r *ResponseOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &r.Response)
+	err = openapi.JSON.Unmarshal(data, &r.Response)
 	if err != nil {
 		oneOfErrors["Response"] = err
 		r.Response = nil
@@ -3431,7 +3440,7 @@ func (c *Callbacks) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3448,7 +3457,7 @@ func (c *Callbacks) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3468,7 +3477,7 @@ func (c *Callbacks) UnmarshalJSON(data []byte) error {
 
 		var val PathItemOrReference
 
-		err = json.Unmarshal(rawValue, &val)
+		err = openapi.JSON.Unmarshal(rawValue, &val)
 		if err != nil {
 			return err
 		}
@@ -3527,7 +3536,7 @@ func (p *PathItemOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &p.Reference)
+	err = openapi.JSON.Unmarshal(data, &p.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		p.Reference = nil
@@ -3535,7 +3544,7 @@ func (p *PathItemOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &p.PathItem)
+	err = openapi.JSON.Unmarshal(data, &p.PathItem)
 	if err != nil {
 		oneOfErrors["PathItem"] = err
 		p.PathItem = nil
@@ -3598,7 +3607,7 @@ func (c *CallbacksOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &c.Reference)
+	err = openapi.JSON.Unmarshal(data, &c.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		c.Reference = nil
@@ -3606,7 +3615,7 @@ func (c *CallbacksOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &c.Callbacks)
+	err = openapi.JSON.Unmarshal(data, &c.Callbacks)
 	if err != nil {
 		oneOfErrors["Callbacks"] = err
 		c.Callbacks = nil
@@ -3630,6 +3639,8 @@ func (c CallbacksOrReference) MarshalJSON() ([]byte, error) {
 type Paths struct {
 	MapOfPathItemValues map[string]PathItem    `json:"-"` // Key must match pattern: `^/`.
 	MapOfAnything       map[string]interface{} `json:"-"` // Key must match pattern: `^x-`.
+
+	order []string // Insertion order of path items.
 }
 
 // WithMapOfPathItemValues sets MapOfPathItemValues value.
@@ -3644,6 +3655,10 @@ func (p *Paths) WithMapOfPathItemValuesItem(key string, val PathItem) *Paths {
 		p.MapOfPathItemValues = make(map[string]PathItem, 1)
 	}
 
+	if _, found := p.MapOfPathItemValues[key]; !found {
+		p.order = appendKey(p.order, key)
+	}
+
 	p.MapOfPathItemValues[key] = val
 
 	return p
@@ -3672,7 +3687,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -3689,7 +3704,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 			var val PathItem
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3706,7 +3721,7 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -3724,7 +3739,12 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON encodes JSON.
 func (p Paths) MarshalJSON() ([]byte, error) {
-	return marshalUnion(p.MapOfPathItemValues, p.MapOfAnything)
+	j, err := marshalUnion(p.MapOfPathItemValues, p.MapOfAnything)
+	if err != nil || len(p.order) == 0 {
+		return j, err
+	}
+
+	return applyKeyOrder(map[string][]string{"": p.order}, j)
 }
 
 // Components structure is generated from "#/$defs/components".
@@ -3739,6 +3759,8 @@ type Components struct {
 	Links           map[string]LinkOrReference           `json:"links,omitempty"`
 	Callbacks       map[string]CallbacksOrReference      `json:"callbacks,omitempty"`
 	PathItems       map[string]PathItemOrReference       `json:"pathItems,omitempty"`
+
+	order map[string][]string // Insertion order of components by kind, e.g. "schemas".
 }
 
 // WithSchemas sets Schemas value.
@@ -3753,6 +3775,10 @@ func (c *Components) WithSchemasItem(key string, val map[string]interface{}) *Co
 		c.Schemas = make(map[string]map[string]interface{}, 1)
 	}
 
+	if _, found := c.Schemas[key]; !found {
+		c.addOrder("schemas", key)
+	}
+
 	c.Schemas[key] = val
 
 	return c
@@ -3770,6 +3796,10 @@ func (c *Components) WithResponsesItem(key string, val ResponseOrReference) *Com
 		c.Responses = make(map[string]ResponseOrReference, 1)
 	}
 
+	if _, found := c.Responses[key]; !found {
+		c.addOrder("responses", key)
+	}
+
 	c.Responses[key] = val
 
 	return c
@@ -3787,6 +3817,10 @@ func (c *Components) WithParametersItem(key string, val ParameterOrReference) *C
 		c.Parameters = make(map[string]ParameterOrReference, 1)
 	}
 
+	if _, found := c.Parameters[key]; !found {
+		c.addOrder("parameters", key)
+	}
+
 	c.Parameters[key] = val
 
 	return c
@@ -3804,6 +3838,10 @@ func (c *Components) WithExamplesItem(key string, val ExampleOrReference) *Compo
 		c.Examples = make(map[string]ExampleOrReference, 1)
 	}
 
+	if _, found := c.Examples[key]; !found {
+		c.addOrder("examples", key)
+	}
+
 	c.Examples[key] = val
 
 	return c
@@ -3821,6 +3859,10 @@ func (c *Components) WithRequestBodiesItem(key string, val RequestBodyOrReferenc
 		c.RequestBodies = make(map[string]RequestBodyOrReference, 1)
 	}
 
+	if _, found := c.RequestBodies[key]; !found {
+		c.addOrder("requestBodies", key)
+	}
+
 	c.RequestBodies[key] = val
 
 	return c
@@ -3838,6 +3880,10 @@ func (c *Components) WithHeadersItem(key string, val HeaderOrReference) *Compone
 		c.Headers = make(map[string]HeaderOrReference, 1)
 	}
 
+	if _, found := c.Headers[key]; !found {
+		c.addOrder("headers", key)
+	}
+
 	c.Headers[key] = val
 
 	return c
@@ -3855,6 +3901,10 @@ func (c *Components) WithSecuritySchemesItem(key string, val SecuritySchemeOrRef
 		c.SecuritySchemes = make(map[string]SecuritySchemeOrReference, 1)
 	}
 
+	if _, found := c.SecuritySchemes[key]; !found {
+		c.addOrder("securitySchemes", key)
+	}
+
 	c.SecuritySchemes[key] = val
 
 	return c
@@ -3872,6 +3922,10 @@ func (c *Components) WithLinksItem(key string, val LinkOrReference) *Components
 		c.Links = make(map[string]LinkOrReference, 1)
 	}
 
+	if _, found := c.Links[key]; !found {
+		c.addOrder("links", key)
+	}
+
 	c.Links[key] = val
 
 	return c
@@ -3889,6 +3943,10 @@ func (c *Components) WithCallbacksItem(key string, val CallbacksOrReference) *Co
 		c.Callbacks = make(map[string]CallbacksOrReference, 1)
 	}
 
+	if _, found := c.Callbacks[key]; !found {
+		c.addOrder("callbacks", key)
+	}
+
 	c.Callbacks[key] = val
 
 	return c
@@ -3906,11 +3964,41 @@ func (c *Components) WithPathItemsItem(key string, val PathItemOrReference) *Com
 		c.PathItems = make(map[string]PathItemOrReference, 1)
 	}
 
+	if _, found := c.PathItems[key]; !found {
+		c.addOrder("pathItems", key)
+	}
+
 	c.PathItems[key] = val
 
 	return c
 }
 
+func (c *Components) addOrder(kind, key string) {
+	if c.order == nil {
+		c.order = make(map[string][]string, 1)
+	}
+
+	names := c.order[kind]
+	c.order[kind] = append(names[:len(names):len(names)], key)
+}
+
+type marshalComponents Components
+
+// MarshalJSON encodes JSON.
+func (c Components) MarshalJSON() ([]byte, error) {
+	j, err := openapi.JSON.Marshal(marshalComponents(c))
+	if err != nil || len(c.order) == 0 {
+		return j, err
+	}
+
+	keyOrder := make(map[string][]string, len(c.order))
+	for kind, names := range c.order {
+		keyOrder["/"+kind] = names
+	}
+
+	return applyKeyOrder(keyOrder, j)
+}
+
 // SecurityScheme structure is generated from "#/$defs/security-scheme".
 type SecurityScheme struct {
 	Description   *string                   `json:"description,omitempty"`
@@ -4052,7 +4140,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecurityScheme(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
@@ -4060,7 +4148,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 6)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &ms.APIKey)
+	err = openapi.JSON.Unmarshal(data, &ms.APIKey)
 	if err != nil {
 		oneOfErrors["APIKey"] = err
 		ms.APIKey = nil
@@ -4068,7 +4156,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &ms.HTTP)
+	err = openapi.JSON.Unmarshal(data, &ms.HTTP)
 	if err != nil {
 		oneOfErrors["HTTP"] = err
 		ms.HTTP = nil
@@ -4076,7 +4164,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &ms.HTTPBearer)
+	err = openapi.JSON.Unmarshal(data, &ms.HTTPBearer)
 	if err != nil {
 		oneOfErrors["HTTPBearer"] = err
 		ms.HTTPBearer = nil
@@ -4084,7 +4172,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &ms.Oauth2)
+	err = openapi.JSON.Unmarshal(data, &ms.Oauth2)
 	if err != nil {
 		oneOfErrors["Oauth2"] = err
 		ms.Oauth2 = nil
@@ -4092,7 +4180,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &ms.Oidc)
+	err = openapi.JSON.Unmarshal(data, &ms.Oidc)
 	if err != nil {
 		oneOfErrors["Oidc"] = err
 		ms.Oidc = nil
@@ -4100,7 +4188,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &ms.MutualTLS)
+	err = openapi.JSON.Unmarshal(data, &ms.MutualTLS)
 	if err != nil {
 		oneOfErrors["MutualTLS"] = err
 		ms.MutualTLS = nil
@@ -4114,7 +4202,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4141,7 +4229,7 @@ func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4196,14 +4284,14 @@ func (s *SecuritySchemeAPIKey) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeAPIKey(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4257,14 +4345,14 @@ func (s *SecuritySchemeHTTP) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeHTTP(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4331,14 +4419,14 @@ func (s *SecuritySchemeHTTPBearer) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeHTTPBearer(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4396,14 +4484,14 @@ func (s *SecuritySchemeOauth2) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeOauth2(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4534,14 +4622,14 @@ func (o *OauthFlows) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOauthFlows(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4562,7 +4650,7 @@ func (o *OauthFlows) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4660,14 +4748,14 @@ func (o *OauthFlowsDefsImplicit) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOauthFlowsDefsImplicit(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4694,7 +4782,7 @@ func (o *OauthFlowsDefsImplicit) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4792,14 +4880,14 @@ func (o *OauthFlowsDefsPassword) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOauthFlowsDefsPassword(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4826,7 +4914,7 @@ func (o *OauthFlowsDefsPassword) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -4924,14 +5012,14 @@ func (o *OauthFlowsDefsClientCredentials) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOauthFlowsDefsClientCredentials(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -4958,7 +5046,7 @@ func (o *OauthFlowsDefsClientCredentials) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5067,14 +5155,14 @@ func (o *OauthFlowsDefsAuthorizationCode) UnmarshalJSON(data []byte) error {
 
 	mo := marshalOauthFlowsDefsAuthorizationCode(*o)
 
-	err = json.Unmarshal(data, &mo)
+	err = openapi.JSON.Unmarshal(data, &mo)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5101,7 +5189,7 @@ func (o *OauthFlowsDefsAuthorizationCode) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5150,14 +5238,14 @@ func (s *SecuritySchemeOidc) UnmarshalJSON(data []byte) error {
 
 	ms := marshalSecuritySchemeOidc(*s)
 
-	err = json.Unmarshal(data, &ms)
+	err = openapi.JSON.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5198,7 +5286,7 @@ func (m *MutualTLS) UnmarshalJSON(data []byte) error {
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5263,7 +5351,7 @@ func (s *SecuritySchemeOrReference) UnmarshalJSON(data []byte) error {
 	oneOfErrors := make(map[string]error, 2)
 	oneOfValid := 0
 
-	err = json.Unmarshal(data, &s.Reference)
+	err = openapi.JSON.Unmarshal(data, &s.Reference)
 	if err != nil {
 		oneOfErrors["Reference"] = err
 		s.Reference = nil
@@ -5271,7 +5359,7 @@ func (s *SecuritySchemeOrReference) UnmarshalJSON(data []byte) error {
 		oneOfValid++
 	}
 
-	err = json.Unmarshal(data, &s.SecurityScheme)
+	err = openapi.JSON.Unmarshal(data, &s.SecurityScheme)
 	if err != nil {
 		oneOfErrors["SecurityScheme"] = err
 		s.SecurityScheme = nil
@@ -5361,14 +5449,14 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 
 	mt := marshalTag(*t)
 
-	err = json.Unmarshal(data, &mt)
+	err = openapi.JSON.Unmarshal(data, &mt)
 	if err != nil {
 		return err
 	}
 
 	var rawMap map[string]json.RawMessage
 
-	err = json.Unmarshal(data, &rawMap)
+	err = openapi.JSON.Unmarshal(data, &rawMap)
 	if err != nil {
 		rawMap = nil
 	}
@@ -5395,7 +5483,7 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 
 			var val interface{}
 
-			err = json.Unmarshal(rawValue, &val)
+			err = openapi.JSON.Unmarshal(rawValue, &val)
 			if err != nil {
 				return err
 			}
@@ -5441,14 +5529,14 @@ func (i ParameterIn) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected ParameterIn value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *ParameterIn) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -5493,14 +5581,14 @@ func (i EncodingStyle) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected EncodingStyle value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *EncodingStyle) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -5545,14 +5633,14 @@ func (i ParameterStyle) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected ParameterStyle value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *ParameterStyle) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -5595,14 +5683,14 @@ func (i SecuritySchemeAPIKeyIn) MarshalJSON() ([]byte, error) {
 		return nil, fmt.Errorf("unexpected SecuritySchemeAPIKeyIn value: %v", i)
 	}
 
-	return json.Marshal(string(i))
+	return openapi.JSON.Marshal(string(i))
 }
 
 // UnmarshalJSON decodes JSON.
 func (i *SecuritySchemeAPIKeyIn) UnmarshalJSON(data []byte) error {
 	var ii string
 
-	err := json.Unmarshal(data, &ii)
+	err := openapi.JSON.Unmarshal(data, &ii)
 	if err != nil {
 		return err
 	}
@@ -5628,7 +5716,7 @@ func marshalUnion(maps ...interface{}) ([]byte, error) {
 	isObject := true
 
 	for _, m := range maps {
-		j, err := json.Marshal(m)
+		j, err := openapi.JSON.Marshal(m)
 		if err != nil {
 			return nil, err
 		}
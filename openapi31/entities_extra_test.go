@@ -1,8 +1,12 @@
 package openapi31_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/openapi-go/openapi31"
 )
@@ -93,3 +97,191 @@ paths:
 
 	require.NoError(t, s.UnmarshalYAML([]byte(spec)))
 }
+
+func TestSpec_UnmarshalYAML_keyOrder(t *testing.T) {
+	var s openapi31.Spec
+
+	spec := `openapi: 3.1.0
+info:
+  version: 1.0.0
+  title: Orders
+paths:
+  /orders:
+    post:
+      responses:
+        "201":
+          description: Created
+    get:
+      responses:
+        "200":
+          description: OK
+  /carts:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        total:
+          type: number
+        id:
+          type: string
+    Cart:
+      type: object
+`
+
+	require.NoError(t, s.UnmarshalYAML([]byte(spec)))
+
+	s.Info.WithDescription("Orders API.")
+
+	y, err := s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(spec, "  title: Orders\n", "  title: Orders\n  description: Orders API.\n", 1), string(y))
+
+	s.ResetKeyOrder()
+
+	y, err = s.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(y), "paths:\n  /carts:")
+}
+
+func TestPaths_MarshalJSON_insertionOrder(t *testing.T) {
+	var s openapi31.Spec
+
+	s.PathsEns().WithMapOfPathItemValuesItem("/users", openapi31.PathItem{})
+	s.PathsEns().WithMapOfPathItemValuesItem("/orders", openapi31.PathItem{})
+	s.PathsEns().WithMapOfPathItemValuesItem("/users", openapi31.PathItem{Summary: new(string)})
+	s.Paths.MapOfPathItemValues["/carts"] = openapi31.PathItem{}
+
+	j, err := json.Marshal(s.Paths)
+	require.NoError(t, err)
+	assert.Equal(t, `{"/users":{"summary":""},"/orders":{},"/carts":{}}`, string(j))
+}
+
+func TestPaths_MarshalJSON_deletedKey(t *testing.T) {
+	var s openapi31.Spec
+
+	s.PathsEns().WithMapOfPathItemValuesItem("/users", openapi31.PathItem{})
+	s.PathsEns().WithMapOfPathItemValuesItem("/orders", openapi31.PathItem{})
+	delete(s.Paths.MapOfPathItemValues, "/users")
+	s.PathsEns().WithMapOfPathItemValuesItem("/users", openapi31.PathItem{})
+
+	j, err := json.Marshal(s.Paths)
+	require.NoError(t, err)
+	assert.Equal(t, `{"/orders":{},"/users":{}}`, string(j))
+}
+
+func TestSpec_SortAlphabetically(t *testing.T) {
+	var s openapi31.Spec
+
+	s.WithOpenapi("3.1.0")
+	s.Info.WithTitle("API").WithVersion("1.0.0")
+	s.PathsEns().
+		WithMapOfPathItemValuesItem("/users", openapi31.PathItem{}).
+		WithMapOfPathItemValuesItem("/orders", openapi31.PathItem{})
+	s.ComponentsEns().
+		WithSchemasItem("User", map[string]interface{}{"type": "object"}).
+		WithSchemasItem("Order", map[string]interface{}{"type": "object"})
+
+	j, err := s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},`+
+		`"paths":{"/users":{},"/orders":{}},`+
+		`"components":{"schemas":{"User":{"type":"object"},"Order":{"type":"object"}}}}`, string(j))
+
+	s.SortAlphabetically()
+
+	j, err = s.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, `{"openapi":"3.1.0","info":{"title":"API","version":"1.0.0"},`+
+		`"paths":{"/orders":{},"/users":{}},`+
+		`"components":{"schemas":{"Order":{"type":"object"},"User":{"type":"object"}}}}`, string(j))
+}
+
+func TestSpec_UnmarshalYAML_strict(t *testing.T) {
+	var s openapi31.Spec
+
+	require.NoError(t, s.UnmarshalYAML([]byte(`openapi: 3.1.0
+info: {title: API, version: 1.0.0, x-team: users}
+paths:
+  /users:
+    get:
+      responses:
+        200: {description: OK}
+`)))
+	assert.Equal(t, "users", s.Info.MapOfAnything["x-team"])
+
+	assert.EqualError(t, s.UnmarshalYAML([]byte(`openapi: 3.1.0
+info: {title: API, version: 1.0.0}
+paths:
+  /users:
+    get:
+      responses:
+        "200": {description: OK}
+        "200": {description: Found}
+`)), "duplicate key: /paths/~1users/get/responses/200")
+
+	assert.EqualError(t, s.UnmarshalYAML([]byte(`info: {title: API, version: 1.0.0}`)),
+		"required key missing: openapi")
+}
+
+func TestSpec_MarshalYAMLWithComments(t *testing.T) {
+	s := openapi31.Spec{Openapi: "3.1.0"}
+	s.Info.WithTitle("API").WithVersion("1.0.0")
+	s.WithTags(openapi31.Tag{Name: "users"})
+	s.PathsEns().WithMapOfPathItemValuesItem("/users", openapi31.PathItem{
+		Get: &openapi31.Operation{
+			Responses: &openapi31.Responses{MapOfResponseOrReferenceValues: map[string]openapi31.ResponseOrReference{
+				"200": {Response: &openapi31.Response{
+					Description: "OK",
+					Content:     map[string]openapi31.MediaType{"application/json": {}},
+				}},
+			}},
+		},
+	})
+
+	y, err := s.MarshalYAMLWithComments("Code generated by apigen. DO NOT EDIT.")
+	require.NoError(t, err)
+	assert.Equal(t, `# Code generated by apigen. DO NOT EDIT.
+openapi: 3.1.0
+info:
+  title: API
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          content:
+            application/json: {}
+          description: OK
+tags:
+- name: users
+`, string(y))
+}
+
+func TestSpec_Encode(t *testing.T) {
+	s := openapi31.Spec{Openapi: "3.1.0"}
+	s.Info.WithTitle("API").WithVersion("1.0.0").WithSummary("Tom & Jerry")
+
+	var buf bytes.Buffer
+
+	require.NoError(t, s.Encode(&buf, openapi31.WithEncodeIndent("  "), openapi31.WithEncodeEscapeHTML(false),
+		openapi31.WithEncodeTrailingNewline()))
+	assert.Equal(t, `{
+  "openapi": "3.1.0",
+  "info": {
+    "title": "API",
+    "summary": "Tom & Jerry",
+    "version": "1.0.0"
+  }
+}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, s.Encode(&buf, openapi31.WithEncodeYAML()))
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  summary: Tom & Jerry\n  title: API\n  version: 1.0.0\n", buf.String())
+}
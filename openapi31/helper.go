@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -136,12 +137,20 @@ func (o *Operation) validatePathParams(pathParams map[string]bool) error {
 		paramIndex[p.Parameter.Name+string(p.Parameter.In)] = true
 	}
 
+	undefined := make([]string, 0, len(pathParams))
+
 	for pathParam := range pathParams {
 		if !paramIndex[pathParam+string(ParameterInPath)] {
-			errs = append(errs, "undefined path parameter: "+pathParam)
+			undefined = append(undefined, pathParam)
 		}
 	}
 
+	sort.Strings(undefined)
+
+	for _, pathParam := range undefined {
+		errs = append(errs, "undefined path parameter: "+pathParam)
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, ", "))
 	}
@@ -149,6 +158,60 @@ func (o *Operation) validatePathParams(pathParams map[string]bool) error {
 	return nil
 }
 
+// Operations calls fn for every operation ordered by path and method, changes of operation are kept in spec.
+//
+// Method is in upper case, e.g. http.MethodGet, iteration stops at first error of fn.
+func (s *Spec) Operations(fn func(method, path string, op *Operation) error) error {
+	if s.Paths == nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		ops := map[string]*Operation{
+			http.MethodGet: pi.Get, http.MethodPut: pi.Put, http.MethodPost: pi.Post, http.MethodDelete: pi.Delete,
+			http.MethodOptions: pi.Options, http.MethodHead: pi.Head, http.MethodPatch: pi.Patch, http.MethodTrace: pi.Trace,
+		}
+
+		methods := make([]string, 0, len(ops))
+
+		for method, op := range ops {
+			if op != nil {
+				methods = append(methods, method)
+			}
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			if err := fn(method, path, ops[method]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkOperationID fails if operation ID is already used by another operation.
+func (s *Spec) checkOperationID(method, path, id string) error {
+	return s.Operations(func(m, p string, op *Operation) error {
+		if op.ID != nil && *op.ID == id {
+			return fmt.Errorf("operationId %s of %s %s is already used by %s %s", id, strings.ToUpper(method), path, m, p)
+		}
+
+		return nil
+	})
+}
+
 // AddOperation validates and sets operation by path and method.
 //
 // It will fail if operation with method and path already exists.
@@ -166,6 +229,12 @@ func (s *Spec) AddOperation(method, path string, operation Operation) error {
 		return fmt.Errorf("operation already exists: %s %s", method, path)
 	}
 
+	if operation.ID != nil {
+		if err := s.checkOperationID(method, path, *operation.ID); err != nil {
+			return err
+		}
+	}
+
 	// Add "No Content" response if there are no responses configured.
 	if len(operation.ResponsesEns().MapOfResponseOrReferenceValues) == 0 && operation.Responses.Default == nil {
 		operation.Responses.WithMapOfResponseOrReferenceValuesItem(strconv.Itoa(http.StatusNoContent), ResponseOrReference{
@@ -182,6 +251,47 @@ func (s *Spec) AddOperation(method, path string, operation Operation) error {
 	})
 }
 
+// AddWebhook sets webhook operation by name and method.
+//
+// It will fail if webhook operation with name and method already exists.
+func (s *Spec) AddWebhook(name, method string, operation Operation) error {
+	pi := s.Webhooks[name]
+
+	if pi.PathItem == nil {
+		pi.PathItem = &PathItem{}
+	}
+
+	existing, err := pi.PathItem.Operation(method)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return fmt.Errorf("webhook already exists: %s %s", method, name)
+	}
+
+	// Add "No Content" response if there are no responses configured.
+	if len(operation.ResponsesEns().MapOfResponseOrReferenceValues) == 0 && operation.Responses.Default == nil {
+		operation.Responses.WithMapOfResponseOrReferenceValuesItem(strconv.Itoa(http.StatusNoContent), ResponseOrReference{
+			Response: &Response{
+				Description: http.StatusText(http.StatusNoContent),
+			},
+		})
+	}
+
+	if err := pi.PathItem.SetOperation(method, &operation); err != nil {
+		return err
+	}
+
+	if s.Webhooks == nil {
+		s.Webhooks = make(map[string]PathItemOrReference)
+	}
+
+	s.Webhooks[name] = pi
+
+	return nil
+}
+
 // UnknownParamIsForbidden indicates forbidden unknown parameters.
 func (o Operation) UnknownParamIsForbidden(in ParameterIn) bool {
 	f, ok := o.MapOfAnything[xForbidUnknown+string(in)].(bool)
@@ -0,0 +1,75 @@
+package openapi31
+
+import (
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal"
+)
+
+// recordKeyOrder returns order of object keys of a loaded document, so that marshaled document
+// follows the source and diffs show only intentional edits, nil if document can not be parsed.
+func recordKeyOrder(data []byte) map[string][]string {
+	ko, err := internal.RecordKeyOrder(data)
+	if err != nil {
+		return nil
+	}
+
+	return ko
+}
+
+func applyKeyOrder(keyOrder map[string][]string, data []byte) ([]byte, error) {
+	return internal.KeyOrder(keyOrder).Apply(data)
+}
+
+func appendKey(order []string, key string) []string {
+	return internal.AppendKey(order, key)
+}
+
+// ResetKeyOrder discards order of keys of loaded document, marshaled document is then ordered by default rules.
+func (s *Spec) ResetKeyOrder() {
+	s.keyOrder = nil
+}
+
+// SortAlphabetically discards order of adding and order of loaded document of paths and components,
+// so that they are marshaled sorted by name and documents are reproducible regardless of order of building.
+func (s *Spec) SortAlphabetically() {
+	if s.Paths != nil {
+		s.Paths.SortAlphabetically()
+	}
+
+	if s.Components != nil {
+		s.Components.SortAlphabetically()
+	}
+
+	for ptr := range s.keyOrder {
+		if ptr == "/paths" || (strings.HasPrefix(ptr, "/components/") && strings.Count(ptr, "/") == 2) {
+			delete(s.keyOrder, ptr)
+		}
+	}
+}
+
+// SortAlphabetically discards order of adding path items.
+func (p *Paths) SortAlphabetically() {
+	p.order = nil
+}
+
+// SortAlphabetically discards order of adding components.
+func (c *Components) SortAlphabetically() {
+	c.order = nil
+}
+
+// canonicalKeys is an order of document keys as they appear in OpenAPI specification.
+var canonicalKeys = []string{
+	"openapi", "info", "jsonSchemaDialect", "servers", "paths", "webhooks", "components",
+	"security", "tags", "externalDocs",
+}
+
+// canonicalKeyOrder puts top level keys of built document in canonical order,
+// loaded document keeps order of its source.
+func canonicalKeyOrder(keyOrder map[string][]string, data []byte) ([]byte, error) {
+	if _, loaded := keyOrder[""]; loaded {
+		return data, nil
+	}
+
+	return applyKeyOrder(map[string][]string{"": canonicalKeys}, data)
+}
@@ -1,6 +1,7 @@
 package openapi31
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -19,6 +20,66 @@ import (
 type Reflector struct {
 	jsonschema.Reflector
 	Spec *Spec
+
+	// RequestRequiredPolicy controls which properties of request bodies are required.
+	RequestRequiredPolicy openapi.RequiredPolicy
+
+	// ResponseRequiredPolicy controls which properties of response bodies are required.
+	//
+	// If it differs from RequestRequiredPolicy, struct components of request bodies are named
+	// with `Request` suffix, so that a type used in both directions gets a component per policy.
+	ResponseRequiredPolicy openapi.RequiredPolicy
+
+	// Nullability controls whether pointer fields are nullable, optional or both.
+	Nullability openapi.NullabilityPolicy
+
+	// Int64Policy controls how reflected 64-bit integers are described to avoid precision loss in JSON clients.
+	Int64Policy openapi.Int64Policy
+
+	// AnnotateSource enables `x-source` operation extension with Go registration site and structures,
+	// see Spec.MarshalAnnotatedYAML.
+	AnnotateSource bool
+
+	// EmitGoSource enables `x-go-source` operation extension with file:line of registration site.
+	EmitGoSource bool
+
+	// EmbeddedAllOf composes schemas of embedded structures with `allOf` references to their components
+	// instead of flattening their properties, `json:",inline"` tag keeps a field flattened.
+	EmbeddedAllOf bool
+
+	// SchemaPlacement controls whether reflected schemas of request and response bodies are hoisted
+	// into components with references (default) or inlined in operations, openapi.WithSchemaPlacement
+	// overrides it for a particular body.
+	SchemaPlacement openapi.SchemaPlacement
+
+	// DefaultContentType replaces `application/json` as media type of reflected request and response bodies
+	// that have no content type, openapi.WithContentType sets media type of a particular body.
+	DefaultContentType string
+
+	// OperationIDNamer generates IDs of operations without explicit ID, e.g. openapi.OperationIDFromPath.
+	OperationIDNamer openapi.OperationIDNamer
+
+	sources map[string]openapi.OperationSource
+
+	ctx context.Context
+
+	options           reflectorOptions
+	interceptorsAdded bool
+	checkSecurity     bool
+}
+
+// reflectorOptions are set by option methods of Reflector and read by interceptors on every reflection,
+// so that repeated calls replace values and order of calls does not matter.
+type reflectorOptions struct {
+	genericNames    openapi.TypeArgsMangler
+	schemaNamer     openapi.SchemaNamer
+	nameCollision   *openapi.NameCollisionStrategy
+	durationPolicy  openapi.DurationPolicy
+	timeFormat      openapi.TimeFormat
+	docComments     openapi.DocComments
+	implementations openapi.ImplementationRegistry
+	enums           openapi.EnumRegistry
+	validatorTags   bool
 }
 
 // NewReflector creates an instance of OpenAPI 3.1 reflector.
@@ -216,6 +277,10 @@ func (r *Reflector) AddOperation(oc openapi.OperationContext) error {
 		return fmt.Errorf("wrong operation context %T received, %T expected", oc, operationContext{})
 	}
 
+	if c.op.ID == nil && r.OperationIDNamer != nil {
+		c.op.WithID(r.OperationIDNamer(oc.Method(), oc.PathPattern()))
+	}
+
 	if err := r.setupRequest(c.op, oc); err != nil {
 		return fmt.Errorf("setup request %s %s: %w", oc.Method(), oc.PathPattern(), err)
 	}
@@ -228,17 +293,101 @@ func (r *Reflector) AddOperation(oc openapi.OperationContext) error {
 		return fmt.Errorf("setup response %s %s: %w", oc.Method(), oc.PathPattern(), err)
 	}
 
-	return r.SpecEns().AddOperation(oc.Method(), oc.PathPattern(), *c.op)
+	if err := r.validateSecurity(c.op); err != nil {
+		return fmt.Errorf("validate security %s %s: %w", oc.Method(), oc.PathPattern(), err)
+	}
+
+	src := internal.CaptureSource(oc, reflect.TypeOf(r).Elem().PkgPath())
+
+	if r.AnnotateSource {
+		c.op.WithMapOfAnythingItem(xSource, internal.SourceExtension(src))
+	}
+
+	if r.EmitGoSource {
+		c.op.WithMapOfAnythingItem(xGoSource, src.String())
+	}
+
+	if err := r.SpecEns().AddOperation(oc.Method(), oc.PathPattern(), *c.op); err != nil {
+		return err
+	}
+
+	if r.sources == nil {
+		r.sources = make(map[string]openapi.OperationSource)
+	}
+
+	r.sources[oc.Method()+" "+oc.PathPattern()] = src
+
+	return nil
+}
+
+// AddWebhook configures webhook operation request and response schema.
+//
+// Operation context should be created with NewOperationContext, its path pattern is not used.
+func (r *Reflector) AddWebhook(name string, oc openapi.OperationContext) error {
+	c, ok := oc.(operationContext)
+	if !ok {
+		return fmt.Errorf("wrong operation context %T received, %T expected", oc, operationContext{})
+	}
+
+	if err := r.setupRequest(c.op, oc); err != nil {
+		return fmt.Errorf("setup request %s webhook %s: %w", oc.Method(), name, err)
+	}
+
+	if err := r.setupResponse(c.op, oc); err != nil {
+		return fmt.Errorf("setup response %s webhook %s: %w", oc.Method(), name, err)
+	}
+
+	return r.SpecEns().AddWebhook(name, oc.Method(), *c.op)
+}
+
+// AddOperationContext adds operation to spec unless context is done.
+//
+// Reflection of operation structures is aborted with context error when context is canceled or
+// its deadline is exceeded, so that specs rebuilt on demand do not outlive their requests.
+func (r *Reflector) AddOperationContext(ctx context.Context, oc openapi.OperationContext) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	prev := r.ctx
+	r.ctx = ctx
+
+	defer func() {
+		r.ctx = prev
+	}()
+
+	err := r.AddOperation(oc)
+
+	// Reflection errors are joined as text, so context error is restored for errors.Is.
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("add operation %s %s: %w", oc.Method(), oc.PathPattern(), ctxErr)
+	}
+
+	return err
+}
+
+// SourceOf returns Go registration site of an operation added with AddOperation.
+func (r *Reflector) SourceOf(method, pathPattern string) (openapi.OperationSource, bool) {
+	method, pathPattern, _, err := openapi.SanitizeMethodPath(method, pathPattern)
+	if err != nil {
+		return openapi.OperationSource{}, false
+	}
+
+	src, found := r.sources[method+" "+pathPattern]
+
+	return src, found
 }
 
 func (r *Reflector) setupRequest(o *Operation, oc openapi.OperationContext) error {
+	r.ensureInterceptors()
+
 	for _, cu := range oc.Request() {
 		switch cu.ContentType {
 		case "":
 			if err := joinErrors(
 				r.parseRequestBody(o, oc, cu, mimeFormUrlencoded, oc.Method(), cu.FieldMapping(openapi.InFormData), tagFormData, tagForm),
 				r.parseParameters(o, oc, cu),
-				r.parseRequestBody(o, oc, cu, mimeJSON, oc.Method(), nil, tagJSON),
+				r.parseRequestBody(o, oc, cu, r.bodyContentType(), oc.Method(), nil, tagJSON),
 			); err != nil {
 				return err
 			}
@@ -259,7 +408,16 @@ func (r *Reflector) setupRequest(o *Operation, oc openapi.OperationContext) erro
 				return err
 			}
 		default:
-			r.stringRequestBody(o, cu.ContentType, cu.Format)
+			if err := joinErrors(
+				r.parseParameters(o, oc, cu),
+				r.parsePrimitiveRequestBody(o, oc, cu),
+			); err != nil {
+				return err
+			}
+		}
+
+		if o.RequestBody != nil && o.RequestBody.RequestBody != nil {
+			shareContent(o.RequestBody.RequestBody.Content, cu.ContentType, cu.AdditionalContentTypes)
 		}
 
 		if cu.Description != "" && o.RequestBody != nil && o.RequestBody.RequestBody != nil {
@@ -304,6 +462,15 @@ func mediaType(format string) MediaType {
 	return mt
 }
 
+// bodyContentType returns media type of reflected bodies without content type.
+func (r *Reflector) bodyContentType() string {
+	if r.DefaultContentType != "" {
+		return r.DefaultContentType
+	}
+
+	return mimeJSON
+}
+
 func (r *Reflector) stringRequestBody(
 	o *Operation,
 	mime string,
@@ -312,6 +479,38 @@ func (r *Reflector) stringRequestBody(
 	o.RequestBodyEns().RequestBodyEns().WithContentItem(mime, mediaType(format))
 }
 
+// parsePrimitiveRequestBody adds schema of a request body with custom content type.
+//
+// Structures are reflected with `json` tags, string schema is used if there are no body fields.
+func (r *Reflector) parsePrimitiveRequestBody(o *Operation, oc openapi.OperationContext, cu openapi.ContentUnit) error {
+	schema, err := internal.ReflectPrimitiveBody(r.JSONSchemaReflector(), cu)
+	if err != nil {
+		return err
+	}
+
+	if schema == nil {
+		if err := r.parseRequestBody(o, oc, cu, cu.ContentType, oc.Method(), nil, tagJSON); err != nil {
+			return err
+		}
+
+		if o.RequestBody == nil || o.RequestBody.RequestBody == nil ||
+			o.RequestBody.RequestBody.Content[cu.ContentType].Schema == nil {
+			r.stringRequestBody(o, cu.ContentType, cu.Format)
+		}
+
+		return nil
+	}
+
+	sm, err := schema.ToSchemaOrBool().ToSimpleMap()
+	if err != nil {
+		return err
+	}
+
+	o.RequestBodyEns().RequestBodyEns().WithContentItem(cu.ContentType, MediaType{Schema: sm})
+
+	return nil
+}
+
 func (r *Reflector) parseRawRequestBody(o *Operation, cu openapi.ContentUnit) {
 	if cu.Structure == nil {
 		return
@@ -332,6 +531,8 @@ func (r *Reflector) parseRequestBody(
 	tag string,
 	additionalTags ...string,
 ) error {
+	var fileFields []string
+
 	schema, hasFileUpload, err := internal.ReflectRequestBody(
 		true,
 		r.JSONSchemaReflector(),
@@ -342,6 +543,12 @@ func (r *Reflector) parseRequestBody(
 		additionalTags,
 		openapi.WithOperationCtx(oc, false, "body"),
 		jsonschema.DefinitionsPrefix(componentsSchemas),
+		internal.InterceptRequired(r.RequestRequiredPolicy, r.Nullability),
+		internal.InterceptRequestDefName(r.RequestRequiredPolicy != r.ResponseRequiredPolicy),
+		internal.SchemaPlacement(r.SchemaPlacement, cu),
+		internal.InterceptFileFields(func(name string) {
+			fileFields = append(fileFields, name)
+		}),
 	)
 	if err != nil || schema == nil {
 		return err
@@ -359,6 +566,8 @@ func (r *Reflector) parseRequestBody(
 		Schema: sm,
 	}
 
+	addExamples(&mt, internal.MediaTypeExamples(cu))
+
 	for name, def := range definitions {
 		sm, err := def.ToSimpleMap()
 		if err != nil {
@@ -372,6 +581,12 @@ func (r *Reflector) parseRequestBody(
 		mime = mimeMultipart
 	}
 
+	if mime == mimeMultipart {
+		for _, name := range fileFields {
+			mt.WithEncodingItem(name, *(&Encoding{}).WithContentType("application/octet-stream"))
+		}
+	}
+
 	o.RequestBodyEns().RequestBodyEns().WithContentItem(mime, mt)
 
 	return nil
@@ -381,6 +596,12 @@ const (
 	// xForbidUnknown is a prefix of a vendor extension to indicate forbidden unknown parameters.
 	// It should be used together with ParameterIn as a suffix.
 	xForbidUnknown = "x-forbid-unknown-"
+
+	// xSource is a vendor extension with Go origin of an operation.
+	xSource = "x-source"
+
+	// xGoSource is a vendor extension with file:line of operation registration.
+	xGoSource = "x-go-source"
 )
 
 func (r *Reflector) parseParameters(o *Operation, oc openapi.OperationContext, cu openapi.ContentUnit) error {
@@ -412,6 +633,10 @@ func (r *Reflector) parseParametersIn(
 			propertySchema := params.PropertySchema
 			field := params.Field
 
+			if internal.IsIgnoredHeader(in, name) {
+				return nil
+			}
+
 			sm, err := propertySchema.ToSchemaOrBool().ToSimpleMap()
 			if err != nil {
 				return err
@@ -439,6 +664,15 @@ func (r *Reflector) parseParametersIn(
 				p.WithStyle(ParameterStyleForm).WithExplode(true)
 			}
 
+			style := ""
+			refl.ReadStringTag(field.Tag, "style", &style)
+
+			if style != "" {
+				if err := internal.CheckParameterStyle(in, style); err != nil {
+					return fmt.Errorf("parameter %s: %w", name, err)
+				}
+			}
+
 			// Check if parameter is an JSON encoded object.
 			property := reflect.New(field.Type).Interface()
 			if collectionFormat == "json" || //nolint:nestif
@@ -459,8 +693,13 @@ func (r *Reflector) parseParametersIn(
 					return err
 				}
 
-				p.Schema = nil
-				p.WithContentItem("application/json", MediaType{Schema: sm})
+				if style != "" && collectionFormat != "json" {
+					// Explicit style serializes object properties instead of JSON encoding.
+					p.Schema = sm
+				} else {
+					p.Schema = nil
+					p.WithContentItem("application/json", MediaType{Schema: sm})
+				}
 			} else {
 				ps, err := r.Reflect(reflect.New(field.Type).Interface(),
 					openapi.WithOperationCtx(oc, false, in),
@@ -481,6 +720,16 @@ func (r *Reflector) parseParametersIn(
 				return err
 			}
 
+			// Style enum of OpenAPI 3.1 entities only covers query parameters.
+			if style != "" && in == openapi.InQuery {
+				p.WithStyle(ParameterStyle(style))
+			}
+
+			// Deep object serialization is only defined for exploded form.
+			if style == string(ParameterStyleDeepObject) && p.Explode == nil {
+				p.WithExplode(true)
+			}
+
 			if in == openapi.InPath {
 				p.WithRequired(true)
 			}
@@ -488,7 +737,8 @@ func (r *Reflector) parseParametersIn(
 			alreadyExists := false
 
 			for _, ep := range o.Parameters {
-				if ep.Parameter != nil && ep.Parameter.In == p.In && ep.Parameter.Name == p.Name {
+				if ep.Parameter != nil && ep.Parameter.In == p.In &&
+					internal.SameParameter(in, ep.Parameter.Name, p.Name) {
 					alreadyExists = true
 
 					break
@@ -608,6 +858,8 @@ func (r *Reflector) parseRawResponseBody(resp *Response, cu openapi.ContentUnit)
 }
 
 func (r *Reflector) setupResponse(o *Operation, oc openapi.OperationContext) error {
+	r.ensureInterceptors()
+
 	for _, cu := range oc.Response() {
 		if cu.HTTPStatus == 0 && !cu.IsDefault {
 			cu.HTTPStatus = http.StatusOK
@@ -649,6 +901,8 @@ func (r *Reflector) setupResponse(o *Operation, oc openapi.OperationContext) err
 			if cu.ContentType != "" {
 				r.ensureResponseContentType(resp, cu.ContentType, cu.Format)
 			}
+
+			shareContent(resp.Content, cu.ContentType, cu.AdditionalContentTypes)
 		} else {
 			// Only headers with HEAD method.
 			if err := r.parseResponseHeader(resp, oc, cu); err != nil {
@@ -680,6 +934,45 @@ func (r *Reflector) setupResponse(o *Operation, oc openapi.OperationContext) err
 	return nil
 }
 
+// parsePrimitiveResponse adds schema of a scalar or []byte response with non-JSON content type.
+func (r *Reflector) parsePrimitiveResponse(resp *Response, cu openapi.ContentUnit) (bool, error) {
+	schema, err := internal.ReflectPrimitiveBody(r.JSONSchemaReflector(), cu)
+	if err != nil || schema == nil {
+		return false, err
+	}
+
+	sm, err := schema.ToSchemaOrBool().ToSimpleMap()
+	if err != nil {
+		return false, err
+	}
+
+	resp.WithContentItem(cu.ContentType, MediaType{Schema: sm})
+
+	return true, nil
+}
+
+// addExamples sets named examples of media type.
+func addExamples(mt *MediaType, examples map[string]interface{}) {
+	for name, value := range examples {
+		e := Example{}
+		e.WithValue(value)
+
+		mt.WithExamplesItem(name, ExampleOrReference{Example: &e})
+	}
+}
+
+// shareContent documents media type of content type under additional content types.
+func shareContent(content map[string]MediaType, contentType string, additional []string) {
+	mt, ok := content[contentType]
+	if !ok {
+		return
+	}
+
+	for _, ct := range additional {
+		content[ct] = mt
+	}
+}
+
 func (r *Reflector) ensureResponseContentType(resp *Response, contentType string, format string) {
 	if _, ok := resp.Content[contentType]; !ok {
 		if resp.Content == nil {
@@ -691,12 +984,18 @@ func (r *Reflector) ensureResponseContentType(resp *Response, contentType string
 }
 
 func (r *Reflector) parseJSONResponse(resp *Response, oc openapi.OperationContext, cu openapi.ContentUnit) error {
+	if ok, err := r.parsePrimitiveResponse(resp, cu); ok || err != nil {
+		return err
+	}
+
 	sch, err := internal.ReflectJSONResponse(
 		r.JSONSchemaReflector(),
 		cu.Structure,
 		openapi.WithOperationCtx(oc, true, openapi.InBody),
 		jsonschema.DefinitionsPrefix(componentsSchemas),
 		jsonschema.CollectDefinitions(r.collectDefinition()),
+		internal.InterceptRequired(r.ResponseRequiredPolicy, r.Nullability),
+		internal.SchemaPlacement(r.SchemaPlacement, cu),
 	)
 
 	if err != nil || sch == nil {
@@ -714,12 +1013,14 @@ func (r *Reflector) parseJSONResponse(resp *Response, oc openapi.OperationContex
 
 	contentType := cu.ContentType
 	if contentType == "" {
-		contentType = mimeJSON
+		contentType = r.bodyContentType()
 	}
 
 	mt := resp.Content[contentType]
 	mt.Schema = sm
 
+	addExamples(&mt, internal.MediaTypeExamples(cu))
+
 	resp.Content[contentType] = mt
 
 	if sch.Description != nil && resp.Description == "" {
@@ -738,3 +1039,114 @@ func (r *Reflector) SpecSchema() openapi.SpecSchema {
 func (r *Reflector) JSONSchemaReflector() *jsonschema.Reflector {
 	return &r.Reflector
 }
+
+// ensureInterceptors registers interceptors that apply reflector options before the first reflection
+// of operation, interceptors read options on every use, so options can be changed later.
+func (r *Reflector) ensureInterceptors() {
+	if r.interceptorsAdded {
+		return
+	}
+
+	r.interceptorsAdded = true
+	o := &r.options
+
+	// Name interceptors are chained in order, so that name collisions are resolved for final names.
+	r.DefaultOptions = append(r.DefaultOptions,
+		internal.InterceptGenericDefName(func() openapi.TypeArgsMangler { return o.genericNames }),
+		internal.InterceptSchemaNamer(func() openapi.SchemaNamer { return o.schemaNamer }),
+		internal.InterceptNameCollision(func() *openapi.NameCollisionStrategy { return o.nameCollision }),
+		internal.InterceptDuration(func() openapi.DurationPolicy { return o.durationPolicy }),
+		internal.InterceptTimeFormat(func() openapi.TimeFormat { return o.timeFormat }),
+		internal.InterceptDocComments(func() openapi.DocComments { return o.docComments }),
+		internal.InterceptImplementations(&r.Reflector,
+			func() openapi.ImplementationRegistry { return o.implementations }, r.collectDefinition()),
+		internal.InterceptEnums(func() openapi.EnumRegistry { return o.enums }),
+		internal.InterceptEmbedded(&r.Reflector, func() bool { return r.EmbeddedAllOf }, r.collectDefinition()),
+		internal.InterceptNullabilityPolicy(func() openapi.NullabilityPolicy { return r.Nullability }),
+		internal.InterceptInt64(func() openapi.Int64Policy { return r.Int64Policy }),
+		internal.InterceptValidatorTags(func() bool { return o.validatorTags }),
+		internal.InterceptContext(func() context.Context { return r.ctx }),
+	)
+}
+
+// AddTypeMapping describes type of src with dst, that is a sample of another type, jsonschema.Schema or
+// schema as map[string]interface{}, e.g. to describe third-party types like uuid.UUID or decimal.Decimal as strings
+// without leaking their internal fields.
+func (r *Reflector) AddTypeMapping(src, dst interface{}) {
+	if s, ok := dst.(map[string]interface{}); ok {
+		if js := ToJSONSchema(s, r.SpecEns()).TypeObject; js != nil {
+			dst = *js
+		}
+	}
+
+	r.Reflector.AddTypeMapping(src, dst)
+}
+
+// SQLNullTypes describes null types of database/sql, e.g. sql.NullString, as nullable scalars.
+func (r *Reflector) SQLNullTypes() {
+	for _, m := range internal.SQLNullTypeMappings() {
+		r.Reflector.AddTypeMapping(m.Sample, m.Schema)
+	}
+}
+
+// GenericNames enables component names of instantiated generic types built from type arguments,
+// e.g. `Page[User]` becomes `PageOfUser` with default openapi.MangleOf.
+func (r *Reflector) GenericNames(m openapi.TypeArgsMangler) {
+	if m == nil {
+		m = openapi.MangleOf
+	}
+
+	r.options.genericNames = m
+}
+
+// SchemaNamer sets a custom naming of component schemas.
+//
+// Types that are named empty by namer keep default names.
+func (r *Reflector) SchemaNamer(namer openapi.SchemaNamer) {
+	r.options.schemaNamer = namer
+}
+
+// NameCollision sets how component names of distinct types with same name are resolved.
+//
+// Strategy applies to final names, after GenericNames and SchemaNamer.
+func (r *Reflector) NameCollision(strategy openapi.NameCollisionStrategy) {
+	r.options.nameCollision = &strategy
+}
+
+// DurationPolicy sets how reflected time.Duration values are described.
+func (r *Reflector) DurationPolicy(policy openapi.DurationPolicy) {
+	r.options.durationPolicy = policy
+}
+
+// TimeFormat sets how reflected `date-time` values of `example`, `default`, `const` and `enum` are written.
+func (r *Reflector) TimeFormat(f openapi.TimeFormat) {
+	r.options.timeFormat = f
+}
+
+// DocComments uses doc comments of Go types and fields as descriptions of schemas and properties
+// that have no `description` tag, see openapi.DocComments.
+func (r *Reflector) DocComments(dc openapi.DocComments) {
+	r.options.docComments = dc
+}
+
+// Implementations reflects fields of registered interface types as `oneOf` of references
+// to their implementations with optional discriminator.
+//
+// Registry is used by reference, so types can be added later.
+func (r *Reflector) Implementations(registry openapi.ImplementationRegistry) {
+	r.options.implementations = registry
+}
+
+// Enums adds `enum` values of registered types to reflected schemas.
+//
+// Registry is used by reference, so types can be added later.
+// Types that implement openapi.Enumer do not need registration.
+func (r *Reflector) Enums(enums openapi.EnumRegistry) {
+	r.options.enums = enums
+}
+
+// ValidatorTags enables schema constraints from `validate` field tags of go-playground/validator,
+// e.g. `validate:"required,min=1,max=10,oneof=a b,email"`.
+func (r *Reflector) ValidatorTags() {
+	r.options.validatorTags = true
+}
@@ -1,11 +1,14 @@
 package openapi31_test
 
 import (
+	"encoding/xml"
 	"mime/multipart"
 	"net/http"
 	"os"
+	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -141,7 +144,8 @@ func TestReflector_AddOperation_uploadInterface(t *testing.T) {
 			"requestBody":{
 			  "content":{
 				"multipart/form-data":{
-				  "schema":{"$ref":"#/components/schemas/FormDataOpenapi31TestReq"}
+				  "schema":{"$ref":"#/components/schemas/FormDataOpenapi31TestReq"},
+				  "encoding":{"upload1":{"contentType":"application/octet-stream"}}
 				}
 			  }
 			},
@@ -469,7 +473,8 @@ func TestReflector_AddOperation_setup_request(t *testing.T) {
 					  "value3":{"type":"number"}
 					},
 					"type":"object"
-				  }
+				  },
+				  "encoding":{"upload6":{"contentType":"application/octet-stream"}}
 				}
 			  }
 			},
@@ -1594,3 +1599,813 @@ func TestSelfReference(t *testing.T) {
 	  }
 	}`, reflector.SpecSchema())
 }
+
+func TestReflector_AddWebhook(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type event struct {
+		ID   string `json:"id"`
+		Kind string `json:"kind" enum:"created,deleted"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/")
+	require.NoError(t, err)
+
+	oc.SetSummary("Thing changed.")
+	oc.AddReqStructure(event{})
+	oc.AddRespStructure(nil, openapi.WithHTTPStatus(http.StatusAccepted))
+
+	require.NoError(t, r.AddWebhook("thingChanged", oc))
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/")
+	require.NoError(t, err)
+	assert.EqualError(t, r.AddWebhook("thingChanged", oc), "webhook already exists: post thingChanged")
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.1.0","info":{"title":"","version":""},"paths":{},
+	  "webhooks":{
+		"thingChanged":{
+		  "post":{
+			"summary":"Thing changed.",
+			"requestBody":{
+			  "content":{
+				"application/json":{"schema":{"$ref":"#/components/schemas/Openapi31TestEvent"}}
+			  }
+			},
+			"responses":{"202":{"description":"Accepted"}}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "Openapi31TestEvent":{
+			"properties":{"id":{"type":"string"},"kind":{"enum":["created","deleted"],"type":"string"}},
+			"type":"object"
+		  }
+		}
+	  }
+	}`, r.SpecSchema())
+}
+
+func TestReflector_AddOperation_queryStyle(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type filter struct {
+		Status string `json:"status"`
+	}
+
+	type req struct {
+		Filter filter `query:"filter" style:"form" explode:"true"`
+		Deep   filter `query:"deep" style:"deepObject"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[
+	  {
+		"name":"filter","in":"query","style":"form","explode":true,
+		"schema":{"$ref":"#/components/schemas/Openapi31TestFilter"}
+	  },
+	  {
+		"name":"deep","in":"query","style":"deepObject","explode":true,
+		"schema":{"$ref":"#/components/schemas/Openapi31TestFilter"}
+	  }
+	]`, r.Spec.Paths.MapOfPathItemValues["/things"].Get.Parameters)
+}
+
+func TestReflector_RequiredPolicy(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.RequestRequiredPolicy = openapi.RequiredUnlessOptional
+
+	type address struct {
+		City string  `json:"city"`
+		Zip  *string `json:"zip"`
+	}
+
+	type req struct {
+		Name    string   `json:"name"`
+		Email   string   `json:"email" required:"false"`
+		Age     int      `json:"age,omitempty"`
+		Address *address `json:"address"`
+		Alias   *string  `json:"alias" required:"true"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi31TestAddressRequest":{
+		"properties":{"city":{"type":"string"},"zip":{"type":["null","string"]}},
+		"required":["city"],"type":"object"
+	  },
+	  "Openapi31TestReqRequest":{
+		"properties":{
+		  "address":{"$ref":"#/components/schemas/Openapi31TestAddressRequest"},
+		  "age":{"type":"integer"},"alias":{"type":["null","string"]},"email":{"type":"string"},
+		  "name":{"type":"string"}
+		},
+		"required":["name","alias"],"type":"object"
+	  }
+	}`, r.Spec.Components.Schemas)
+}
+
+func TestReflector_AnnotateSource(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.AnnotateSource = true
+
+	type req struct {
+		ID string `path:"id"`
+	}
+
+	type resp struct {
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id}")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure(resp{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	src, ok := r.Spec.Paths.MapOfPathItemValues["/things/{id}"].Get.MapOfAnything["x-source"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Contains(t, src["file"], "reflect_test.go:")
+	assert.Equal(t, "github.com/swaggest/openapi-go/openapi31_test.TestReflector_AnnotateSource", src["function"])
+	assert.Equal(t, []string{"openapi31_test.req"}, src["request"])
+	assert.Equal(t, []string{"openapi31_test.resp"}, src["response"])
+
+	y, err := r.Spec.MarshalAnnotatedYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(y), `  /things/{id}:
+    # file: `+src["file"].(string)+`
+    # function: github.com/swaggest/openapi-go/openapi31_test.TestReflector_AnnotateSource
+    # request: [openapi31_test.req]
+    # response: [openapi31_test.resp]
+    get:`)
+	assert.NotContains(t, string(y), "x-source")
+	assert.Contains(t, r.Spec.Paths.MapOfPathItemValues["/things/{id}"].Get.MapOfAnything, "x-source")
+}
+
+func TestReflector_SourceOf(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.EmitGoSource = true
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things/{id:[0-9]+}")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(struct {
+		ID int `path:"id"`
+	}{})
+
+	require.NoError(t, r.AddOperation(oc))
+
+	src, found := r.SourceOf("GET", "/things/{id}")
+	require.True(t, found)
+	assert.Contains(t, src.String(), "reflect_test.go:")
+	assert.Equal(t, "github.com/swaggest/openapi-go/openapi31_test.TestReflector_SourceOf", src.Function)
+	assert.Equal(t, src.String(), r.Spec.Paths.MapOfPathItemValues["/things/{id}"].Get.MapOfAnything["x-go-source"])
+
+	_, found = r.SourceOf("POST", "/things/{id}")
+	assert.False(t, found)
+}
+
+func TestReflector_Nullability(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.RequestRequiredPolicy = openapi.RequiredUnlessOmitEmpty
+	r.Nullability = openapi.PointerOptional
+
+	type req struct {
+		Name  string  `json:"name"`
+		Zip   *string `json:"zip"`
+		Alias *string `json:"alias" required:"true"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{"alias":{"type":"string"},"name":{"type":"string"},"zip":{"type":"string"}},
+	  "required":["name","alias"],"type":"object"
+	}`, r.Spec.Components.Schemas["Openapi31TestReqRequest"])
+}
+
+type exampleThing struct {
+	Name string `json:"name"`
+}
+
+func (exampleThing) Examples() map[string]interface{} {
+	return map[string]interface{}{
+		"short": exampleThing{Name: "Bob"},
+		"long":  exampleThing{Name: "Robert"},
+	}
+}
+
+func TestReflector_AddOperation_namedExamples(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(exampleThing{})
+	oc.AddRespStructure(exampleThing{}, openapi.WithExamples(map[string]interface{}{
+		"long":  map[string]interface{}{"name": "Roberta"},
+		"empty": map[string]interface{}{"name": ""},
+	}))
+	require.NoError(t, r.AddOperation(oc))
+
+	op := r.Spec.Paths.MapOfPathItemValues["/things"].Post
+
+	assertjson.EqMarshal(t, `{
+	  "long":{"value":{"name":"Robert"}},"short":{"value":{"name":"Bob"}}
+	}`, op.RequestBody.RequestBody.Content["application/json"].Examples)
+
+	assertjson.EqMarshal(t, `{
+	  "empty":{"value":{"name":""}},"long":{"value":{"name":"Roberta"}},"short":{"value":{"name":"Bob"}}
+	}`, op.Responses.MapOfResponseOrReferenceValues["200"].Response.Content["application/json"].Examples)
+}
+
+func TestReflector_NameCollision(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.NameCollision(openapi.NameCollisionSuffix)
+
+	for i, resp := range []interface{}{
+		func() interface{} {
+			type user struct {
+				ID int `json:"id"`
+			}
+
+			return user{}
+		}(),
+		func() interface{} {
+			type user struct {
+				Name string `json:"name"`
+			}
+
+			return user{}
+		}(),
+	} {
+		oc, err := r.NewOperationContext(http.MethodGet, "/users/"+strconv.Itoa(i))
+		require.NoError(t, err)
+		oc.AddRespStructure(resp)
+		require.NoError(t, r.AddOperation(oc))
+	}
+
+	assertjson.EqMarshal(t, `{
+	  "Openapi31TestUser":{"properties":{"id":{"type":"integer"}},"type":"object"},
+	  "Openapi31TestUser2":{"properties":{"name":{"type":"string"}},"type":"object"}
+	}`, r.Spec.Components.Schemas)
+}
+
+func TestReflector_AddOperation_primitiveBody(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/counter")
+	require.NoError(t, err)
+	oc.AddReqStructure(int64(0), openapi.WithContentType("text/plain"))
+	oc.AddRespStructure([]byte(nil), openapi.WithContentType("application/octet-stream"))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{"content":{"text/plain":{"schema":{"type":"integer","format":"int64"}}}},
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{"application/octet-stream":{"schema":{"type":"string","format":"binary"}}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/counter"].Post)
+}
+
+func TestReflector_AddOperation_multipleResponseContent(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	type problem struct {
+		Title string `json:"title"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	oc.AddRespStructure([]thing{})
+	oc.AddRespStructure([]thing{}, openapi.WithContentType("application/xml"))
+	oc.AddRespStructure("", openapi.WithContentType("text/csv"))
+	oc.AddRespStructure(problem{}, openapi.WithContentType("application/problem+json"),
+		openapi.WithHTTPStatus(http.StatusBadRequest))
+	oc.AddRespStructure("", openapi.WithContentType("text/plain"), openapi.WithHTTPStatus(http.StatusBadRequest),
+		func(cu *openapi.ContentUnit) {
+			cu.Description = "Invalid request"
+		})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "200":{
+		"description":"OK",
+		"content":{
+		  "application/json":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Openapi31TestThing"}}},
+		  "application/xml":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Openapi31TestThing"}}},
+		  "text/csv":{"schema":{"type":"string"}}
+		}
+	  },
+	  "400":{
+		"description":"Invalid request",
+		"content":{
+		  "application/problem+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestProblem"}},
+		  "text/plain":{"schema":{"type":"string"}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things"].Get.Responses)
+}
+
+func TestReflector_AddOperation_versionedContentType(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(thing{}, openapi.WithVersionedContentType("application/vnd.myco.{version}+json", "v1", "v2"))
+	oc.AddRespStructure(thing{}, openapi.WithVersionedContentType("application/vnd.myco.{version}+json", "v1", "v2"))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{
+		"content":{
+		  "application/vnd.myco.v1+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}},
+		  "application/vnd.myco.v2+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}}
+		}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{
+			"application/vnd.myco.v1+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}},
+			"application/vnd.myco.v2+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}}
+		  }
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things"].Post)
+}
+
+func TestReflector_AddOperation_contentType(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.DefaultContentType = "application/vnd.api+json"
+
+	type thing struct {
+		Name string `json:"name"`
+	}
+
+	type req struct {
+		ID   int    `path:"id"`
+		Name string `json:"name"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/things/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{}, openapi.WithContentType("application/xml"))
+	oc.AddReqStructure(thing{})
+	oc.AddRespStructure(thing{})
+	oc.AddRespStructure(thing{}, openapi.WithContentType("application/x-ndjson"), openapi.WithHTTPStatus(http.StatusAccepted))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+	  "requestBody":{
+		"content":{
+		  "application/vnd.api+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}},
+		  "application/xml":{"schema":{"$ref":"#/components/schemas/Openapi31TestReq"}}
+		}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{"application/vnd.api+json":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}}}
+		},
+		"202":{
+		  "description":"Accepted",
+		  "content":{"application/x-ndjson":{"schema":{"$ref":"#/components/schemas/Openapi31TestThing"}}}
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/things/{id}"].Put)
+
+	assertjson.EqMarshal(t, `{"type":"object","properties":{"name":{"type":"string"}}}`,
+		r.Spec.Components.Schemas["Openapi31TestThing"])
+}
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (c circle) Area() float64 {
+	return 3.14 * c.Radius * c.Radius
+}
+
+type shapeGroup struct {
+	Kind   string  `json:"kind"`
+	Shapes []shape `json:"shapes"`
+}
+
+func (g shapeGroup) Area() float64 {
+	a := 0.0
+	for _, s := range g.Shapes {
+		a += s.Area()
+	}
+
+	return a
+}
+
+func TestReflector_AddOperation_operationID(t *testing.T) {
+	r := openapi31.NewReflector()
+	r.OperationIDNamer = openapi.OperationIDFromPath
+
+	type req struct {
+		ID int `path:"id"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/users/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assert.Equal(t, "getUsersById", *r.Spec.Paths.MapOfPathItemValues["/users/{id}"].Get.ID)
+
+	oc, err = r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+	oc.SetID("getUsersById")
+	assert.EqualError(t, r.AddOperation(oc), "operationId getUsersById of POST /users is already used by GET /users/{id}")
+}
+
+func TestReflector_AddOperation_download(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPut, "/reports")
+	require.NoError(t, err)
+	oc.AddReqStructure(openapi.Binary("image/png"))
+	oc.AddRespStructure(openapi.Download("application/pdf"))
+	oc.AddRespStructure(nil, openapi.WithHTTPStatus(http.StatusNotFound))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{"content":{"image/png":{"schema":{"type":"string","format":"binary"}}}},
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "headers":{
+			"Content-Disposition":{
+			  "style":"simple","description":"Presentation and file name of downloaded content.","required":true,
+			  "schema":{
+				"type":"string","description":"Presentation and file name of downloaded content.",
+				"examples":["attachment; filename=\"file\""]
+			  }
+			}
+		  },
+		  "content":{"application/pdf":{"schema":{"type":"string","format":"binary"}}}
+		},
+		"404":{"description":"Not Found"}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/reports"].Put)
+}
+
+func TestReflector_AddOperation_stream(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type created struct {
+		ID int `json:"id"`
+	}
+
+	type deleted struct {
+		ID     int  `json:"id"`
+		Purged bool `json:"purged"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/events")
+	require.NoError(t, err)
+	oc.AddReqStructure([]created{}, openapi.WithStream(openapi.MediaTypeNDJSON))
+	oc.AddRespStructure(jsonschema.OneOf(created{}, deleted{}), openapi.WithStream(openapi.MediaTypeEventStream))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "requestBody":{
+		"content":{"application/x-ndjson":{"schema":{"$ref":"#/components/schemas/Openapi31TestCreated"}}}
+	  },
+	  "responses":{
+		"200":{
+		  "description":"OK",
+		  "content":{
+			"text/event-stream":{
+			  "schema":{
+				"oneOf":[
+				  {"$ref":"#/components/schemas/Openapi31TestCreated"},
+				  {"$ref":"#/components/schemas/Openapi31TestDeleted"}
+				]
+			  }
+			}
+		  }
+		}
+	  }
+	}`, r.Spec.Paths.MapOfPathItemValues["/events"].Post)
+}
+
+func TestReflector_AddOperation_headerParameters(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type req struct {
+		RequestID   string    `header:"X-Request-ID" required:"true" format:"uuid" description:"Request trace."`
+		Since       time.Time `header:"If-Modified-Since"`
+		Accept      string    `header:"Accept"`
+		ContentType string    `header:"content-type"`
+		Auth        string    `header:"Authorization"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[
+	  {
+		"name":"X-Request-ID","in":"header","description":"Request trace.","required":true,
+		"schema":{"type":"string","description":"Request trace.","format":"uuid"}
+	  },
+	  {"name":"If-Modified-Since","in":"header","schema":{"type":"string","format":"date-time"}}
+	]`, r.Spec.Paths.MapOfPathItemValues["/things"].Get.Parameters)
+
+	type dup struct {
+		ID  string `header:"X-Request-ID"`
+		ID2 string `header:"x-request-id"`
+	}
+
+	oc, err = r.NewOperationContext(http.MethodGet, "/dups")
+	require.NoError(t, err)
+	oc.AddReqStructure(dup{})
+	assert.ErrorContains(t, r.AddOperation(oc), "parameter x-request-id in header is already defined")
+}
+
+func TestReflector_AddOperation_constraintTags(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type req struct {
+		Limit int      `query:"limit" minimum:"1" maximum:"50"`
+		Count int      `json:"count" minimum:"5" maximum:"100" multipleOf:"5"`
+		Ratio float64  `json:"ratio" exclusiveMinimum:"0" exclusiveMaximum:"1"`
+		Code  string   `json:"code" minLength:"2" maxLength:"8" pattern:"^[A-Z]+$"`
+		Tags  []string `json:"tags" minItems:"1" maxItems:"5" uniqueItems:"true" nullable:"false"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/things")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"count":{"multipleOf":5,"maximum":100,"minimum":5,"type":"integer"},
+		"ratio":{"exclusiveMaximum":1,"exclusiveMinimum":0,"type":"number","format":"double"},
+		"code":{"maxLength":8,"minLength":2,"pattern":"^[A-Z]+$","type":"string"},
+		"tags":{"maxItems":5,"minItems":1,"uniqueItems":true,"type":"array","items":{"type":"string"}}
+	  }
+	}`, r.Spec.Components.Schemas["Openapi31TestReq"])
+}
+
+func TestReflector_AddOperation_readWriteOnly(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type profile struct {
+		Bio string `json:"bio"`
+	}
+
+	type user struct {
+		ID       int     `json:"id" readOnly:"true"`
+		Password string  `json:"password" writeOnly:"true"`
+		Profile  profile `json:"profile" readOnly:"true"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/users")
+	require.NoError(t, err)
+	oc.AddReqStructure(user{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"id":{"type":"integer","readOnly":true},"password":{"type":"string","writeOnly":true},
+		"profile":{"$ref":"#/components/schemas/Openapi31TestProfile","readOnly":true}
+	  }
+	}`, r.Spec.Components.Schemas["Openapi31TestUser"])
+}
+
+func TestReflector_AddOperation_xml(t *testing.T) {
+	r := openapi31.NewReflector()
+
+	type order struct {
+		XMLName xml.Name `json:"-" xml:"order"`
+		ID      int      `json:"id" xml:"id,attr"`
+		Tags    []string `json:"tags" xml:"labels>label"`
+	}
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.AddRespStructure(order{}, openapi.WithContentType("application/xml"))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "type":"object",
+	  "properties":{
+		"id":{"type":"integer","xml":{"attribute":true}},
+		"tags":{
+		  "type":["array","null"],"items":{"type":"string","xml":{"name":"label"}},
+		  "xml":{"name":"labels","wrapped":true}
+		}
+	  },
+	  "xml":{"name":"order"}
+	}`, r.Spec.Components.Schemas["Openapi31TestOrder"])
+}
+
+func TestReflector_Implementations(t *testing.T) {
+	type drawing struct {
+		Main   shape   `json:"main"`
+		Others []shape `json:"others"`
+	}
+
+	shapes := openapi.ImplementationRegistry{}
+
+	r := openapi31.NewReflector()
+	r.Implementations(shapes)
+
+	shapes.AddImplementations((*shape)(nil), openapi.Implementations{
+		Samples:       []interface{}{circle{}, shapeGroup{}},
+		Discriminator: "kind",
+	})
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/drawings")
+	require.NoError(t, err)
+	oc.AddReqStructure(drawing{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "discriminator":{"propertyName":"kind"},
+	  "oneOf":[
+		{"$ref":"#/components/schemas/Openapi31TestCircle"},
+		{"$ref":"#/components/schemas/Openapi31TestShapeGroup"}
+	  ]
+	}`, r.Spec.Components.Schemas["Openapi31TestShape"])
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{"kind":{"type":"string"},"radius":{"format":"double","type":"number"}},
+	  "type":"object"
+	}`, r.Spec.Components.Schemas["Openapi31TestCircle"])
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"kind":{"type":"string"},
+		"shapes":{"items":{"$ref":"#/components/schemas/Openapi31TestShape"},"type":["array","null"]}
+	  },
+	  "type":"object"
+	}`, r.Spec.Components.Schemas["Openapi31TestShapeGroup"])
+}
+
+func TestReflector_Implementations_discriminated(t *testing.T) {
+	type req struct {
+		ID int `path:"id"`
+	}
+
+	r := openapi31.NewReflector()
+	r.Implementations(openapi.ImplementationRegistry{
+		reflect.TypeOf((*shape)(nil)).Elem(): openapi.Discriminated("kind", map[string]interface{}{
+			"group":  shapeGroup{},
+			"circle": circle{},
+			"disc":   circle{},
+		}),
+	})
+
+	oc, err := r.NewOperationContext(http.MethodGet, "/shapes/{id}")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	oc.AddRespStructure((*shape)(nil))
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "description":"OK",
+	  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/Openapi31TestShape"}}}
+	}`, r.Spec.Paths.MapOfPathItemValues["/shapes/{id}"].Get.Responses.MapOfResponseOrReferenceValues["200"].Response)
+
+	assertjson.EqMarshal(t, `{
+	  "discriminator":{
+		"propertyName":"kind",
+		"mapping":{
+		  "circle":"#/components/schemas/Openapi31TestCircle",
+		  "disc":"#/components/schemas/Openapi31TestCircle",
+		  "group":"#/components/schemas/Openapi31TestShapeGroup"
+		}
+	  },
+	  "oneOf":[
+		{"$ref":"#/components/schemas/Openapi31TestCircle"},
+		{"$ref":"#/components/schemas/Openapi31TestShapeGroup"}
+	  ]
+	}`, r.Spec.Components.Schemas["Openapi31TestShape"])
+}
+
+func TestReflector_AddOperation_maps(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	type req struct {
+		Items map[string]item `json:"items" maxProperties:"5" propertyNamePattern:"^[a-z]+$"`
+	}
+
+	r := openapi31.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/items")
+	require.NoError(t, err)
+	oc.AddReqStructure(req{})
+	require.NoError(t, r.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `{
+	  "properties":{
+		"items":{
+		  "additionalProperties":{"$ref":"#/components/schemas/Openapi31TestItem"},
+		  "maxProperties":5,"propertyNames":{"pattern":"^[a-z]+$","type":"string"},
+		  "type":["object","null"]
+		}
+	  },
+	  "type":"object"
+	}`, r.Spec.Components.Schemas["Openapi31TestReq"])
+}
+
+func TestReflector_AddOperation_embedded(t *testing.T) {
+	type audit struct {
+		CreatedAt string `json:"createdAt" required:"true"`
+	}
+
+	type base struct {
+		ID   int    `json:"id" required:"true"`
+		Kind string `json:"kind"`
+	}
+
+	type pet struct {
+		base
+		audit `json:",inline"`
+		Kind  string `json:"kind" enum:"cat,dog"`
+	}
+
+	for _, allOf := range []bool{false, true} {
+		r := openapi31.NewReflector()
+		r.EmbeddedAllOf = allOf
+
+		oc, err := r.NewOperationContext(http.MethodPost, "/pets")
+		require.NoError(t, err)
+		oc.AddReqStructure(pet{})
+		require.NoError(t, r.AddOperation(oc))
+
+		if !allOf {
+			assertjson.EqMarshal(t, `{
+			  "required":["id","createdAt"],
+			  "properties":{
+				"createdAt":{"type":"string"},"id":{"type":"integer"},
+				"kind":{"enum":["cat","dog"],"type":"string"}
+			  },
+			  "type":"object"
+			}`, r.Spec.Components.Schemas["Openapi31TestPet"])
+			assert.NotContains(t, r.Spec.Components.Schemas, "Openapi31TestBase")
+
+			continue
+		}
+
+		assertjson.EqMarshal(t, `{
+		  "required":["createdAt"],
+		  "allOf":[{"$ref":"#/components/schemas/Openapi31TestBase"}],
+		  "properties":{
+			"createdAt":{"type":"string"},
+			"kind":{"enum":["cat","dog"],"type":"string"}
+		  },
+		  "type":"object"
+		}`, r.Spec.Components.Schemas["Openapi31TestPet"])
+
+		assertjson.EqMarshal(t, `{
+		  "required":["id"],
+		  "properties":{"id":{"type":"integer"},"kind":{"type":"string"}},
+		  "type":"object"
+		}`, r.Spec.Components.Schemas["Openapi31TestBase"])
+	}
+}
@@ -0,0 +1,94 @@
+package openapi31
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// SetSecurity replaces security requirements of operation after validation.
+//
+// Example: openapi.RequireAny(openapi.RequireAll(openapi.Scheme("apiKey"), openapi.Scheme("oauth", "read")),
+// openapi.RequireAll(openapi.Scheme("basic"))) accepts either API key with OAuth2 token or basic auth.
+func SetSecurity(oc openapi.OperationContext, s openapi.Security) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	reqs, err := s.Requirements()
+	if err != nil {
+		return err
+	}
+
+	e.Operation().Security = reqs
+
+	return nil
+}
+
+// AllowAnonymous appends empty security requirement `{}` to operation,
+// so that requests without credentials are also accepted.
+//
+// It must be called after security requirements are added to operation, because empty list of operation
+// requirements inherits spec security and `[{}]` alone would disable it.
+func AllowAnonymous(oc openapi.OperationContext) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	op := e.Operation()
+	if len(op.Security) == 0 {
+		return errors.New("operation has no security requirements to make optional")
+	}
+
+	for _, req := range op.Security {
+		if len(req) == 0 {
+			return nil
+		}
+	}
+
+	op.Security = append(op.Security, map[string][]string{})
+
+	return nil
+}
+
+// AddSecurityScheme registers security scheme in components, operations require it by name
+// with openapi.OperationContext AddSecurity.
+//
+// Once a scheme is added, AddOperation fails for operations that require schemes missing in components.
+func (r *Reflector) AddSecurityScheme(name string, s SecurityScheme) {
+	r.SpecEns().ComponentsEns().WithSecuritySchemesItem(name, SecuritySchemeOrReference{
+		SecurityScheme: &s,
+	})
+
+	r.checkSecurity = true
+}
+
+// validateSecurity checks that security requirements of operation refer to defined schemes.
+func (r *Reflector) validateSecurity(op *Operation) error {
+	if !r.checkSecurity {
+		return nil
+	}
+
+	for _, req := range op.Security {
+		for name := range req {
+			if !r.hasSecurityScheme(name) {
+				return fmt.Errorf("security scheme %q is not defined in components", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Reflector) hasSecurityScheme(name string) bool {
+	if r.Spec.Components == nil {
+		return false
+	}
+
+	_, ok := r.Spec.Components.SecuritySchemes[name]
+
+	return ok
+}
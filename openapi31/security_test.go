@@ -132,3 +132,67 @@ func TestSpec_SetAPIKeySecurity(t *testing.T) {
 	  }
 	}`, reflector.SpecSchema())
 }
+
+func TestSetSecurity(t *testing.T) {
+	reflector := openapi31.NewReflector()
+
+	oc, err := reflector.NewOperationContext(http.MethodGet, "/secure")
+	require.NoError(t, err)
+
+	require.NoError(t, openapi31.SetSecurity(oc, openapi.RequireAny(
+		openapi.RequireAll(openapi.Scheme("apiKey"), openapi.Scheme("oauth", "read")),
+		openapi.RequireAll(openapi.Scheme("basic")),
+	)))
+	require.Error(t, openapi31.SetSecurity(oc, openapi.RequireAny(openapi.RequireAll())))
+	require.NoError(t, reflector.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[{"apiKey":[],"oauth":["read"]},{"basic":[]}]`,
+		reflector.Spec.Paths.MapOfPathItemValues["/secure"].Get.Security)
+}
+
+func TestAllowAnonymous(t *testing.T) {
+	reflector := openapi31.NewReflector()
+
+	oc, err := reflector.NewOperationContext(http.MethodGet, "/profile")
+	require.NoError(t, err)
+
+	require.EqualError(t, openapi31.AllowAnonymous(oc), "operation has no security requirements to make optional")
+
+	oc.AddSecurity("apiKey")
+	require.NoError(t, openapi31.AllowAnonymous(oc))
+	require.NoError(t, openapi31.AllowAnonymous(oc))
+	require.NoError(t, reflector.AddOperation(oc))
+
+	assertjson.EqMarshal(t, `[{"apiKey":[]},{}]`, reflector.Spec.Paths.MapOfPathItemValues["/profile"].Get.Security)
+}
+
+func TestReflector_AddSecurityScheme(t *testing.T) {
+	reflector := openapi31.NewReflector()
+
+	reflector.AddSecurityScheme("bearer", *(&openapi31.SecurityScheme{
+		HTTPBearer: (&openapi31.SecuritySchemeHTTPBearer{}).WithScheme("bearer").WithBearerFormat("JWT"),
+	}).WithDescription("Access token."))
+
+	oc, err := reflector.NewOperationContext(http.MethodGet, "/profile")
+	require.NoError(t, err)
+	oc.AddSecurity("bearer")
+	require.NoError(t, reflector.AddOperation(oc))
+
+	oc, err = reflector.NewOperationContext(http.MethodGet, "/admin")
+	require.NoError(t, err)
+	oc.AddSecurity("baerer")
+	require.EqualError(t, reflector.AddOperation(oc),
+		`validate security get /admin: security scheme "baerer" is not defined in components`)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.1.0","info":{"title":"","version":""},
+	  "paths":{
+		"/profile":{"get":{"responses":{"204":{"description":"No Content"}},"security":[{"bearer":[]}]}}
+	  },
+	  "components":{
+		"securitySchemes":{
+		  "bearer":{"description":"Access token.","type":"http","scheme":"bearer","bearerFormat":"JWT"}
+		}
+	  }
+	}`, reflector.SpecSchema())
+}
@@ -0,0 +1,24 @@
+package openapi31
+
+import (
+	"errors"
+	"time"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// SetTimeout sets `x-timeout-ms` extension of operation with a deadline for clients and servers.
+func SetTimeout(oc openapi.OperationContext, d time.Duration) error {
+	e, ok := oc.(OperationExposer)
+	if !ok {
+		return errors.New("operation context does not expose operation")
+	}
+
+	if d < time.Millisecond {
+		return errors.New("timeout must be at least 1ms")
+	}
+
+	e.Operation().WithMapOfAnythingItem(openapi.XTimeoutMs, d.Milliseconds())
+
+	return nil
+}
@@ -5,22 +5,34 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
+	yaml2 "github.com/goccy/go-yaml"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/internal"
 	"gopkg.in/yaml.v2"
 )
 
 // UnmarshalYAML reads from YAML bytes.
+//
+// Order of keys is preserved when document is marshaled back.
+// Document is validated as JSON, duplicate keys are not allowed.
 func (s *Spec) UnmarshalYAML(data []byte) error {
-	var v interface{}
+	var ms yaml.MapSlice
 
-	err := yaml.Unmarshal(data, &v)
+	err := yaml.Unmarshal(data, &ms)
 	if err != nil {
 		return err
 	}
 
-	v = convertMapI2MapS(v)
+	if err := checkDuplicateKeys(ms, ""); err != nil {
+		return err
+	}
+
+	v := convertMapI2MapS(ms)
 
-	data, err = json.Marshal(v)
+	data, err = openapi.JSON.Marshal(v)
 	if err != nil {
 		return err
 	}
@@ -29,15 +41,32 @@ func (s *Spec) UnmarshalYAML(data []byte) error {
 }
 
 // MarshalYAML produces YAML bytes.
+//
+// Top level keys of built document follow OpenAPI specification (openapi, info, jsonSchemaDialect, servers,
+// paths, ...) and nested keys are sorted, loaded document keeps order of its source.
 func (s *Spec) MarshalYAML() ([]byte, error) {
 	jsonData, err := s.MarshalJSON()
 	if err != nil {
 		return nil, err
 	}
 
+	// Loaded document keeps order of keys of all objects.
+	if s.keyOrder != nil {
+		v, err := orderedValue(jsonData)
+		if err != nil {
+			return nil, err
+		}
+
+		return yaml.Marshal(v)
+	}
+
+	if jsonData, err = canonicalKeyOrder(s.keyOrder, jsonData); err != nil {
+		return nil, err
+	}
+
 	var v orderedMap
 
-	err = json.Unmarshal(jsonData, &v)
+	err = openapi.JSON.Unmarshal(jsonData, &v)
 	if err != nil {
 		return nil, err
 	}
@@ -45,12 +74,148 @@ func (s *Spec) MarshalYAML() ([]byte, error) {
 	return yaml.Marshal(yaml.MapSlice(v))
 }
 
+// MarshalYAMLWithComments produces YAML bytes headed with comments, e.g. generation metadata.
+//
+// Multiline comments are split into lines, each line is prefixed with `# `.
+func (s *Spec) MarshalYAMLWithComments(comments ...string) ([]byte, error) {
+	y, err := s.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(yamlComments(comments), y...), nil
+}
+
+// MarshalAnnotatedYAML produces YAML bytes with `x-source` operation extensions rendered as comments.
+//
+// Such output is meant for review, to trace generated operations back to Go code, see Reflector.AnnotateSource.
+func (s *Spec) MarshalAnnotatedYAML() ([]byte, error) {
+	cp := *s
+	comments := yaml2.CommentMap{}
+
+	if s.Paths != nil {
+		paths := *s.Paths
+		paths.MapOfPathItemValues = make(map[string]PathItem, len(s.Paths.MapOfPathItemValues))
+
+		for path, pi := range s.Paths.MapOfPathItemValues {
+			for _, method := range []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} {
+				op, _ := pi.Operation(method)
+				if op == nil {
+					continue
+				}
+
+				src, ok := op.MapOfAnything[xSource]
+				if !ok {
+					continue
+				}
+
+				yamlPath := (&yaml2.PathBuilder{}).Root().Child("paths").Child(path).Child(method).Build().String()
+				comments[yamlPath] = []*yaml2.Comment{yaml2.HeadComment(sourceComments(src)...)}
+
+				opCp := *op
+				opCp.MapOfAnything = make(map[string]interface{}, len(op.MapOfAnything))
+
+				for k, v := range op.MapOfAnything {
+					if k != xSource {
+						opCp.MapOfAnything[k] = v
+					}
+				}
+
+				if err := pi.SetOperation(method, &opCp); err != nil {
+					return nil, err
+				}
+			}
+
+			paths.MapOfPathItemValues[path] = pi
+		}
+
+		cp.Paths = &paths
+	}
+
+	jsonData, err := cp.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if jsonData, err = canonicalKeyOrder(s.keyOrder, jsonData); err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := yaml2.UnmarshalWithOptions(jsonData, &v, yaml2.UseOrderedMap()); err != nil {
+		return nil, err
+	}
+
+	return yaml2.MarshalWithOptions(v, yaml2.UseLiteralStyleIfMultiline(true), yaml2.WithComment(comments))
+}
+
+func sourceComments(src interface{}) []string {
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf(" source: %v", src)}
+	}
+
+	var res []string
+
+	for _, k := range []string{"file", "function", "request", "response"} {
+		if v, ok := m[k]; ok {
+			res = append(res, fmt.Sprintf(" %s: %v", k, v))
+		}
+	}
+
+	return res
+}
+
+func yamlComments(comments []string) []byte {
+	var buf bytes.Buffer
+
+	for _, c := range comments {
+		for _, line := range strings.Split(c, "\n") {
+			buf.WriteString(strings.TrimRight("# "+line, " "))
+			buf.WriteByte('\n')
+		}
+	}
+
+	return buf.Bytes()
+}
+
 type orderedMap []yaml.MapItem
 
+// MarshalJSON encodes items as JSON object in their order.
+func (om orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, item := range om {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := openapi.JSON.Marshal(fmt.Sprint(item.Key))
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := openapi.JSON.Marshal(item.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
 func (om *orderedMap) UnmarshalJSON(data []byte) error {
 	var mapData map[string]interface{}
 
-	err := json.Unmarshal(data, &mapData)
+	err := openapi.JSON.Unmarshal(data, &mapData)
 	if err != nil {
 		return err
 	}
@@ -70,6 +235,58 @@ func (om *orderedMap) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// orderedValue decodes JSON keeping order of keys of all objects, they are decoded as yaml.MapSlice.
+func orderedValue(data json.RawMessage) (interface{}, error) {
+	switch d := bytes.TrimSpace(data); {
+	case len(d) > 0 && d[0] == '{':
+		var mapData map[string]json.RawMessage
+		if err := openapi.JSON.Unmarshal(d, &mapData); err != nil {
+			return nil, err
+		}
+
+		keys, err := objectKeys(d)
+		if err != nil {
+			return nil, err
+		}
+
+		ms := make(yaml.MapSlice, 0, len(keys))
+
+		for _, key := range keys {
+			v, err := orderedValue(mapData[key])
+			if err != nil {
+				return nil, err
+			}
+
+			ms = append(ms, yaml.MapItem{Key: key, Value: v})
+		}
+
+		return ms, nil
+	case len(d) > 0 && d[0] == '[':
+		var items []json.RawMessage
+		if err := openapi.JSON.Unmarshal(d, &items); err != nil {
+			return nil, err
+		}
+
+		res := make([]interface{}, 0, len(items))
+
+		for _, item := range items {
+			v, err := orderedValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, v)
+		}
+
+		return res, nil
+	}
+
+	var v interface{}
+	err := openapi.JSON.Unmarshal(data, &v)
+
+	return v, err
+}
+
 func objectKeys(b []byte) ([]string, error) {
 	d := json.NewDecoder(bytes.NewReader(b))
 
@@ -130,6 +347,37 @@ func skipValue(d *json.Decoder) error {
 	return nil
 }
 
+// checkDuplicateKeys fails on repeated keys of mappings, JSON decoding would silently keep the last value.
+func checkDuplicateKeys(v interface{}, pointer string) error {
+	switch x := v.(type) {
+	case yaml.MapSlice:
+		seen := make(map[string]bool, len(x))
+
+		for _, item := range x {
+			key := fmt.Sprint(item.Key)
+			at := pointer + "/" + internal.EscapePointerToken(key)
+
+			if seen[key] {
+				return fmt.Errorf("duplicate key: %s", at)
+			}
+
+			seen[key] = true
+
+			if err := checkDuplicateKeys(item.Value, at); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range x {
+			if err := checkDuplicateKeys(item, pointer+"/"+strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // convertMapI2MapS walks the given dynamic object recursively, and
 // converts maps with interface{} key type to maps with string key type.
 // This function comes handy if you want to marshal a dynamic object into
@@ -140,6 +388,7 @@ func skipValue(d *json.Decoder) error {
 //	-map[interface{}]interface{}
 //	-map[string]interface{}
 //	-[]interface{}
+//	-yaml.MapSlice, it is converted to orderedMap to keep order of keys
 //
 // When converting map[interface{}]interface{} to map[string]interface{},
 // fmt.Sprint() with default formatting is used to convert the key to a string key.
@@ -147,6 +396,15 @@ func skipValue(d *json.Decoder) error {
 // See github.com/icza/dyno.
 func convertMapI2MapS(v interface{}) interface{} {
 	switch x := v.(type) {
+	case yaml.MapSlice:
+		om := make(orderedMap, 0, len(x))
+
+		for _, item := range x {
+			om = append(om, yaml.MapItem{Key: fmt.Sprint(item.Key), Value: convertMapI2MapS(item.Value)})
+		}
+
+		v = om
+
 	case map[interface{}]interface{}:
 		m := map[string]interface{}{}
 
@@ -3,7 +3,6 @@ package openapi
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/swaggest/jsonschema-go"
@@ -39,6 +38,19 @@ type ContentUnit struct {
 
 	Description string
 
+	// AdditionalContentTypes are media types that share content of ContentType,
+	// see WithVersionedContentType.
+	AdditionalContentTypes []string
+
+	// Examples are named examples of media type of body, see WithExamples and ExampleProvider.
+	Examples map[string]interface{}
+
+	// Stream indicates that body is a stream of items described by Structure, see WithStream.
+	Stream bool
+
+	// SchemaPlacement overrides placement of reflected body schema of reflector, see WithSchemaPlacement.
+	SchemaPlacement SchemaPlacement
+
 	// Customize allows fine control over prepared content entities.
 	// The cor value can be asserted to one of these types:
 	// *openapi3.RequestBodyOrRef
@@ -83,6 +95,40 @@ func WithContentType(contentType string) func(cu *ContentUnit) {
 	}
 }
 
+// WithVersionedContentType is a ContentUnit option to document same content under multiple media types
+// expanded from template with `{version}` placeholder, e.g. `application/vnd.myco.{version}+json`.
+func WithVersionedContentType(template string, versions ...string) func(cu *ContentUnit) {
+	return func(cu *ContentUnit) {
+		contentTypes := ExpandContentType(template, versions...)
+
+		cu.ContentType = contentTypes[0]
+		cu.AdditionalContentTypes = contentTypes[1:]
+	}
+}
+
+// ExpandContentType replaces `{version}` placeholder of template with versions,
+// template is returned as is if there are no versions.
+func ExpandContentType(template string, versions ...string) []string {
+	if len(versions) == 0 {
+		return []string{template}
+	}
+
+	contentTypes := make([]string, 0, len(versions))
+
+	for _, v := range versions {
+		contentTypes = append(contentTypes, strings.ReplaceAll(template, "{version}", v))
+	}
+
+	return contentTypes
+}
+
+// WithSchemaPlacement is a ContentUnit option to inline body schema or to hoist it to components.
+func WithSchemaPlacement(p SchemaPlacement) ContentOption {
+	return func(cu *ContentUnit) {
+		cu.SchemaPlacement = p
+	}
+}
+
 // WithHTTPStatus is a ContentUnit option.
 func WithHTTPStatus(httpStatus int) func(cu *ContentUnit) {
 	return func(cu *ContentUnit) {
@@ -121,6 +167,9 @@ type OperationContext interface {
 	Response() []ContentUnit
 
 	AddReqStructure(i interface{}, options ...ContentOption)
+
+	// AddRespStructure can be called multiple times for the same HTTP status with different
+	// content types (e.g. JSON, XML and CSV), they are documented as multiple content entries of a response.
 	AddRespStructure(o interface{}, options ...ContentOption)
 
 	UnknownParamsAreForbidden(in In) bool
@@ -176,12 +225,12 @@ func OperationCtx(rc *jsonschema.ReflectContext) (OperationContext, bool) {
 	return nil, false
 }
 
-var regexFindPathParameter = regexp.MustCompile(`{([^}:]+)(:[^}]+)?(?:})`)
-
 // SanitizeMethodPath validates method and parses path element names.
+//
+// Path template must have balanced braces and unique non-empty parameter names,
+// gorilla/mux-style regular expressions of parameters (e.g. `{id:[0-9]+}`) are removed from clean path.
 func SanitizeMethodPath(method, pathPattern string) (cleanMethod string, cleanPath string, pathParams []string, err error) {
 	method = strings.ToLower(method)
-	pathParametersSubmatches := regexFindPathParameter.FindAllStringSubmatch(pathPattern, -1)
 
 	switch method {
 	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
@@ -190,15 +239,75 @@ func SanitizeMethodPath(method, pathPattern string) (cleanMethod string, cleanPa
 		return "", "", nil, fmt.Errorf("unexpected http method: %s", method)
 	}
 
-	if len(pathParametersSubmatches) > 0 {
-		for _, submatch := range pathParametersSubmatches {
-			pathParams = append(pathParams, submatch[1])
+	cleanPath, pathParams, err = parsePathTemplate(pathPattern)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid path %s: %w", pathPattern, err)
+	}
+
+	return method, cleanPath, pathParams, nil
+}
+
+func parsePathTemplate(pathPattern string) (string, []string, error) {
+	var (
+		clean  strings.Builder
+		params []string
+		seen   = map[string]bool{}
+	)
+
+	for i := 0; i < len(pathPattern); i++ {
+		switch pathPattern[i] {
+		case '}':
+			return "", nil, fmt.Errorf("unexpected } at %d", i)
+		case '{':
+			end := closingBrace(pathPattern, i)
+			if end < 0 {
+				return "", nil, fmt.Errorf("unclosed { at %d", i)
+			}
+
+			name := pathPattern[i+1 : end]
+			if pos := strings.Index(name, ":"); pos >= 0 {
+				name = name[:pos]
+			}
+
+			if name == "" || strings.ContainsAny(name, "{/") {
+				return "", nil, fmt.Errorf("invalid parameter name %q at %d", name, i)
+			}
+
+			if seen[name] {
+				return "", nil, fmt.Errorf("duplicate parameter %s", name)
+			}
+
+			seen[name] = true
+			params = append(params, name)
+
+			clean.WriteString("{" + name + "}")
+
+			i = end
+		default:
+			clean.WriteByte(pathPattern[i])
+		}
+	}
+
+	return clean.String(), params, nil
+}
+
+// closingBrace returns position of brace that closes brace at start, nested braces of regular expressions
+// are skipped, -1 is returned for unclosed brace.
+func closingBrace(s string, start int) int {
+	depth := 0
+
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
 
-			if submatch[2] != "" { // Remove gorilla.Mux-style regexp in path.
-				pathPattern = strings.Replace(pathPattern, submatch[0], "{"+submatch[1]+"}", 1)
+			if depth == 0 {
+				return i
 			}
 		}
 	}
 
-	return method, pathPattern, pathParams, nil
+	return -1
 }
@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OperationIDNamer returns ID of an operation that has no explicit ID.
+type OperationIDNamer func(method, pathPattern string) string
+
+// OperationIDFromPath is an OperationIDNamer that joins lower case method and camel case path elements,
+// path parameters are prefixed with `By`, e.g. `GET /users/{id}/api-keys` becomes `getUsersByIdApiKeys`.
+func OperationIDFromPath(method, pathPattern string) string {
+	var sb strings.Builder
+
+	sb.WriteString(strings.ToLower(method))
+
+	for _, elem := range strings.Split(pathPattern, "/") {
+		if strings.HasPrefix(elem, "{") && strings.HasSuffix(elem, "}") {
+			sb.WriteString("By")
+
+			elem = elem[1 : len(elem)-1]
+		}
+
+		for _, word := range strings.FieldsFunc(elem, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			r := []rune(word)
+			r[0] = unicode.ToUpper(r[0])
+
+			sb.WriteString(string(r))
+		}
+	}
+
+	return sb.String()
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/swaggest/openapi-go"
 )
 
@@ -16,3 +17,49 @@ func TestContentUnit_options(t *testing.T) {
 	assert.Equal(t, "text/csv", cu.ContentType)
 	assert.Equal(t, http.StatusConflict, cu.HTTPStatus)
 }
+
+func TestWithVersionedContentType(t *testing.T) {
+	cu := openapi.ContentUnit{}
+	openapi.WithVersionedContentType("application/vnd.myco.{version}+json", "v1", "v2", "v3")(&cu)
+
+	assert.Equal(t, "application/vnd.myco.v1+json", cu.ContentType)
+	assert.Equal(t, []string{"application/vnd.myco.v2+json", "application/vnd.myco.v3+json"}, cu.AdditionalContentTypes)
+
+	assert.Equal(t, []string{"text/plain"}, openapi.ExpandContentType("text/plain"))
+}
+
+func TestWithStream(t *testing.T) {
+	type event struct{}
+
+	cu := openapi.ContentUnit{Structure: new([]event)}
+	openapi.WithStream(openapi.MediaTypeEventStream)(&cu)
+
+	assert.Equal(t, "text/event-stream", cu.ContentType)
+	assert.True(t, cu.Stream)
+	assert.Equal(t, event{}, cu.Structure)
+}
+
+func TestSanitizeMethodPath(t *testing.T) {
+	method, path, params, err := openapi.SanitizeMethodPath("GET", "/users/{id:[0-9]{3}}/files/{name}")
+	require.NoError(t, err)
+	assert.Equal(t, "get", method)
+	assert.Equal(t, "/users/{id}/files/{name}", path)
+	assert.Equal(t, []string{"id", "name"}, params)
+
+	for path, msg := range map[string]string{
+		"/users/{id":           "invalid path /users/{id: unclosed { at 7",
+		"/users/id}":           "invalid path /users/id}: unexpected } at 9",
+		"/users/{}":            `invalid path /users/{}: invalid parameter name "" at 7`,
+		"/users/{:[0-9]+}":     `invalid path /users/{:[0-9]+}: invalid parameter name "" at 7`,
+		"/users/{id}/tag/{id}": "invalid path /users/{id}/tag/{id}: duplicate parameter id",
+	} {
+		_, _, _, err := openapi.SanitizeMethodPath("GET", path)
+		assert.EqualError(t, err, msg, path)
+	}
+}
+
+func TestOperationIDFromPath(t *testing.T) {
+	assert.Equal(t, "getUsersByIdApiKeys", openapi.OperationIDFromPath(http.MethodGet, "/users/{id}/api-keys"))
+	assert.Equal(t, "post", openapi.OperationIDFromPath(http.MethodPost, "/"))
+	assert.Equal(t, "deleteV1OrdersByOrderId", openapi.OperationIDFromPath(http.MethodDelete, "/v1/orders/{order_id}"))
+}
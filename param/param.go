@@ -0,0 +1,276 @@
+// Package param serializes and parses path parameters with `simple`, `label` and `matrix` styles
+// of OpenAPI 3, e.g. `3`, `.3` and `;id=3` for value 3 of parameter `id`.
+//
+// Values can be scalars, slices of scalars or maps of scalars, map keys are encoded in sorted order.
+package param
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Path parameter styles.
+const (
+	Simple = "simple"
+	Label  = "label"
+	Matrix = "matrix"
+)
+
+// Path describes serialization of a path parameter.
+type Path struct {
+	Name string
+
+	// Style is one of Simple (default), Label or Matrix.
+	Style string
+
+	// Explode serializes items of arrays and objects as separate values.
+	Explode bool
+}
+
+// PathOf returns serialization of a path parameter of a document.
+func PathOf(p openapi3.Parameter) Path {
+	pp := Path{Name: p.Name, Style: Simple}
+
+	if p.Style != nil {
+		pp.Style = *p.Style
+	}
+
+	if p.Explode != nil {
+		pp.Explode = *p.Explode
+	}
+
+	return pp
+}
+
+// Encode serializes value into path segment.
+func (p Path) Encode(value interface{}) (string, error) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return p.encodeItems(nil, false), nil
+		}
+
+		v = v.Elem()
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds are scalars.
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, p.escape(fmt.Sprint(v.Index(i).Interface())))
+		}
+
+		return p.encodeItems(items, false), nil
+	case reflect.Map:
+		keys := make([]string, 0, v.Len())
+		values := make(map[string]string, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			k := fmt.Sprint(iter.Key().Interface())
+			keys = append(keys, k)
+			values[k] = fmt.Sprint(iter.Value().Interface())
+		}
+
+		sort.Strings(keys)
+
+		items := make([]string, 0, 2*len(keys))
+
+		for _, k := range keys {
+			if p.Explode {
+				items = append(items, p.escape(k)+"="+p.escape(values[k]))
+			} else {
+				items = append(items, p.escape(k), p.escape(values[k]))
+			}
+		}
+
+		return p.encodeItems(items, true), nil
+	case reflect.Struct, reflect.Func, reflect.Chan, reflect.Interface:
+		return "", fmt.Errorf("unsupported value type %T of path parameter %s", value, p.Name)
+	}
+
+	return p.encodeItems([]string{p.escape(fmt.Sprint(v.Interface()))}, false), nil
+}
+
+func (p Path) encodeItems(items []string, isObject bool) string {
+	switch p.Style {
+	case Label:
+		if p.Explode {
+			return "." + strings.Join(items, ".")
+		}
+
+		return "." + strings.Join(items, ",")
+	case Matrix:
+		if len(items) == 0 || (len(items) == 1 && items[0] == "") {
+			return ";" + p.Name
+		}
+
+		if !p.Explode {
+			return ";" + p.Name + "=" + strings.Join(items, ",")
+		}
+
+		if isObject {
+			return ";" + strings.Join(items, ";")
+		}
+
+		return ";" + p.Name + "=" + strings.Join(items, ";"+p.Name+"=")
+	default:
+		return strings.Join(items, ",")
+	}
+}
+
+// escape percent-encodes delimiters of styles in a value.
+func (p Path) escape(s string) string {
+	s = url.PathEscape(s)
+	s = strings.NewReplacer(",", "%2C", ";", "%3B", "=", "%3D").Replace(s)
+
+	if p.Style == Label {
+		s = strings.ReplaceAll(s, ".", "%2E")
+	}
+
+	return s
+}
+
+// ErrStyle is returned when a path segment does not match style of parameter.
+var ErrStyle = errors.New("path segment does not match parameter style")
+
+// Decode parses path segment into destination, that is *string, *[]string or *map[string]string.
+func (p Path) Decode(segment string, dst interface{}) error {
+	items, err := p.items(segment, reflect.TypeOf(dst) == reflect.TypeOf(&map[string]string{}))
+	if err != nil {
+		return err
+	}
+
+	for i, it := range items {
+		if items[i], err = url.PathUnescape(it); err != nil {
+			return fmt.Errorf("path parameter %s: %w", p.Name, err)
+		}
+	}
+
+	switch d := dst.(type) {
+	case *string:
+		*d = strings.Join(items, ",")
+	case *[]string:
+		*d = items
+	case *map[string]string:
+		if len(items)%2 != 0 {
+			return fmt.Errorf("path parameter %s: %w: odd number of object items", p.Name, ErrStyle)
+		}
+
+		m := make(map[string]string, len(items)/2)
+
+		for i := 0; i < len(items); i += 2 {
+			m[items[i]] = items[i+1]
+		}
+
+		*d = m
+	default:
+		return fmt.Errorf("unsupported destination %T of path parameter %s", dst, p.Name)
+	}
+
+	return nil
+}
+
+// items splits segment into escaped items, exploded objects are returned as key and value pairs.
+func (p Path) items(segment string, isObject bool) ([]string, error) {
+	var parts []string
+
+	switch p.Style {
+	case Label:
+		if !strings.HasPrefix(segment, ".") {
+			return nil, fmt.Errorf("path parameter %s: %w: %q", p.Name, ErrStyle, segment)
+		}
+
+		segment = segment[1:]
+
+		if p.Explode {
+			parts = strings.Split(segment, ".")
+		} else {
+			parts = strings.Split(segment, ",")
+		}
+	case Matrix:
+		if !strings.HasPrefix(segment, ";") {
+			return nil, fmt.Errorf("path parameter %s: %w: %q", p.Name, ErrStyle, segment)
+		}
+
+		for _, pair := range strings.Split(segment[1:], ";") {
+			name, value, found := strings.Cut(pair, "=")
+
+			switch {
+			case p.Explode && isObject:
+				parts = append(parts, pair)
+
+				continue
+			case name != p.Name:
+				return nil, fmt.Errorf("path parameter %s: %w: unexpected name %q", p.Name, ErrStyle, name)
+			case !found:
+				continue
+			case p.Explode:
+				parts = append(parts, value)
+			default:
+				parts = append(parts, strings.Split(value, ",")...)
+			}
+		}
+	default:
+		parts = strings.Split(segment, ",")
+	}
+
+	if len(parts) == 1 && parts[0] == "" {
+		return nil, nil
+	}
+
+	if !isObject || !p.Explode {
+		return parts, nil
+	}
+
+	pairs := make([]string, 0, 2*len(parts))
+
+	for _, part := range parts {
+		k, v, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("path parameter %s: %w: %q is not a key=value pair", p.Name, ErrStyle, part)
+		}
+
+		pairs = append(pairs, k, v)
+	}
+
+	return pairs, nil
+}
+
+// MatchPath matches URL path with path template, e.g. "/things/;id=3" with "/things/{id}",
+// and returns raw path segments of parameters by name to be decoded with Path.Decode.
+func MatchPath(template, urlPath string) (map[string]string, bool) {
+	tpl := strings.Split(template, "/")
+	segments := strings.Split(urlPath, "/")
+
+	if len(tpl) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+
+	for i, s := range tpl {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			if segments[i] == "" {
+				return nil, false
+			}
+
+			params[s[1:len(s)-1]] = segments[i]
+
+			continue
+		}
+
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
@@ -0,0 +1,110 @@
+package param_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/param"
+)
+
+func TestPath_Encode(t *testing.T) {
+	// Examples of OpenAPI 3 style values.
+	color := []string{"blue", "black", "brown"}
+	rgb := map[string]int{"R": 100, "G": 200, "B": 150}
+
+	for _, tc := range []struct {
+		style   string
+		explode bool
+		value   interface{}
+		encoded string
+	}{
+		{param.Simple, false, "blue", "blue"},
+		{param.Simple, false, color, "blue,black,brown"},
+		{param.Simple, false, rgb, "B,150,G,200,R,100"},
+		{param.Simple, true, rgb, "B=150,G=200,R=100"},
+		{param.Label, false, "", "."},
+		{param.Label, false, "blue", ".blue"},
+		{param.Label, false, color, ".blue,black,brown"},
+		{param.Label, true, color, ".blue.black.brown"},
+		{param.Label, false, rgb, ".B,150,G,200,R,100"},
+		{param.Label, true, rgb, ".B=150.G=200.R=100"},
+		{param.Matrix, false, "", ";color"},
+		{param.Matrix, false, "blue", ";color=blue"},
+		{param.Matrix, false, color, ";color=blue,black,brown"},
+		{param.Matrix, true, color, ";color=blue;color=black;color=brown"},
+		{param.Matrix, false, rgb, ";color=B,150,G,200,R,100"},
+		{param.Matrix, true, rgb, ";B=150;G=200;R=100"},
+		{param.Label, false, "v1.2", ".v1%2E2"},
+		{param.Matrix, false, "a;b=c,d", ";color=a%3Bb%3Dc%2Cd"},
+	} {
+		p := param.Path{Name: "color", Style: tc.style, Explode: tc.explode}
+
+		encoded, err := p.Encode(tc.value)
+		require.NoError(t, err)
+		assert.Equal(t, tc.encoded, encoded, tc)
+
+		switch v := tc.value.(type) {
+		case string:
+			var s string
+			require.NoError(t, p.Decode(encoded, &s))
+			assert.Equal(t, v, s, tc)
+		case []string:
+			var s []string
+			require.NoError(t, p.Decode(encoded, &s))
+			assert.Equal(t, v, s, tc)
+		default:
+			var m map[string]string
+			require.NoError(t, p.Decode(encoded, &m))
+			assert.Equal(t, map[string]string{"R": "100", "G": "200", "B": "150"}, m, tc)
+		}
+	}
+}
+
+func TestPath_Decode_errors(t *testing.T) {
+	var s string
+
+	assert.ErrorIs(t, param.Path{Name: "id", Style: param.Matrix}.Decode("3", &s), param.ErrStyle)
+	assert.ErrorIs(t, param.Path{Name: "id", Style: param.Matrix}.Decode(";ids=3", &s), param.ErrStyle)
+	assert.ErrorIs(t, param.Path{Name: "id", Style: param.Label}.Decode("3", &s), param.ErrStyle)
+
+	var m map[string]string
+
+	assert.ErrorIs(t, param.Path{Name: "id", Style: param.Label, Explode: true}.Decode(".a.b", &m), param.ErrStyle)
+}
+
+func TestMatchPath(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: Partner, version: 1.0.0}
+paths:
+  /things/{id}/versions/{version}:
+    get:
+      parameters:
+        - {name: id, in: path, required: true, style: matrix, explode: true, schema: {type: array, items: {type: integer}}}
+        - {name: version, in: path, required: true, style: label, schema: {type: string}}
+      responses: {200: {description: OK}}
+`)))
+
+	template := "/things/{id}/versions/{version}"
+	op := s.Paths.MapOfPathItemValues[template].MapOfOperationValues["get"]
+
+	raw, ok := param.MatchPath(template, "/things/;id=3;id=4/versions/.v1%2E2")
+	require.True(t, ok)
+
+	var (
+		ids     []string
+		version string
+	)
+
+	require.NoError(t, param.PathOf(*op.Parameters[0].Parameter).Decode(raw["id"], &ids))
+	require.NoError(t, param.PathOf(*op.Parameters[1].Parameter).Decode(raw["version"], &version))
+
+	assert.Equal(t, []string{"3", "4"}, ids)
+	assert.Equal(t, "v1.2", version)
+
+	_, ok = param.MatchPath(template, "/things/;id=3/versions")
+	assert.False(t, ok)
+}
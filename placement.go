@@ -0,0 +1,17 @@
+package openapi
+
+// SchemaPlacement defines whether reflected schemas of bodies are inlined in operations
+// or hoisted into `components.schemas` with `$ref`.
+type SchemaPlacement int
+
+// SchemaPlacement values enumeration.
+const (
+	// SchemaPlacementDefault inherits placement, reflector places schemas as SchemaRef by default.
+	SchemaPlacementDefault = SchemaPlacement(iota)
+
+	// SchemaRef hoists named schemas into components and refers to them with `$ref`.
+	SchemaRef
+
+	// SchemaInline puts whole schemas in operations without references, recursive structures keep references.
+	SchemaInline
+)
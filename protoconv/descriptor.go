@@ -0,0 +1,124 @@
+package protoconv
+
+// FileDescriptorSet is a JSON form of google.protobuf.FileDescriptorSet, e.g. `buf build -o image.json`
+// or protojson encoding of descriptors built with protodesc.ToFileDescriptorProto.
+type FileDescriptorSet struct {
+	File []FileDescriptor `json:"file"`
+}
+
+// FileDescriptor describes a proto file.
+type FileDescriptor struct {
+	Name        string              `json:"name"`
+	Package     string              `json:"package"`
+	MessageType []MessageDescriptor `json:"messageType"`
+	EnumType    []EnumDescriptor    `json:"enumType"`
+	Service     []ServiceDescriptor `json:"service"`
+}
+
+// MessageDescriptor describes a message type.
+type MessageDescriptor struct {
+	Name       string              `json:"name"`
+	Field      []FieldDescriptor   `json:"field"`
+	NestedType []MessageDescriptor `json:"nestedType"`
+	EnumType   []EnumDescriptor    `json:"enumType"`
+	Options    *struct {
+		MapEntry   bool `json:"mapEntry"`
+		Deprecated bool `json:"deprecated"`
+	} `json:"options"`
+}
+
+// FieldDescriptor describes a field of message.
+type FieldDescriptor struct {
+	Name     string `json:"name"`
+	JSONName string `json:"jsonName"`
+	Number   int32  `json:"number"`
+
+	// Label is LABEL_OPTIONAL, LABEL_REQUIRED or LABEL_REPEATED.
+	Label string `json:"label"`
+
+	// Type is a scalar type (e.g. TYPE_STRING), TYPE_ENUM or TYPE_MESSAGE.
+	Type string `json:"type"`
+
+	// TypeName is a fully-qualified name of enum or message type, e.g. `.library.v1.Book`.
+	TypeName string `json:"typeName"`
+
+	Options *struct {
+		Deprecated bool `json:"deprecated"`
+
+		// FieldBehavior is a google.api.field_behavior annotation, e.g. REQUIRED or OUTPUT_ONLY.
+		FieldBehavior []string `json:"[google.api.field_behavior]"`
+	} `json:"options"`
+}
+
+// EnumDescriptor describes an enum type.
+type EnumDescriptor struct {
+	Name  string `json:"name"`
+	Value []struct {
+		Name   string `json:"name"`
+		Number int32  `json:"number"`
+	} `json:"value"`
+}
+
+// ServiceDescriptor describes a service.
+type ServiceDescriptor struct {
+	Name    string             `json:"name"`
+	Method  []MethodDescriptor `json:"method"`
+	Options *struct {
+		Deprecated bool `json:"deprecated"`
+	} `json:"options"`
+}
+
+// MethodDescriptor describes a method of service.
+type MethodDescriptor struct {
+	Name            string `json:"name"`
+	InputType       string `json:"inputType"`
+	OutputType      string `json:"outputType"`
+	ClientStreaming bool   `json:"clientStreaming"`
+	ServerStreaming bool   `json:"serverStreaming"`
+	Options         *struct {
+		Deprecated bool `json:"deprecated"`
+
+		// HTTP is a google.api.http annotation.
+		HTTP *HTTPRule `json:"[google.api.http]"`
+	} `json:"options"`
+}
+
+// HTTPRule is a google.api.HttpRule that maps method to REST operation.
+type HTTPRule struct {
+	Get    string `json:"get"`
+	Put    string `json:"put"`
+	Post   string `json:"post"`
+	Delete string `json:"delete"`
+	Patch  string `json:"patch"`
+	Custom *struct {
+		Kind string `json:"kind"`
+		Path string `json:"path"`
+	} `json:"custom"`
+
+	// Body is a field of request message mapped to request body, `*` for whole message.
+	Body string `json:"body"`
+
+	// ResponseBody is a field of response message mapped to response body, whole message if empty.
+	ResponseBody string `json:"responseBody"`
+
+	AdditionalBindings []HTTPRule `json:"additionalBindings"`
+}
+
+func (r HTTPRule) pattern() (method, template string) {
+	switch {
+	case r.Get != "":
+		return "GET", r.Get
+	case r.Put != "":
+		return "PUT", r.Put
+	case r.Post != "":
+		return "POST", r.Post
+	case r.Delete != "":
+		return "DELETE", r.Delete
+	case r.Patch != "":
+		return "PATCH", r.Patch
+	case r.Custom != nil:
+		return r.Custom.Kind, r.Custom.Path
+	}
+
+	return "", ""
+}
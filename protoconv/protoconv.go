@@ -0,0 +1,334 @@
+// Package protoconv converts protobuf descriptors of gRPC services into OpenAPI 3.0 documents,
+// so that REST surface of gRPC-gateway services is documented with this module.
+//
+// Messages become component schemas following proto3 JSON mapping, methods with google.api.http
+// annotations become operations. Descriptors are read from JSON form of google.protobuf.FileDescriptorSet,
+// so this package does not depend on google.golang.org/protobuf.
+package protoconv
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Convert adds operations of services with google.api.http annotations and component schemas
+// of their messages to spec.
+//
+// Services are selected by fully-qualified names (e.g. `library.v1.LibraryService`), all services
+// of set are converted if none are given. Streaming methods and methods without annotations are skipped.
+func Convert(s *openapi3.Spec, set FileDescriptorSet, services ...string) error {
+	c := converter{
+		spec:     s,
+		messages: map[string]*MessageDescriptor{},
+		enums:    map[string]*EnumDescriptor{},
+		done:     map[string]bool{},
+	}
+
+	selected := map[string]bool{}
+	for _, name := range services {
+		selected[name] = true
+	}
+
+	for i := range set.File {
+		f := &set.File[i]
+		prefix := "." + f.Package
+
+		if f.Package == "" {
+			prefix = ""
+		}
+
+		c.index(prefix, f.MessageType, f.EnumType)
+	}
+
+	found := 0
+
+	for _, f := range set.File {
+		for _, svc := range f.Service {
+			name := strings.TrimPrefix(f.Package+"."+svc.Name, ".")
+			if len(selected) > 0 && !selected[name] {
+				continue
+			}
+
+			found++
+
+			if err := c.service(svc); err != nil {
+				return fmt.Errorf("protoconv: %s: %w", name, err)
+			}
+		}
+	}
+
+	if len(selected) > found {
+		return errors.New("protoconv: services not found in descriptor set")
+	}
+
+	return nil
+}
+
+type converter struct {
+	spec     *openapi3.Spec
+	messages map[string]*MessageDescriptor
+	enums    map[string]*EnumDescriptor
+	done     map[string]bool
+}
+
+func (c *converter) index(prefix string, messages []MessageDescriptor, enums []EnumDescriptor) {
+	for i := range messages {
+		m := &messages[i]
+		name := prefix + "." + m.Name
+
+		c.messages[name] = m
+		c.index(name, m.NestedType, m.EnumType)
+	}
+
+	for i := range enums {
+		c.enums[prefix+"."+enums[i].Name] = &enums[i]
+	}
+}
+
+func (c *converter) service(svc ServiceDescriptor) error {
+	for _, m := range svc.Method {
+		if m.Options == nil || m.Options.HTTP == nil || m.ClientStreaming || m.ServerStreaming {
+			continue
+		}
+
+		rules := append([]HTTPRule{*m.Options.HTTP}, m.Options.HTTP.AdditionalBindings...)
+
+		for i, rule := range rules {
+			method, template := rule.pattern()
+			if template == "" {
+				continue
+			}
+
+			op, path, err := c.operation(m, rule, template)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.Name, err)
+			}
+
+			op.WithID(svc.Name + "_" + m.Name)
+			if i > 0 {
+				op.WithID(*op.ID + strconv.Itoa(i+1))
+			}
+
+			op.WithTags(svc.Name)
+
+			if m.Options.Deprecated || (svc.Options != nil && svc.Options.Deprecated) {
+				op.WithDeprecated(true)
+			}
+
+			if err := c.spec.AddOperation(method, path, op); err != nil {
+				return fmt.Errorf("%s: %w", m.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) operation(m MethodDescriptor, rule HTTPRule, template string) (openapi3.Operation, string, error) {
+	op := openapi3.Operation{}
+
+	in, ok := c.messages[m.InputType]
+	if !ok {
+		return op, "", fmt.Errorf("unknown message type %s", m.InputType)
+	}
+
+	path, fieldPaths := pathTemplate(template)
+	inPath := map[string]bool{}
+
+	for _, fp := range fieldPaths {
+		inPath[strings.SplitN(fp, ".", 2)[0]] = true
+
+		schema := &openapi3.SchemaOrRef{Schema: (&openapi3.Schema{}).WithType(openapi3.SchemaTypeString)}
+
+		if f := c.field(in, fp); f != nil {
+			sr, err := c.typeSchema(*f)
+			if err != nil {
+				return op, "", err
+			}
+
+			schema = &sr
+		}
+
+		p := openapi3.Parameter{In: openapi3.ParameterInPath, Name: fp, Schema: schema}
+		op.Parameters = append(op.Parameters, openapi3.ParameterOrRef{Parameter: p.WithRequired(true)})
+	}
+
+	var body *openapi3.SchemaOrRef
+
+	switch rule.Body {
+	case "":
+	case "*":
+		sr, err := c.messageSchema(m.InputType)
+		if err != nil {
+			return op, "", err
+		}
+
+		body = &sr
+	default:
+		f := c.field(in, rule.Body)
+		if f == nil {
+			return op, "", fmt.Errorf("body field %s not found in %s", rule.Body, m.InputType)
+		}
+
+		sr, err := c.fieldSchema(*f)
+		if err != nil {
+			return op, "", err
+		}
+
+		body = &sr
+	}
+
+	if body != nil {
+		rb := openapi3.RequestBody{Content: map[string]openapi3.MediaType{"application/json": {Schema: body}}}
+		op.RequestBody = &openapi3.RequestBodyOrRef{RequestBody: rb.WithRequired(true)}
+	}
+
+	if rule.Body != "*" {
+		if err := c.queryParameters(&op, in, inPath, rule.Body); err != nil {
+			return op, "", err
+		}
+	}
+
+	if err := c.responses(&op, m, rule); err != nil {
+		return op, "", err
+	}
+
+	return op, path, nil
+}
+
+// queryParameters documents scalar and enum fields of request message that are not bound to path or body.
+func (c *converter) queryParameters(op *openapi3.Operation, in *MessageDescriptor, inPath map[string]bool, body string) error {
+	for _, f := range in.Field {
+		if inPath[f.Name] || f.Name == body || f.Type == "TYPE_MESSAGE" || f.Type == "TYPE_GROUP" {
+			continue
+		}
+
+		sr, err := c.fieldSchema(f)
+		if err != nil {
+			return err
+		}
+
+		p := openapi3.Parameter{In: openapi3.ParameterInQuery, Name: jsonName(f), Schema: &sr}
+		if required(f) {
+			p.WithRequired(true)
+		}
+
+		if f.Options != nil && f.Options.Deprecated {
+			p.WithDeprecated(true)
+		}
+
+		op.Parameters = append(op.Parameters, openapi3.ParameterOrRef{Parameter: &p})
+	}
+
+	return nil
+}
+
+func (c *converter) responses(op *openapi3.Operation, m MethodDescriptor, rule HTTPRule) error {
+	sr, err := c.messageSchema(m.OutputType)
+	if err != nil {
+		return err
+	}
+
+	if rule.ResponseBody != "" {
+		out := c.messages[m.OutputType]
+
+		f := c.field(out, rule.ResponseBody)
+		if f == nil {
+			return fmt.Errorf("response body field %s not found in %s", rule.ResponseBody, m.OutputType)
+		}
+
+		if sr, err = c.fieldSchema(*f); err != nil {
+			return err
+		}
+	}
+
+	op.Responses.WithMapOfResponseOrRefValuesItem(strconv.Itoa(http.StatusOK), openapi3.ResponseOrRef{
+		Response: &openapi3.Response{
+			Description: http.StatusText(http.StatusOK),
+			Content:     map[string]openapi3.MediaType{"application/json": {Schema: &sr}},
+		},
+	})
+
+	// Errors of gRPC-gateway are encoded google.rpc.Status messages.
+	if _, ok := c.messages[".google.rpc.Status"]; ok {
+		status, err := c.messageSchema(".google.rpc.Status")
+		if err != nil {
+			return err
+		}
+
+		op.Responses.WithDefault(openapi3.ResponseOrRef{Response: &openapi3.Response{
+			Description: "An unexpected error response.",
+			Content:     map[string]openapi3.MediaType{"application/json": {Schema: &status}},
+		}})
+	}
+
+	return nil
+}
+
+// field finds field by dot-separated path of field names.
+func (c *converter) field(m *MessageDescriptor, path string) *FieldDescriptor {
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		var found *FieldDescriptor
+
+		for j := range m.Field {
+			if m.Field[j].Name == name {
+				found = &m.Field[j]
+
+				break
+			}
+		}
+
+		if found == nil {
+			return nil
+		}
+
+		if i == len(names)-1 {
+			return found
+		}
+
+		if m = c.messages[found.TypeName]; m == nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// pathTemplate returns OpenAPI path of google.api.http template and field paths of its variables,
+// e.g. "/v1/{name}" and "name" for "/v1/{name=shelves/*}".
+func pathTemplate(template string) (string, []string) {
+	var (
+		sb     strings.Builder
+		fields []string
+	)
+
+	for {
+		start := strings.Index(template, "{")
+		if start < 0 {
+			break
+		}
+
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			break
+		}
+
+		field := strings.SplitN(template[start+1:start+end], "=", 2)[0]
+		fields = append(fields, field)
+
+		sb.WriteString(template[:start] + "{" + field + "}")
+
+		template = template[start+end+1:]
+	}
+
+	sb.WriteString(template)
+
+	return sb.String(), fields
+}
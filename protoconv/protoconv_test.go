@@ -0,0 +1,211 @@
+package protoconv_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/protoconv"
+)
+
+// library is a descriptor set in a form of `buf build -o image.json`.
+const library = `{
+  "file": [
+    {
+      "name": "google/rpc/status.proto",
+      "package": "google.rpc",
+      "messageType": [{
+        "name": "Status",
+        "field": [
+          {"name": "code", "number": 1, "label": "LABEL_OPTIONAL", "type": "TYPE_INT32", "jsonName": "code"},
+          {"name": "message", "number": 2, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "message"}
+        ]
+      }]
+    },
+    {
+      "name": "library/v1/library.proto",
+      "package": "library.v1",
+      "messageType": [
+        {
+          "name": "Book",
+          "field": [
+            {"name": "name", "number": 1, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "name",
+             "options": {"[google.api.field_behavior]": ["OUTPUT_ONLY"]}},
+            {"name": "title", "number": 2, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "title",
+             "options": {"[google.api.field_behavior]": ["REQUIRED"]}},
+            {"name": "page_count", "number": 3, "label": "LABEL_OPTIONAL", "type": "TYPE_INT64", "jsonName": "pageCount"},
+            {"name": "genre", "number": 4, "label": "LABEL_OPTIONAL", "type": "TYPE_ENUM",
+             "typeName": ".library.v1.Book.Genre", "jsonName": "genre"},
+            {"name": "published", "number": 5, "label": "LABEL_OPTIONAL", "type": "TYPE_MESSAGE",
+             "typeName": ".google.protobuf.Timestamp", "jsonName": "published"},
+            {"name": "labels", "number": 6, "label": "LABEL_REPEATED", "type": "TYPE_MESSAGE",
+             "typeName": ".library.v1.Book.LabelsEntry", "jsonName": "labels"},
+            {"name": "authors", "number": 7, "label": "LABEL_REPEATED", "type": "TYPE_STRING", "jsonName": "authors"}
+          ],
+          "nestedType": [{
+            "name": "LabelsEntry",
+            "field": [
+              {"name": "key", "number": 1, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "key"},
+              {"name": "value", "number": 2, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "value"}
+            ],
+            "options": {"mapEntry": true}
+          }],
+          "enumType": [{
+            "name": "Genre",
+            "value": [{"name": "GENRE_UNSPECIFIED", "number": 0}, {"name": "FICTION", "number": 1}]
+          }]
+        },
+        {
+          "name": "GetBookRequest",
+          "field": [
+            {"name": "name", "number": 1, "label": "LABEL_OPTIONAL", "type": "TYPE_STRING", "jsonName": "name"},
+            {"name": "view", "number": 2, "label": "LABEL_OPTIONAL", "type": "TYPE_ENUM",
+             "typeName": ".library.v1.Book.Genre", "jsonName": "view"}
+          ]
+        },
+        {
+          "name": "UpdateBookRequest",
+          "field": [
+            {"name": "book", "number": 1, "label": "LABEL_OPTIONAL", "type": "TYPE_MESSAGE",
+             "typeName": ".library.v1.Book", "jsonName": "book"},
+            {"name": "update_mask", "number": 2, "label": "LABEL_OPTIONAL", "type": "TYPE_MESSAGE",
+             "typeName": ".google.protobuf.FieldMask", "jsonName": "updateMask"},
+            {"name": "allow_missing", "number": 3, "label": "LABEL_OPTIONAL", "type": "TYPE_BOOL",
+             "jsonName": "allowMissing"}
+          ]
+        }
+      ],
+      "service": [{
+        "name": "LibraryService",
+        "method": [
+          {
+            "name": "GetBook",
+            "inputType": ".library.v1.GetBookRequest",
+            "outputType": ".library.v1.Book",
+            "options": {"[google.api.http]": {
+              "get": "/v1/{name=shelves/*/books/*}",
+              "additionalBindings": [{"get": "/v1/books/{name}"}]
+            }}
+          },
+          {
+            "name": "UpdateBook",
+            "inputType": ".library.v1.UpdateBookRequest",
+            "outputType": ".library.v1.Book",
+            "options": {"[google.api.http]": {"patch": "/v1/{book.name=shelves/*/books/*}", "body": "book"}}
+          },
+          {
+            "name": "WatchBooks",
+            "inputType": ".library.v1.GetBookRequest",
+            "outputType": ".library.v1.Book",
+            "serverStreaming": true,
+            "options": {"[google.api.http]": {"get": "/v1/books:watch"}}
+          }
+        ]
+      }]
+    }
+  ]
+}`
+
+func TestConvert(t *testing.T) {
+	var set protoconv.FileDescriptorSet
+
+	require.NoError(t, json.Unmarshal([]byte(library), &set))
+
+	s := openapi3.Spec{Openapi: "3.0.3"}
+	s.Info.WithTitle("Library").WithVersion("1.0.0")
+
+	assert.EqualError(t, protoconv.Convert(&s, set, "library.v1.Unknown"),
+		"protoconv: services not found in descriptor set")
+
+	require.NoError(t, protoconv.Convert(&s, set, "library.v1.LibraryService"))
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"Library","version":"1.0.0"},
+	  "paths":{
+		"/v1/{name}":{
+		  "get":{
+			"tags":["LibraryService"],"operationId":"LibraryService_GetBook",
+			"parameters":[
+			  {"name":"name","in":"path","required":true,"schema":{"type":"string"}},
+			  {"name":"view","in":"query","schema":{"$ref":"#/components/schemas/library.v1.Book.Genre"}}
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/library.v1.Book"}}}
+			  },
+			  "default":{
+				"description":"An unexpected error response.",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/google.rpc.Status"}}}
+			  }
+			}
+		  }
+		},
+		"/v1/books/{name}":{
+		  "get":{
+			"tags":["LibraryService"],"operationId":"LibraryService_GetBook2",
+			"parameters":[
+			  {"name":"name","in":"path","required":true,"schema":{"type":"string"}},
+			  {"name":"view","in":"query","schema":{"$ref":"#/components/schemas/library.v1.Book.Genre"}}
+			],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/library.v1.Book"}}}
+			  },
+			  "default":{
+				"description":"An unexpected error response.",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/google.rpc.Status"}}}
+			  }
+			}
+		  }
+		},
+		"/v1/{book.name}":{
+		  "patch":{
+			"tags":["LibraryService"],"operationId":"LibraryService_UpdateBook",
+			"parameters":[
+			  {"name":"book.name","in":"path","required":true,"schema":{"type":"string"}},
+			  {"name":"allowMissing","in":"query","schema":{"type":"boolean"}}
+			],
+			"requestBody":{
+			  "content":{"application/json":{"schema":{"$ref":"#/components/schemas/library.v1.Book"}}},
+			  "required":true
+			},
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/library.v1.Book"}}}
+			  },
+			  "default":{
+				"description":"An unexpected error response.",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/google.rpc.Status"}}}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{
+		  "library.v1.Book.Genre":{"enum":["GENRE_UNSPECIFIED","FICTION"],"type":"string"},
+		  "library.v1.Book":{
+			"required":["title"],"type":"object",
+			"properties":{
+			  "name":{"type":"string","readOnly":true},"title":{"type":"string"},
+			  "pageCount":{"type":"string","format":"int64"},
+			  "genre":{"$ref":"#/components/schemas/library.v1.Book.Genre"},
+			  "published":{"type":"string","format":"date-time"},
+			  "labels":{"type":"object","additionalProperties":{"type":"string"}},
+			  "authors":{"type":"array","items":{"type":"string"}}
+			}
+		  },
+		  "google.rpc.Status":{
+			"type":"object",
+			"properties":{"code":{"type":"integer","format":"int32"},"message":{"type":"string"}}
+		  }
+		}
+	  }
+	}`, s)
+}
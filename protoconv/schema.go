@@ -0,0 +1,259 @@
+package protoconv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// wellKnown describes well-known types with their JSON mapping instead of message structure.
+var wellKnown = map[string]func() *openapi3.Schema{
+	".google.protobuf.Timestamp": func() *openapi3.Schema { return scalar(openapi3.SchemaTypeString, "date-time") },
+	".google.protobuf.Duration":  func() *openapi3.Schema { return scalar(openapi3.SchemaTypeString, "") },
+	".google.protobuf.FieldMask": func() *openapi3.Schema { return scalar(openapi3.SchemaTypeString, "") },
+	".google.protobuf.Empty":     func() *openapi3.Schema { return scalar(openapi3.SchemaTypeObject, "") },
+	".google.protobuf.Struct":    func() *openapi3.Schema { return scalar(openapi3.SchemaTypeObject, "") },
+	".google.protobuf.Value":     func() *openapi3.Schema { return &openapi3.Schema{} },
+	".google.protobuf.ListValue": func() *openapi3.Schema {
+		return scalar(openapi3.SchemaTypeArray, "").WithItems(openapi3.SchemaOrRef{Schema: &openapi3.Schema{}})
+	},
+	".google.protobuf.Any": func() *openapi3.Schema {
+		return scalar(openapi3.SchemaTypeObject, "").
+			WithPropertiesItem("@type", openapi3.SchemaOrRef{Schema: scalar(openapi3.SchemaTypeString, "")})
+	},
+	".google.protobuf.DoubleValue": wrapper(openapi3.SchemaTypeNumber, "double"),
+	".google.protobuf.FloatValue":  wrapper(openapi3.SchemaTypeNumber, "float"),
+	".google.protobuf.Int64Value":  wrapper(openapi3.SchemaTypeString, "int64"),
+	".google.protobuf.UInt64Value": wrapper(openapi3.SchemaTypeString, "uint64"),
+	".google.protobuf.Int32Value":  wrapper(openapi3.SchemaTypeInteger, "int32"),
+	".google.protobuf.UInt32Value": wrapper(openapi3.SchemaTypeInteger, "int64"),
+	".google.protobuf.BoolValue":   wrapper(openapi3.SchemaTypeBoolean, ""),
+	".google.protobuf.StringValue": wrapper(openapi3.SchemaTypeString, ""),
+	".google.protobuf.BytesValue":  wrapper(openapi3.SchemaTypeString, "byte"),
+}
+
+func scalar(t openapi3.SchemaType, format string) *openapi3.Schema {
+	s := (&openapi3.Schema{}).WithType(t)
+	if format != "" {
+		s.WithFormat(format)
+	}
+
+	return s
+}
+
+func wrapper(t openapi3.SchemaType, format string) func() *openapi3.Schema {
+	return func() *openapi3.Schema {
+		return scalar(t, format).WithNullable(true)
+	}
+}
+
+// fieldSchema returns schema of field value, including repeated fields and maps.
+func (c *converter) fieldSchema(f FieldDescriptor) (openapi3.SchemaOrRef, error) {
+	if f.Label == "LABEL_REPEATED" && f.Type == "TYPE_MESSAGE" {
+		if m := c.messages[f.TypeName]; m != nil && m.Options != nil && m.Options.MapEntry {
+			for _, vf := range m.Field {
+				if vf.Number != 2 {
+					continue
+				}
+
+				value, err := c.fieldSchema(vf)
+				if err != nil {
+					return value, err
+				}
+
+				return openapi3.SchemaOrRef{Schema: scalar(openapi3.SchemaTypeObject, "").WithAdditionalProperties(
+					openapi3.SchemaAdditionalProperties{SchemaOrRef: &value},
+				)}, nil
+			}
+		}
+	}
+
+	sr, err := c.typeSchema(f)
+	if err != nil {
+		return sr, err
+	}
+
+	if f.Label == "LABEL_REPEATED" {
+		return openapi3.SchemaOrRef{Schema: scalar(openapi3.SchemaTypeArray, "").WithItems(sr)}, nil
+	}
+
+	return sr, nil
+}
+
+// typeSchema returns schema of a single value of field type.
+func (c *converter) typeSchema(f FieldDescriptor) (openapi3.SchemaOrRef, error) {
+	var s *openapi3.Schema
+
+	switch f.Type {
+	case "TYPE_DOUBLE":
+		s = scalar(openapi3.SchemaTypeNumber, "double")
+	case "TYPE_FLOAT":
+		s = scalar(openapi3.SchemaTypeNumber, "float")
+	case "TYPE_INT32", "TYPE_SINT32", "TYPE_SFIXED32":
+		s = scalar(openapi3.SchemaTypeInteger, "int32")
+	case "TYPE_UINT32", "TYPE_FIXED32":
+		s = scalar(openapi3.SchemaTypeInteger, "int64")
+	case "TYPE_INT64", "TYPE_SINT64", "TYPE_SFIXED64":
+		// 64-bit integers are encoded as strings in JSON.
+		s = scalar(openapi3.SchemaTypeString, "int64")
+	case "TYPE_UINT64", "TYPE_FIXED64":
+		s = scalar(openapi3.SchemaTypeString, "uint64")
+	case "TYPE_BOOL":
+		s = scalar(openapi3.SchemaTypeBoolean, "")
+	case "TYPE_STRING":
+		s = scalar(openapi3.SchemaTypeString, "")
+	case "TYPE_BYTES":
+		s = scalar(openapi3.SchemaTypeString, "byte")
+	case "TYPE_ENUM":
+		return c.enumSchema(f.TypeName)
+	case "TYPE_MESSAGE", "TYPE_GROUP":
+		return c.messageSchema(f.TypeName)
+	default:
+		return openapi3.SchemaOrRef{}, fmt.Errorf("unsupported type %s of field %s", f.Type, f.Name)
+	}
+
+	return openapi3.SchemaOrRef{Schema: s}, nil
+}
+
+func componentName(typeName string) string {
+	return strings.TrimPrefix(typeName, ".")
+}
+
+func ref(typeName string) openapi3.SchemaOrRef {
+	return openapi3.SchemaOrRef{SchemaReference: &openapi3.SchemaReference{
+		Ref: "#/components/schemas/" + componentName(typeName),
+	}}
+}
+
+// component adds schema of type to components and returns reference to it.
+func (c *converter) component(typeName string, s *openapi3.Schema) openapi3.SchemaOrRef {
+	c.spec.ComponentsEns().SchemasEns().
+		WithMapOfSchemaOrRefValuesItem(componentName(typeName), openapi3.SchemaOrRef{Schema: s})
+
+	return ref(typeName)
+}
+
+func (c *converter) enumSchema(typeName string) (openapi3.SchemaOrRef, error) {
+	if c.done[typeName] {
+		return ref(typeName), nil
+	}
+
+	e, ok := c.enums[typeName]
+	if !ok {
+		return openapi3.SchemaOrRef{}, fmt.Errorf("unknown enum type %s", typeName)
+	}
+
+	c.done[typeName] = true
+
+	s := scalar(openapi3.SchemaTypeString, "")
+	for _, v := range e.Value {
+		s.Enum = append(s.Enum, v.Name)
+	}
+
+	return c.component(typeName, s), nil
+}
+
+func (c *converter) messageSchema(typeName string) (openapi3.SchemaOrRef, error) {
+	if wk, ok := wellKnown[typeName]; ok {
+		return openapi3.SchemaOrRef{Schema: wk()}, nil
+	}
+
+	if c.done[typeName] {
+		return ref(typeName), nil
+	}
+
+	m, ok := c.messages[typeName]
+	if !ok {
+		return openapi3.SchemaOrRef{}, fmt.Errorf("unknown message type %s", typeName)
+	}
+
+	// Marked before fields are converted, so that recursive messages are referenced.
+	c.done[typeName] = true
+
+	s := scalar(openapi3.SchemaTypeObject, "")
+
+	if m.Options != nil && m.Options.Deprecated {
+		s.WithDeprecated(true)
+	}
+
+	for _, f := range m.Field {
+		sr, err := c.fieldSchema(f)
+		if err != nil {
+			return sr, fmt.Errorf("%s: %w", componentName(typeName), err)
+		}
+
+		if f.Options != nil {
+			sr = fieldBehavior(sr, f)
+		}
+
+		name := jsonName(f)
+		s.WithPropertiesItem(name, sr)
+
+		if required(f) {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return c.component(typeName, s), nil
+}
+
+// fieldBehavior applies deprecation and google.api.field_behavior annotations to property schema.
+func fieldBehavior(sr openapi3.SchemaOrRef, f FieldDescriptor) openapi3.SchemaOrRef {
+	s := sr.Schema
+	if s == nil {
+		// Siblings of reference are ignored, so referenced schema is wrapped.
+		s = (&openapi3.Schema{}).WithAllOf(sr)
+	}
+
+	changed := false
+
+	if f.Options.Deprecated {
+		s.WithDeprecated(true)
+
+		changed = true
+	}
+
+	for _, b := range f.Options.FieldBehavior {
+		switch b {
+		case "OUTPUT_ONLY":
+			s.WithReadOnly(true)
+
+			changed = true
+		case "INPUT_ONLY":
+			s.WithWriteOnly(true)
+
+			changed = true
+		}
+	}
+
+	if !changed {
+		return sr
+	}
+
+	return openapi3.SchemaOrRef{Schema: s}
+}
+
+func jsonName(f FieldDescriptor) string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+
+	return f.Name
+}
+
+func required(f FieldDescriptor) bool {
+	if f.Label == "LABEL_REQUIRED" {
+		return true
+	}
+
+	if f.Options != nil {
+		for _, b := range f.Options.FieldBehavior {
+			if b == "REQUIRED" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// XQuota is a name of operation extension with rate limits.
+const XQuota = "x-quota"
+
+// Quota describes rate limits of an operation per client, it is stored in `x-quota` extension.
+type Quota struct {
+	// RequestsPerMinute limits sustained request rate.
+	RequestsPerMinute int `json:"requestsPerMinute,omitempty"`
+
+	// RequestsPerDay limits daily number of requests.
+	RequestsPerDay int `json:"requestsPerDay,omitempty"`
+
+	// Burst allows a number of requests above the rate before limiting.
+	Burst int `json:"burst,omitempty"`
+}
+
+// Validate checks that quota has a limit and no negative values.
+func (q Quota) Validate() error {
+	if q.RequestsPerMinute < 0 || q.RequestsPerDay < 0 || q.Burst < 0 {
+		return errors.New("quota values must not be negative")
+	}
+
+	if q.RequestsPerMinute == 0 && q.RequestsPerDay == 0 {
+		return errors.New("quota must limit requests per minute or per day")
+	}
+
+	return nil
+}
+
+// QuotaOf decodes extension value into Quota.
+func QuotaOf(extension interface{}) (Quota, bool) {
+	switch v := extension.(type) {
+	case Quota:
+		return v, true
+	case *Quota:
+		if v != nil {
+			return *v, true
+		}
+
+		return Quota{}, false
+	case nil:
+		return Quota{}, false
+	}
+
+	j, err := json.Marshal(extension)
+	if err != nil {
+		return Quota{}, false
+	}
+
+	var q Quota
+	if err := json.Unmarshal(j, &q); err != nil {
+		return Quota{}, false
+	}
+
+	return q, true
+}
@@ -0,0 +1,260 @@
+// Package recorder captures request and response pairs of integration tests as named examples of operations,
+// so that examples of a document stay real and up to date.
+//
+// Recorder is used as a middleware of tested handler, e.g.:
+//
+//	rec := recorder.New(spec)
+//	srv := httptest.NewServer(rec.Middleware(handler))
+//	// ... run integration tests against srv ...
+//	rec.Apply()
+//	// ... write spec ...
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// NameHeader is a request header with a name of example, e.g. a name of test case.
+const NameHeader = "X-Example-Name"
+
+// DefaultName is a name of example for requests without NameHeader.
+const DefaultName = "recorded"
+
+// Example is a recorded request and response of an operation.
+type Example struct {
+	Name string
+
+	// Method is an upper-case HTTP method and Path is a path template of matched operation.
+	Method string
+	Path   string
+
+	Status int
+
+	// RequestContentType and ResponseContentType are media types without parameters.
+	RequestContentType  string
+	ResponseContentType string
+
+	// Request and Response are bodies decoded from JSON, or strings for other media types, nil if empty.
+	Request  interface{}
+	Response interface{}
+}
+
+// Recorder collects examples of operations of a document.
+//
+// It is safe for concurrent use.
+type Recorder struct {
+	spec  *openapi3.Spec
+	table openapi3.TelemetryTable
+
+	mu       sync.Mutex
+	examples map[string]Example
+}
+
+// New creates a recorder of document operations.
+func New(s *openapi3.Spec) *Recorder {
+	return &Recorder{
+		spec:     s,
+		table:    s.TelemetryTable(),
+		examples: map[string]Example{},
+	}
+}
+
+// Middleware records requests and responses of handler, requests of unknown operations are not recorded.
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var reqBody []byte
+
+		if req.Body != nil {
+			b, err := io.ReadAll(req.Body)
+			if err == nil {
+				reqBody = b
+			}
+
+			req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+
+		cw := &captureWriter{ResponseWriter: rw}
+
+		next.ServeHTTP(cw, req)
+
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+
+		r.Record(req, reqBody, cw.status, cw.Header(), cw.body.Bytes())
+	})
+}
+
+// Record adds an example of request and response.
+//
+// Later example of an operation with same status and name replaces earlier one.
+func (r *Recorder) Record(req *http.Request, reqBody []byte, status int, header http.Header, respBody []byte) {
+	rt, ok := r.table.Match(req.Method, req.URL.Path)
+	if !ok {
+		return
+	}
+
+	ex := Example{
+		Name:   req.Header.Get(NameHeader),
+		Method: rt.Method,
+		Path:   rt.Route,
+		Status: status,
+	}
+
+	if ex.Name == "" {
+		ex.Name = DefaultName
+	}
+
+	ex.RequestContentType, ex.Request = decode(req.Header.Get("Content-Type"), reqBody)
+	ex.ResponseContentType, ex.Response = decode(header.Get("Content-Type"), respBody)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.examples[ex.Method+" "+ex.Path+" "+strconv.Itoa(ex.Status)+" "+ex.Name] = ex
+}
+
+// Examples returns recorded examples ordered by path, method, status and name.
+func (r *Recorder) Examples() []Example {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]Example, 0, len(r.examples))
+	for _, ex := range r.examples {
+		res = append(res, ex)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		a, b := res[i], res[j]
+
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+
+		return a.Name < b.Name
+	})
+
+	return res
+}
+
+// Apply writes recorded examples into `examples` of request and response media types of the document.
+//
+// Examples are only added to documented request bodies, responses and media types,
+// response status is matched exactly, then by status family (e.g. `4XX`), then as `default`.
+func (r *Recorder) Apply() {
+	for _, ex := range r.Examples() {
+		op := r.spec.Paths.MapOfPathItemValues[ex.Path].MapOfOperationValues[strings.ToLower(ex.Method)]
+
+		if ex.Request != nil && op.RequestBody != nil && op.RequestBody.RequestBody != nil {
+			addExample(op.RequestBody.RequestBody.Content, ex.RequestContentType, ex.Name, ex.Request)
+		}
+
+		if ex.Response != nil {
+			if resp := response(op.Responses, ex.Status); resp != nil {
+				addExample(resp.Content, ex.ResponseContentType, ex.Name, ex.Response)
+			}
+		}
+	}
+}
+
+func response(responses openapi3.Responses, status int) *openapi3.Response {
+	code := strconv.Itoa(status)
+
+	for _, key := range []string{code, code[:1] + "XX"} {
+		if rr, ok := responses.MapOfResponseOrRefValues[key]; ok {
+			return rr.Response
+		}
+	}
+
+	if responses.Default != nil {
+		return responses.Default.Response
+	}
+
+	return nil
+}
+
+func addExample(content map[string]openapi3.MediaType, contentType, name string, value interface{}) {
+	mt, ok := content[contentType]
+	if !ok {
+		return
+	}
+
+	if mt.Examples == nil {
+		mt.Examples = map[string]openapi3.ExampleOrRef{}
+	}
+
+	e := openapi3.Example{}
+	e.WithValue(value)
+
+	mt.Examples[name] = openapi3.ExampleOrRef{Example: &e}
+	content[contentType] = mt
+}
+
+// decode returns media type without parameters and body value.
+func decode(contentType string, body []byte) (string, interface{}) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = contentType
+	}
+
+	if len(body) == 0 {
+		return mt, nil
+	}
+
+	if mt == "application/json" || strings.HasSuffix(mt, "+json") {
+		var v interface{}
+
+		d := json.NewDecoder(bytes.NewReader(body))
+		d.UseNumber()
+
+		if err := d.Decode(&v); err == nil {
+			return mt, v
+		}
+	}
+
+	return mt, string(body)
+}
+
+// captureWriter keeps status and body of response.
+type captureWriter struct {
+	http.ResponseWriter
+
+	status int
+	body   bytes.Buffer
+}
+
+func (w *captureWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b)
+}
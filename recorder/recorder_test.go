@@ -0,0 +1,92 @@
+package recorder_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/recorder"
+)
+
+type order struct {
+	ID   int    `json:"id"`
+	Item string `json:"item"`
+}
+
+type problem struct {
+	Title string `json:"title"`
+}
+
+func TestRecorder(t *testing.T) {
+	r := openapi3.NewReflector()
+
+	oc, err := r.NewOperationContext(http.MethodPost, "/orders")
+	require.NoError(t, err)
+	oc.AddReqStructure(order{})
+	oc.AddRespStructure(order{}, openapi.WithHTTPStatus(http.StatusCreated))
+	oc.AddRespStructure(problem{}, openapi.WithHTTPStatus(http.StatusBadRequest))
+	require.NoError(t, r.AddOperation(oc))
+
+	rec := recorder.New(r.Spec)
+
+	h := rec.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var o order
+
+		rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		if err := json.NewDecoder(req.Body).Decode(&o); err != nil || o.Item == "" {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"title":"item is required"}`))
+
+			return
+		}
+
+		o.ID = 1
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(o)
+	}))
+
+	for _, tc := range []struct {
+		name, path, body string
+		status           int
+	}{
+		{name: "create-order", path: "/orders", body: `{"item":"book"}`, status: http.StatusCreated},
+		{path: "/orders", body: `{}`, status: http.StatusBadRequest},
+		{path: "/unknown", body: `{}`, status: http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		if tc.name != "" {
+			req.Header.Set(recorder.NameHeader, tc.name)
+		}
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+		assert.Equal(t, tc.status, rw.Code)
+	}
+
+	assert.Len(t, rec.Examples(), 2)
+
+	rec.Apply()
+
+	op := r.Spec.Paths.MapOfPathItemValues["/orders"].MapOfOperationValues["post"]
+
+	assertjson.EqMarshal(t, `{
+	  "create-order":{"value":{"item":"book"}},
+	  "recorded":{"value":{}}
+	}`, op.RequestBody.RequestBody.Content["application/json"].Examples)
+
+	assertjson.EqMarshal(t, `{"create-order":{"value":{"id":1,"item":"book"}}}`,
+		op.Responses.MapOfResponseOrRefValues["201"].Response.Content["application/json"].Examples)
+
+	assertjson.EqMarshal(t, `{"recorded":{"value":{"title":"item is required"}}}`,
+		op.Responses.MapOfResponseOrRefValues["400"].Response.Content["application/json"].Examples)
+}
@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/swaggest/jsonschema-go"
+import (
+	"context"
+
+	"github.com/swaggest/jsonschema-go"
+)
 
 // Reflector defines OpenAPI reflector behavior.
 type Reflector interface {
@@ -13,6 +17,14 @@ type Reflector interface {
 	JSONSchemaReflector() *jsonschema.Reflector
 }
 
+// ContextReflector adds operations with cancellation, it is implemented by openapi3.Reflector
+// and openapi31.Reflector.
+type ContextReflector interface {
+	Reflector
+
+	AddOperationContext(ctx context.Context, oc OperationContext) error
+}
+
 // JSONSchemaCallback is a user function called by JSONSchemaWalker.
 type JSONSchemaCallback func(in In, paramName string, schema *jsonschema.SchemaOrBool, required bool) error
 
@@ -0,0 +1,23 @@
+package openapi
+
+// RequiredPolicy defines how reflected body properties are marked as required.
+//
+// Explicit `required:"..."` field tags always take precedence over the policy.
+type RequiredPolicy int
+
+// RequiredPolicy values enumeration.
+const (
+	// RequiredExplicit only marks fields with `required:"true"` tag as required, this is the default.
+	RequiredExplicit = RequiredPolicy(iota)
+
+	// RequiredUnlessOptional marks fields as required unless they are pointers or have `omitempty`.
+	//
+	// This policy fits request bodies, where a client can omit optional values.
+	RequiredUnlessOptional
+
+	// RequiredUnlessOmitEmpty marks fields as required unless they have `omitempty`.
+	//
+	// This policy fits response bodies, where a field without `omitempty` is always
+	// returned by the server (possibly as null).
+	RequiredUnlessOmitEmpty
+)
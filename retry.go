@@ -0,0 +1,57 @@
+package openapi
+
+import "encoding/json"
+
+// XRetryable is a name of operation extension with retry semantics.
+const XRetryable = "x-retryable"
+
+// Retry describes retry semantics of an operation, it is stored in `x-retryable` extension.
+type Retry struct {
+	// Idempotent tells that repeating the request has no additional effect, so it can be retried
+	// after network failures and retryable statuses, non-idempotent requests are only retried
+	// if response tells that request was not processed.
+	Idempotent bool `json:"idempotent"`
+
+	// MaxAttempts limits total number of attempts including the first one, zero means client default.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// InitialBackoffMs is a suggested delay before the first retry in milliseconds.
+	InitialBackoffMs int64 `json:"initialBackoffMs,omitempty"`
+
+	// MaxBackoffMs caps the delay between attempts in milliseconds.
+	MaxBackoffMs int64 `json:"maxBackoffMs,omitempty"`
+
+	// Multiplier grows the delay after every attempt, e.g. 2 doubles it.
+	Multiplier float64 `json:"multiplier,omitempty"`
+
+	// Statuses lists response statuses that can be retried, e.g. 429 and 503.
+	Statuses []int `json:"statuses,omitempty"`
+}
+
+// RetryOf decodes extension value into Retry.
+func RetryOf(extension interface{}) (Retry, bool) {
+	switch v := extension.(type) {
+	case Retry:
+		return v, true
+	case *Retry:
+		if v != nil {
+			return *v, true
+		}
+
+		return Retry{}, false
+	case nil:
+		return Retry{}, false
+	}
+
+	j, err := json.Marshal(extension)
+	if err != nil {
+		return Retry{}, false
+	}
+
+	var r Retry
+	if err := json.Unmarshal(j, &r); err != nil {
+		return Retry{}, false
+	}
+
+	return r, true
+}
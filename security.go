@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SchemeScopes references a security scheme by name with scopes required from it.
+type SchemeScopes struct {
+	Name   string
+	Scopes []string
+}
+
+// Scheme references a security scheme with optional scopes.
+func Scheme(name string, scopes ...string) SchemeScopes {
+	return SchemeScopes{Name: name, Scopes: scopes}
+}
+
+// SecurityRequirement lists security schemes that must all be satisfied together (AND).
+type SecurityRequirement []SchemeScopes
+
+// Anonymous is an empty requirement `{}` that allows access without credentials,
+// as an alternative it makes authentication optional.
+func Anonymous() SecurityRequirement {
+	return SecurityRequirement{}
+}
+
+// RequireAll combines schemes into a single requirement, e.g. an API key together with OAuth2 token.
+func RequireAll(schemes ...SchemeScopes) SecurityRequirement {
+	return schemes
+}
+
+// Security lists alternative security requirements, any of them is sufficient (OR).
+type Security []SecurityRequirement
+
+// RequireAny combines alternative requirements, e.g. either basic auth or a bearer token.
+func RequireAny(requirements ...SecurityRequirement) Security {
+	return requirements
+}
+
+// Or adds an alternative requirement.
+func (s Security) Or(schemes ...SchemeScopes) Security {
+	return append(s, schemes)
+}
+
+// OrAnonymous makes authentication optional by adding Anonymous alternative.
+func (s Security) OrAnonymous() Security {
+	return append(s, Anonymous())
+}
+
+// Validate checks that every requirement has uniquely named schemes and that alternatives are distinct.
+//
+// Requirement without schemes is only valid if it is made with Anonymous.
+func (s Security) Validate() error {
+	var errs []string
+
+	seen := make(map[string]int, len(s))
+
+	for i, req := range s {
+		if req == nil {
+			errs = append(errs, fmt.Sprintf("requirement %d: no security schemes", i))
+
+			continue
+		}
+
+		names := make([]string, 0, len(req))
+		schemes := make(map[string]bool, len(req))
+		valid := true
+
+		for _, sc := range req {
+			if sc.Name == "" {
+				errs = append(errs, fmt.Sprintf("requirement %d: empty security scheme name", i))
+				valid = false
+
+				continue
+			}
+
+			if schemes[sc.Name] {
+				errs = append(errs, fmt.Sprintf("requirement %d: duplicate security scheme %q", i, sc.Name))
+				valid = false
+
+				continue
+			}
+
+			schemes[sc.Name] = true
+
+			names = append(names, sc.Name)
+		}
+
+		if !valid {
+			continue
+		}
+
+		sort.Strings(names)
+		key := strings.Join(names, " ")
+
+		if j, ok := seen[key]; ok {
+			errs = append(errs, fmt.Sprintf("requirement %d: same schemes as requirement %d", i, j))
+		} else {
+			seen[key] = i
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New("invalid security: " + strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// Requirements validates security and returns its value for `security` of operation or spec.
+func (s Security) Requirements() ([]map[string][]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string][]string, 0, len(s))
+
+	for _, req := range s {
+		r := make(map[string][]string, len(req))
+
+		for _, sc := range req {
+			scopes := sc.Scopes
+			if scopes == nil {
+				scopes = []string{}
+			}
+
+			r[sc.Name] = scopes
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
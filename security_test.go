@@ -0,0 +1,41 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go"
+)
+
+func TestSecurity_Requirements(t *testing.T) {
+	s := openapi.RequireAny(
+		openapi.RequireAll(openapi.Scheme("apiKey"), openapi.Scheme("oauth", "read", "write")),
+		openapi.RequireAll(openapi.Scheme("basic")),
+	)
+
+	reqs, err := s.Requirements()
+	require.NoError(t, err)
+	assert.Equal(t, []map[string][]string{
+		{"apiKey": {}, "oauth": {"read", "write"}},
+		{"basic": {}},
+	}, reqs)
+
+	_, err = s.Or(openapi.Scheme("oauth"), openapi.Scheme("apiKey")).
+		Or(openapi.Scheme("basic"), openapi.Scheme("basic")).
+		Or().
+		Or(openapi.Scheme("")).
+		Requirements()
+	assert.EqualError(t, err, `invalid security: requirement 2: same schemes as requirement 0, `+
+		`requirement 3: duplicate security scheme "basic", requirement 4: no security schemes, `+
+		`requirement 5: empty security scheme name`)
+}
+
+func TestSecurity_OrAnonymous(t *testing.T) {
+	reqs, err := openapi.RequireAny(openapi.RequireAll(openapi.Scheme("apiKey"))).OrAnonymous().Requirements()
+	require.NoError(t, err)
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}, {}}, reqs)
+
+	_, err = openapi.RequireAny(openapi.Anonymous()).OrAnonymous().Requirements()
+	assert.EqualError(t, err, "invalid security: requirement 1: same schemes as requirement 0")
+}
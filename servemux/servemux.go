@@ -0,0 +1,99 @@
+// Package servemux registers handlers on http.ServeMux and documents them as operations of a reflector,
+// so that routing table and documentation do not drift apart.
+//
+// Patterns use method and wildcard syntax of Go 1.22 ServeMux, e.g. `GET /things/{id}`,
+// it requires go.mod with `go 1.22` or later (or GODEBUG=httpmuxgo121=0).
+package servemux
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/swaggest/openapi-go"
+)
+
+// Mux registers handlers on http.ServeMux and adds their operations to reflector.
+type Mux struct {
+	mux       *http.ServeMux
+	reflector openapi.Reflector
+}
+
+var _ http.Handler = &Mux{}
+
+// New creates an adapter of mux and reflector, new http.ServeMux is created if mux is nil.
+func New(mux *http.ServeMux, r openapi.Reflector) *Mux {
+	if mux == nil {
+		mux = http.NewServeMux()
+	}
+
+	return &Mux{mux: mux, reflector: r}
+}
+
+// ServeMux returns underlying http.ServeMux.
+func (m *Mux) ServeMux() *http.ServeMux {
+	return m.mux
+}
+
+// ServeHTTP dispatches request to handler of matching pattern.
+func (m *Mux) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(rw, r)
+}
+
+// Handle documents operation of pattern and registers handler on mux.
+//
+// Pattern must have a method, e.g. `GET /things/{id}`, host of pattern is ignored in documentation,
+// `{name...}` wildcards are documented as `{name}` path parameters and `{$}` is omitted.
+// Setup functions describe operation, e.g. with oc.AddReqStructure and oc.AddRespStructure.
+//
+// Handler is not registered if operation can not be added to reflector.
+func (m *Mux) Handle(pattern string, h http.Handler, setup ...func(oc openapi.OperationContext)) error {
+	method, path, err := Operation(pattern)
+	if err != nil {
+		return err
+	}
+
+	oc, err := m.reflector.NewOperationContext(method, path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", pattern, err)
+	}
+
+	for _, s := range setup {
+		s(oc)
+	}
+
+	if err := m.reflector.AddOperation(oc); err != nil {
+		return fmt.Errorf("%s: %w", pattern, err)
+	}
+
+	m.mux.Handle(pattern, h)
+
+	return nil
+}
+
+// HandleFunc documents operation of pattern and registers handler function on mux, see Handle.
+func (m *Mux) HandleFunc(pattern string, h func(http.ResponseWriter, *http.Request), setup ...func(oc openapi.OperationContext)) error {
+	return m.Handle(pattern, http.HandlerFunc(h), setup...)
+}
+
+// Operation returns method and OpenAPI path template of ServeMux pattern, e.g. "GET" and "/files/{path}"
+// for `GET example.com/files/{path...}`.
+func Operation(pattern string) (method, path string, err error) {
+	method, rest, found := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !found || strings.HasPrefix(method, "/") {
+		return "", "", errors.New("pattern without method can not be documented: " + pattern)
+	}
+
+	rest = strings.TrimLeft(rest, " \t")
+
+	pos := strings.Index(rest, "/")
+	if pos < 0 {
+		return "", "", errors.New("pattern without path: " + pattern)
+	}
+
+	path = strings.ReplaceAll(rest[pos:], "{$}", "")
+	path = strings.ReplaceAll(path, "...}", "}")
+
+	return method, path, nil
+}
@@ -0,0 +1,90 @@
+//go:debug httpmuxgo121=0
+
+package servemux_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/servemux"
+)
+
+func TestOperation(t *testing.T) {
+	for pattern, expected := range map[string][2]string{
+		"GET /things/{id}":                 {"GET", "/things/{id}"},
+		"POST /things/{$}":                 {"POST", "/things/"},
+		"GET  example.com/files/{path...}": {"GET", "/files/{path}"},
+		"DELETE /users/{user}/keys/{key}":  {"DELETE", "/users/{user}/keys/{key}"},
+	} {
+		method, path, err := servemux.Operation(pattern)
+		require.NoError(t, err, pattern)
+		assert.Equal(t, expected, [2]string{method, path}, pattern)
+	}
+
+	_, _, err := servemux.Operation("/things/{id}")
+	assert.EqualError(t, err, "pattern without method can not be documented: /things/{id}")
+
+	_, _, err = servemux.Operation("GET example.com")
+	assert.EqualError(t, err, "pattern without path: GET example.com")
+}
+
+func TestMux_Handle(t *testing.T) {
+	r := openapi3.NewReflector()
+	m := servemux.New(nil, r)
+
+	type thingReq struct {
+		ID int `path:"id"`
+	}
+
+	type thing struct {
+		ID int `json:"id"`
+	}
+
+	require.NoError(t, m.HandleFunc("GET /things/{id}", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":1}`))
+	}, func(oc openapi.OperationContext) {
+		oc.SetSummary("Get thing.")
+		oc.AddReqStructure(thingReq{})
+		oc.AddRespStructure(thing{})
+	}))
+
+	// Undocumented path parameter fails before handler is registered.
+	assert.Error(t, m.HandleFunc("DELETE /things/{id}", func(http.ResponseWriter, *http.Request) {}))
+	assert.EqualError(t, m.HandleFunc("/health", func(http.ResponseWriter, *http.Request) {}),
+		"pattern without method can not be documented: /health")
+
+	rw := httptest.NewRecorder()
+	m.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/things/1", nil))
+	assert.Equal(t, `{"id":1}`, rw.Body.String())
+
+	rw = httptest.NewRecorder()
+	m.ServeHTTP(rw, httptest.NewRequest(http.MethodDelete, "/things/1", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rw.Code)
+
+	assertjson.EqMarshal(t, `{
+	  "openapi":"3.0.3","info":{"title":"","version":""},
+	  "paths":{
+		"/things/{id}":{
+		  "get":{
+			"summary":"Get thing.",
+			"parameters":[{"name":"id","in":"path","required":true,"schema":{"type":"integer"}}],
+			"responses":{
+			  "200":{
+				"description":"OK",
+				"content":{"application/json":{"schema":{"$ref":"#/components/schemas/ServemuxTestThing"}}}
+			  }
+			}
+		  }
+		}
+	  },
+	  "components":{
+		"schemas":{"ServemuxTestThing":{"type":"object","properties":{"id":{"type":"integer"}}}}
+	  }
+	}`, r.SpecEns())
+}
@@ -0,0 +1,531 @@
+// Package server generates Go server interfaces and net/http routing from OpenAPI documents.
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/internal/codegen"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// Config controls code generation.
+type Config struct {
+	// Package is a name of Go package of generated file, required.
+	Package string
+
+	// Interface is a name of generated server interface, default "API".
+	Interface string
+}
+
+// Generate renders Go file with a server interface and routing glue of document operations.
+//
+// For every operation it declares a request structure with parameters and body,
+// a response type with constructors of documented statuses (e.g. `GetThingOK(body)`,
+// `GetThingNotFound(body)`) and a method of interface, e.g.
+// `GetThing(ctx context.Context, req GetThingRequest) (GetThingResponse, error)`.
+//
+// `New{Interface}Handler(api)` returns http.Handler that routes requests by method and path,
+// decodes parameters and JSON body (responding with 400 Bad Request on failure),
+// calls interface method and encodes its response, errors result in 500 Internal Server Error.
+//
+// Generated file contains unexported helpers, so there should be one generated file per package.
+func Generate(s *openapi3.Spec, cfg Config) ([]byte, error) {
+	if cfg.Package == "" {
+		return nil, errors.New("server: package is required")
+	}
+
+	if cfg.Interface == "" {
+		cfg.Interface = "API"
+	}
+
+	g := generator{spec: s, cfg: cfg, names: map[string]int{}, types: codegen.NewTypes(s)}
+
+	ops := bytes.NewBuffer(nil)
+
+	paths := make([]string, 0, len(s.Paths.MapOfPathItemValues))
+	for path := range s.Paths.MapOfPathItemValues {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pi := s.Paths.MapOfPathItemValues[path]
+
+		methods := make([]string, 0, len(pi.MapOfOperationValues))
+		for method := range pi.MapOfOperationValues {
+			methods = append(methods, method)
+		}
+
+		sort.Strings(methods)
+
+		for _, method := range methods {
+			if err := g.operation(ops, strings.ToUpper(method), path, pi.Parameters, pi.MapOfOperationValues[method]); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+		}
+	}
+
+	if len(g.methods) == 0 {
+		return nil, errors.New("server: document has no operations")
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	buf.WriteString("// Code generated by openapi-go/server, DO NOT EDIT.\n\n")
+	buf.WriteString("package " + cfg.Package + "\n\n")
+	buf.WriteString("import (\n\"context\"\n\"encoding/json\"\n\"errors\"\n\"fmt\"\n\"io\"\n\"net/http\"\n\"net/url\"\n" +
+		"\"reflect\"\n\"strconv\"\n\"strings\"\n)\n\n")
+
+	fmt.Fprintf(buf, "// %s is implemented by server of API.\ntype %s interface {\n", cfg.Interface, cfg.Interface)
+
+	for _, m := range g.methods {
+		buf.WriteString(m)
+	}
+
+	buf.WriteString("}\n")
+
+	g.router(buf)
+
+	if err := g.types.Render(buf); err != nil {
+		return nil, fmt.Errorf("server: %w", err)
+	}
+
+	buf.Write(ops.Bytes())
+	buf.WriteString(helpers)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("server: format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+type generator struct {
+	spec  *openapi3.Spec
+	cfg   Config
+	names map[string]int
+	types *codegen.Types
+
+	methods []string
+	routes  []route
+}
+
+type route struct {
+	method   string
+	path     string
+	literals int
+	serve    string
+}
+
+func (g *generator) operation(buf *bytes.Buffer, method, path string, common []openapi3.ParameterOrRef, op openapi3.Operation) error {
+	rt := route{method: method, path: path}
+
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+		case strings.ContainsAny(segment, "{}"):
+			return fmt.Errorf("path segment %s is not supported, parameter must be a whole segment", segment)
+		default:
+			rt.literals++
+		}
+	}
+
+	name := g.opName(method, path, op.ID)
+	rt.serve = "serve" + name
+
+	params, err := g.parameters(common, op.Parameters)
+	if err != nil {
+		return err
+	}
+
+	// Request structure.
+	fmt.Fprintf(buf, "\n// %sRequest is a request of %s %s.\ntype %sRequest struct {\n", name, method, path, name)
+
+	fields := map[string]int{"Body": 1}
+	decode := bytes.NewBuffer(nil)
+
+	for _, p := range params {
+		field := codegen.GoName(p.Name)
+
+		fields[field]++
+		if n := fields[field]; n > 1 {
+			field += strconv.Itoa(n)
+		}
+
+		required := p.Required != nil && *p.Required
+
+		fmt.Fprintf(buf, "// %s is a %s parameter %q.\n", field, p.In, p.Name)
+
+		if p.Description != nil && *p.Description != "" {
+			fmt.Fprintf(buf, "//\n// %s\n", strings.ReplaceAll(*p.Description, "\n", "\n// "))
+		}
+
+		fmt.Fprintf(buf, "%s %s\n", field, g.paramType(p.Schema, required))
+		fmt.Fprintf(decode, "if err := decodeParam(r, params, %q, %q, %t, &req.%s); err != nil {\n"+
+			"http.Error(rw, err.Error(), http.StatusBadRequest)\n\nreturn\n}\n\n", p.In, p.Name, required, field)
+	}
+
+	if err := g.requestBody(buf, decode, op.RequestBody); err != nil {
+		return err
+	}
+
+	buf.WriteString("}\n")
+
+	// Response type and constructors.
+	fmt.Fprintf(buf, "\n// %sResponse is a response of %s %s, use constructors of documented statuses,\n"+
+		"// e.g. %s.\ntype %sResponse Response\n", name, method, path, g.firstConstructor(name, op.Responses), name)
+
+	if err := g.responses(buf, name, op.Responses); err != nil {
+		return err
+	}
+
+	// Serving function.
+	fmt.Fprintf(buf, "\nfunc %s(api %s, rw http.ResponseWriter, r *http.Request, params map[string]string) {\n",
+		rt.serve, g.cfg.Interface)
+	fmt.Fprintf(buf, "var req %sRequest\n\n", name)
+	buf.Write(decode.Bytes())
+	fmt.Fprintf(buf, "resp, err := api.%s(r.Context(), req)\nif err != nil {\n"+
+		"http.Error(rw, err.Error(), http.StatusInternalServerError)\n\nreturn\n}\n\n", name)
+	buf.WriteString("writeResponse(rw, Response(resp))\n}\n")
+
+	// Interface method.
+	m := fmt.Sprintf("// %s handles %s %s.\n", name, method, path)
+
+	for _, doc := range []*string{op.Summary, op.Description} {
+		if doc != nil && *doc != "" {
+			m += "//\n// " + strings.ReplaceAll(*doc, "\n", "\n// ") + "\n"
+		}
+	}
+
+	if op.Deprecated != nil && *op.Deprecated {
+		m += "//\n// Deprecated: operation is deprecated.\n"
+	}
+
+	m += fmt.Sprintf("%s(ctx context.Context, req %sRequest) (%sResponse, error)\n", name, name, name)
+
+	g.methods = append(g.methods, m)
+	g.routes = append(g.routes, rt)
+
+	return nil
+}
+
+// parameters merges path item and operation parameters, operation parameters override by name and location.
+func (g *generator) parameters(lists ...[]openapi3.ParameterOrRef) ([]openapi3.Parameter, error) {
+	var res []openapi3.Parameter
+
+	index := map[string]int{}
+
+	for _, list := range lists {
+		for _, pr := range list {
+			p := pr.Parameter
+
+			if pr.ParameterReference != nil {
+				name := strings.TrimPrefix(pr.ParameterReference.Ref, "#/components/parameters/")
+
+				if c := g.spec.Components; c != nil && c.Parameters != nil {
+					p = c.Parameters.MapOfParameterOrRefValues[name].Parameter
+				}
+
+				if p == nil {
+					return nil, fmt.Errorf("unresolved parameter reference %s", pr.ParameterReference.Ref)
+				}
+			}
+
+			if p == nil {
+				continue
+			}
+
+			key := string(p.In) + " " + p.Name
+			if i, ok := index[key]; ok {
+				res[i] = *p
+
+				continue
+			}
+
+			index[key] = len(res)
+			res = append(res, *p)
+		}
+	}
+
+	return res, nil
+}
+
+// paramType returns Go type of parameter, optional scalars are pointers.
+func (g *generator) paramType(sr *openapi3.SchemaOrRef, required bool) string {
+	s := g.types.Schema(sr)
+
+	t := "string"
+
+	if s != nil && s.Type != nil {
+		switch *s.Type {
+		case openapi3.SchemaTypeInteger:
+			t = "int64"
+		case openapi3.SchemaTypeNumber:
+			t = "float64"
+		case openapi3.SchemaTypeBoolean:
+			t = "bool"
+		case openapi3.SchemaTypeArray:
+			return "[]" + g.paramType(s.Items, true)
+		}
+	}
+
+	if !required {
+		t = "*" + t
+	}
+
+	return t
+}
+
+func (g *generator) requestBody(buf, decode *bytes.Buffer, rbr *openapi3.RequestBodyOrRef) error {
+	if rbr == nil {
+		return nil
+	}
+
+	rb := rbr.RequestBody
+
+	if rbr.RequestBodyReference != nil {
+		name := strings.TrimPrefix(rbr.RequestBodyReference.Ref, "#/components/requestBodies/")
+
+		if c := g.spec.Components; c != nil && c.RequestBodies != nil {
+			rb = c.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody
+		}
+
+		if rb == nil {
+			return fmt.Errorf("unresolved request body reference %s", rbr.RequestBodyReference.Ref)
+		}
+	}
+
+	required := rb.Required != nil && *rb.Required
+
+	buf.WriteString("\n// Body is a request body.\n")
+
+	if ct, ok := jsonContentType(rb.Content); ok {
+		t := g.types.GoType(rb.Content[ct].Schema)
+		if !required && !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") && !strings.HasPrefix(t, "*") {
+			t = "*" + t
+		}
+
+		fmt.Fprintf(buf, "Body %s\n", t)
+		fmt.Fprintf(decode, "if err := decodeJSONBody(r, %t, &req.Body); err != nil {\n"+
+			"http.Error(rw, err.Error(), http.StatusBadRequest)\n\nreturn\n}\n\n", required)
+
+		return nil
+	}
+
+	buf.WriteString("Body []byte\n")
+	decode.WriteString("body, err := io.ReadAll(r.Body)\nif err != nil {\n" +
+		"http.Error(rw, err.Error(), http.StatusBadRequest)\n\nreturn\n}\n\nreq.Body = body\n\n")
+
+	return nil
+}
+
+func jsonContentType(content map[string]openapi3.MediaType) (string, bool) {
+	cts := make([]string, 0, len(content))
+	for ct := range content {
+		cts = append(cts, ct)
+	}
+
+	sort.Strings(cts)
+
+	for _, ct := range cts {
+		if ct == "application/json" || strings.HasSuffix(ct, "+json") {
+			return ct, true
+		}
+	}
+
+	return "", false
+}
+
+func (g *generator) firstConstructor(opName string, responses openapi3.Responses) string {
+	codes := sortedKeys(responses.MapOfResponseOrRefValues)
+	if len(codes) > 0 {
+		return opName + statusName(codes[0])
+	}
+
+	return opName + "Default"
+}
+
+func (g *generator) responses(buf *bytes.Buffer, opName string, responses openapi3.Responses) error {
+	codes := sortedKeys(responses.MapOfResponseOrRefValues)
+
+	rrs := make([]openapi3.ResponseOrRef, 0, len(codes)+1)
+	for _, code := range codes {
+		rrs = append(rrs, responses.MapOfResponseOrRefValues[code])
+	}
+
+	if responses.Default != nil {
+		codes = append(codes, "default")
+		rrs = append(rrs, *responses.Default)
+	}
+
+	for i, code := range codes {
+		resp := rrs[i].Response
+
+		if rr := rrs[i]; rr.ResponseReference != nil {
+			name := strings.TrimPrefix(rr.ResponseReference.Ref, "#/components/responses/")
+
+			if c := g.spec.Components; c != nil && c.Responses != nil {
+				resp = c.Responses.MapOfResponseOrRefValues[name].Response
+			}
+
+			if resp == nil {
+				return fmt.Errorf("unresolved response reference %s", rr.ResponseReference.Ref)
+			}
+		}
+
+		fn := opName + statusName(code)
+
+		var args, status string
+
+		if _, err := strconv.Atoi(code); err == nil {
+			status = code
+		} else {
+			args = "statusCode int"
+			status = "statusCode"
+		}
+
+		contentType, bodyType := "", ""
+
+		if ct, ok := jsonContentType(resp.Content); ok {
+			contentType, bodyType = ct, g.types.GoType(resp.Content[ct].Schema)
+		} else if cts := sortedKeys(resp.Content); len(cts) > 0 {
+			contentType, bodyType = cts[0], "[]byte"
+		}
+
+		if bodyType != "" {
+			if args != "" {
+				args += ", "
+			}
+
+			args += "body " + bodyType
+		}
+
+		fmt.Fprintf(buf, "\n// %s is a %s response of %s.\n", fn, code, opName)
+
+		if resp.Description != "" {
+			fmt.Fprintf(buf, "//\n// %s\n", strings.ReplaceAll(resp.Description, "\n", "\n// "))
+		}
+
+		fmt.Fprintf(buf, "func %s(%s) %sResponse {\n", fn, args, opName)
+
+		if bodyType == "" {
+			fmt.Fprintf(buf, "return %sResponse{StatusCode: %s}\n}\n", opName, status)
+		} else {
+			fmt.Fprintf(buf, "return %sResponse{StatusCode: %s, ContentType: %q, Body: body}\n}\n", opName, status, contentType)
+		}
+	}
+
+	return nil
+}
+
+// router renders handler constructor and routes, templates with more literal segments take precedence.
+func (g *generator) router(buf *bytes.Buffer) {
+	sort.SliceStable(g.routes, func(i, j int) bool {
+		return g.routes[i].literals > g.routes[j].literals
+	})
+
+	h := "handler" + g.cfg.Interface
+
+	fmt.Fprintf(buf, "\n// New%sHandler returns http.Handler that serves requests with api.\n", g.cfg.Interface)
+	fmt.Fprintf(buf, "func New%sHandler(api %s) http.Handler {\nreturn %s{api: api}\n}\n\n", g.cfg.Interface, g.cfg.Interface, h)
+	fmt.Fprintf(buf, "type %s struct {\napi %s\n}\n\n", h, g.cfg.Interface)
+	fmt.Fprintf(buf, "var routes%s = []struct {\nmethod string\nsegments []string\n"+
+		"serve func(api %s, rw http.ResponseWriter, r *http.Request, params map[string]string)\n}{\n", g.cfg.Interface, g.cfg.Interface)
+
+	for _, rt := range g.routes {
+		segments := strings.Split(rt.path, "/")
+		for i, s := range segments {
+			segments[i] = strconv.Quote(s)
+		}
+
+		fmt.Fprintf(buf, "{method: %q, segments: []string{%s}, serve: %s},\n", rt.method, strings.Join(segments, ", "), rt.serve)
+	}
+
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, `// ServeHTTP routes request to operation, unknown paths are responded with 404 Not Found
+// and unknown methods of known paths with 405 Method Not Allowed.
+func (h %s) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.EscapedPath(), "/")
+	pathFound := false
+
+	for _, rt := range routes%s {
+		params, ok := matchSegments(rt.segments, segments)
+		if !ok {
+			continue
+		}
+
+		if rt.method != r.Method {
+			pathFound = true
+
+			continue
+		}
+
+		rt.serve(h.api, rw, r, params)
+
+		return
+	}
+
+	if pathFound {
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	http.NotFound(rw, r)
+}
+`, h, g.cfg.Interface)
+}
+
+func statusName(status string) string {
+	switch {
+	case status == "default":
+		return "Default"
+	case strings.HasSuffix(status, "XX"):
+		return "Status" + status
+	}
+
+	code, err := strconv.Atoi(status)
+	if err != nil || http.StatusText(code) == "" {
+		return "Status" + status
+	}
+
+	return codegen.GoName(http.StatusText(code))
+}
+
+func (g *generator) opName(method, path string, id *string) string {
+	var name string
+
+	if id != nil && *id != "" {
+		name = codegen.GoName(*id)
+	} else {
+		name = codegen.GoName(strings.ToLower(method) + " " + path)
+	}
+
+	g.names[name]++
+	if n := g.names[name]; n > 1 {
+		name += strconv.Itoa(n)
+	}
+
+	return name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,161 @@
+package server_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/server"
+)
+
+func TestGenerate(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /things/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: integer}}
+    get:
+      operationId: getThing
+      summary: Get a thing.
+      parameters:
+        - {name: fields, in: query, schema: {type: array, items: {type: string}}}
+        - {name: X-Trace, in: header, description: Trace ID., schema: {type: string}}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/Thing'}
+        "404": {description: Not found}
+        default:
+          description: Error
+          content:
+            text/plain: {schema: {type: string}}
+    put:
+      operationId: updateThing
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema: {$ref: '#/components/schemas/Thing'}
+      responses:
+        "204": {description: Updated}
+  /things/latest:
+    get:
+      operationId: getLatestThing
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Thing:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        size: {type: integer}
+        parent: {$ref: '#/components/schemas/Thing'}
+        owner:
+          type: object
+          properties:
+            userId: {type: string}
+            homepageUrl: {type: string}
+`)))
+
+	_, err := server.Generate(&s, server.Config{})
+	assert.EqualError(t, err, "server: package is required")
+
+	src, err := server.Generate(&s, server.Config{Package: "api", Interface: "ThingsAPI"})
+	require.NoError(t, err)
+
+	typeCheck(t, "api.go", src)
+
+	code := string(src)
+
+	assert.Contains(t, code, `// ThingsAPI is implemented by server of API.
+type ThingsAPI interface {
+	// GetLatestThing handles GET /things/latest.
+	GetLatestThing(ctx context.Context, req GetLatestThingRequest) (GetLatestThingResponse, error)
+	// GetThing handles GET /things/{id}.
+	//
+	// Get a thing.
+	GetThing(ctx context.Context, req GetThingRequest) (GetThingResponse, error)
+	// UpdateThing handles PUT /things/{id}.
+	UpdateThing(ctx context.Context, req UpdateThingRequest) (UpdateThingResponse, error)
+}`)
+
+	assert.Contains(t, code, `// GetThingRequest is a request of GET /things/{id}.
+type GetThingRequest struct {
+	// ID is a path parameter "id".
+	ID int64
+	// Fields is a query parameter "fields".
+	Fields []string
+	// XTrace is a header parameter "X-Trace".
+	//
+	// Trace ID.
+	XTrace *string
+}`)
+
+	assert.Contains(t, code, `type UpdateThingRequest struct {
+	// ID is a path parameter "id".
+	ID int64
+
+	// Body is a request body.
+	Body Thing
+}`)
+
+	assert.Contains(t, code, `// GetThingOK is a 200 response of GetThing.
+//
+// OK
+func GetThingOK(body Thing) GetThingResponse {
+	return GetThingResponse{StatusCode: 200, ContentType: "application/json", Body: body}
+}`)
+	assert.Contains(t, code, `func GetThingNotFound() GetThingResponse {
+	return GetThingResponse{StatusCode: 404}
+}`)
+	assert.Contains(t, code, `func GetThingDefault(statusCode int, body []byte) GetThingResponse {
+	return GetThingResponse{StatusCode: statusCode, ContentType: "text/plain", Body: body}
+}`)
+
+	// Literal paths take precedence.
+	assert.Contains(t, code, `var routesThingsAPI = []struct {
+	method   string
+	segments []string
+	serve    func(api ThingsAPI, rw http.ResponseWriter, r *http.Request, params map[string]string)
+}{
+	{method: "GET", segments: []string{"", "things", "latest"}, serve: serveGetLatestThing},
+	{method: "GET", segments: []string{"", "things", "{id}"}, serve: serveGetThing},
+	{method: "PUT", segments: []string{"", "things", "{id}"}, serve: serveUpdateThing},
+}`)
+
+	assert.Contains(t, code, `type Thing struct {
+	Name   string `+"`"+`json:"name"`+"`"+`
+	Size   int64  `+"`"+`json:"size,omitempty"`+"`"+`
+	Parent *Thing `+"`"+`json:"parent,omitempty"`+"`"+`
+	Owner  *struct {
+		UserID      string `+"`"+`json:"userId,omitempty"`+"`"+`
+		HomepageURL string `+"`"+`json:"homepageUrl,omitempty"`+"`"+`
+	} `+"`"+`json:"owner,omitempty"`+"`"+`
+}`)
+}
+
+// typeCheck fails if generated code is not a valid Go package, e.g. has recursive types.
+func typeCheck(t *testing.T, name string, src []byte) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	f, err := parser.ParseFile(fset, name, src, 0)
+	require.NoError(t, err)
+
+	_, err = (&types.Config{Importer: importer.Default()}).Check("api", fset, []*ast.File{f}, nil)
+	require.NoError(t, err)
+}
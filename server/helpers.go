@@ -0,0 +1,171 @@
+package server
+
+// helpers are rendered once per generated file.
+const helpers = `
+// Response is an HTTP response of operation.
+type Response struct {
+	StatusCode  int
+	Header      http.Header
+	ContentType string
+
+	// Body is written as is if it is []byte, encoded as JSON otherwise, nil body is not written.
+	Body interface{}
+}
+
+func writeResponse(rw http.ResponseWriter, resp Response) {
+	for k, vs := range resp.Header {
+		for _, v := range vs {
+			rw.Header().Add(k, v)
+		}
+	}
+
+	if resp.Body == nil {
+		rw.WriteHeader(resp.StatusCode)
+
+		return
+	}
+
+	body, ok := resp.Body.([]byte)
+	if !ok {
+		var err error
+
+		if body, err = json.Marshal(resp.Body); err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	if resp.ContentType != "" {
+		rw.Header().Set("Content-Type", resp.ContentType)
+	}
+
+	rw.WriteHeader(resp.StatusCode)
+	_, _ = rw.Write(body)
+}
+
+func matchSegments(template, actual []string) (map[string]string, bool) {
+	if len(template) != len(actual) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") {
+			v, err := url.PathUnescape(actual[i])
+			if err != nil || v == "" {
+				return nil, false
+			}
+
+			params[t[1:len(t)-1]] = v
+
+			continue
+		}
+
+		if t != actual[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func decodeParam(r *http.Request, params map[string]string, in, name string, required bool, dst interface{}) error {
+	var values []string
+
+	switch in {
+	case "path":
+		values = []string{params[name]}
+	case "query":
+		values = r.URL.Query()[name]
+	case "header":
+		values = r.Header.Values(name)
+	case "cookie":
+		if c, err := r.Cookie(name); err == nil {
+			values = []string{c.Value}
+		}
+	}
+
+	if len(values) == 0 {
+		if required {
+			return fmt.Errorf("%s parameter %s is required", in, name)
+		}
+
+		return nil
+	}
+
+	if err := decodeValues(values, reflect.ValueOf(dst).Elem()); err != nil {
+		return fmt.Errorf("%s parameter %s: %w", in, name, err)
+	}
+
+	return nil
+}
+
+func decodeValues(values []string, v reflect.Value) error {
+	switch v.Kind() { //nolint:exhaustive // Only parameter types are generated.
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+
+		return decodeValues(values, v.Elem())
+	case reflect.Slice:
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+
+		for i, value := range values {
+			if err := decodeValues([]string{value}, s.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		v.Set(s)
+	case reflect.String:
+		v.SetString(values[0])
+	case reflect.Int64:
+		n, err := strconv.ParseInt(values[0], 10, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+func decodeJSONBody(r *http.Request, required bool, dst interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if errors.Is(err, io.EOF) {
+		if required {
+			return errors.New("request body is required")
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("request body: %w", err)
+	}
+
+	return nil
+}
+`
@@ -0,0 +1,22 @@
+package openapi
+
+import "strconv"
+
+// OperationSource describes Go origin of an operation.
+type OperationSource struct {
+	// File and Line point to the operation registration site.
+	File string
+	Line int
+
+	// Function is a fully qualified name of the function that registered operation.
+	Function string
+
+	// Request and Response contain Go types of operation structures.
+	Request  []string
+	Response []string
+}
+
+// String returns registration site as file:line.
+func (s OperationSource) String() string {
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
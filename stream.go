@@ -0,0 +1,34 @@
+package openapi
+
+import "reflect"
+
+// Media types of streaming bodies.
+const (
+	MediaTypeEventStream = "text/event-stream"
+	MediaTypeNDJSON      = "application/x-ndjson"
+)
+
+// WithStream is a ContentUnit option to document a streaming body, e.g. server-sent events with
+// MediaTypeEventStream or newline-delimited JSON with MediaTypeNDJSON, schema of body describes a single item.
+//
+// Structures of unnamed slice or array types are described by their elements, e.g. streams of []Event
+// are documented as Event, use jsonschema.OneOf to document streams of different items.
+func WithStream(contentType string) ContentOption {
+	return func(cu *ContentUnit) {
+		cu.ContentType = contentType
+		cu.Stream = true
+
+		if cu.Structure == nil {
+			return
+		}
+
+		t := reflect.TypeOf(cu.Structure)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t.Name() == "" && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+			cu.Structure = reflect.New(t.Elem()).Elem().Interface()
+		}
+	}
+}
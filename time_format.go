@@ -0,0 +1,20 @@
+package openapi
+
+// TimeFormat defines how reflected `date-time` values of `example`, `default`, `const` and `enum` are written.
+type TimeFormat int
+
+// TimeFormat values enumeration.
+const (
+	// TimeKeep leaves values as reflected, this is the default.
+	TimeKeep = TimeFormat(iota)
+
+	// TimeUTC converts values to UTC with trailing `Z`, e.g. `2024-01-02T03:04:05Z`.
+	TimeUTC
+
+	// TimeOffset writes values with numeric offset, also for UTC, e.g. `2024-01-02T03:04:05+00:00`.
+	TimeOffset
+
+	// TimeDate truncates values to date in their own time zone and describes properties with `format: date`,
+	// e.g. `2024-01-02`, this policy fits APIs that only expose calendar dates.
+	TimeDate
+)
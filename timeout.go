@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// XTimeoutMs is a name of operation extension with request timeout in milliseconds.
+const XTimeoutMs = "x-timeout-ms"
+
+// TimeoutOf decodes `x-timeout-ms` extension value, non-positive values are ignored.
+func TimeoutOf(extension interface{}) (time.Duration, bool) {
+	var ms float64
+
+	switch v := extension.(type) {
+	case int:
+		ms = float64(v)
+	case int64:
+		ms = float64(v)
+	case float64:
+		ms = v
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		ms = f
+	default:
+		return 0, false
+	}
+
+	if ms <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
@@ -0,0 +1,579 @@
+// Package validator checks incoming HTTP requests against operations of OpenAPI 3.0 document.
+//
+// Validator is used as a middleware of a handler, e.g.:
+//
+//	v := validator.New(spec)
+//	http.ListenAndServe(":8080", v.Middleware(handler))
+//
+// Requests of undocumented operations are passed through, invalid requests are rejected with
+// 400 Bad Request (or 413 Request Entity Too Large for bodies beyond Validator.MaxBodySize) and JSON RequestError.
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/param"
+)
+
+// Parameter locations of FieldError besides those of openapi3.ParameterIn.
+const (
+	InBody = "body"
+)
+
+// DefaultMaxBodySize is a default limit of request body size.
+const DefaultMaxBodySize = 10 << 20
+
+// FieldError describes an invalid parameter or body value.
+type FieldError struct {
+	// In is a location of value: path, query, header, cookie or body.
+	In string `json:"in"`
+
+	// Name is a name of parameter, empty for body.
+	Name string `json:"name,omitempty"`
+
+	// Pointer is a JSON Pointer into value, e.g. "/items/0/name", empty for whole value.
+	Pointer string `json:"pointer,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// String returns field error as a single line.
+func (e FieldError) String() string {
+	loc := e.In
+	if e.Name != "" {
+		loc += " " + e.Name
+	}
+
+	return loc + e.Pointer + ": " + e.Message
+}
+
+// RequestError lists problems of a request.
+type RequestError struct {
+	// Method is an upper-case HTTP method and Path is a path template of matched operation.
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	Errors []FieldError `json:"errors"`
+
+	// Status is an HTTP status of rejection, 400 Bad Request if zero.
+	Status int `json:"-"`
+}
+
+// Error implements error.
+func (e *RequestError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.String())
+	}
+
+	return "invalid request " + e.Method + " " + e.Path + ": " + strings.Join(msgs, "; ")
+}
+
+// Validator checks requests against document operations, it is safe for concurrent use.
+type Validator struct {
+	spec   *openapi3.Spec
+	routes []route
+
+	// OnError writes response to an invalid request, default writes RequestError.Status with JSON RequestError.
+	OnError func(rw http.ResponseWriter, req *http.Request, err *RequestError)
+
+	// MaxBodySize limits request body size in bytes, DefaultMaxBodySize if zero, negative value disables the limit.
+	MaxBodySize int64
+}
+
+type route struct {
+	method   string
+	template string
+	literals int
+
+	params []openapi3.Parameter
+	body   *openapi3.RequestBody
+}
+
+// New compiles operations of document into route matchers.
+//
+// Document should not be changed while validator is in use.
+func New(s *openapi3.Spec) *Validator {
+	v := &Validator{spec: s}
+
+	for path, pi := range s.Paths.MapOfPathItemValues {
+		for method, op := range pi.MapOfOperationValues {
+			rt := route{
+				method:   strings.ToUpper(method),
+				template: path,
+				params:   v.parameters(pi.Parameters, op.Parameters),
+				body:     v.requestBody(op.RequestBody),
+			}
+
+			for _, segment := range strings.Split(path, "/") {
+				if !strings.HasPrefix(segment, "{") {
+					rt.literals++
+				}
+			}
+
+			v.routes = append(v.routes, rt)
+		}
+	}
+
+	// Templates with more literal segments take precedence, e.g. `/users/me` over `/users/{id}`.
+	sort.Slice(v.routes, func(i, j int) bool {
+		if v.routes[i].literals != v.routes[j].literals {
+			return v.routes[i].literals > v.routes[j].literals
+		}
+
+		return v.routes[i].template < v.routes[j].template
+	})
+
+	return v
+}
+
+// Middleware rejects invalid requests of documented operations, other requests are passed to next.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var re *RequestError
+
+		if err := v.validate(rw, req); errors.As(err, &re) {
+			onError := v.OnError
+			if onError == nil {
+				onError = writeError
+			}
+
+			onError(rw, req, re)
+
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// Validate checks parameters and body of request, it returns *RequestError for an invalid request,
+// or nil if request is valid or does not match document operations.
+//
+// Body is read and replaced with a copy, so that it is available to handler.
+func (v *Validator) Validate(req *http.Request) error {
+	return v.validate(nil, req)
+}
+
+// validate checks request, rw is notified of too large body if not nil.
+func (v *Validator) validate(rw http.ResponseWriter, req *http.Request) error {
+	rt, pathParams, ok := v.match(req)
+	if !ok {
+		return nil
+	}
+
+	re := &RequestError{Method: rt.method, Path: rt.template}
+
+	for _, p := range rt.params {
+		v.validateParameter(req, p, pathParams, re)
+	}
+
+	v.validateBody(rw, req, rt.body, re)
+
+	if len(re.Errors) == 0 {
+		return nil
+	}
+
+	return re
+}
+
+func (v *Validator) match(req *http.Request) (route, map[string]string, bool) {
+	for _, rt := range v.routes {
+		if rt.method != req.Method {
+			continue
+		}
+
+		if params, ok := param.MatchPath(rt.template, req.URL.EscapedPath()); ok {
+			return rt, params, true
+		}
+	}
+
+	return route{}, nil, false
+}
+
+func (v *Validator) validateParameter(req *http.Request, p openapi3.Parameter, pathParams map[string]string, re *RequestError) {
+	fail := func(pointer, message string) {
+		re.Errors = append(re.Errors, FieldError{In: string(p.In), Name: p.Name, Pointer: pointer, Message: message})
+	}
+
+	var (
+		raw     []string
+		explode bool
+		sep     = ","
+	)
+
+	switch p.In {
+	case openapi3.ParameterInPath:
+		segment, err := url.PathUnescape(pathParams[p.Name])
+		if err != nil {
+			fail("", err.Error())
+
+			return
+		}
+
+		pp := param.PathOf(p)
+
+		switch pp.Style {
+		case param.Label:
+			segment = strings.TrimPrefix(segment, ".")
+		case param.Matrix:
+			segment = strings.TrimPrefix(segment, ";"+p.Name+"=")
+		}
+
+		raw = []string{segment}
+	case openapi3.ParameterInQuery:
+		if s := v.objectSchema(p.Schema); s != nil {
+			if obj := v.queryObject(req.URL.Query(), p, s); obj != nil {
+				v.validateValue(*p.Schema, obj, fail)
+			} else if p.Required != nil && *p.Required {
+				fail("", "required parameter is missing")
+			}
+
+			return
+		}
+
+		raw = req.URL.Query()[p.Name]
+		explode = p.Explode == nil || *p.Explode
+
+		if p.Style != nil {
+			switch *p.Style {
+			case "spaceDelimited":
+				sep = " "
+			case "pipeDelimited":
+				sep = "|"
+			}
+		}
+	case openapi3.ParameterInHeader:
+		raw = req.Header.Values(p.Name)
+	case openapi3.ParameterInCookie:
+		if c, err := req.Cookie(p.Name); err == nil {
+			raw = []string{c.Value}
+		}
+	}
+
+	if len(raw) == 0 {
+		if p.Required != nil && *p.Required {
+			fail("", "required parameter is missing")
+		}
+
+		return
+	}
+
+	if p.Schema == nil {
+		return
+	}
+
+	v.validateValue(*p.Schema, v.coerce(raw, *p.Schema, explode, sep), fail)
+}
+
+func (v *Validator) validateValue(sr openapi3.SchemaOrRef, value interface{}, fail func(pointer, message string)) {
+	var errs openapi3.ValidationErrors
+
+	if err := v.spec.ValidateValue(sr, value); errors.As(err, &errs) {
+		for _, e := range errs {
+			fail(e.Pointer, e.Message)
+		}
+	}
+}
+
+// objectSchema returns resolved schema of object parameter, nil for other types.
+func (v *Validator) objectSchema(sr *openapi3.SchemaOrRef) *openapi3.Schema {
+	if sr == nil {
+		return nil
+	}
+
+	s := v.schema(*sr)
+	if s == nil || s.Type == nil || *s.Type != openapi3.SchemaTypeObject {
+		return nil
+	}
+
+	return s
+}
+
+// queryObject decodes object query parameter according to its style, nil if parameter is missing.
+//
+// Style `deepObject` reads `name[prop]=value` keys, exploded `form` (default) reads keys of schema properties,
+// non-exploded `form` reads `name=prop,value,prop,value`.
+func (v *Validator) queryObject(query url.Values, p openapi3.Parameter, s *openapi3.Schema) map[string]interface{} {
+	raw := map[string]string{}
+
+	switch {
+	case p.Style != nil && *p.Style == "deepObject":
+		prefix := p.Name + "["
+
+		for k, values := range query {
+			if strings.HasPrefix(k, prefix) && strings.HasSuffix(k, "]") && len(values) > 0 {
+				raw[k[len(prefix):len(k)-1]] = values[0]
+			}
+		}
+	case p.Explode == nil || *p.Explode:
+		if s.Properties == nil {
+			return nil
+		}
+
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			if values := query[pair.Key]; len(values) > 0 {
+				raw[pair.Key] = values[0]
+			}
+		}
+	default:
+		values := query[p.Name]
+		if len(values) == 0 {
+			return nil
+		}
+
+		items := strings.Split(values[0], ",")
+		for i := 0; i+1 < len(items); i += 2 {
+			raw[items[i]] = items[i+1]
+		}
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	obj := make(map[string]interface{}, len(raw))
+
+	for k, value := range raw {
+		obj[k] = value
+
+		if s.Properties == nil {
+			continue
+		}
+
+		if ps, ok := s.Properties.Get(k); ok {
+			obj[k] = v.coerce([]string{value}, ps, false, ",")
+		}
+	}
+
+	return obj
+}
+
+// coerce converts raw parameter values to generic JSON value according to schema type,
+// arrays are split with sep unless exploded, values that can not be converted are kept as strings
+// to be reported by validation.
+func (v *Validator) coerce(raw []string, sr openapi3.SchemaOrRef, explode bool, sep string) interface{} {
+	s := v.schema(sr)
+	if s == nil || s.Type == nil {
+		return raw[0]
+	}
+
+	if *s.Type == openapi3.SchemaTypeArray {
+		items := raw
+		if !explode || len(raw) == 1 {
+			items = strings.Split(strings.Join(raw, sep), sep)
+		}
+
+		res := make([]interface{}, 0, len(items))
+
+		for _, item := range items {
+			if s.Items != nil {
+				res = append(res, v.coerce([]string{item}, *s.Items, false, sep))
+			} else {
+				res = append(res, item)
+			}
+		}
+
+		return res
+	}
+
+	switch *s.Type {
+	case openapi3.SchemaTypeInteger, openapi3.SchemaTypeNumber:
+		if f, err := strconv.ParseFloat(raw[0], 64); err == nil {
+			return f
+		}
+	case openapi3.SchemaTypeBoolean:
+		if b, err := strconv.ParseBool(raw[0]); err == nil {
+			return b
+		}
+	}
+
+	return raw[0]
+}
+
+func (v *Validator) validateBody(rw http.ResponseWriter, req *http.Request, rb *openapi3.RequestBody, re *RequestError) {
+	if rb == nil {
+		return
+	}
+
+	fail := func(pointer, message string) {
+		re.Errors = append(re.Errors, FieldError{In: InBody, Pointer: pointer, Message: message})
+	}
+
+	var body []byte
+
+	if req.Body != nil {
+		r := req.Body
+
+		limit := v.MaxBodySize
+		if limit == 0 {
+			limit = DefaultMaxBodySize
+		}
+
+		if limit > 0 {
+			r = http.MaxBytesReader(rw, r, limit)
+		}
+
+		b, err := io.ReadAll(r)
+		if err != nil {
+			// MaxBytesReader fails on reading beyond limit.
+			if limit > 0 && int64(len(b)) == limit {
+				re.Status = http.StatusRequestEntityTooLarge
+
+				fail("", "request body exceeds "+strconv.FormatInt(limit, 10)+" bytes")
+
+				return
+			}
+
+			fail("", "failed to read request body: "+err.Error())
+
+			return
+		}
+
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if len(body) == 0 {
+		if rb.Required != nil && *rb.Required {
+			fail("", "request body is required")
+		}
+
+		return
+	}
+
+	contentType := req.Header.Get("Content-Type")
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt = contentType
+	}
+
+	media, ok := mediaType(rb.Content, mt)
+	if !ok {
+		fail("", "unsupported content type "+strconv.Quote(contentType))
+
+		return
+	}
+
+	if media.Schema == nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+		return
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(body, &value); err != nil {
+		fail("", "invalid JSON: "+err.Error())
+
+		return
+	}
+
+	var errs openapi3.ValidationErrors
+
+	if err := v.spec.ValidateValue(*media.Schema, value); errors.As(err, &errs) {
+		for _, e := range errs {
+			fail(e.Pointer, e.Message)
+		}
+	}
+}
+
+// mediaType finds content of media type, ranges like `application/*` and `*/*` are matched too.
+func mediaType(content map[string]openapi3.MediaType, mt string) (openapi3.MediaType, bool) {
+	if m, ok := content[mt]; ok {
+		return m, true
+	}
+
+	if i := strings.Index(mt, "/"); i > 0 {
+		if m, ok := content[mt[:i]+"/*"]; ok {
+			return m, true
+		}
+	}
+
+	m, ok := content["*/*"]
+
+	return m, ok
+}
+
+// parameters merges path item and operation parameters, operation parameters override by name and location.
+func (v *Validator) parameters(lists ...[]openapi3.ParameterOrRef) []openapi3.Parameter {
+	var res []openapi3.Parameter
+
+	index := map[string]int{}
+
+	for _, list := range lists {
+		for _, pr := range list {
+			p := pr.Parameter
+
+			if pr.ParameterReference != nil && v.spec.Components != nil && v.spec.Components.Parameters != nil {
+				name := strings.TrimPrefix(pr.ParameterReference.Ref, "#/components/parameters/")
+				p = v.spec.Components.Parameters.MapOfParameterOrRefValues[name].Parameter
+			}
+
+			if p == nil {
+				continue
+			}
+
+			key := string(p.In) + " " + p.Name
+			if i, ok := index[key]; ok {
+				res[i] = *p
+
+				continue
+			}
+
+			index[key] = len(res)
+			res = append(res, *p)
+		}
+	}
+
+	return res
+}
+
+func (v *Validator) requestBody(rbr *openapi3.RequestBodyOrRef) *openapi3.RequestBody {
+	if rbr == nil {
+		return nil
+	}
+
+	if rbr.RequestBodyReference != nil && v.spec.Components != nil && v.spec.Components.RequestBodies != nil {
+		name := strings.TrimPrefix(rbr.RequestBodyReference.Ref, "#/components/requestBodies/")
+
+		return v.spec.Components.RequestBodies.MapOfRequestBodyOrRefValues[name].RequestBody
+	}
+
+	return rbr.RequestBody
+}
+
+// schema resolves local reference to component schema.
+func (v *Validator) schema(sr openapi3.SchemaOrRef) *openapi3.Schema {
+	for i := 0; sr.SchemaReference != nil && i < 64; i++ {
+		if v.spec.Components == nil || v.spec.Components.Schemas == nil {
+			return nil
+		}
+
+		sr = v.spec.Components.Schemas.MapOfSchemaOrRefValues[strings.TrimPrefix(sr.SchemaReference.Ref, "#/components/schemas/")]
+	}
+
+	return sr.Schema
+}
+
+func writeError(rw http.ResponseWriter, _ *http.Request, err *RequestError) {
+	status := err.Status
+	if status == 0 {
+		status = http.StatusBadRequest
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(err) //nolint:errchkjson // Error is a plain structure.
+}
@@ -0,0 +1,262 @@
+package validator_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/validator"
+)
+
+func newValidator(t *testing.T) *validator.Validator {
+	t.Helper()
+
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /users/{id}:
+    parameters:
+      - {name: id, in: path, required: true, schema: {type: integer, minimum: 1}}
+    get:
+      parameters:
+        - {name: fields, in: query, schema: {type: array, items: {type: string, enum: [name, email]}}}
+        - {name: X-Trace, in: header, required: true, schema: {type: string, minLength: 8}}
+        - {name: session, in: cookie, schema: {type: string}}
+      responses:
+        "200": {description: OK}
+    put:
+      requestBody:
+        $ref: '#/components/requestBodies/User'
+      responses:
+        "204": {description: Updated}
+  /users/me:
+    get:
+      responses:
+        "200": {description: OK}
+components:
+  requestBodies:
+    User:
+      required: true
+      content:
+        application/json:
+          schema: {$ref: '#/components/schemas/User'}
+  schemas:
+    User:
+      type: object
+      required: [name]
+      properties:
+        name: {type: string}
+        birthday: {type: string, format: date}
+`)))
+
+	return validator.New(&s)
+}
+
+func TestValidator_Validate(t *testing.T) {
+	v := newValidator(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/0?fields=name,phone", nil)
+	req.Header.Set("X-Trace", "abc")
+
+	err := v.Validate(req)
+	require.Error(t, err)
+	assert.Equal(t, `invalid request GET /users/{id}: path id: value 0 is less than 1; `+
+		`query fields/1: value phone is not one of enum; header X-Trace: length 3 is less than 8`, err.Error())
+
+	req = httptest.NewRequest(http.MethodGet, "/users/42?fields=name&fields=email", nil)
+	req.Header.Set("X-Trace", "abcdefgh")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	assert.NoError(t, v.Validate(req))
+
+	// Literal path takes precedence over template.
+	assert.NoError(t, v.Validate(httptest.NewRequest(http.MethodGet, "/users/me", nil)))
+
+	// Undocumented operations are not validated.
+	assert.NoError(t, v.Validate(httptest.NewRequest(http.MethodDelete, "/users/1", nil)))
+	assert.NoError(t, v.Validate(httptest.NewRequest(http.MethodGet, "/orders", nil)))
+}
+
+func TestValidator_Validate_queryStyles(t *testing.T) {
+	s := openapi3.Spec{}
+	require.NoError(t, s.UnmarshalYAML([]byte(`
+openapi: 3.0.3
+info: {title: API, version: 1.0.0}
+paths:
+  /orders:
+    get:
+      parameters:
+        - name: filter
+          in: query
+          required: true
+          style: deepObject
+          schema: {$ref: '#/components/schemas/Filter'}
+        - {name: ids, in: query, style: spaceDelimited, explode: false, schema: {type: array, items: {type: integer}}}
+        - {name: tags, in: query, style: pipeDelimited, explode: false, schema: {type: array, items: {type: string, maxLength: 3}}}
+      responses:
+        "200": {description: OK}
+  /items:
+    get:
+      parameters:
+        - {name: page, in: query, required: true, schema: {$ref: '#/components/schemas/Page'}}
+        - {name: color, in: query, explode: false, schema: {type: object, properties: {R: {type: integer, maximum: 255}}}}
+      responses:
+        "200": {description: OK}
+components:
+  schemas:
+    Filter:
+      type: object
+      properties:
+        status: {type: string, enum: [open, closed]}
+        total: {type: integer, minimum: 1}
+    Page:
+      type: object
+      required: [limit]
+      properties:
+        limit: {type: integer, maximum: 100}
+        offset: {type: integer}
+`)))
+
+	v := validator.New(&s)
+
+	for target, expected := range map[string]string{
+		"/orders?filter[status]=open&filter[total]=3&ids=1%202&tags=a|b": "",
+		"/orders?filter[status]=new&ids=1%20x&tags=a|long": "invalid request GET /orders: query filter/status: value new is not one of enum; " +
+			"query ids/1: string value for integer; query tags/1: length 4 is greater than 3",
+		"/orders":                   "invalid request GET /orders: query filter: required parameter is missing",
+		"/items?limit=10&offset=20": "",
+		"/items?limit=200&color=R,300": "invalid request GET /items: query page/limit: value 200 is greater than 100; " +
+			"query color/R: value 300 is greater than 255",
+		"/items?offset=20": "invalid request GET /items: query page/limit: required property is missing",
+		"/items":           "invalid request GET /items: query page: required parameter is missing",
+	} {
+		err := v.Validate(httptest.NewRequest(http.MethodGet, target, nil))
+		if expected == "" {
+			assert.NoError(t, err, target)
+		} else {
+			assert.EqualError(t, err, expected, target)
+		}
+	}
+}
+
+func TestValidator_Validate_body(t *testing.T) {
+	v := newValidator(t)
+
+	for _, tc := range []struct {
+		name, contentType, body, err string
+	}{
+		{name: "valid", contentType: "application/json", body: `{"name":"John"}`},
+		{name: "missing", err: "body: request body is required"},
+		{name: "content type", contentType: "text/plain", body: "John", err: `body: unsupported content type "text/plain"`},
+		{name: "malformed", contentType: "application/json", body: `{"name":`, err: "body: invalid JSON: unexpected end of JSON input"},
+		{
+			name: "invalid", contentType: "application/json; charset=utf-8", body: `{"birthday":"May 1"}`,
+			err: `body/birthday: invalid date "May 1": parsing time "May 1" as "2006-01-02": cannot parse "May 1" as "2006"; ` +
+				"body/name: required property is missing",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			err := v.Validate(req)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "invalid request PUT /users/{id}: "+tc.err)
+			}
+
+			// Body is still available to handler.
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, string(body))
+		})
+	}
+}
+
+func TestValidator_Middleware(t *testing.T) {
+	v := newValidator(t)
+
+	h := v.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name":"John"}`)))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assertjson.Equal(t, []byte(`{
+	  "method":"PUT","path":"/users/{id}",
+	  "errors":[{"in":"body","message":"unsupported content type \"\""}]
+	}`), rw.Body.Bytes())
+
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+
+	v.OnError = func(rw http.ResponseWriter, _ *http.Request, err *validator.RequestError) {
+		http.Error(rw, err.Errors[0].String(), http.StatusUnprocessableEntity)
+	}
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/users/abc", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rw.Code)
+	assert.Equal(t, "path id: string value for integer\n", rw.Body.String())
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestValidator_Middleware_bodyLimit(t *testing.T) {
+	v := newValidator(t)
+	v.MaxBodySize = 16
+
+	h := v.Middleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name":"John Doe"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rw.Code)
+	assertjson.Equal(t, []byte(`{
+	  "method":"PUT","path":"/users/{id}",
+	  "errors":[{"in":"body","message":"request body exceeds 16 bytes"}]
+	}`), rw.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodPut, "/users/1", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+
+	// Failed body is not passed to handler.
+	req = httptest.NewRequest(http.MethodPut, "/users/1", failingReader{})
+	req.Header.Set("Content-Type", "application/json")
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, req)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assertjson.Equal(t, []byte(`{
+	  "method":"PUT","path":"/users/{id}",
+	  "errors":[{"in":"body","message":"failed to read request body: unexpected EOF"}]
+	}`), rw.Body.Bytes())
+}
@@ -0,0 +1,305 @@
+// Package watch regenerates OpenAPI documents when Go sources change.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config controls regeneration loop.
+type Config struct {
+	// Dirs are directories with Go sources, watched recursively, current directory by default.
+	Dirs []string
+
+	// Interval is a polling interval, default 500ms.
+	Interval time.Duration
+
+	// Generate produces spec document, see GoRun.
+	Generate func(ctx context.Context) ([]byte, error)
+
+	// Report receives results of every regeneration, including the initial one.
+	Report func(r Result)
+}
+
+// Result describes regenerated spec.
+type Result struct {
+	Spec []byte
+	Err  error
+
+	// Diff contains changed lines, prefixed with "+" or "-", empty for the initial generation.
+	Diff []string
+}
+
+// Run watches sources and regenerates spec on changes until context is done.
+func Run(ctx context.Context, cfg Config) error {
+	if cfg.Generate == nil {
+		return errors.New("watch: missing Generate function")
+	}
+
+	if len(cfg.Dirs) == 0 {
+		cfg.Dirs = []string{"."}
+	}
+
+	if cfg.Interval == 0 {
+		cfg.Interval = 500 * time.Millisecond
+	}
+
+	if cfg.Report == nil {
+		cfg.Report = func(Result) {}
+	}
+
+	state, err := snapshot(cfg.Dirs)
+	if err != nil {
+		return err
+	}
+
+	prev, err := cfg.Generate(ctx)
+	cfg.Report(Result{Spec: prev, Err: err})
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		next, err := snapshot(cfg.Dirs)
+		if err != nil {
+			// Files may be transiently missing while editors or VCS rewrite them, retrying on next tick.
+			cfg.Report(Result{Err: err})
+
+			continue
+		}
+
+		if next == state {
+			continue
+		}
+
+		state = next
+
+		spec, err := cfg.Generate(ctx)
+		if err != nil {
+			cfg.Report(Result{Err: err})
+
+			continue
+		}
+
+		cfg.Report(Result{Spec: spec, Diff: Diff(prev, spec)})
+		prev = spec
+	}
+}
+
+// snapshot returns a fingerprint of Go files modification state.
+func snapshot(dirs []string) (string, error) {
+	var sb strings.Builder
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(&sb, "%s:%d:%d;", path, fi.Size(), fi.ModTime().UnixNano())
+
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("watch: %w", err)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// GoRun creates a generator that runs Go package with `go run` and captures its standard output.
+func GoRun(pkg string, args ...string) func(ctx context.Context) ([]byte, error) {
+	return func(ctx context.Context) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+
+		cmd := exec.CommandContext(ctx, "go", append([]string{"run", pkg}, args...)...) //nolint:gosec // Package is provided by developer.
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("go run %s: %w: %s", pkg, err, stderr.String())
+		}
+
+		return stdout.Bytes(), nil
+	}
+}
+
+// Diff returns changed lines between two documents.
+//
+// Removed lines are prefixed with "-", added lines with "+", removals of a changed block precede additions.
+// Lines are matched with Myers algorithm in linear space.
+func Diff(prev, next []byte) []string {
+	d := differ{
+		a: strings.Split(string(prev), "\n"),
+		b: strings.Split(string(next), "\n"),
+	}
+
+	d.compare(0, len(d.a), 0, len(d.b))
+
+	var res []string
+
+	i, j := 0, 0
+
+	for _, m := range append(d.matches, [2]int{len(d.a), len(d.b)}) {
+		for ; i < m[0]; i++ {
+			res = append(res, "-"+d.a[i])
+		}
+
+		for ; j < m[1]; j++ {
+			res = append(res, "+"+d.b[j])
+		}
+
+		i, j = m[0]+1, m[1]+1
+	}
+
+	return res
+}
+
+type differ struct {
+	a, b []string
+
+	// matches are indexes of equal lines in a and b, in increasing order.
+	matches [][2]int
+}
+
+// compare finds matching lines of a[aLo:aHi] and b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.matches = append(d.matches, [2]int{aLo, bLo})
+		aLo++
+		bLo++
+	}
+
+	suffix := 0
+
+	for aLo < aHi && bLo < bHi && d.a[aHi-1] == d.b[bHi-1] {
+		aHi--
+		bHi--
+		suffix++
+	}
+
+	if aLo < aHi && bLo < bHi {
+		if x, y, ok := d.middle(aLo, aHi, bLo, bHi); ok {
+			d.compare(aLo, x, bLo, y)
+			d.compare(x, aHi, y, bHi)
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		d.matches = append(d.matches, [2]int{aHi + k, bHi + k})
+	}
+}
+
+// middle finds a split point on the middle snake of the shortest edit script,
+// searching forward and backward paths simultaneously.
+func (d *differ) middle(aLo, aHi, bLo, bHi int) (x, y int, ok bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+
+	for i := range vf {
+		vf[i] = -1
+		vb[i] = -1
+	}
+
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+
+	delta := n - m
+	front := delta%2 != 0
+
+	// Diagonals that went out of bounds are trimmed from both ends.
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+
+	for e := 0; e < maxD; e++ {
+		for k := -e + fStart; k <= e-fEnd; k += 2 {
+			var x1 int
+			if k == -e || (k != e && vf[offset+k-1] < vf[offset+k+1]) {
+				x1 = vf[offset+k+1]
+			} else {
+				x1 = vf[offset+k-1] + 1
+			}
+
+			y1 := x1 - k
+
+			for x1 < n && y1 < m && d.a[aLo+x1] == d.b[bLo+y1] {
+				x1++
+				y1++
+			}
+
+			vf[offset+k] = x1
+
+			switch {
+			case x1 > n:
+				fEnd += 2
+			case y1 > m:
+				fStart += 2
+			case front:
+				if kb := offset + delta - k; kb >= 0 && kb < len(vb) && vb[kb] != -1 && x1 >= n-vb[kb] {
+					return aLo + x1, bLo + y1, true
+				}
+			}
+		}
+
+		for k := -e + bStart; k <= e-bEnd; k += 2 {
+			var x2 int
+			if k == -e || (k != e && vb[offset+k-1] < vb[offset+k+1]) {
+				x2 = vb[offset+k+1]
+			} else {
+				x2 = vb[offset+k-1] + 1
+			}
+
+			y2 := x2 - k
+
+			for x2 < n && y2 < m && d.a[aHi-x2-1] == d.b[bHi-y2-1] {
+				x2++
+				y2++
+			}
+
+			vb[offset+k] = x2
+
+			switch {
+			case x2 > n:
+				bEnd += 2
+			case y2 > m:
+				bStart += 2
+			case !front:
+				if kf := offset + delta - k; kf >= 0 && kf < len(vf) && vf[kf] != -1 {
+					x1 := vf[kf]
+					if x1 >= n-x2 {
+						return aLo + x1, bLo + offset + x1 - kf, true
+					}
+				}
+			}
+		}
+	}
+
+	// No common lines.
+	return 0, 0, false
+}
@@ -0,0 +1,138 @@
+package watch_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/watch"
+)
+
+func TestDiff(t *testing.T) {
+	assert.Equal(t, []string{"-b: 2", "+b: 3", "+c: 4"}, watch.Diff(
+		[]byte("a: 1\nb: 2"),
+		[]byte("a: 1\nb: 3\nc: 4"),
+	))
+	assert.Empty(t, watch.Diff([]byte("a: 1"), []byte("a: 1")))
+	assert.Equal(t, []string{"-a", "-b", "+x", "+y", "+z"}, watch.Diff([]byte("a\nb"), []byte("x\ny\nz")))
+	assert.Equal(t, []string{"-b", "+x", "-d", "+e", "+f"}, watch.Diff(
+		[]byte("a\nb\nc\nd\ng"),
+		[]byte("a\nx\nc\ne\nf\ng"),
+	))
+}
+
+func TestDiff_large(t *testing.T) {
+	var prev, next strings.Builder
+
+	for i := 0; i < 100000; i++ {
+		_, _ = fmt.Fprintf(&prev, "line %d\n", i)
+
+		if i == 500 {
+			next.WriteString("inserted\n")
+		}
+
+		if i != 90000 {
+			_, _ = fmt.Fprintf(&next, "line %d\n", i)
+		}
+	}
+
+	assert.Equal(t, []string{"+inserted", "-line 90000"}, watch.Diff([]byte(prev.String()), []byte(next.String())))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "api.go")
+
+	require.NoError(t, os.WriteFile(src, []byte("package api"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var version atomic.Value
+
+	version.Store("1")
+
+	results := make(chan watch.Result, 10)
+
+	go func() {
+		_ = watch.Run(ctx, watch.Config{
+			Dirs:     []string{dir},
+			Interval: 10 * time.Millisecond,
+			Generate: func(_ context.Context) ([]byte, error) {
+				return []byte("version: " + version.Load().(string)), nil
+			},
+			Report: func(r watch.Result) {
+				results <- r
+			},
+		})
+	}()
+
+	r := <-results
+	assert.Equal(t, "version: 1", string(r.Spec))
+	assert.Empty(t, r.Diff)
+
+	version.Store("2")
+
+	require.NoError(t, os.WriteFile(src, []byte("package api\n\nconst v = 2"), 0o600))
+
+	r = <-results
+	require.NoError(t, r.Err)
+	assert.Equal(t, []string{"-version: 1", "+version: 2"}, r.Diff)
+}
+
+func TestRun_snapshotError(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "api")
+	src := filepath.Join(dir, "api.go")
+
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	require.NoError(t, os.WriteFile(src, []byte("package api"), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := make(chan watch.Result, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- watch.Run(ctx, watch.Config{
+			Dirs:     []string{dir},
+			Interval: 10 * time.Millisecond,
+			Generate: func(_ context.Context) ([]byte, error) {
+				return []byte("version: 1"), nil
+			},
+			Report: func(r watch.Result) {
+				results <- r
+			},
+		})
+	}()
+
+	r := <-results
+	require.NoError(t, r.Err)
+
+	// Directory is missing while being rewritten, watching continues.
+	require.NoError(t, os.RemoveAll(dir))
+
+	r = <-results
+	assert.Error(t, r.Err)
+
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	require.NoError(t, os.WriteFile(src, []byte("package api\n\nconst v = 2"), 0o600))
+
+	for r = range results {
+		if r.Err == nil {
+			break
+		}
+	}
+
+	assert.Equal(t, "version: 1", string(r.Spec))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}